@@ -406,9 +406,30 @@ func rewriteTableDescs(tables []*sqlbase.TableDescriptor, tableRewrites tableRew
 				}
 			}
 			col.UsesSequenceIds = newSeqRefs
+
+			// Sequences owned by the column that aren't being restored are
+			// simply no longer owned.
+			var newOwnedSeqRefs []sqlbase.ID
+			for _, seqID := range col.OwnsSequenceIds {
+				if rewrite, ok := tableRewrites[seqID]; ok {
+					newOwnedSeqRefs = append(newOwnedSeqRefs, rewrite.TableID)
+				}
+			}
+			col.OwnsSequenceIds = newOwnedSeqRefs
 			table.Columns[idx] = col
 		}
 
+		// Rewrite the reference from a sequence to the column owning it. If the
+		// owning table isn't being restored, the sequence is no longer owned.
+		if table.SequenceOpts != nil && table.SequenceOpts.SequenceOwner.OwnerTableID != 0 {
+			owner := &table.SequenceOpts.SequenceOwner
+			if rewrite, ok := tableRewrites[owner.OwnerTableID]; ok {
+				owner.OwnerTableID = rewrite.TableID
+			} else {
+				*owner = sqlbase.TableDescriptor_SequenceOwner{}
+			}
+		}
+
 		// since this is a "new" table in eyes of new cluster, any leftover change
 		// lease is obviously bogus (plus the nodeID is relative to backup cluster).
 		table.Lease = nil
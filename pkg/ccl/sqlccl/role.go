@@ -35,7 +35,9 @@ func createRolePlanHook(
 	}
 
 	// Call directly into the OSS code.
-	return p.CreateUserNode(ctx, createRole.Name, nil /* password */, createRole.IfNotExists, true /* isRole */, "CREATE ROLE")
+	return p.CreateUserNode(
+		ctx, createRole.Name, nil /* password */, createRole.Options,
+		createRole.IfNotExists, true /* isRole */, "CREATE ROLE")
 }
 
 func dropRolePlanHook(
@@ -61,7 +61,7 @@ func TestSetup(t *testing.T) {
 				t.Fatalf("%+v", err)
 			}
 
-			if _, err := workload.Setup(sqlDB.DB, gen, test.batchSize); err != nil {
+			if _, err := workload.Setup(ctx, sqlDB.DB, gen, test.batchSize); err != nil {
 				t.Fatalf("%+v", err)
 			}
 
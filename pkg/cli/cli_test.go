@@ -2125,7 +2125,13 @@ writing ` + os.DevNull + `
   debug/nodes/1/ranges/18
   debug/nodes/1/ranges/19
   debug/nodes/1/ranges/20
+  debug/nodes/1/ranges/21
+  debug/nodes/1/ranges/22
+  debug/nodes/1/ranges/23
+  debug/nodes/1/ranges/24
   debug/schema/system@details
+  debug/schema/system/comments
+  debug/schema/system/default_privileges
   debug/schema/system/descriptor
   debug/schema/system/eventlog
   debug/schema/system/jobs
@@ -2134,6 +2140,8 @@ writing ` + os.DevNull + `
   debug/schema/system/namespace
   debug/schema/system/rangelog
   debug/schema/system/role_members
+  debug/schema/system/role_options
+  debug/schema/system/role_settings
   debug/schema/system/settings
   debug/schema/system/table_statistics
   debug/schema/system/ui
@@ -311,7 +311,7 @@ func getBasicMetadata(conn *sqlConn, dbName, tableName string, ts string) (basic
 func getMetadataForTable(conn *sqlConn, md basicMetadata, ts string) (tableMetadata, error) {
 	// Fetch column types.
 	rows, err := conn.Query(fmt.Sprintf(`
-		SELECT COLUMN_NAME, DATA_TYPE
+		SELECT COLUMN_NAME, CRDB_SQL_TYPE
 		FROM "".information_schema.columns
 		AS OF SYSTEM TIME %s
 		WHERE TABLE_SCHEMA = $1
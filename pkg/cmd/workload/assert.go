@@ -0,0 +1,248 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils/workload/results"
+)
+
+var assertions = runFlags.StringArray("assert", nil,
+	"Expression that must hold for the results of the run, e.g. "+
+		"'p99_ms < 20 && ops_per_sec > 5000'. The names are those of the fields of "+
+		"--json-results. May be repeated; the workload exits with an error if any "+
+		"assertion fails.")
+
+// errAssertionsFailed is returned by a run whose assertions don't all hold.
+// It is the only error the workload exits with a non-zero status for, so
+// that the exit status of the other failures stays what it was.
+var errAssertionsFailed = errors.New(`some assertions failed`)
+
+// resultAssertion is a boolean expression over the fields of the results of
+// a run. It supports numbers, the names of the numeric fields of results.Run
+// as written in JSON, parentheses and the operators of Go on them: + - * /
+// for numbers, == != < <= > >= to compare them and ! && || on booleans.
+type resultAssertion struct {
+	src  string
+	expr ast.Expr
+}
+
+func parseResultAssertion(src string) (resultAssertion, error) {
+	expr, err := parser.ParseExpr(src)
+	if err != nil {
+		return resultAssertion{}, errors.Wrapf(err, `invalid --assert %q`, src)
+	}
+	a := resultAssertion{src: src, expr: expr}
+	// Unknown results and misused operators are reported before the run
+	// rather than after it. The results are checked separately, as && and
+	// || may skip some of them.
+	fields, err := resultFields(results.Run{})
+	if err != nil {
+		return resultAssertion{}, err
+	}
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && err == nil {
+			if _, ok := fields[id.Name]; !ok && id.Name != `true` && id.Name != `false` {
+				err = errors.Errorf(`invalid --assert %q: unknown result %q`, src, id.Name)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return resultAssertion{}, err
+	}
+	if _, _, err := a.eval(results.Run{}); err != nil {
+		return resultAssertion{}, err
+	}
+	return a, nil
+}
+
+// eval returns whether the assertion holds for the results, and the fields
+// of the results it was evaluated with.
+func (a resultAssertion) eval(r results.Run) (bool, map[string]float64, error) {
+	fields, err := resultFields(r)
+	if err != nil {
+		return false, nil, err
+	}
+	v, err := evalAssertion(a.expr, fields)
+	if err != nil {
+		return false, nil, errors.Wrapf(err, `invalid --assert %q`, a.src)
+	}
+	holds, ok := v.(bool)
+	if !ok {
+		return false, nil, errors.Errorf(`invalid --assert %q: a number, not a condition`, a.src)
+	}
+	return holds, fields, nil
+}
+
+// check returns an error if the assertion doesn't hold for the results.
+func (a resultAssertion) check(r results.Run) error {
+	holds, fields, err := a.eval(r)
+	if err != nil {
+		return err
+	}
+	if !holds {
+		return errors.Errorf(`assertion failed: %s (%s)`, a.src, describeFields(a.expr, fields))
+	}
+	return nil
+}
+
+// resultFields returns the numeric fields of the results by JSON name.
+func resultFields(r results.Run) (map[string]float64, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]float64, len(all))
+	for name, v := range all {
+		if f, ok := v.(float64); ok {
+			fields[name] = f
+		}
+	}
+	return fields, nil
+}
+
+// describeFields lists the values of the fields used in the expression, to
+// tell by how much an assertion failed.
+func describeFields(expr ast.Expr, fields map[string]float64) string {
+	var values []string
+	seen := make(map[string]bool)
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && !seen[id.Name] {
+			seen[id.Name] = true
+			if v, ok := fields[id.Name]; ok {
+				values = append(values, fmt.Sprintf(`%s=%g`, id.Name, v))
+			}
+		}
+		return true
+	})
+	return strings.Join(values, `, `)
+}
+
+// evalAssertion evaluates the expression to a float64 or a bool.
+func evalAssertion(expr ast.Expr, fields map[string]float64) (interface{}, error) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return evalAssertion(e.X, fields)
+
+	case *ast.BasicLit:
+		if e.Kind != token.INT && e.Kind != token.FLOAT {
+			return nil, errors.Errorf(`unsupported literal %s`, e.Value)
+		}
+		return strconv.ParseFloat(e.Value, 64)
+
+	case *ast.Ident:
+		switch e.Name {
+		case `true`:
+			return true, nil
+		case `false`:
+			return false, nil
+		}
+		v, ok := fields[e.Name]
+		if !ok {
+			return nil, errors.Errorf(`unknown result %q`, e.Name)
+		}
+		return v, nil
+
+	case *ast.UnaryExpr:
+		x, err := evalAssertion(e.X, fields)
+		if err != nil {
+			return nil, err
+		}
+		switch x := x.(type) {
+		case float64:
+			switch e.Op {
+			case token.SUB:
+				return -x, nil
+			case token.ADD:
+				return x, nil
+			}
+		case bool:
+			if e.Op == token.NOT {
+				return !x, nil
+			}
+		}
+		return nil, errors.Errorf(`unsupported operator %s on %v`, e.Op, x)
+
+	case *ast.BinaryExpr:
+		x, err := evalAssertion(e.X, fields)
+		if err != nil {
+			return nil, err
+		}
+		// && and || short-circuit.
+		if xb, ok := x.(bool); ok && (e.Op == token.LAND || e.Op == token.LOR) {
+			if xb == (e.Op == token.LOR) {
+				return xb, nil
+			}
+			y, err := evalAssertion(e.Y, fields)
+			if err != nil {
+				return nil, err
+			}
+			if yb, ok := y.(bool); ok {
+				return yb, nil
+			}
+			return nil, errors.Errorf(`unsupported operator %s on %v`, e.Op, y)
+		}
+		y, err := evalAssertion(e.Y, fields)
+		if err != nil {
+			return nil, err
+		}
+		xf, xok := x.(float64)
+		yf, yok := y.(float64)
+		if !xok || !yok {
+			return nil, errors.Errorf(`unsupported operator %s on %v and %v`, e.Op, x, y)
+		}
+		switch e.Op {
+		case token.ADD:
+			return xf + yf, nil
+		case token.SUB:
+			return xf - yf, nil
+		case token.MUL:
+			return xf * yf, nil
+		case token.QUO:
+			return xf / yf, nil
+		case token.EQL:
+			return xf == yf, nil
+		case token.NEQ:
+			return xf != yf, nil
+		case token.LSS:
+			return xf < yf, nil
+		case token.LEQ:
+			return xf <= yf, nil
+		case token.GTR:
+			return xf > yf, nil
+		case token.GEQ:
+			return xf >= yf, nil
+		}
+		return nil, errors.Errorf(`unsupported operator %s`, e.Op)
+
+	default:
+		return nil, errors.Errorf(`unsupported expression %T`, expr)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/workload/results"
+)
+
+func TestResultAssertion(t *testing.T) {
+	r := results.Run{
+		Ops:       1000,
+		Errors:    2,
+		OpsPerSec: 5500,
+		P50Ms:     4,
+		P99Ms:     25,
+	}
+	testCases := []struct {
+		src      string
+		expected bool
+	}{
+		{`p99_ms < 20`, false},
+		{`p99_ms <= 25`, true},
+		{`ops_per_sec > 5000 && errors == 0`, false},
+		{`ops_per_sec > 5000 || errors == 0`, true},
+		{`!(errors > 0)`, false},
+		{`errors / ops < 0.01`, true},
+		{`p99_ms - p50_ms*5 != 5`, false},
+		{`-p99_ms + 30 >= +5`, true},
+		{`(true || false) && p50_ms == 4`, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.src, func(t *testing.T) {
+			a, err := parseResultAssertion(tc.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			holds, _, err := a.eval(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if holds != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, holds)
+			}
+		})
+	}
+}
+
+func TestResultAssertionErrors(t *testing.T) {
+	testCases := []struct {
+		src      string
+		expected string
+	}{
+		{`p99_ms <`, `invalid --assert "p99_ms <"`},
+		{`p42_ms < 20`, `unknown result "p42_ms"`},
+		// Unknown results are reported even where && skips them.
+		{`false && p42_ms < 20`, `unknown result "p42_ms"`},
+		// Only the numeric fields are results.
+		{`generator == 1`, `unknown result "generator"`},
+		{`p99_ms + 20`, `a number, not a condition`},
+		{`p99_ms < "20"`, `unsupported literal "20"`},
+		{`p99_ms && true`, `unsupported operator && on 0 and true`},
+		{`!p99_ms`, `unsupported operator ! on 0`},
+		{`(p99_ms < 20) < 1`, `unsupported operator < on true and 1`},
+		{`p99_ms % 2 == 0`, `unsupported operator %`},
+		{`p99_ms[0] < 20`, `unsupported expression \*ast.IndexExpr`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.src, func(t *testing.T) {
+			_, err := parseResultAssertion(tc.src)
+			if !testutils.IsError(err, tc.expected) {
+				t.Errorf("expected %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestResultAssertionCheck(t *testing.T) {
+	a, err := parseResultAssertion(`p99_ms < 20 && ops_per_sec > 5000 && p99_ms > 0`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.check(results.Run{OpsPerSec: 6000, P99Ms: 10}); err != nil {
+		t.Fatal(err)
+	}
+	// The failure lists the values of the results the assertion uses, once
+	// each.
+	const expected = `assertion failed: p99_ms < 20 && ops_per_sec > 5000 && p99_ms > 0 ` +
+		`\(p99_ms=25, ops_per_sec=6000\)`
+	if err := a.check(results.Run{OpsPerSec: 6000, P99Ms: 25}); !testutils.IsError(err, expected) {
+		t.Fatalf("expected %q, got %v", expected, err)
+	}
+}
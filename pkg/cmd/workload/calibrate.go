@@ -0,0 +1,278 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	gosql "database/sql"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var calibrateCmd = &cobra.Command{
+	Use:   `calibrate [CRDB URI...]`,
+	Short: `Suggest an operation mix from the statement statistics of a running cluster`,
+	Long: `
+Read the statement statistics collected by the nodes of a running cluster and
+suggest a workload configuration approximating the observed mix of statements.
+
+Statement statistics are kept per node, so pass the URI of every node whose
+traffic should be taken into account; the counts are summed across them.
+
+With --op, each statement fingerprint is attributed to the first operation
+whose regular expression matches it, and the weights of the operations are
+printed as a --mix flag (e.g. for tpcc). Pick a regular expression matching a
+single statement of each transaction, so that transactions are not counted
+once per statement. Without --op, the fingerprints are printed one per line,
+most frequent first, each preceded by a comment with its weight. Constants are
+replaced by _ in fingerprints and need to be filled in before the statements
+can be run.
+`,
+	RunE: runCalibrate,
+}
+
+var calibrateFlags = pflag.NewFlagSet(`calibrate`, pflag.ContinueOnError)
+var calibrateApp = calibrateFlags.String("app", "",
+	"Only consider the statements of this application_name. "+
+		"By default, the statements of all non-internal applications are considered.")
+var calibrateOps = calibrateFlags.StringArray("op", nil,
+	"Operation to weigh, as name=regexp. May be repeated.")
+var calibrateMinShare = calibrateFlags.Float64("min-share", 0.001,
+	"Omit the fingerprints accounting for less than this fraction of the statements "+
+		"(ignored with --op)")
+
+func init() {
+	calibrateCmd.Flags().AddFlagSet(calibrateFlags)
+	calibrateCmd.Flags().AddFlagSet(connFlags)
+	rootCmd.AddCommand(calibrateCmd)
+}
+
+// calibrateOp is an operation whose weight is derived from the counts of the
+// statement fingerprints it matches.
+type calibrateOp struct {
+	name  string
+	re    *regexp.Regexp
+	count int64
+}
+
+func parseCalibrateOps(specs []string) ([]calibrateOp, error) {
+	ops := make([]calibrateOp, 0, len(specs))
+	for _, spec := range specs {
+		kv := strings.SplitN(spec, `=`, 2)
+		if len(kv) != 2 || kv[0] == `` {
+			return nil, errors.Errorf(`invalid --op %s: not a name=regexp pair`, spec)
+		}
+		re, err := regexp.Compile(kv[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, `invalid --op %s`, spec)
+		}
+		ops = append(ops, calibrateOp{name: kv[0], re: re})
+	}
+	return ops, nil
+}
+
+func runCalibrate(cmd *cobra.Command, args []string) error {
+	ops, err := parseCalibrateOps(*calibrateOps)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		args = []string{crdbDefaultURI}
+	}
+
+	counts := make(map[string]int64)
+	for _, dbURL := range args {
+		if err := readStatementCounts(dbURL, *calibrateApp, counts); err != nil {
+			return err
+		}
+	}
+	if len(counts) == 0 {
+		return errors.New(`no statement statistics found`)
+	}
+
+	if len(ops) > 0 {
+		var unmatched int64
+		for fingerprint, count := range counts {
+			matched := false
+			for i := range ops {
+				if ops[i].re.MatchString(fingerprint) {
+					ops[i].count += count
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				unmatched += count
+			}
+		}
+		if unmatched > 0 {
+			fmt.Fprintf(os.Stderr, "%d statements matched no --op\n", unmatched)
+		}
+		return printCalibratedMix(os.Stdout, ops)
+	}
+	return printCalibratedQueries(os.Stdout, counts, *calibrateMinShare)
+}
+
+// readStatementCounts adds the number of executions of each statement
+// fingerprint recorded by the node at dbURL to counts.
+func readStatementCounts(dbURL string, app string, counts map[string]int64) error {
+	dbURL, err := sanitizeDBURL(dbURL)
+	if err != nil {
+		return err
+	}
+	if *certsDir != "" {
+		if dbURL, err = addCertsToDBURL(dbURL, *certsDir); err != nil {
+			return err
+		}
+	}
+	db, err := gosql.Open(`postgres`, dbURL)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// Internal applications have names starting with "$ ".
+	rows, err := db.Query(`
+SELECT key, sum(count)::INT FROM crdb_internal.node_statement_statistics
+WHERE CASE WHEN $1 = '' THEN application_name NOT LIKE '$ %' ELSE application_name = $1 END
+GROUP BY key`, app)
+	if err != nil {
+		return errors.Wrapf(err, `reading statement statistics from %s`, dbURL)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var fingerprint string
+		var count int64
+		if err := rows.Scan(&fingerprint, &count); err != nil {
+			return err
+		}
+		counts[fingerprint] += count
+	}
+	return rows.Err()
+}
+
+// printCalibratedMix prints the weights of the operations, as percentages, in
+// the format of the --mix flag.
+func printCalibratedMix(w io.Writer, ops []calibrateOp) error {
+	counts := make([]int64, len(ops))
+	for i, op := range ops {
+		counts[i] = op.count
+	}
+	weights := percentages(counts)
+	if weights == nil {
+		return errors.New(`no statement matched any --op`)
+	}
+	pairs := make([]string, len(ops))
+	for i, op := range ops {
+		pairs[i] = fmt.Sprintf(`%s=%d`, op.name, weights[i])
+	}
+	_, err := fmt.Fprintf(w, "--mix=%s\n", strings.Join(pairs, `,`))
+	return err
+}
+
+// printCalibratedQueries prints the statement fingerprints, one per line and
+// most frequent first, each preceded by a comment with its count and share of
+// the statements. Fingerprints whose share is below minShare are omitted.
+func printCalibratedQueries(w io.Writer, counts map[string]int64, minShare float64) error {
+	var total int64
+	fingerprints := make([]string, 0, len(counts))
+	for fingerprint, count := range counts {
+		fingerprints = append(fingerprints, fingerprint)
+		total += count
+	}
+	sort.Slice(fingerprints, func(i, j int) bool {
+		if ci, cj := counts[fingerprints[i]], counts[fingerprints[j]]; ci != cj {
+			return ci > cj
+		}
+		return fingerprints[i] < fingerprints[j]
+	})
+	for _, fingerprint := range fingerprints {
+		share := float64(counts[fingerprint]) / float64(total)
+		if share < minShare {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "-- count: %d, share: %.2f%%\n%s;\n",
+			counts[fingerprint], share*100, fingerprint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// percentages converts counts to integer percentages summing to 100, using
+// the largest remainder method. Nonzero counts get a weight of at least 1 so
+// that rare operations are still exercised. It returns nil if all counts are
+// zero.
+func percentages(counts []int64) []int {
+	var total int64
+	for _, c := range counts {
+		total += c
+	}
+	if total == 0 {
+		return nil
+	}
+	weights := make([]int, len(counts))
+	remainders := make([]int64, len(counts))
+	sum := 0
+	for i, c := range counts {
+		weights[i] = int(c * 100 / total)
+		remainders[i] = c * 100 % total
+		if c > 0 && weights[i] == 0 {
+			weights[i] = 1
+			remainders[i] = 0
+		}
+		sum += weights[i]
+	}
+	// Rounding the small counts up may take the sum over 100, in which case
+	// the excess is taken from the largest weights.
+	for sum > 100 {
+		largest := 0
+		for i := range weights {
+			if weights[i] > weights[largest] {
+				largest = i
+			}
+		}
+		if weights[largest] <= 1 {
+			break
+		}
+		weights[largest]--
+		sum--
+	}
+	order := make([]int, len(counts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]] > remainders[order[j]]
+	})
+	for _, i := range order {
+		if sum >= 100 {
+			break
+		}
+		if remainders[i] > 0 {
+			weights[i]++
+			sum++
+		}
+	}
+	return weights
+}
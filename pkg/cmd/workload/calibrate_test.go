@@ -0,0 +1,116 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestPercentages(t *testing.T) {
+	testCases := []struct {
+		counts   []int64
+		expected []int
+	}{
+		{[]int64{0, 0}, nil},
+		{[]int64{1}, []int{100}},
+		{[]int64{1, 1}, []int{50, 50}},
+		{[]int64{1, 1, 1}, []int{34, 33, 33}},
+		{[]int64{2, 1, 0}, []int{67, 33, 0}},
+		{[]int64{45, 43, 4, 4, 4}, []int{45, 43, 4, 4, 4}},
+		// Nonzero counts get a weight of at least 1, taken from the largest.
+		{[]int64{1000, 1, 1}, []int{98, 1, 1}},
+		{[]int64{10000, 1}, []int{99, 1}},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprint(tc.counts), func(t *testing.T) {
+			weights := percentages(tc.counts)
+			if !reflect.DeepEqual(weights, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, weights)
+			}
+		})
+	}
+}
+
+func TestPercentagesSumTo100(t *testing.T) {
+	for n := 1; n <= 100; n++ {
+		counts := make([]int64, n)
+		for i := range counts {
+			counts[i] = int64(1 + i*i*7%13)
+		}
+		counts[0] = 1000
+		sum := 0
+		for i, w := range percentages(counts) {
+			if w < 1 {
+				t.Fatalf("%v: count %d got a weight of %d", counts, counts[i], w)
+			}
+			sum += w
+		}
+		if sum != 100 {
+			t.Fatalf("%v: expected the weights to sum to 100, got %d", counts, sum)
+		}
+	}
+}
+
+func TestPrintCalibratedMix(t *testing.T) {
+	ops := []calibrateOp{{name: "newOrder", count: 45}, {name: "payment", count: 43},
+		{name: "orderStatus", count: 4}, {name: "delivery", count: 4}, {name: "stockLevel", count: 4}}
+	var buf bytes.Buffer
+	if err := printCalibratedMix(&buf, ops); err != nil {
+		t.Fatal(err)
+	}
+	const expected = "--mix=newOrder=45,payment=43,orderStatus=4,delivery=4,stockLevel=4\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+	}
+
+	if err := printCalibratedMix(&buf, []calibrateOp{{name: "a"}}); err == nil {
+		t.Fatal("expected an error for ops matching no statement")
+	}
+}
+
+func TestPrintCalibratedQueries(t *testing.T) {
+	counts := map[string]int64{
+		"SELECT a FROM t WHERE b = _": 750,
+		"UPDATE t SET a = _":          249,
+		"DELETE FROM t":               1,
+	}
+	var buf bytes.Buffer
+	if err := printCalibratedQueries(&buf, counts, 0.001); err != nil {
+		t.Fatal(err)
+	}
+	const expected = `-- count: 750, share: 75.00%
+SELECT a FROM t WHERE b = _;
+-- count: 249, share: 24.90%
+UPDATE t SET a = _;
+-- count: 1, share: 0.10%
+DELETE FROM t;
+`
+	if buf.String() != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+
+	// Fingerprints below the minimum share are omitted.
+	buf.Reset()
+	if err := printCalibratedQueries(&buf, counts, 0.01); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("DELETE")) {
+		t.Fatalf("expected the rare fingerprint to be omitted, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+)
+
+// connFlags are the flags that control how workload connects to a cluster.
+// They are shared by every command that opens a connection.
+var connFlags = pflag.NewFlagSet(`conn`, pflag.ContinueOnError)
+var certsDir = connFlags.String("certs-dir", "",
+	"Directory containing the CA certificate and the client certificates and keys, "+
+		"laid out like the cockroach certs directory. If set, the CRDB URIs are "+
+		"rewritten to connect securely with the certificate of their user.")
+
+var certsCmd = &cobra.Command{
+	Use:   `certs`,
+	Short: `Manage the certificates used to connect to secure clusters`,
+}
+
+var certsCreateClientCmd = &cobra.Command{
+	Use:   `create-client <username>`,
+	Short: `Create a client certificate and key signed by the CA in --certs-dir`,
+	Long: `
+Create a throwaway client certificate and key for the given user. The
+certificate is signed by the CA whose certificate is in --certs-dir and
+whose key is given by --ca-key, and both files are written to --certs-dir,
+where 'workload run --certs-dir' finds them.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCertsCreateClient,
+}
+
+var certsCreateFlags = pflag.NewFlagSet(`certs create-client`, pflag.ContinueOnError)
+var certsCAKey = certsCreateFlags.String("ca-key", "", "Path to the CA key")
+var certsKeySize = certsCreateFlags.Int("key-size", 2048, "Key size in bits")
+var certsLifetime = certsCreateFlags.Duration("lifetime", 24*time.Hour,
+	"Duration until the certificate expires")
+var certsOverwrite = certsCreateFlags.Bool("overwrite", false,
+	"Overwrite the certificate and key if they already exist")
+
+var certsPGURLCmd = &cobra.Command{
+	Use:   `pgurl [CRDB URI...]`,
+	Short: `Print the CRDB URIs rewritten to use the certificates in --certs-dir`,
+	RunE:  runCertsPGURL,
+}
+
+func init() {
+	certsCreateClientCmd.Flags().AddFlagSet(connFlags)
+	certsCreateClientCmd.Flags().AddFlagSet(certsCreateFlags)
+	certsPGURLCmd.Flags().AddFlagSet(connFlags)
+	certsCmd.AddCommand(certsCreateClientCmd)
+	certsCmd.AddCommand(certsPGURLCmd)
+	rootCmd.AddCommand(certsCmd)
+}
+
+func runCertsCreateClient(cmd *cobra.Command, args []string) error {
+	if *certsDir == "" {
+		return errors.New("--certs-dir is required")
+	}
+	if *certsCAKey == "" {
+		return errors.New("--ca-key is required")
+	}
+	return security.CreateClientPair(
+		*certsDir, *certsCAKey, *certsKeySize, *certsLifetime, *certsOverwrite, args[0])
+}
+
+func runCertsPGURL(cmd *cobra.Command, args []string) error {
+	if *certsDir == "" {
+		return errors.New("--certs-dir is required")
+	}
+	if len(args) == 0 {
+		args = []string{crdbDefaultURI}
+	}
+	for _, dbURL := range args {
+		secureURL, err := addCertsToDBURL(dbURL, *certsDir)
+		if err != nil {
+			return err
+		}
+		fmt.Println(secureURL)
+	}
+	return nil
+}
+
+// addCertsToDBURL rewrites a database URL to connect securely using the CA
+// certificate and the client certificate of the URL's user found in
+// certsDir. The user defaults to root. Options already present in the URL
+// are kept, except that sslmode=disable is upgraded to verify-full.
+func addCertsToDBURL(dbURL string, certsDir string) (string, error) {
+	parsedURL, err := url.Parse(dbURL)
+	if err != nil {
+		return "", err
+	}
+	user := security.RootUser
+	if parsedURL.User != nil && parsedURL.User.Username() != "" {
+		user = parsedURL.User.Username()
+	} else {
+		parsedURL.User = url.User(user)
+	}
+
+	cm, err := security.NewCertificateManager(certsDir)
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot load certificates from %s", certsDir)
+	}
+	caCertPath, err := cm.GetCACertPath()
+	if err != nil {
+		return "", err
+	}
+	certPath, keyPath, err := cm.GetClientCertPaths(user)
+	if err != nil {
+		return "", errors.Wrapf(err,
+			"no client certificate for user %s in %s (see 'workload certs create-client')",
+			user, certsDir)
+	}
+
+	options := parsedURL.Query()
+	if mode := options.Get("sslmode"); mode == "" || mode == "disable" {
+		options.Set("sslmode", "verify-full")
+	}
+	setDefault := func(key, value string) {
+		if options.Get(key) == "" {
+			options.Set(key, value)
+		}
+	}
+	setDefault("sslrootcert", caCertPath)
+	setDefault("sslcert", certPath)
+	setDefault("sslkey", keyPath)
+	parsedURL.RawQuery = options.Encode()
+	return parsedURL.String(), nil
+}
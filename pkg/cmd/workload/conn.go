@@ -0,0 +1,85 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"context"
+	gosql "database/sql"
+	"database/sql/driver"
+	"io"
+	"net"
+	"sync/atomic"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+var dedicatedConns = runFlags.Bool("dedicated-conns", false,
+	"Give each worker its own connection, which is re-dialed when it is lost. The "+
+		"operation that lost it is attempted again on the new connection if the generator's "+
+		"operations are idempotent, and fails with the connection error otherwise.")
+
+// workerConn is the dedicated connection of a worker. It is a pool of a
+// single connection, so that the statements prepared by the operation stay
+// valid across re-dials: database/sql prepares them again on the new
+// connection.
+type workerConn struct {
+	db *gosql.DB
+	// redials counts the connections dialed to replace a lost one, and
+	// replays the operations attempted again on them.
+	redials, replays int64
+}
+
+func openWorkerConn(dbURLs []string) (*workerConn, error) {
+	db, err := setupCockroach(dbURLs)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	return &workerConn{db: db}, nil
+}
+
+// redial closes the worker's connection, which must not be in use, and
+// dials a new one. With the cockroach driver, the new connection goes to the
+// next URL.
+func (c *workerConn) redial(ctx context.Context) error {
+	atomic.AddInt64(&c.redials, 1)
+	// The lost connection is back in the pool unless the driver already
+	// discarded it. Disallowing idle connections closes it, instead of
+	// leaving the next statement to find out that it is broken.
+	c.db.SetMaxIdleConns(0)
+	c.db.SetMaxIdleConns(1)
+	return errors.Wrap(c.db.PingContext(ctx), `re-dialing lost connection`)
+}
+
+func (c *workerConn) stats() (redials, replays int64) {
+	return atomic.LoadInt64(&c.redials), atomic.LoadInt64(&c.replays)
+}
+
+// isConnLoss returns whether err means that the connection was lost, in
+// which case the outcome of the statement that returned it is unknown.
+func isConnLoss(err error) bool {
+	switch err := errors.Cause(err).(type) {
+	case *pq.Error:
+		// Class 08 is connection exceptions.
+		return err.Code.Class() == "08"
+	case net.Error:
+		return true
+	default:
+		return err == driver.ErrBadConn || err == io.EOF || err == io.ErrUnexpectedEOF
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"context"
+	gosql "database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils/workload"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+var connStorm = runFlags.Bool("conn-storm", false,
+	"Instead of the generator's operations, have each worker repeatedly open a new "+
+		"connection, run a trivial query on it and close it, to measure how many connections "+
+		"per second the cluster can authenticate. The handshake is a TLS one, with client "+
+		"certificates, if --certs-dir is set. The latencies of the handshakes and of the "+
+		"queries are reported as connect and query.")
+
+// connStormOpName is the name of the operation of --conn-storm, a whole
+// connection cycle.
+const connStormOpName = `conn-storm`
+
+// openConnStormPool returns the pool the workers of --conn-storm open their
+// connections from. It keeps no idle connection, so that each connection
+// taken from it is dialed and authenticated, and closed once released.
+func openConnStormPool(dbURLs []string) (*gosql.DB, error) {
+	db, err := setupCockroach(dbURLs)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxIdleConns(0)
+	return db, nil
+}
+
+// connStormOp is the operation of --conn-storm. It must be given a pool
+// opened by openConnStormPool.
+var connStormOp = workload.Operation{
+	Name: connStormOpName,
+	Fn: func(db *gosql.DB) (func(context.Context) error, error) {
+		return func(ctx context.Context) error {
+			start := timeutil.Now()
+			// The pool has no idle connection, so this dials a new one.
+			conn, err := db.Conn(ctx)
+			if err != nil {
+				return errors.Wrap(err, `connecting`)
+			}
+			workload.RecordLatency(ctx, `connect`, timeutil.Since(start))
+			// Closing the connection closes its network connection, which the
+			// pool doesn't keep.
+			defer conn.Close()
+
+			start = timeutil.Now()
+			if _, err := conn.ExecContext(ctx, `SELECT 1`); err != nil {
+				return err
+			}
+			workload.RecordLatency(ctx, `query`, timeutil.Since(start))
+			return nil
+		}, nil
+	},
+	// A connection cycle changes no data.
+	Idempotent: true,
+}
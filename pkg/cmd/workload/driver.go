@@ -22,8 +22,15 @@ import (
 	"sync/atomic"
 
 	"github.com/lib/pq"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils/workload"
 )
 
+var driverName = connFlags.String("driver", string(workload.CockroachDialect),
+	"Database to connect to: cockroach to spread the connections over the URLs of the "+
+		"nodes of a CockroachDB cluster, or postgres to run against a single PostgreSQL "+
+		"server, whose test database must exist.")
+
 // cockroachDriver is a wrapper around lib/pq which provides for round-robin
 // load balancing amongst a list of URLs. The name passed to Open() is a space
 // separated list of "postgres" URLs to connect to.
@@ -0,0 +1,138 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// errorAction is what to do when an operation returns an error.
+type errorAction int
+
+const (
+	// errorActionAbort stops the workload and returns the error.
+	errorActionAbort errorAction = iota
+	// errorActionContinue counts and logs the error, then moves on to the
+	// next operation.
+	errorActionContinue
+	// errorActionRetry runs the operation again, with backoff, until it
+	// succeeds or fails with an error that isn't retried. The retried errors
+	// aren't counted, and the latency of the operation includes the retries.
+	errorActionRetry
+)
+
+var errorActionNames = map[string]errorAction{
+	`abort`:    errorActionAbort,
+	`continue`: errorActionContinue,
+	`retry`:    errorActionRetry,
+}
+
+// errorPolicyAny matches every error, including those without a SQLSTATE
+// code (e.g. network errors).
+const errorPolicyAny = `*`
+
+// errorPolicy maps SQLSTATE codes to the action to take on errors with that
+// code. An entry is either a full five-character code (e.g. 40001), a
+// two-character class (e.g. 40, for all transaction rollbacks) or *. The most
+// specific entry matching an error wins; errors matching no entry use the
+// default action.
+type errorPolicy struct {
+	actions       map[string]errorAction
+	defaultAction errorAction
+}
+
+// makeErrorPolicy builds the policy from the entries in the file at path, if
+// any, followed by the given CODE=ACTION entries, which take precedence.
+func makeErrorPolicy(
+	path string, entries []string, defaultAction errorAction,
+) (errorPolicy, error) {
+	p := errorPolicy{actions: make(map[string]errorAction), defaultAction: defaultAction}
+	if path != `` {
+		if err := p.addFile(path); err != nil {
+			return errorPolicy{}, err
+		}
+	}
+	for _, entry := range entries {
+		if err := p.add(entry); err != nil {
+			return errorPolicy{}, errors.Wrap(err, `invalid --error-policy`)
+		}
+	}
+	return p, nil
+}
+
+// addFile adds the entries of a policy file. The file contains one CODE=ACTION
+// entry per line; blank lines and lines starting with # are ignored.
+func (p *errorPolicy) addFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == `` || strings.HasPrefix(line, `#`) {
+			continue
+		}
+		if err := p.add(line); err != nil {
+			return errors.Wrapf(err, `%s:%d`, path, lineNum)
+		}
+	}
+	return scanner.Err()
+}
+
+func (p *errorPolicy) add(entry string) error {
+	kv := strings.SplitN(entry, `=`, 2)
+	if len(kv) != 2 {
+		return errors.Errorf(`%s: not a CODE=ACTION pair`, entry)
+	}
+	code, name := strings.ToUpper(strings.TrimSpace(kv[0])), strings.TrimSpace(kv[1])
+	if code != errorPolicyAny && len(code) != 2 && len(code) != 5 {
+		return errors.Errorf(`%s: %q is neither a SQLSTATE code, a class of codes nor *`,
+			entry, code)
+	}
+	action, ok := errorActionNames[strings.ToLower(name)]
+	if !ok {
+		return errors.Errorf(`%s: unknown action %q (expected abort, continue or retry)`,
+			entry, name)
+	}
+	p.actions[code] = action
+	return nil
+}
+
+// action returns the action to take on the error.
+func (p errorPolicy) action(err error) errorAction {
+	if pqErr, ok := errors.Cause(err).(*pq.Error); ok {
+		code := string(pqErr.Code)
+		if action, ok := p.actions[code]; ok {
+			return action
+		}
+		if len(code) == 5 {
+			if action, ok := p.actions[code[:2]]; ok {
+				return action
+			}
+		}
+	}
+	if action, ok := p.actions[errorPolicyAny]; ok {
+		return action
+	}
+	return p.defaultAction
+}
@@ -0,0 +1,162 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils/workload"
+	"github.com/cockroachdb/cockroach/pkg/testutils/workload/histogram"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+var growthStages = runFlags.Int("growth-stages", 0,
+	"Number of data growth phases, each followed by a measurement phase of --duration "+
+		"(as is the first phase). The operations are paused while the tables are grown "+
+		"by --growth-percent with bulk inserts of the rows following their initial data, "+
+		"which they must hold only (e.g. with --drop). If 0, the data is not grown.")
+var growthPercent = runFlags.Float64("growth-percent", 100,
+	"Percentage by which each data growth phase of --growth-stages grows the tables")
+
+// stagedGrowth alternates the measurement phases of --growth-stages, during
+// which the workers run, with its growth phases, during which they are
+// paused while the tables grow, and reports how the latencies of the
+// operations change with the size of the data.
+type stagedGrowth struct {
+	db *gosql.DB
+	// tables are the tables with initial data, and rows their numbers of
+	// rows, which are also the indexes of the next rows to insert.
+	tables []workload.Table
+	rows   []int
+	// pause is held for reading by the workers around each operation, and
+	// for writing during the growth phases to hold off the operations.
+	pause sync.RWMutex
+
+	// growths is the number of growth phases that ran.
+	growths    int
+	stages     []growthStage
+	stageStart time.Time
+	// last holds the figures of the registry at the end of the previous
+	// measurement phase.
+	last map[string]histogram.Counts
+}
+
+// growthStage holds the figures of a measurement phase.
+type growthStage struct {
+	rows    int
+	elapsed time.Duration
+	counts  map[string]histogram.Counts
+}
+
+func newStagedGrowth(db *gosql.DB, gen workload.Generator) (*stagedGrowth, error) {
+	g := &stagedGrowth{db: db, stageStart: timeutil.Now()}
+	for _, table := range gen.Tables() {
+		if table.InitialRowFn == nil || table.InitialRowCount == 0 {
+			continue
+		}
+		g.tables = append(g.tables, table)
+		g.rows = append(g.rows, table.InitialRowCount)
+	}
+	if len(g.tables) == 0 {
+		return nil, errors.Errorf(
+			"'growth-stages' flag requires initial data, which %s has none of", gen.Meta().Name)
+	}
+	return g, nil
+}
+
+// totalRows returns the number of rows of the tables.
+func (g *stagedGrowth) totalRows() int {
+	var total int
+	for _, rows := range g.rows {
+		total += rows
+	}
+	return total
+}
+
+// endStage records the figures of the measurement phase that ends. The
+// registry must have just ticked, with the workers paused.
+func (g *stagedGrowth) endStage(reg *histogram.Registry) {
+	cumulative := reg.Cumulative()
+	counts := make(map[string]histogram.Counts, len(cumulative))
+	for name, c := range cumulative {
+		counts[name] = c.Sub(g.last[name])
+	}
+	g.stages = append(g.stages, growthStage{
+		rows:    g.totalRows(),
+		elapsed: timeutil.Since(g.stageStart),
+		counts:  counts,
+	})
+	g.last = cumulative
+}
+
+// grow grows each table by --growth-percent, with the workers paused, and
+// starts the next measurement phase.
+func (g *stagedGrowth) grow(ctx context.Context) error {
+	start := timeutil.Now()
+	var sizes []string
+	for i, table := range g.tables {
+		rows := g.rows[i] + int(math.Ceil(*growthPercent*float64(g.rows[i])/100))
+		if _, err := workload.LoadRows(ctx, g.db, table, g.rows[i], rows, 0 /* batchSize */); err != nil {
+			return err
+		}
+		sizes = append(sizes, fmt.Sprintf("%s %d -> %d rows", table.Name, g.rows[i], rows))
+		g.rows[i] = rows
+	}
+	g.growths++
+	fmt.Printf("growth phase %d of %d: %s (%.1fs)\n",
+		g.growths, *growthStages, strings.Join(sizes, ", "), timeutil.Since(start).Seconds())
+	g.stageStart = timeutil.Now()
+	return nil
+}
+
+// report prints a line per measurement phase and name summarizing the
+// operations run during the phase.
+func (g *stagedGrowth) report(w io.Writer) {
+	fmt.Fprintln(w, "\n_stage_______rows__elapsed___ops/sec__avg(ms)__p50(ms)__p95(ms)__p99(ms)_pMax(ms)__name")
+	for i, s := range g.stages {
+		names := make([]string, 0, len(s.counts))
+		for name := range s.counts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			c := s.counts[name]
+			if c.Hist.TotalCount() == 0 && c.Ops == 0 {
+				continue
+			}
+			fmt.Fprintf(w, "%6d %10d %7.1fs %9.1f %8.1f %8.1f %8.1f %8.1f %8.1f  %s\n",
+				i, s.rows, s.elapsed.Seconds(),
+				float64(c.Ops)/s.elapsed.Seconds(),
+				time.Duration(c.Hist.Mean()).Seconds()*1000,
+				millis(c.Hist.ValueAtQuantile(50)),
+				millis(c.Hist.ValueAtQuantile(95)),
+				millis(c.Hist.ValueAtQuantile(99)),
+				millis(c.Hist.ValueAtQuantile(100)),
+				name)
+		}
+	}
+	fmt.Fprintln(w)
+}
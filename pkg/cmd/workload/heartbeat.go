@@ -0,0 +1,86 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+var heartbeatInterval = runFlags.Duration("heartbeat-interval", 0,
+	"Interval at which the client's liveness and progress are written to the "+
+		"test.workload_heartbeat table. If 0, no heartbeat is written.")
+var heartbeatClientID = runFlags.String("heartbeat-client-id", "",
+	"Identifies the client in the heartbeat table. Defaults to hostname:pid.")
+
+// heartbeatWriter periodically upserts a row with the number of operations
+// run so far into the heartbeat table, so that the liveness and progress of
+// workload clients can be monitored through the database itself.
+type heartbeatWriter struct {
+	db       *gosql.DB
+	clientID string
+	interval time.Duration
+	// ops returns the cumulative number of successful operations.
+	ops func() uint64
+}
+
+func defaultHeartbeatClientID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = `unknown`
+	}
+	return fmt.Sprintf(`%s:%d`, hostname, os.Getpid())
+}
+
+// init creates the heartbeat table if it doesn't exist.
+func (h heartbeatWriter) init() error {
+	_, err := h.db.Exec(`CREATE TABLE IF NOT EXISTS test.workload_heartbeat (
+	client_id STRING PRIMARY KEY,
+	updated   TIMESTAMP NOT NULL,
+	ops       INT NOT NULL
+)`)
+	return err
+}
+
+func (h heartbeatWriter) write(ctx context.Context) error {
+	_, err := h.db.ExecContext(ctx,
+		`UPSERT INTO test.workload_heartbeat (client_id, updated, ops) VALUES ($1, now(), $2)`,
+		h.clientID, int64(h.ops()))
+	return err
+}
+
+// run writes a heartbeat every interval until the context is canceled.
+// Failing to write a heartbeat does not stop the workload; the missing
+// heartbeats are what the monitoring systems are looking for.
+func (h heartbeatWriter) run(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		if err := h.write(ctx); err != nil && ctx.Err() == nil {
+			log.Warningf(ctx, "failed to write heartbeat: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils/workload/results"
+)
+
+// collectHostInfo describes the machine the workload runs on. The fields that
+// can't be detected on this platform are left empty.
+func collectHostInfo() results.Host {
+	h := results.Host{
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		NumCPU:     runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		GoVersion:  runtime.Version(),
+	}
+	h.Hostname, _ = os.Hostname()
+	if runtime.GOOS == "linux" {
+		h.Kernel = linuxKernelRelease()
+		h.CPUModel = linuxCPUModel()
+		h.NICSpeedMbps = linuxNICSpeedMbps()
+	}
+	return h
+}
+
+// hostBenchmarkSuffix returns the components added to the benchmark name for
+// the host, which are limited to the core counts to keep the names short. The
+// other fields are only reported in the JSON results.
+func hostBenchmarkSuffix(h results.Host) string {
+	return fmt.Sprintf("/client-cpus=%d/client-gomaxprocs=%d", h.NumCPU, h.GOMAXPROCS)
+}
+
+func linuxKernelRelease() string {
+	release, err := ioutil.ReadFile("/proc/sys/kernel/osrelease")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(release))
+}
+
+func linuxCPUModel() string {
+	f, err := os.Open("/proc/cpuinfo")
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Lines are formatted as "model name	: Intel(R) Xeon(R) ...".
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) == 2 && strings.TrimSpace(parts[0]) == "model name" {
+			return strings.TrimSpace(parts[1])
+		}
+	}
+	return ""
+}
+
+func linuxNICSpeedMbps() int {
+	paths, err := filepath.Glob("/sys/class/net/*/speed")
+	if err != nil {
+		return 0
+	}
+	var max int
+	for _, path := range paths {
+		// Reading the speed of an interface that is down or virtual fails or
+		// returns -1.
+		speed, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(string(speed))); err == nil && n > max {
+			max = n
+		}
+	}
+	return max
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"context"
+	gosql "database/sql"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+var idleConns = runFlags.Int("idle-conns", 0,
+	"Number of extra connections opened and held idle alongside the workers, to measure "+
+		"how idle connections affect the memory of the servers and the latency of the "+
+		"operations.")
+var idlePingInterval = runFlags.Duration("idle-ping-interval", 0,
+	"Interval at which each idle connection runs a trivial query, as the connection "+
+		"pools of applications do. If 0, the idle connections send nothing.")
+
+// idleConnPool holds connections open without running operations on them.
+type idleConnPool struct {
+	db    *gosql.DB
+	conns []*gosql.Conn
+}
+
+// openIdleConns opens n connections to the given URLs, which are spread over
+// them like the connections of the workers.
+func openIdleConns(ctx context.Context, dbURLs []string, n int) (*idleConnPool, error) {
+	db, err := setupCockroach(dbURLs)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(n)
+	db.SetMaxIdleConns(n)
+
+	p := &idleConnPool{db: db}
+	for i := 0; i < n; i++ {
+		// The pool has no free connection, so this dials a new one.
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			p.close()
+			return nil, errors.Wrapf(err, "opening idle connection %d of %d", i+1, n)
+		}
+		p.conns = append(p.conns, conn)
+	}
+	return p, nil
+}
+
+// run pings each connection every interval until the context is canceled.
+// The pings of the different connections are spread over the interval.
+// Failing to ping does not stop the workload.
+func (p *idleConnPool) run(ctx context.Context, interval time.Duration) {
+	for _, conn := range p.conns {
+		go func(conn *gosql.Conn) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Duration(rand.Int63n(int64(interval)))):
+			}
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				if _, err := conn.ExecContext(ctx, `SELECT 1`); err != nil && ctx.Err() == nil {
+					log.Warningf(ctx, "failed to ping idle connection: %v", err)
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}(conn)
+	}
+}
+
+func (p *idleConnPool) close() {
+	for _, conn := range p.conns {
+		_ = conn.Close()
+	}
+	_ = p.db.Close()
+}
@@ -16,6 +16,8 @@
 package main
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 
 	_ "github.com/cockroachdb/cockroach/pkg/ccl/testutilsccl/workloadccl/allccl"
@@ -26,5 +28,8 @@ var rootCmd = &cobra.Command{
 }
 
 func main() {
-	_ = rootCmd.Execute()
+	if err := rootCmd.Execute(); err == errAssertionsFailed {
+		// cobra has printed the error already.
+		os.Exit(1)
+	}
 }
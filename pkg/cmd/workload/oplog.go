@@ -0,0 +1,93 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils/workload"
+)
+
+var opLogSize = runFlags.Int("op-log-size", 16,
+	"Number of most recent operations remembered by each worker and printed when "+
+		"the worker hits an error that stops the workload. If 0, nothing is remembered.")
+
+type opLogEntry struct {
+	start   time.Time
+	latency time.Duration
+	details workload.OpDetails
+	err     error
+}
+
+// opLog is a ring buffer of the most recent operations run by a worker, to
+// help debug the sporadic failures of long runs. It is not safe for
+// concurrent use.
+type opLog struct {
+	entries []opLogEntry
+	// next is the index of the entry overwritten by the next operation.
+	next int
+	// count is the total number of operations recorded.
+	count int
+}
+
+func newOpLog(size int) *opLog {
+	return &opLog{entries: make([]opLogEntry, size)}
+}
+
+func (l *opLog) record(e opLogEntry) {
+	l.entries[l.next] = e
+	l.next = (l.next + 1) % len(l.entries)
+	l.count++
+}
+
+// dump writes the remembered operations, oldest first. Operations that
+// didn't report their type are shown with the given default type.
+func (l *opLog) dump(w io.Writer, header string, defaultType string) {
+	n := len(l.entries)
+	if l.count < n {
+		n = l.count
+	}
+	fmt.Fprintf(w, "%s: last %d of %d operations, oldest first\n", header, n, l.count)
+	fmt.Fprintln(w, "__________start_____________type__params-hash__latency(ms)__result")
+	for i := 0; i < n; i++ {
+		e := l.entries[(l.next-n+i+len(l.entries))%len(l.entries)]
+		typ := e.details.Type
+		if typ == "" {
+			typ = defaultType
+		}
+		result := "ok"
+		if e.err != nil {
+			result = e.err.Error()
+		}
+		fmt.Fprintf(w, "%15s %16s %12s %12.1f  %s\n",
+			e.start.Format("15:04:05.000000"), typ, paramsHash(e.details.Params),
+			e.latency.Seconds()*1000, result)
+	}
+}
+
+// paramsHash returns a short hash of the parameters of an operation, which
+// is enough to tell whether operations ran with the same parameters.
+func paramsHash(params []interface{}) string {
+	if params == nil {
+		return "-"
+	}
+	h := fnv.New32a()
+	fmt.Fprint(h, params...)
+	return fmt.Sprintf("%08x", h.Sum32())
+}
@@ -0,0 +1,151 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils/workload"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+var skipWait = runFlags.Bool("skip-wait", false,
+	"Start running without waiting for the ranges of the workload's tables to be fully "+
+		"replicated and for their leases to be spread over the nodes")
+var waitTimeout = runFlags.Duration("wait-timeout", 10*time.Minute,
+	"Maximum time to wait for the ranges of the workload's tables to be fully replicated. "+
+		"The workload runs anyway once it has elapsed.")
+
+// defaultReplicationFactor is the number of replicas of the ranges in the
+// default zone config. The ranges of smaller clusters are fully replicated
+// with a replica on each node.
+const defaultReplicationFactor = 3
+
+// replicationStatus describes how far the replication of the ranges of the
+// workload's tables is from completion.
+type replicationStatus struct {
+	nodes int
+	// ranges is the number of ranges of the tables, and underReplicated the
+	// number of these ranges with fewer replicas than the target.
+	ranges, underReplicated int
+	// leaseHolders is the number of nodes holding the lease of a range.
+	leaseHolders int
+}
+
+func (s replicationStatus) targetReplicas() int {
+	if s.nodes < defaultReplicationFactor {
+		return s.nodes
+	}
+	return defaultReplicationFactor
+}
+
+// done returns whether all the ranges are fully replicated and their
+// leases are spread over as many nodes as possible.
+func (s replicationStatus) done() bool {
+	wantLeaseHolders := s.nodes
+	if s.ranges < wantLeaseHolders {
+		wantLeaseHolders = s.ranges
+	}
+	return s.underReplicated == 0 && s.leaseHolders >= wantLeaseHolders
+}
+
+func (s replicationStatus) String() string {
+	return fmt.Sprintf("%d of %d ranges under-replicated, leases on %d of %d nodes",
+		s.underReplicated, s.ranges, s.leaseHolders, s.nodes)
+}
+
+func getReplicationStatus(
+	ctx context.Context, db *gosql.DB, tables []workload.Table,
+) (replicationStatus, error) {
+	var s replicationStatus
+	if err := db.QueryRowContext(ctx,
+		`SELECT count(*) FROM crdb_internal.gossip_nodes`,
+	).Scan(&s.nodes); err != nil {
+		return s, err
+	}
+
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		names[i] = fmt.Sprintf(`'%s'`, table.Name)
+	}
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(
+		`SELECT array_length(replicas, 1), lease_holder_node FROM crdb_internal.ranges `+
+			`WHERE database = 'test' AND "table" IN (%s)`, strings.Join(names, `, `)))
+	if err != nil {
+		return s, err
+	}
+	defer rows.Close()
+	leaseHolders := make(map[int64]struct{})
+	for rows.Next() {
+		var replicas int
+		var leaseHolder gosql.NullInt64
+		if err := rows.Scan(&replicas, &leaseHolder); err != nil {
+			return s, err
+		}
+		s.ranges++
+		if replicas < s.targetReplicas() {
+			s.underReplicated++
+		}
+		// The lease holder is NULL when no replica could report it.
+		if leaseHolder.Valid {
+			leaseHolders[leaseHolder.Int64] = struct{}{}
+		}
+	}
+	s.leaseHolders = len(leaseHolders)
+	return s, rows.Err()
+}
+
+// waitForReplication waits until the ranges of the given tables are fully
+// replicated and their leases are spread over the nodes, so that the
+// rebalancing that follows init and the splits doesn't happen while the
+// workload is measured. Once timeout has elapsed, it logs a warning and
+// returns without error.
+func waitForReplication(
+	ctx context.Context, db *gosql.DB, tables []workload.Table, timeout time.Duration,
+) error {
+	if len(tables) == 0 {
+		return nil
+	}
+	const pollInterval = time.Second
+	start := timeutil.Now()
+	for {
+		s, err := getReplicationStatus(ctx, db, tables)
+		if err != nil {
+			return errors.Wrap(err, `waiting for replication (use --skip-wait to skip)`)
+		}
+		if s.done() {
+			log.Infof(ctx, `%d ranges fully replicated after %s`, s.ranges, timeutil.Since(start))
+			return nil
+		}
+		if timeutil.Since(start) > timeout {
+			log.Warningf(ctx, `running before replication is done after waiting %s: %s`, timeout, s)
+			return nil
+		}
+		log.Infof(ctx, `waiting for replication: %s`, s)
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
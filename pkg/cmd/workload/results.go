@@ -0,0 +1,76 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/codahale/hdrhistogram"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils/workload/results"
+)
+
+var jsonResults = runFlags.String("json-results", "",
+	"Write the results of the run as JSON to file, or stdout if - is specified. "+
+		"See the package pkg/testutils/workload/results for their format.")
+
+func millis(v int64) float64 {
+	return time.Duration(v).Seconds() * 1000
+}
+
+func makeRunResults(
+	benchmark, generator string,
+	elapsed time.Duration,
+	ops uint64,
+	numErr int,
+	redials, replays int64,
+	total *hdrhistogram.Histogram,
+	host results.Host,
+) results.Run {
+	return results.Run{
+		Benchmark:   benchmark,
+		Generator:   generator,
+		Concurrency: *concurrency,
+		ElapsedSec:  elapsed.Seconds(),
+		Ops:         ops,
+		Errors:      numErr,
+		Redials:     redials,
+		Replays:     replays,
+		OpsPerSec:   float64(ops) / elapsed.Seconds(),
+		AvgMs:       time.Duration(total.Mean()).Seconds() * 1000,
+		P50Ms:       millis(total.ValueAtQuantile(50)),
+		P95Ms:       millis(total.ValueAtQuantile(95)),
+		P99Ms:       millis(total.ValueAtQuantile(99)),
+		MaxMs:       millis(total.ValueAtQuantile(100)),
+		Host:        host,
+	}
+}
+
+// writeResultsJSON writes the results to the file at path, or to stdout if
+// path is -.
+func writeResultsJSON(r results.Run, path string) error {
+	data, err := results.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if path == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
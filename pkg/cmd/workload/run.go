@@ -19,6 +19,7 @@ import (
 	"context"
 	gosql "database/sql"
 	"fmt"
+	"math/rand"
 	"net/url"
 	"os"
 	"os/signal"
@@ -32,19 +33,22 @@ import (
 
 	"golang.org/x/time/rate"
 
-	"github.com/codahale/hdrhistogram"
+	isatty "github.com/mattn/go-isatty"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
-	"github.com/tylertreat/hdrhistogram-writer"
 
 	"github.com/cockroachdb/cockroach/pkg/testutils/workload"
+	"github.com/cockroachdb/cockroach/pkg/testutils/workload/histogram"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
-	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 )
 
-const crdbDefaultURI = `postgres://root@localhost:26257?sslmode=disable`
+const (
+	crdbDefaultURI     = `postgres://root@localhost:26257?sslmode=disable`
+	postgresDefaultURI = `postgres://postgres@localhost:5432?sslmode=disable`
+)
 
 var runCmd = &cobra.Command{
 	Use:   `run`,
@@ -55,11 +59,28 @@ var runFlags = pflag.NewFlagSet(`run`, pflag.ContinueOnError)
 var concurrency = runFlags.Int(
 	"concurrency", 2*runtime.NumCPU(), "Number of concurrent writers inserting blocks")
 var tolerateErrors = runFlags.Bool("tolerate-errors", false, "Keep running on error")
+var errorPolicyEntries = runFlags.StringArray("error-policy", nil,
+	"Action to take on errors with a SQLSTATE code, as CODE=ACTION where CODE is a "+
+		"code (e.g. 40001), a class of codes (e.g. 40) or * and ACTION is abort, continue "+
+		"or retry. May be repeated; overrides --error-policy-file and --tolerate-errors.")
+var errorPolicyFile = runFlags.String("error-policy-file", "",
+	"File containing CODE=ACTION entries as in --error-policy, one per line")
 var maxRate = runFlags.Float64(
 	"max-rate", 0, "Maximum frequency of operations (reads/writes). If 0, no limit.")
 var maxOps = runFlags.Uint64("max-ops", 0, "Maximum number of operations to run")
 var duration = runFlags.Duration("duration", 0, "The duration to run. If 0, run forever.")
 var doInit = runFlags.Bool("init", false, "Automatically run init")
+var splitTimeout = runFlags.Duration("split-timeout", 0,
+	"Maximum time to split each table into its initial ranges. If 0, no limit.")
+var displayEvery = runFlags.Duration("display-every", time.Second,
+	"How often to print the throughput and latencies of the last interval. May be below a "+
+		"second (e.g. 100ms) to observe short transients.")
+var ui = runFlags.Bool("ui", false,
+	"Display the throughput, latencies and errors as a dashboard redrawn in place every "+
+		"--display-every rather than as lines of text. Requires a terminal.")
+var latencySampleRate = runFlags.Float64("latency-sample-rate", 1,
+	"Fraction of operations whose latency is recorded. Lower values reduce the client's "+
+		"overhead at very high operation rates.")
 
 var initCmd = &cobra.Command{
 	Use:   `init`,
@@ -68,6 +89,9 @@ var initCmd = &cobra.Command{
 
 var initFlags = pflag.NewFlagSet(`init`, pflag.ContinueOnError)
 var drop = initFlags.Bool("drop", false, "Drop the existing database, if it exists")
+var initTimeout = initFlags.Duration("init-timeout", 0,
+	"Maximum time to create the tables and load their initial data, including the "+
+		"attempts made again under --error-policy. If 0, no limit.")
 
 // Output in HdrHistogram Plotter format. See
 // https://hdrhistogram.github.io/HdrHistogram/plotFiles.html
@@ -83,6 +107,7 @@ func init() {
 
 		genInitCmd := &cobra.Command{Use: meta.Name, Short: meta.Description}
 		genInitCmd.Flags().AddFlagSet(initFlags)
+		genInitCmd.Flags().AddFlagSet(connFlags)
 		genInitCmd.Flags().AddFlagSet(genFlags)
 		genInitCmd.RunE = func(cmd *cobra.Command, args []string) error {
 			if genHooks.Validate != nil {
@@ -96,6 +121,7 @@ func init() {
 
 		genRunCmd := &cobra.Command{Use: meta.Name, Short: meta.Description}
 		genRunCmd.Flags().AddFlagSet(runFlags)
+		genRunCmd.Flags().AddFlagSet(connFlags)
 		genRunCmd.Flags().AddFlagSet(genFlags)
 		initFlags.VisitAll(func(initFlag *pflag.Flag) {
 			// Every init flag is a valid run flag that implies the --init option.
@@ -117,76 +143,238 @@ func init() {
 	rootCmd.AddCommand(runCmd)
 }
 
-// numOps keeps a global count of successful operations.
-var numOps uint64
+// maxOpsCount keeps a global count of successful operations. It is only
+// maintained when --max-ops is set, as it is shared by all workers; the
+// per-worker counts are used everywhere else.
+var maxOpsCount uint64
 
-const (
-	minLatency = 100 * time.Microsecond
-	maxLatency = 10 * time.Second
-)
+type worker struct {
+	idx       int
+	db        *gosql.DB
+	opName    string
+	op        func(context.Context) error
+	errPolicy errorPolicy
+	latency   *histogram.Stripe
+	// measured holds the Stripes of the latencies the operation measures
+	// besides its own, by name. They are created from reg when first used.
+	reg      *histogram.Registry
+	measured map[string]*histogram.Stripe
+	// rng decides which operations have their latency sampled. It is owned
+	// by the worker to avoid the lock around the global source.
+	rng *rand.Rand
+	// opLog remembers the most recent operations, if --op-log-size is set.
+	opLog *opLog
+	// conn is the dedicated connection of the worker, if --dedicated-conns
+	// is set, and idempotent whether op may be attempted again on a new
+	// connection when the connection is lost.
+	conn       *workerConn
+	idempotent bool
+	// limiterWait and opTime are the total nanoseconds the worker spent
+	// blocked on the --max-rate limiter and executing operations. They are
+	// only maintained when --max-rate is set.
+	limiterWait int64
+	opTime      int64
+	// pause is held for reading around each operation if --growth-stages is
+	// set, so that the growth phases can hold off the operations.
+	pause *sync.RWMutex
+}
 
-func clampLatency(d, min, max time.Duration) time.Duration {
-	if d < min {
-		return min
+func newWorker(
+	idx int,
+	db *gosql.DB,
+	opName string,
+	op func(context.Context) error,
+	errPolicy errorPolicy,
+	reg *histogram.Registry,
+) *worker {
+	w := &worker{
+		idx:       idx,
+		db:        db,
+		opName:    opName,
+		op:        op,
+		errPolicy: errPolicy,
+		latency:   reg.NewStripe(opName),
+		reg:       reg,
+		measured:  make(map[string]*histogram.Stripe),
+		rng:       rand.New(rand.NewSource(int64(idx))),
 	}
-	if d > max {
-		return max
+	if *opLogSize > 0 {
+		w.opLog = newOpLog(*opLogSize)
 	}
-	return d
+	return w
 }
 
-type worker struct {
-	db      *gosql.DB
-	op      func(context.Context) error
-	latency struct {
-		syncutil.Mutex
-		*hdrhistogram.WindowedHistogram
-	}
+// workerError is an error returned by an operation of a worker.
+type workerError struct {
+	worker *worker
+	err    error
 }
 
-func newWorker(db *gosql.DB, op func(context.Context) error) *worker {
-	w := &worker{
-		db: db,
-		op: op,
+// RecordLatency implements the workload.LatencyRecorder interface.
+func (w *worker) RecordLatency(name string, d time.Duration) {
+	s, ok := w.measured[name]
+	if !ok {
+		s = w.reg.NewStripe(name)
+		w.measured[name] = s
 	}
-	w.latency.WindowedHistogram = hdrhistogram.NewWindowed(1,
-		minLatency.Nanoseconds(), maxLatency.Nanoseconds(), 1)
-	return w
+	s.Record(d)
+}
+
+// sampleLatency returns whether the latency of the next operation should be
+// recorded.
+func (w *worker) sampleLatency() bool {
+	return *latencySampleRate >= 1 || w.rng.Float64() < *latencySampleRate
 }
 
 // run is an infinite loop in which the worker continuously attempts to
 // read / write blocks of random data into a table in cockroach DB.
 func (w *worker) run(
-	ctx context.Context, errCh chan<- error, wg *sync.WaitGroup, limiter *rate.Limiter,
+	ctx context.Context, errCh chan<- workerError, wg *sync.WaitGroup, limiter *rate.Limiter,
 ) {
 	defer wg.Done()
 
+	opCtx := workload.WithLatencyRecorder(ctx, w)
+	var details workload.OpDetails
+	if w.opLog != nil {
+		opCtx = workload.WithOpDetails(opCtx, &details)
+	}
+
 	for {
 		// Limit how quickly the load generator sends requests based on --max-rate.
+		var opStart time.Time
 		if limiter != nil {
+			waitStart := timeutil.Now()
 			if err := limiter.Wait(ctx); err != nil {
 				panic(err)
 			}
+			opStart = timeutil.Now()
+			atomic.AddInt64(&w.limiterWait, int64(opStart.Sub(waitStart)))
 		}
 
-		start := timeutil.Now()
-		if err := w.op(ctx); err != nil {
-			errCh <- err
+		if w.pause != nil {
+			w.pause.RLock()
+		}
+		sample := w.sampleLatency()
+		timed := sample || w.opLog != nil
+		var start time.Time
+		if timed {
+			start = timeutil.Now()
+		}
+		details = workload.OpDetails{}
+		err := w.runOp(opCtx)
+		if err != nil && w.errPolicy.action(err) == errorActionRetry {
+			err = w.retryOp(opCtx, err)
+		}
+		if limiter != nil {
+			atomic.AddInt64(&w.opTime, int64(timeutil.Since(opStart)))
+		}
+		var elapsed time.Duration
+		if timed {
+			elapsed = timeutil.Since(start)
+		}
+		if w.pause != nil {
+			w.pause.RUnlock()
+		}
+		if w.opLog != nil {
+			w.opLog.record(opLogEntry{start: start, latency: elapsed, details: details, err: err})
+		}
+		if err != nil {
+			switch w.errPolicy.action(err) {
+			case errorActionAbort:
+				// The workload stops on this error and dumps the operation log,
+				// which mustn't change anymore.
+				errCh <- workerError{worker: w, err: err}
+				return
+			case errorActionContinue:
+				errCh <- workerError{worker: w, err: err}
+			}
+			// An error the policy retries is only left when the workload is
+			// stopping.
 			continue
 		}
-		elapsed := clampLatency(timeutil.Since(start), minLatency, maxLatency)
-		w.latency.Lock()
-		if err := w.latency.Current.RecordValue(elapsed.Nanoseconds()); err != nil {
-			log.Fatal(ctx, err)
+		if sample {
+			w.latency.Record(elapsed)
 		}
-		w.latency.Unlock()
-		v := atomic.AddUint64(&numOps, 1)
-		if *maxOps > 0 && v >= *maxOps {
+		w.latency.IncOps()
+		if *maxOps > 0 && atomic.AddUint64(&maxOpsCount, 1) >= *maxOps {
 			return
 		}
 	}
 }
 
+// limiterSummary returns the line of the summary comparing the load offered
+// with --max-rate to the load achieved, with the time the workers spent
+// blocked on the limiter and executing operations. The rate is deemed
+// sustained if the achieved load is within 5% of it; when it is not, the
+// workers barely waited on the limiter and the cluster is the bottleneck.
+func limiterSummary(workers []*worker, elapsed time.Duration, ops uint64) string {
+	var wait, exec time.Duration
+	for _, w := range workers {
+		wait += time.Duration(atomic.LoadInt64(&w.limiterWait))
+		exec += time.Duration(atomic.LoadInt64(&w.opTime))
+	}
+	offered, achieved := *maxRate, float64(ops)/elapsed.Seconds()
+	var waitPct float64
+	if wait+exec > 0 {
+		waitPct = 100 * float64(wait) / float64(wait+exec)
+	}
+	verdict := "sustained"
+	if achieved < 0.95*offered {
+		verdict = "not sustained"
+	}
+	return fmt.Sprintf("offered load %.1f ops/sec, achieved %.1f ops/sec (%s); "+
+		"workers waited %.1fs on the limiter and executed operations for %.1fs (%.1f%% waiting)",
+		offered, achieved, verdict, wait.Seconds(), exec.Seconds(), waitPct)
+}
+
+// runOp runs the operation of the worker once, re-dialing its connection if
+// it is lost.
+func (w *worker) runOp(ctx context.Context) error {
+	err := w.op(ctx)
+	if err != nil && w.conn != nil && isConnLoss(err) {
+		err = w.recoverConn(ctx, err)
+	}
+	return err
+}
+
+// workerRetryOptions are the backoff between the attempts of operations
+// whose errors the error policy retries.
+var workerRetryOptions = retry.Options{
+	InitialBackoff: 10 * time.Millisecond,
+	MaxBackoff:     time.Second,
+	Multiplier:     2,
+}
+
+// retryOp runs the operation of the worker again after it returned err, which
+// the error policy retries, until it succeeds, returns an error the policy
+// doesn't retry or ctx is done. It returns the error of the last attempt. The
+// operations are generated anew by each call, so an attempt may differ from
+// the failed one, e.g. in the keys it reads.
+func (w *worker) retryOp(ctx context.Context, err error) error {
+	for r := retry.StartWithCtx(ctx, workerRetryOptions); r.Next(); {
+		if err = w.runOp(ctx); err == nil || w.errPolicy.action(err) != errorActionRetry {
+			return err
+		}
+	}
+	return err
+}
+
+// recoverConn re-dials the connection of the worker after its operation
+// returned connErr, which means the connection was lost, and attempts the
+// operation again on the new connection if it is idempotent. It returns the
+// error the operation ends with. The latency of the operation includes the
+// re-dial, as a client would observe it.
+func (w *worker) recoverConn(ctx context.Context, connErr error) error {
+	if err := w.conn.redial(ctx); err != nil {
+		return err
+	}
+	if !w.idempotent {
+		return connErr
+	}
+	atomic.AddInt64(&w.conn.replays, 1)
+	return w.op(ctx)
+}
+
 func sanitizeDBURL(dbURL string) (string, error) {
 	parsedURL, err := url.Parse(dbURL)
 	if err != nil {
@@ -197,6 +385,13 @@ func sanitizeDBURL(dbURL string) (string, error) {
 			`database URL specifies database %q, but database "test" is always used`, parsedURL.Path)
 	}
 	parsedURL.Path = "test"
+	if *statementStats {
+		q := parsedURL.Query()
+		if q.Get("application_name") == "" {
+			q.Set("application_name", workloadAppName)
+			parsedURL.RawQuery = q.Encode()
+		}
+	}
 
 	switch parsedURL.Scheme {
 	case "postgres", "postgresql":
@@ -206,9 +401,14 @@ func sanitizeDBURL(dbURL string) (string, error) {
 	}
 }
 
-func setupCockroach(dbURLs []string) (*gosql.DB, error) {
+// sanitizeDBURLs returns the URLs to connect to, defaulting to the local
+// node, or the local server with the postgres driver.
+func sanitizeDBURLs(dbURLs []string) ([]string, error) {
 	if len(dbURLs) == 0 {
 		dbURLs = []string{crdbDefaultURI}
+		if workload.Dialect(*driverName) == workload.PostgresDialect {
+			dbURLs = []string{postgresDefaultURI}
+		}
 	}
 
 	var sanitizedURLs = make([]string, len(dbURLs))
@@ -218,10 +418,36 @@ func setupCockroach(dbURLs []string) (*gosql.DB, error) {
 		if err != nil {
 			return nil, err
 		}
+		if *certsDir != "" {
+			sanitizedURLs[i], err = addCertsToDBURL(sanitizedURLs[i], *certsDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return sanitizedURLs, nil
+}
+
+func setupCockroach(dbURLs []string) (*gosql.DB, error) {
+	sanitizedURLs, err := sanitizeDBURLs(dbURLs)
+	if err != nil {
+		return nil, err
 	}
 
 	// Open connection to server and create a database.
-	db, err := gosql.Open("cockroach", strings.Join(sanitizedURLs, " "))
+	var db *gosql.DB
+	switch workload.Dialect(*driverName) {
+	case workload.CockroachDialect:
+		db, err = gosql.Open("cockroach", strings.Join(sanitizedURLs, " "))
+	case workload.PostgresDialect:
+		if len(sanitizedURLs) != 1 {
+			return nil, errors.Errorf("the postgres driver connects to a single server, got %d URLs",
+				len(sanitizedURLs))
+		}
+		db, err = gosql.Open("postgres", sanitizedURLs[0])
+	default:
+		return nil, errors.Errorf("unknown driver: %s", *driverName)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -239,21 +465,59 @@ func runInit(gen workload.Generator, args []string) error {
 		return err
 	}
 
-	return runInitImpl(gen, db)
+	ctx, cancel := withTimeout(context.Background(), *initTimeout)
+	defer cancel()
+	return timeoutError(ctx, runInitImpl(ctx, gen, db), `init`, `init-timeout`, *initTimeout)
 }
 
-func runInitImpl(gen workload.Generator, db *gosql.DB) error {
-	if *drop {
-		if _, err := db.Exec(`DROP DATABASE IF EXISTS test`); err != nil {
-			return err
-		}
-	}
-	if _, err := db.Exec("CREATE DATABASE IF NOT EXISTS test"); err != nil {
+func runInitImpl(ctx context.Context, gen workload.Generator, db *gosql.DB) error {
+	if err := workload.CheckDialect(gen, workload.Dialect(*driverName)); err != nil {
 		return err
 	}
+	if workload.Dialect(*driverName) == workload.PostgresDialect {
+		// The test database is the one connected to, so it can't be dropped
+		// or created. Drop the tables of the generator instead.
+		if *drop {
+			for _, table := range gen.Tables() {
+				stmt := fmt.Sprintf(`DROP TABLE IF EXISTS "%s" CASCADE`, table.Name)
+				if _, err := db.ExecContext(ctx, stmt); err != nil {
+					return errors.Wrapf(err, `dropping table %s`, table.Name)
+				}
+			}
+		}
+	} else {
+		if *drop {
+			if _, err := db.ExecContext(ctx, `DROP DATABASE IF EXISTS test`); err != nil {
+				return errors.Wrap(err, `dropping database test`)
+			}
+		}
+		if _, err := db.ExecContext(ctx, "CREATE DATABASE IF NOT EXISTS test"); err != nil {
+			return errors.Wrap(err, `creating database test`)
+		}
+	}
 
 	const batchSize = -1
-	_, err := workload.Setup(db, gen, batchSize)
+	_, err := workload.Setup(ctx, db, gen, batchSize)
+	return err
+}
+
+// withTimeout returns a context that is done once timeout has elapsed, or
+// only when canceled if timeout is 0.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, func()) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// timeoutError returns the error of a step, telling if it is because the
+// step ran out of the time given by the flag.
+func timeoutError(
+	ctx context.Context, err error, step string, flag string, timeout time.Duration,
+) error {
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return errors.Wrapf(err, `%s timed out after %s (--%s)`, step, timeout, flag)
+	}
 	return err
 }
 
@@ -264,35 +528,106 @@ func runRun(gen workload.Generator, args []string) error {
 		return errors.Errorf(
 			"Value of 'concurrency' flag (%d) must be greater than or equal to 1", *concurrency)
 	}
+	if *latencySampleRate <= 0 || *latencySampleRate > 1 {
+		return errors.Errorf(
+			"Value of 'latency-sample-rate' flag (%f) must be in (0, 1]", *latencySampleRate)
+	}
+	if *idleConns < 0 {
+		return errors.Errorf(
+			"Value of 'idle-conns' flag (%d) must be greater than or equal to 0", *idleConns)
+	}
+	if *displayEvery <= 0 {
+		return errors.Errorf(
+			"Value of 'display-every' flag (%s) must be greater than 0", *displayEvery)
+	}
+	if *ui && !isatty.IsTerminal(os.Stdout.Fd()) {
+		return errors.New("'ui' flag requires the standard output to be a terminal")
+	}
+	if *growthStages < 0 {
+		return errors.Errorf(
+			"Value of 'growth-stages' flag (%d) must be greater than or equal to 0", *growthStages)
+	}
+	if *growthStages > 0 {
+		if *duration <= 0 {
+			return errors.New("'growth-stages' flag requires 'duration' flag, " +
+				"the length of each measurement phase")
+		}
+		if *growthPercent <= 0 {
+			return errors.Errorf(
+				"Value of 'growth-percent' flag (%f) must be greater than 0", *growthPercent)
+		}
+	}
+	if *connStorm && (*dedicatedConns || *growthStages > 0) {
+		return errors.New("'conn-storm' flag is incompatible with 'dedicated-conns' and " +
+			"'growth-stages' flags, as it runs no operation of the generator")
+	}
+	if err := workload.CheckDialect(gen, workload.Dialect(*driverName)); err != nil {
+		return err
+	}
+	if workload.Dialect(*driverName) == workload.PostgresDialect {
+		// Both read or write CockroachDB-specific tables.
+		if *statementStats || *heartbeatInterval > 0 {
+			return errors.Errorf(
+				"'statement-stats' and 'heartbeat-interval' flags require the cockroach driver")
+		}
+	}
+
+	var resultAssertions []resultAssertion
+	for _, src := range *assertions {
+		a, err := parseResultAssertion(src)
+		if err != nil {
+			return err
+		}
+		resultAssertions = append(resultAssertions, a)
+	}
+
+	defaultAction := errorActionAbort
+	if *tolerateErrors {
+		defaultAction = errorActionContinue
+	}
+	errPolicy, err := makeErrorPolicy(*errorPolicyFile, *errorPolicyEntries, defaultAction)
+	if err != nil {
+		return err
+	}
 
+	// Setup is retried unless the policy aborts on its errors.
 	var db *gosql.DB
-	{
-		var err error
-		for {
-			db, err = setupCockroach(args)
-			if err == nil {
-				break
-			}
-			if !*tolerateErrors {
-				return err
-			}
+	for {
+		db, err = setupCockroach(args)
+		if err == nil {
+			break
+		}
+		if errPolicy.action(err) == errorActionAbort {
+			return err
 		}
 	}
 
-	if *doInit || *drop {
-		var err error
+	if *doInit || *drop || *initTimeout > 0 {
+		initCtx, cancel := withTimeout(ctx, *initTimeout)
 		for {
-			err = runInitImpl(gen, db)
+			err = runInitImpl(initCtx, gen, db)
 			if err == nil {
 				break
 			}
-			if !*tolerateErrors {
-				return err
+			// Once the time is out, the attempts would fail right away.
+			if initCtx.Err() != nil || errPolicy.action(err) == errorActionAbort {
+				cancel()
+				return timeoutError(initCtx, err, `init`, `init-timeout`, *initTimeout)
 			}
 		}
+		cancel()
 	}
 	for _, table := range gen.Tables() {
-		if err := workload.Split(ctx, db, table, *concurrency); err != nil {
+		splitCtx, cancel := withTimeout(ctx, *splitTimeout)
+		err := workload.Split(splitCtx, db, table, *concurrency)
+		cancel()
+		step := fmt.Sprintf(`splitting table %s`, table.Name)
+		if err := timeoutError(splitCtx, err, step, `split-timeout`, *splitTimeout); err != nil {
+			return err
+		}
+	}
+	if workload.Dialect(*driverName) == workload.CockroachDialect && !*skipWait {
+		if err := waitForReplication(ctx, db, gen.Tables(), *waitTimeout); err != nil {
 			return err
 		}
 	}
@@ -309,26 +644,120 @@ func runRun(gen workload.Generator, args []string) error {
 		return errors.Errorf(`generators with more than one operation are not yet supported`)
 	}
 	op := ops[0]
+	var stormDB *gosql.DB
+	if *connStorm {
+		if stormDB, err = openConnStormPool(args); err != nil {
+			return err
+		}
+		defer stormDB.Close()
+		op = connStormOp
+	}
+
+	var statsCollector *stmtStatsCollector
+	var startStmtStats stmtStatsSnapshot
+	if *statementStats {
+		dbURLs, err := sanitizeDBURLs(args)
+		if err != nil {
+			return err
+		}
+		statsCollector = &stmtStatsCollector{dbURLs: dbURLs}
+		if startStmtStats, err = statsCollector.snapshot(ctx); err != nil {
+			return errors.Wrap(err, `snapshotting statement statistics`)
+		}
+	}
+
+	if *idleConns > 0 {
+		// The idle connections are opened before the workers start, so that
+		// they weigh on the servers for the whole run.
+		idle, err := openIdleConns(ctx, args, *idleConns)
+		if err != nil {
+			return err
+		}
+		defer idle.close()
+		if *idlePingInterval > 0 {
+			idleCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			idle.run(idleCtx, *idlePingInterval)
+		}
+	}
+
+	var growth *stagedGrowth
+	if *growthStages > 0 {
+		if growth, err = newStagedGrowth(db, gen); err != nil {
+			return err
+		}
+	}
+
+	reg := histogram.NewRegistry()
+
+	// The heartbeat table is created before the workers start, so that an
+	// error creating it doesn't leave them running.
+	if *heartbeatInterval > 0 {
+		clientID := *heartbeatClientID
+		if clientID == "" {
+			clientID = defaultHeartbeatClientID()
+		}
+		hb := heartbeatWriter{
+			db:       db,
+			clientID: clientID,
+			interval: *heartbeatInterval,
+			ops:      reg.Ops,
+		}
+		if err := hb.init(); err != nil {
+			return err
+		}
+		hbCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go hb.run(hbCtx)
+	}
 
-	lastNow := timeutil.Now()
-	start := lastNow
-	var lastOps uint64
 	workers := make([]*worker, *concurrency)
 
-	errCh := make(chan error)
+	// The dedicated connections are all opened before the first worker
+	// starts, and closed together when the run ends, including when opening
+	// one of them fails.
+	conns := make([]*workerConn, len(workers))
+	defer func() {
+		for _, conn := range conns {
+			if conn != nil {
+				_ = conn.db.Close()
+			}
+		}
+	}()
+	if *dedicatedConns {
+		for i := range conns {
+			if conns[i], err = openWorkerConn(args); err != nil {
+				return err
+			}
+		}
+	}
+
+	errCh := make(chan workerError)
 	var wg sync.WaitGroup
 	for i := range workers {
 		wg.Add(1)
-		opFn, err := op.Fn(db)
+		workerDB := db
+		if stormDB != nil {
+			workerDB = stormDB
+		}
+		conn := conns[i]
+		if conn != nil {
+			workerDB = conn.db
+		}
+		opFn, err := op.Fn(workerDB)
 		if err != nil {
 			return err
 		}
-		workers[i] = newWorker(db, opFn)
+		workers[i] = newWorker(i, workerDB, op.Name, opFn, errPolicy, reg)
+		workers[i].conn, workers[i].idempotent = conn, op.Idempotent
+		if growth != nil {
+			workers[i].pause = &growth.pause
+		}
 		go workers[i].run(ctx, errCh, &wg, limiter)
 	}
 
 	var numErr int
-	tick := time.Tick(time.Second)
+	tick := time.Tick(*displayEvery)
 	done := make(chan os.Signal, 3)
 	signal.Notify(done, syscall.SIGINT, syscall.SIGTERM)
 
@@ -337,122 +766,143 @@ func runRun(gen workload.Generator, args []string) error {
 		done <- syscall.Signal(0)
 	}()
 
-	if *duration > 0 {
+	stopAfter := func(d time.Duration) {
 		go func() {
-			time.Sleep(*duration)
+			time.Sleep(d)
 			done <- syscall.Signal(0)
 		}()
 	}
+	// With --growth-stages, --duration is the length of each measurement
+	// phase, and the run stops after the last one.
+	var stageEnd <-chan time.Time
+	if growth != nil {
+		stageEnd = time.After(*duration)
+	} else if *duration > 0 {
+		stopAfter(*duration)
+	}
+
+	host := collectHostInfo()
+	benchmarkName := strings.Join([]string{
+		"BenchmarkWorkload",
+		fmt.Sprintf("generator=%s", gen.Meta().Name),
+		fmt.Sprintf("concurrency=%d", *concurrency),
+		fmt.Sprintf("duration=%s", *duration),
+	}, "/")
+	if *idleConns > 0 {
+		benchmarkName += fmt.Sprintf("/idle-conns=%d", *idleConns)
+	}
+	if *connStorm {
+		benchmarkName += "/conn-storm"
+	}
+	if *growthStages > 0 {
+		benchmarkName += fmt.Sprintf("/growth-stages=%d/growth-percent=%g", *growthStages, *growthPercent)
+	}
+	// NB: This visits in a deterministic order.
+	gen.Flags().Visit(func(f *pflag.Flag) {
+		benchmarkName += fmt.Sprintf(`/%s=%s`, f.Name, f.Value)
+	})
+	benchmarkName += hostBenchmarkSuffix(host)
 
 	defer func() {
 		// Output results that mimic Go's built-in benchmark format.
-		benchmarkName := strings.Join([]string{
-			"BenchmarkWorkload",
-			fmt.Sprintf("generator=%s", gen.Meta().Name),
-			fmt.Sprintf("concurrency=%d", *concurrency),
-			fmt.Sprintf("duration=%s", *duration),
-		}, "/")
-		// NB: This visits in a deterministic order.
-		gen.Flags().Visit(func(f *pflag.Flag) {
-			benchmarkName += fmt.Sprintf(`/%s=%s`, f.Name, f.Value)
-		})
-
 		result := testing.BenchmarkResult{
-			N: int(numOps),
-			T: timeutil.Since(start),
+			N: int(reg.Ops()),
+			T: timeutil.Since(reg.Start()),
 		}
 		fmt.Printf("%s\t%s\n", benchmarkName, result)
 	}()
 
-	cumLatency := hdrhistogram.New(minLatency.Nanoseconds(), maxLatency.Nanoseconds(), 1)
-
-	for i := 0; ; {
+	var reporter histogram.TickReporter = histogram.NewReporter(os.Stdout, *displayEvery)
+	if *ui {
+		reporter = histogram.NewDashboard(os.Stdout, *displayEvery)
+	}
+	for {
 		select {
-		case err := <-errCh:
+		case werr := <-errCh:
 			numErr++
-			if *tolerateErrors {
-				log.Error(ctx, err)
+			if errPolicy.action(werr.err) == errorActionContinue {
+				log.Error(ctx, werr.err)
 				continue
 			}
-			return err
+			if w := werr.worker; w.opLog != nil {
+				w.opLog.dump(os.Stderr, fmt.Sprintf("worker %d", w.idx), w.opName)
+			}
+			return werr.err
 
 		case <-tick:
-			var h *hdrhistogram.Histogram
-			for _, w := range workers {
-				w.latency.Lock()
-				m := w.latency.Merge()
-				w.latency.Rotate()
-				w.latency.Unlock()
-				if h == nil {
-					h = m
-				} else {
-					h.Merge(m)
-				}
+			reporter.Tick(reg, numErr)
+
+		case <-stageEnd:
+			growth.pause.Lock()
+			reporter.Tick(reg, numErr)
+			growth.endStage(reg)
+			// The workers must be resumed even when growing fails, so that
+			// they can notice the end of the run.
+			err := growth.grow(ctx)
+			growth.pause.Unlock()
+			if err != nil {
+				return errors.Wrap(err, `growing the data`)
 			}
-
-			cumLatency.Merge(h)
-			p50 := h.ValueAtQuantile(50)
-			p95 := h.ValueAtQuantile(95)
-			p99 := h.ValueAtQuantile(99)
-			pMax := h.ValueAtQuantile(100)
-
-			now := timeutil.Now()
-			elapsed := now.Sub(lastNow)
-			ops := atomic.LoadUint64(&numOps)
-			if i%20 == 0 {
-				fmt.Println("_elapsed___errors__ops/sec(inst)___ops/sec(cum)__p50(ms)__p95(ms)__p99(ms)_pMax(ms)")
+			if growth.growths < *growthStages {
+				stageEnd = time.After(*duration)
+			} else {
+				stageEnd = nil
+				stopAfter(*duration)
 			}
-			i++
-			fmt.Printf("%8s %8d %14.1f %14.1f %8.1f %8.1f %8.1f %8.1f\n",
-				time.Duration(timeutil.Since(start).Seconds()+0.5)*time.Second,
-				numErr,
-				float64(ops-lastOps)/elapsed.Seconds(),
-				float64(ops)/timeutil.Since(start).Seconds(),
-				time.Duration(p50).Seconds()*1000,
-				time.Duration(p95).Seconds()*1000,
-				time.Duration(p99).Seconds()*1000,
-				time.Duration(pMax).Seconds()*1000)
-			lastOps = ops
-			lastNow = now
 
 		case <-done:
-			for _, w := range workers {
-				w.latency.Lock()
-				m := w.latency.Merge()
-				w.latency.Rotate()
-				w.latency.Unlock()
-				cumLatency.Merge(m)
+			total := reporter.Total(reg, numErr)
+			if growth != nil {
+				growth.endStage(reg)
+				growth.report(os.Stdout)
 			}
-
-			avg := cumLatency.Mean()
-			p50 := cumLatency.ValueAtQuantile(50)
-			p95 := cumLatency.ValueAtQuantile(95)
-			p99 := cumLatency.ValueAtQuantile(99)
-			pMax := cumLatency.ValueAtQuantile(100)
-
-			ops := atomic.LoadUint64(&numOps)
-			elapsed := timeutil.Since(start).Seconds()
-			fmt.Println("\n_elapsed___errors_____ops(total)___ops/sec(cum)__avg(ms)__p50(ms)__p95(ms)__p99(ms)_pMax(ms)")
-			fmt.Printf("%7.1fs %8d %14d %14.1f %8.1f %8.1f %8.1f %8.1f %8.1f\n\n",
-				timeutil.Since(start).Seconds(), numErr,
-				ops, float64(ops)/elapsed,
-				time.Duration(avg).Seconds()*1000,
-				time.Duration(p50).Seconds()*1000,
-				time.Duration(p95).Seconds()*1000,
-				time.Duration(p99).Seconds()*1000,
-				time.Duration(pMax).Seconds()*1000)
-			if *histFile == "-" {
-				if err := histwriter.WriteDistribution(cumLatency, nil, 1, os.Stdout); err != nil {
-					fmt.Printf("failed to write histogram to stdout: %v\n", err)
+			var redials, replays int64
+			if *dedicatedConns {
+				for _, w := range workers {
+					workerRedials, workerReplays := w.conn.stats()
+					redials += workerRedials
+					replays += workerReplays
+				}
+				fmt.Printf("%d connections re-dialed, %d operations attempted again\n",
+					redials, replays)
+			}
+			if *connStorm {
+				fmt.Printf("%d connections opened, authenticated and closed (%.1f/sec)\n",
+					reg.Ops(), float64(reg.Ops())/timeutil.Since(reg.Start()).Seconds())
+			}
+			if limiter != nil {
+				fmt.Println(limiterSummary(workers, timeutil.Since(reg.Start()), reg.Ops()))
+			}
+			if *histFile != "" {
+				if err := histogram.WriteFile(total, *histFile); err != nil {
+					fmt.Printf("failed to write histogram data: %v\n", err)
 				}
-			} else if *histFile != "" {
-				if err := histwriter.WriteDistributionFile(
-					cumLatency, nil, 1, *histFile,
-				); err != nil {
-					fmt.Printf("failed to write histogram file: %v\n", err)
+			}
+			runResults := makeRunResults(benchmarkName, gen.Meta().Name,
+				timeutil.Since(reg.Start()), reg.Ops(), numErr, redials, replays, total, host)
+			if *jsonResults != "" {
+				if err := writeResultsJSON(runResults, *jsonResults); err != nil {
+					fmt.Printf("failed to write JSON results: %v\n", err)
+				}
+			}
+			if statsCollector != nil {
+				endStmtStats, err := statsCollector.snapshot(ctx)
+				if err != nil {
+					fmt.Printf("failed to snapshot statement statistics: %v\n", err)
+				} else {
+					printStmtStats(endStmtStats.sub(startStmtStats))
+				}
+			}
+			// All the assertions are checked, so that a run reports every
+			// failure at once.
+			var assertErr error
+			for _, a := range resultAssertions {
+				if err := a.check(runResults); err != nil {
+					fmt.Println(err)
+					assertErr = errAssertionsFailed
 				}
 			}
-			return nil
+			return assertErr
 		}
 	}
 }
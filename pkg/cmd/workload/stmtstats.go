@@ -0,0 +1,169 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package main
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+var statementStats = runFlags.Bool("statement-stats", false,
+	"Snapshot the statement statistics of the cluster at the start and end of the run "+
+		"and report the difference for the workload's statements. Requires a user "+
+		"allowed to read crdb_internal.node_statement_statistics.")
+
+// workloadAppName is the application name of the workload's connections
+// when --statement-stats is set and the URLs don't specify one, so that the
+// server's statistics for the workload's statements can be told apart.
+const workloadAppName = `workload`
+
+// stmtStatsAppName is the application name used to take the snapshots, so
+// that their own queries are not attributed to the workload.
+const stmtStatsAppName = `workload-statement-stats`
+
+// stmtStatsKey identifies the statistics of a statement fingerprint on a
+// node. Statement statistics are collected separately by each node.
+type stmtStatsKey struct {
+	nodeID int64
+	key    string
+}
+
+type stmtStats struct {
+	count   int64
+	retries int64
+	// serviceLatSum is the total service latency, in seconds.
+	serviceLatSum float64
+}
+
+func (s stmtStats) serviceLatAvg() time.Duration {
+	if s.count == 0 {
+		return 0
+	}
+	return time.Duration(s.serviceLatSum / float64(s.count) * float64(time.Second))
+}
+
+type stmtStatsSnapshot map[stmtStatsKey]stmtStats
+
+// stmtStatsCollector snapshots the statement statistics of the nodes the
+// workload connects to, to give the server's view of the statements run by
+// the workload next to the client's.
+type stmtStatsCollector struct {
+	// dbURLs are the URLs the workload connects to. Their application_name
+	// is used to select the workload's statements.
+	dbURLs []string
+}
+
+func (c stmtStatsCollector) snapshot(ctx context.Context) (stmtStatsSnapshot, error) {
+	snap := make(stmtStatsSnapshot)
+	for _, dbURL := range c.dbURLs {
+		// Several URLs may point to the same node, in which case the node's
+		// statistics are simply read again.
+		if err := snapshotNodeStmtStats(ctx, dbURL, snap); err != nil {
+			return nil, err
+		}
+	}
+	return snap, nil
+}
+
+func snapshotNodeStmtStats(ctx context.Context, dbURL string, snap stmtStatsSnapshot) error {
+	parsedURL, err := url.Parse(dbURL)
+	if err != nil {
+		return err
+	}
+	q := parsedURL.Query()
+	appName := q.Get(`application_name`)
+	q.Set(`application_name`, stmtStatsAppName)
+	parsedURL.RawQuery = q.Encode()
+
+	db, err := gosql.Open(`postgres`, parsedURL.String())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, `
+SELECT node_id, key, count, first_attempt_count, service_lat_avg
+FROM crdb_internal.node_statement_statistics WHERE application_name = $1`, appName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var k stmtStatsKey
+		var count, firstAttemptCount int64
+		var serviceLatAvg float64
+		if err := rows.Scan(&k.nodeID, &k.key, &count, &firstAttemptCount, &serviceLatAvg); err != nil {
+			return err
+		}
+		// A fingerprint has several rows when its executions differ in their
+		// flags (e.g. distributed or failed).
+		s := snap[k]
+		s.count += count
+		s.retries += count - firstAttemptCount
+		s.serviceLatSum += float64(count) * serviceLatAvg
+		snap[k] = s
+	}
+	return rows.Err()
+}
+
+// sub returns the statistics accumulated since the start snapshot, by
+// statement fingerprint. The statistics of nodes that reset them in the
+// meantime are counted from zero.
+func (end stmtStatsSnapshot) sub(start stmtStatsSnapshot) map[string]stmtStats {
+	delta := make(map[string]stmtStats)
+	for k, e := range end {
+		s := start[k]
+		if e.count < s.count {
+			s = stmtStats{}
+		}
+		if e.count == s.count {
+			continue
+		}
+		d := delta[k.key]
+		d.count += e.count - s.count
+		d.retries += e.retries - s.retries
+		d.serviceLatSum += e.serviceLatSum - s.serviceLatSum
+		delta[k.key] = d
+	}
+	return delta
+}
+
+// printStmtStats prints the statistics of each fingerprint, most executed
+// first.
+func printStmtStats(stats map[string]stmtStats) {
+	keys := make([]string, 0, len(stats))
+	for k := range stats {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if stats[keys[i]].count != stats[keys[j]].count {
+			return stats[keys[i]].count > stats[keys[j]].count
+		}
+		return keys[i] < keys[j]
+	})
+
+	fmt.Println("_____count__retries__svc-avg(ms)__statement")
+	for _, k := range keys {
+		s := stats[k]
+		fmt.Printf("%10d %8d %12.1f  %s\n",
+			s.count, s.retries, s.serviceLatAvg().Seconds()*1000, k)
+	}
+	fmt.Println()
+}
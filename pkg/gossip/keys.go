@@ -73,6 +73,11 @@ const (
 	// pairs in the system DB span.
 	KeySystemConfig = "system-db"
 
+	// KeyRoleSettings is the gossip key used to notify the nodes that the
+	// default session variables in system.role_settings changed, so that
+	// they drop the ones they cached. The value is meaningless.
+	KeyRoleSettings = "role-settings"
+
 	// KeyDistSQLNodeVersionKeyPrefix is key prefix for each node's DistSQL
 	// version.
 	KeyDistSQLNodeVersionKeyPrefix = "distsql-version"
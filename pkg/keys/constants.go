@@ -312,17 +312,21 @@ const (
 	// to "Ranges" instead of a Table - these IDs are needed to store custom
 	// configuration for non-table ranges (e.g. Zone Configs).
 	// NOTE: IDs must be <= MaxReservedDescID.
-	LeaseTableID           = 11
-	EventLogTableID        = 12
-	RangeEventTableID      = 13
-	UITableID              = 14
-	JobsTableID            = 15
-	MetaRangesID           = 16
-	SystemRangesID         = 17
-	TimeseriesRangesID     = 18
-	WebSessionsTableID     = 19
-	TableStatisticsTableID = 20
-	LocationsTableID       = 21
-	LivenessRangesID       = 22
-	RoleMembersTableID     = 23
+	LeaseTableID             = 11
+	EventLogTableID          = 12
+	RangeEventTableID        = 13
+	UITableID                = 14
+	JobsTableID              = 15
+	MetaRangesID             = 16
+	SystemRangesID           = 17
+	TimeseriesRangesID       = 18
+	WebSessionsTableID       = 19
+	TableStatisticsTableID   = 20
+	LocationsTableID         = 21
+	LivenessRangesID         = 22
+	RoleMembersTableID       = 23
+	CommentsTableID          = 24
+	RoleSettingsTableID      = 25
+	RoleOptionsTableID       = 26
+	DefaultPrivilegesTableID = 27
 )
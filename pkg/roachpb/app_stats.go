@@ -45,3 +45,25 @@ func AddNumericStats(a, b NumericStat, countA, countB int64) NumericStat {
 			delta*delta*float64(countA)*float64(countB)/total,
 	}
 }
+
+// Add combines other into these statistics, as if this statement had seen
+// the executions of both.
+func (s *StatementStatistics) Add(other *StatementStatistics) {
+	if other.Count == 0 {
+		return
+	}
+	s.FirstAttemptCount += other.FirstAttemptCount
+	if other.MaxRetries > s.MaxRetries {
+		s.MaxRetries = other.MaxRetries
+	}
+	if other.LastErr != "" {
+		s.LastErr = other.LastErr
+	}
+	s.NumRows.Add(other.NumRows, s.Count, other.Count)
+	s.ParseLat.Add(other.ParseLat, s.Count, other.Count)
+	s.PlanLat.Add(other.PlanLat, s.Count, other.Count)
+	s.RunLat.Add(other.RunLat, s.Count, other.Count)
+	s.ServiceLat.Add(other.ServiceLat, s.Count, other.Count)
+	s.OverheadLat.Add(other.OverheadLat, s.Count, other.Count)
+	s.Count += other.Count
+}
@@ -85,3 +85,46 @@ func TestAddNumericStats(t *testing.T) {
 		t.Fatalf("a.Add(b) should match add(a, b): %+v vs %+v", a, combined)
 	}
 }
+
+func TestAddStatementStatistics(t *testing.T) {
+	record := func(s *StatementStatistics, retries int64, rows, lat float64) {
+		s.Count++
+		if retries == 0 {
+			s.FirstAttemptCount++
+		} else if retries > s.MaxRetries {
+			s.MaxRetries = retries
+		}
+		s.NumRows.Record(s.Count, rows)
+		s.ServiceLat.Record(s.Count, lat)
+	}
+
+	var a, b, ab StatementStatistics
+	record(&a, 0, 1, 0.5)
+	record(&a, 2, 3, 1.5)
+	record(&b, 1, 5, 2.5)
+	record(&ab, 0, 1, 0.5)
+	record(&ab, 2, 3, 1.5)
+	record(&ab, 1, 5, 2.5)
+
+	var combined StatementStatistics
+	combined.Add(&a)
+	combined.Add(&b)
+
+	if combined.Count != ab.Count || combined.FirstAttemptCount != ab.FirstAttemptCount ||
+		combined.MaxRetries != ab.MaxRetries {
+		t.Fatalf("expected counts %+v, got %+v", ab, combined)
+	}
+	const epsilon = 0.0000001
+	for _, c := range []struct {
+		name             string
+		combined, actual NumericStat
+	}{
+		{"NumRows", combined.NumRows, ab.NumRows},
+		{"ServiceLat", combined.ServiceLat, ab.ServiceLat},
+	} {
+		if math.Abs(c.combined.Mean-c.actual.Mean) > epsilon ||
+			math.Abs(c.combined.SquaredDiffs-c.actual.SquaredDiffs) > epsilon {
+			t.Errorf("%s: expected %+v, got %+v", c.name, c.actual, c.combined)
+		}
+	}
+}
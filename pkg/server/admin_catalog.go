@@ -0,0 +1,173 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/httputil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// adminCatalogPrefix is the prefix of the RESTful endpoints listing the SQL
+// catalog:
+//
+//   catalog/databases                              information_schema.schemata
+//   catalog/databases/{db}/grants                  information_schema.schema_privileges
+//   catalog/databases/{db}/tables                  information_schema.tables
+//   catalog/databases/{db}/tables/{table}/columns  information_schema.columns
+//   catalog/databases/{db}/tables/{table}/grants   information_schema.table_privileges
+//
+// Each endpoint returns the rows of the information_schema table next to
+// it, as listed for the user of the request, so that they are identical to
+// what the user gets with SQL.
+const adminCatalogPrefix = adminPrefix + "catalog/"
+
+// catalogResponse is the JSON body of the responses of the catalog
+// endpoints. Each row maps the names of the columns of the information_schema
+// table to their values.
+type catalogResponse struct {
+	Rows []map[string]interface{} `json:"rows"`
+}
+
+// catalogRequest describes the information_schema table listed by a catalog
+// endpoint, and the rows it keeps.
+type catalogRequest struct {
+	table string
+	// dbName restricts the rows to the objects of a database if not empty.
+	dbName string
+	// filters maps the names of columns to the values the rows must have.
+	filters map[string]string
+}
+
+// parseCatalogPath returns the catalogRequest of the path of an endpoint
+// relative to adminCatalogPrefix, or false if the path does not match an
+// endpoint.
+func parseCatalogPath(path string) (catalogRequest, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if parts[0] != "databases" {
+		return catalogRequest{}, false
+	}
+	switch len(parts) {
+	case 1:
+		return catalogRequest{table: "schemata"}, true
+	case 3:
+		db := parts[1]
+		switch parts[2] {
+		case "grants":
+			return catalogRequest{
+				table:   "schema_privileges",
+				filters: map[string]string{"table_schema": db},
+			}, true
+		case "tables":
+			return catalogRequest{table: "tables", dbName: db}, true
+		}
+	case 5:
+		if parts[2] != "tables" {
+			break
+		}
+		db, table := parts[1], parts[3]
+		filters := map[string]string{"table_name": table}
+		switch parts[4] {
+		case "columns":
+			return catalogRequest{table: "columns", dbName: db, filters: filters}, true
+		case "grants":
+			return catalogRequest{table: "table_privileges", dbName: db, filters: filters}, true
+		}
+	}
+	return catalogRequest{}, false
+}
+
+// catalogValue converts a datum of an information_schema table to a JSON
+// value.
+func catalogValue(d tree.Datum) interface{} {
+	if d == tree.DNull {
+		return nil
+	}
+	switch t := d.(type) {
+	case *tree.DString:
+		return string(*t)
+	case *tree.DInt:
+		return int64(*t)
+	case *tree.DBool:
+		return bool(*t)
+	default:
+		return tree.AsStringWithFlags(d, tree.FmtBareStrings)
+	}
+}
+
+// queryCatalog lists the rows of the information_schema table of a catalog
+// request on behalf of the given user.
+func (s *adminServer) queryCatalog(
+	ctx context.Context, user string, cr catalogRequest,
+) (*catalogResponse, error) {
+	resp := &catalogResponse{Rows: []map[string]interface{}{}}
+	err := s.server.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		rows, cols, err := s.executor.QueryVirtualTableInTransaction(
+			ctx, user, txn, cr.dbName, "information_schema", cr.table,
+		)
+		if err != nil {
+			return err
+		}
+		resp.Rows = resp.Rows[:0]
+	nextRow:
+		for _, row := range rows {
+			r := make(map[string]interface{}, len(cols))
+			for i, col := range cols {
+				r[col.Name] = catalogValue(row[i])
+			}
+			for name, value := range cr.filters {
+				if r[name] != value {
+					continue nextRow
+				}
+			}
+			resp.Rows = append(resp.Rows, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// handleCatalog serves the catalog endpoints.
+func (s *adminServer) handleCatalog(w http.ResponseWriter, req *http.Request) {
+	ctx := s.server.AnnotateCtx(req.Context())
+	if req.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	cr, ok := parseCatalogPath(strings.TrimPrefix(req.URL.Path, adminCatalogPrefix))
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	resp, err := s.queryCatalog(ctx, webSessionUser(req), cr)
+	if err != nil {
+		http.Error(w, apiInternalError(ctx, err).Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(httputil.ContentTypeHeader, httputil.JSONContentType)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Error(ctx, err)
+	}
+}
@@ -726,6 +726,94 @@ VALUES ('adminUser', 'abc'), ('bob', 'xyz')`
 	}
 }
 
+// TestAdminAPICatalog verifies that the catalog endpoints list the rows of
+// the information_schema tables, filtered for the user of the request.
+func TestAdminAPICatalog(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.TODO())
+	ts := s.(*TestServer)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE DATABASE d`)
+	sqlDB.Exec(t, `CREATE TABLE d.t (a INT PRIMARY KEY, b STRING)`)
+	sqlDB.Exec(t, `CREATE TABLE d.hidden (a INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `CREATE USER testuser`)
+	sqlDB.Exec(t, `GRANT SELECT ON d.t TO testuser`)
+
+	get := func(path string) []map[string]interface{} {
+		body, err := getText(s, s.AdminURL()+adminCatalogPrefix+path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resp catalogResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatalf("%s: %v, body is:\n%s", path, err, body)
+		}
+		return resp.Rows
+	}
+	names := func(rows []map[string]interface{}, col string) []string {
+		var res []string
+		for _, row := range rows {
+			res = append(res, row[col].(string))
+		}
+		sort.Strings(res)
+		return res
+	}
+
+	testCases := []struct {
+		path     string
+		col      string
+		expected []string
+	}{
+		{"databases/d/tables", "table_name", []string{"hidden", "t"}},
+		{"databases/d/tables/t/columns", "column_name", []string{"a", "b"}},
+		{"databases/d/tables/t/grants", "grantee", []string{"admin", "root", "testuser"}},
+		{"databases/d/grants", "grantee", []string{"admin", "root"}},
+	}
+	for _, tc := range testCases {
+		if a := names(get(tc.path), tc.col); !reflect.DeepEqual(a, tc.expected) {
+			t.Errorf("%s: expected %s, got %s", tc.path, tc.expected, a)
+		}
+	}
+	dbs := names(get("databases"), "schema_name")
+	for _, e := range []string{"d", "system"} {
+		if i := sort.SearchStrings(dbs, e); i == len(dbs) || dbs[i] != e {
+			t.Errorf("database %s missing from %s", e, dbs)
+		}
+	}
+	if rows := get("databases/d/tables/t/columns"); rows[0]["ordinal_position"] != float64(1) {
+		t.Errorf("unexpected ordinal position %v", rows[0]["ordinal_position"])
+	}
+
+	resp, err := getText(s, s.AdminURL()+adminCatalogPrefix+"nonexistent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(resp), "404 page not found") {
+		t.Errorf("expected a 404, got %s", resp)
+	}
+
+	// The rows are filtered for the user like when it queries the tables.
+	ctx := context.Background()
+	for _, tc := range []struct {
+		cr       catalogRequest
+		col      string
+		expected []string
+	}{
+		{catalogRequest{table: "tables", dbName: "d"}, "table_name", []string{"t"}},
+		{catalogRequest{table: "table_privileges", dbName: "d"}, "table_name", []string{"t", "t", "t"}},
+	} {
+		resp, err := ts.admin.queryCatalog(ctx, "testuser", tc.cr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a := names(resp.Rows, tc.col); !reflect.DeepEqual(a, tc.expected) {
+			t.Errorf("%s: expected %s, got %s", tc.cr.table, tc.expected, a)
+		}
+	}
+}
+
 func TestAdminAPIEvents(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	s, _, _ := serverutils.StartServer(t, base.TestServerArgs{})
@@ -232,7 +232,8 @@ WHERE id = $1`
 // verifyPassword verifies the passed username/password pair against the
 // system.users table. The returned boolean indicates whether or not the
 // verification succeeded; an error is returned if the validation process could
-// not be completed.
+// not be completed. The verification fails for the users that are not allowed
+// to log in or whose password has expired.
 func (s *authenticationServer) verifyPassword(
 	ctx context.Context, username string, password string,
 ) (bool, error) {
@@ -245,6 +246,13 @@ func (s *authenticationServer) verifyPassword(
 	if !exists {
 		return false, nil
 	}
+	loginOptions, err := sql.GetUserLoginOptions(ctx, s.server.sqlExecutor, s.memMetrics, username)
+	if err != nil {
+		return false, err
+	}
+	if loginOptions.NoLogin || loginOptions.PasswordExpired(s.server.clock.PhysicalTime()) {
+		return false, nil
+	}
 	return (security.CompareHashAndPassword(hashedPassword, password) == nil), nil
 }
 
@@ -299,6 +307,20 @@ RETURNING id
 	return id, secret, nil
 }
 
+// webSessionUserKey is the key of the username of the web session in the
+// context of the requests authenticated by an authenticationMux.
+type webSessionUserKey struct{}
+
+// webSessionUser returns the user on behalf of which a request served
+// directly over HTTP is served: the user of the web session when the server
+// requires one, and otherwise root, as for the admin endpoints (see getUser).
+func webSessionUser(req *http.Request) string {
+	if user, ok := req.Context().Value(webSessionUserKey{}).(string); ok {
+		return user
+	}
+	return security.RootUser
+}
+
 // authenticationMux implements http.Handler, and is used to provide session
 // authentication for an arbitrary "inner" handler.
 type authenticationMux struct {
@@ -329,7 +351,7 @@ func (am *authenticationMux) ServeHTTP(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	valid, _, err := am.server.verifySession(req.Context(), cookie)
+	valid, username, err := am.server.verifySession(req.Context(), cookie)
 	if err != nil {
 		http.Error(w, apiInternalError(req.Context(), err).Error(), http.StatusInternalServerError)
 		return
@@ -339,10 +361,12 @@ func (am *authenticationMux) ServeHTTP(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	// TODO(mrtracy): At this point, we should set the session ID and username
-	// on the request context. However, GRPC Gateway does not correctly use the
-	// request context, and even if it did we are not providing any
-	// authorization for API methods (only authentication).
+	// The username is set on the request context for the handlers served
+	// directly over HTTP, such as the catalog endpoints.
+	// TODO(mrtracy): We should also set the session ID. However, GRPC Gateway
+	// does not correctly use the request context, and even if it did we are
+	// not providing any authorization for API methods (only authentication).
+	req = req.WithContext(context.WithValue(req.Context(), webSessionUserKey{}, username))
 	am.inner.ServeHTTP(w, req)
 }
 
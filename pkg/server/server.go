@@ -77,6 +77,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/cockroach/pkg/util/sdnotify"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 )
@@ -151,6 +152,13 @@ type Server struct {
 	internalMemMetrics sql.MemoryMetrics
 	adminMemMetrics    sql.MemoryMetrics
 	serveMode
+
+	// reportedFeatureCounts are the feature usage counts of the telemetry
+	// package as of the last diagnostics report.
+	reportedFeatureCounts struct {
+		syncutil.Mutex
+		counts map[string]int64
+	}
 }
 
 // NewServer creates a Server from a server.Config.
@@ -174,6 +182,9 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 		registry: metric.NewRegistry(),
 	}
 	s.serveMode.set(modeInitializing)
+	// The first diagnostics report counts the feature uses since the server
+	// was created.
+	s.resetReportedFeatureCounts()
 
 	// If the tracer has a Close function, call it after the server stops.
 	if tr, ok := cfg.AmbientCtx.Tracer.(stop.Closer); ok {
@@ -410,6 +421,10 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 	s.registry.AddMetricStruct(distSQLMetrics)
 
 	// Set up the DistSQL server.
+	// Like the InternalExecutor, the source of the rows of the node-local
+	// virtual tables needs the ExecutorConfig, which is set later.
+	nodeLocalVirtualTables := sql.NodeLocalVirtualTables{}
+
 	distSQLCfg := distsqlrun.ServerConfig{
 		AmbientContext: s.cfg.AmbientCtx,
 		Settings:       st,
@@ -427,8 +442,9 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 
 		Metrics: &distSQLMetrics,
 
-		JobRegistry: s.jobRegistry,
-		Gossip:      s.gossip,
+		JobRegistry:   s.jobRegistry,
+		Gossip:        s.gossip,
+		VirtualTables: &nodeLocalVirtualTables,
 	}
 	if distSQLTestingKnobs := s.cfg.TestingKnobs.DistSQL; distSQLTestingKnobs != nil {
 		distSQLCfg.TestingKnobs = *distSQLTestingKnobs.(*distsqlrun.TestingKnobs)
@@ -493,6 +509,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 		Clock:                   s.clock,
 		DistSQLSrv:              s.distSQLServer,
 		StatusServer:            s.status,
+		MetricsRecorder:         s.recorder,
 		SessionRegistry:         s.sessionRegistry,
 		JobRegistry:             s.jobRegistry,
 		VirtualSchemas:          virtualSchemas,
@@ -536,6 +553,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 	s.registry.AddMetricStruct(s.pgServer.Metrics())
 
 	sqlExecutor.ExecCfg = &execCfg
+	nodeLocalVirtualTables.ExecCfg = &execCfg
 	s.execCfg = &execCfg
 
 	s.leaseMgr.SetExecCfg(&execCfg)
@@ -1053,8 +1071,12 @@ If problems persist, please see ` + base.DocsURL("cluster-setup-troubleshooting.
 	s.stopper.AddCloser(stop.CloserFn(gwCancel))
 
 	var authHandler http.Handler = gwMux
+	var catalogHandler http.Handler = http.HandlerFunc(s.admin.handleCatalog)
+	statementsHandler := s.status.requireSuperUser("read the statement statistics", gwMux)
 	if s.cfg.RequireWebSession() {
 		authHandler = newAuthenticationMux(s.authentication, authHandler)
+		catalogHandler = newAuthenticationMux(s.authentication, catalogHandler)
+		statementsHandler = newAuthenticationMux(s.authentication, statementsHandler)
 	}
 
 	// Setup HTTP<->gRPC handlers.
@@ -1171,8 +1193,10 @@ If problems persist, please see ` + base.DocsURL("cluster-setup-troubleshooting.
 	s.serveMode.set(modeOperational)
 
 	s.mux.Handle(adminPrefix, authHandler)
+	s.mux.Handle(adminCatalogPrefix, catalogHandler)
 	s.mux.Handle(ts.URLPrefix, authHandler)
 	s.mux.Handle(statusPrefix, authHandler)
+	s.mux.Handle(statusStatements, statementsHandler)
 	s.mux.Handle(authPrefix, gwMux)
 	s.mux.Handle("/health", gwMux)
 	s.mux.Handle(statusVars, http.HandlerFunc(s.status.handleVars))
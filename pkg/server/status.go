@@ -72,6 +72,10 @@ const (
 	// statusVars exposes prometheus metrics for monitoring consumption.
 	statusVars = statusPrefix + "vars"
 
+	// statusStatements exposes the statement statistics of the cluster,
+	// which only superusers may read.
+	statusStatements = statusPrefix + "statements"
+
 	// raftStateDormant is used when there is no known raft state.
 	raftStateDormant = "StateDormant"
 
@@ -886,6 +890,23 @@ func (s *statusServer) handleVars(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// requireSuperUser wraps a handler of the HTTP endpoints so that it only
+// serves superusers: the user of the web session must be root or a member
+// of the admin role.
+func (s *statusServer) requireSuperUser(action string, inner http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.AnnotateCtx(r.Context())
+		user := webSessionUser(r)
+		if err := s.db.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+			return s.admin.executor.RequireSuperUser(ctx, user, txn, action)
+		}); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		inner.ServeHTTP(w, r)
+	})
+}
+
 // Ranges returns range info for the specified node.
 func (s *statusServer) Ranges(
 	ctx context.Context, req *serverpb.RangesRequest,
@@ -1126,6 +1147,104 @@ func (s *statusServer) CommandQueue(
 	}, nil
 }
 
+// Statements returns the statistics of the statements executed on the
+// requested node or, if no node is requested, on all the live nodes.
+func (s *statusServer) Statements(
+	ctx context.Context, req *serverpb.StatementsRequest,
+) (*serverpb.StatementsResponse, error) {
+	ctx = s.AnnotateCtx(ctx)
+	if req.NodeId == "" {
+		return s.statementsForAllNodes(ctx)
+	}
+
+	nodeID, local, err := s.parseNodeID(req.NodeId)
+	if err != nil {
+		return nil, grpcstatus.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if !local {
+		status, err := s.dialNode(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+		return status.Statements(ctx, req)
+	}
+
+	stmts := s.admin.server.sqlExecutor.GetStmtStats()
+	for i := range stmts {
+		stmts[i].NodeID = nodeID
+	}
+	return &serverpb.StatementsResponse{Statements: stmts}, nil
+}
+
+// statementsForAllNodes collects the statement statistics of the live nodes.
+// The nodes that can't be reached are listed in the errors of the response,
+// and their statistics are left out.
+func (s *statusServer) statementsForAllNodes(
+	ctx context.Context,
+) (*serverpb.StatementsResponse, error) {
+	nodeCtx, cancel := context.WithTimeout(ctx, base.NetworkTimeout)
+	defer cancel()
+
+	type nodeResponse struct {
+		nodeID roachpb.NodeID
+		resp   *serverpb.StatementsResponse
+		err    error
+	}
+
+	var numNodes int
+	responses := make(chan nodeResponse)
+	for nodeID, isLive := range s.nodeLiveness.GetIsLiveMap() {
+		if !isLive {
+			continue
+		}
+		nodeID := nodeID
+		numNodes++
+		if err := s.stopper.RunAsyncTask(
+			nodeCtx,
+			"server.statusServer: requesting remote statements",
+			func(ctx context.Context) {
+				resp := nodeResponse{nodeID: nodeID}
+				status, err := s.dialNode(ctx, nodeID)
+				if err != nil {
+					resp.err = errors.Wrapf(err, "failed to dial into node %d", nodeID)
+				} else if resp.resp, err = status.Statements(ctx, &serverpb.StatementsRequest{
+					NodeId: "local",
+				}); err != nil {
+					resp.err = errors.Wrapf(err, "failed to get statements from node %d", nodeID)
+				}
+				select {
+				case responses <- resp:
+					// Response processed.
+				case <-ctx.Done():
+					// Context completed, response no longer needed.
+				}
+			}); err != nil {
+			return nil, grpcstatus.Errorf(codes.Internal, err.Error())
+		}
+	}
+
+	response := &serverpb.StatementsResponse{}
+	for ; numNodes > 0; numNodes-- {
+		select {
+		case resp := <-responses:
+			if resp.err != nil {
+				response.Errors = append(response.Errors, serverpb.ListSessionsError{
+					NodeID:  resp.nodeID,
+					Message: resp.err.Error(),
+				})
+				continue
+			}
+			response.Statements = append(response.Statements, resp.resp.Statements...)
+		case <-nodeCtx.Done():
+			response.Errors = append(response.Errors, serverpb.ListSessionsError{
+				Message: "Statements timed out before completion",
+			})
+			return response, nil
+		}
+	}
+	return response, nil
+}
+
 // ListLocalSessions returns a list of SQL sessions on this node.
 func (s *statusServer) ListLocalSessions(
 	ctx context.Context, req *serverpb.ListSessionsRequest,
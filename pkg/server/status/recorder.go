@@ -185,6 +185,27 @@ func (mr *MetricsRecorder) AddStore(store storeMetrics) {
 	mr.mu.stores[storeID] = store
 }
 
+// Registries returns the registry of the node-level metrics and the
+// registries of the stores, keyed by store ID. The node registry is nil until
+// the node has been added.
+func (mr *MetricsRecorder) Registries() (*metric.Registry, map[roachpb.StoreID]*metric.Registry) {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+	storeRegistries := make(map[roachpb.StoreID]*metric.Registry, len(mr.mu.storeRegistries))
+	for id, reg := range mr.mu.storeRegistries {
+		storeRegistries[id] = reg
+	}
+	return mr.mu.nodeRegistry, storeRegistries
+}
+
+// StartedAt returns the start time of the node, in nanoseconds since the
+// epoch, as passed to AddNode.
+func (mr *MetricsRecorder) StartedAt() int64 {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+	return mr.mu.startedAt
+}
+
 // MarshalJSON returns an appropriate JSON representation of the current values
 // of the metrics being tracked by this recorder.
 func (mr *MetricsRecorder) MarshalJSON() ([]byte, error) {
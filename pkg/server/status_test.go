@@ -40,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/ts"
 	"github.com/cockroachdb/cockroach/pkg/util/httputil"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
@@ -717,3 +718,28 @@ func TestRangeResponse(t *testing.T) {
 		t.Error("expected at least one lease history entry")
 	}
 }
+
+// TestStatusStatementsRequireSuperUser verifies that only superusers can
+// read the statement statistics over HTTP.
+func TestStatusStatementsRequireSuperUser(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.TODO())
+
+	// The web session of the authenticated client is the one of
+	// authentic_user, which is not a superuser.
+	var resp serverpb.StatementsResponse
+	if err := getStatusJSONProto(s, "statements", &resp); !testutils.IsError(err, "status: 403") {
+		t.Fatalf("expected a 403 status, got %v", err)
+	}
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE USER authentic_user`)
+	sqlDB.Exec(t, `INSERT INTO system.role_members (role, member, "isAdmin") VALUES ('admin', 'authentic_user', false)`)
+	if err := getStatusJSONProto(s, "statements", &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Errors) > 0 {
+		t.Errorf("unexpected errors: %v", resp.Errors)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package telemetry counts the uses of features by the clients of the
+// node: the types of the statements run, the codes of the errors returned,
+// the unimplemented features asked for and the experimental optimizer
+// features used. The counts are exposed by crdb_internal.feature_usage, and
+// the diagnostics reports include those of the unimplemented features.
+package telemetry
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+var counters struct {
+	syncutil.RWMutex
+	m map[string]*int64
+}
+
+// Count increments the usage count of the given feature.
+func Count(feature string) {
+	counters.RLock()
+	c, ok := counters.m[feature]
+	counters.RUnlock()
+	if !ok {
+		counters.Lock()
+		if c, ok = counters.m[feature]; !ok {
+			if counters.m == nil {
+				counters.m = make(map[string]*int64)
+			}
+			c = new(int64)
+			counters.m[feature] = c
+		}
+		counters.Unlock()
+	}
+	atomic.AddInt64(c, 1)
+}
+
+// GetFeatureCounts returns the usage count of each feature used since the
+// process started.
+func GetFeatureCounts() map[string]int64 {
+	counters.RLock()
+	defer counters.RUnlock()
+	counts := make(map[string]int64, len(counters.m))
+	for feature, c := range counters.m {
+		counts[feature] = atomic.LoadInt64(c)
+	}
+	return counts
+}
+
+// StatementCounter returns the name of the feature counting the
+// statements with the given tag.
+func StatementCounter(tag string) string {
+	return "sql.statement." + tag
+}
+
+// ErrorCodeCounter returns the name of the feature counting the errors
+// with the given code returned to clients.
+func ErrorCodeCounter(code string) string {
+	return "errorcodes." + code
+}
+
+const unimplementedPrefix = "unimplemented."
+
+// UnimplementedCounter returns the name of the feature counting the
+// attempts to use the given unimplemented feature.
+func UnimplementedCounter(feature string) string {
+	return unimplementedPrefix + feature
+}
+
+// UnimplementedFeature returns the unimplemented feature whose attempted
+// uses are counted by the given feature, as named by UnimplementedCounter,
+// and whether there is one.
+func UnimplementedFeature(counter string) (string, bool) {
+	if !strings.HasPrefix(counter, unimplementedPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(counter, unimplementedPrefix), true
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package telemetry
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestCount(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const a, b = "test.feature.a", "test.feature.b"
+	before := GetFeatureCounts()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				Count(a)
+			}
+		}()
+	}
+	wg.Wait()
+	Count(b)
+
+	counts := GetFeatureCounts()
+	if expected, actual := before[a]+1000, counts[a]; expected != actual {
+		t.Errorf("expected %d uses of %s, got %d", expected, a, actual)
+	}
+	if expected, actual := before[b]+1, counts[b]; expected != actual {
+		t.Errorf("expected %d uses of %s, got %d", expected, b, actual)
+	}
+}
+
+func TestUnimplementedFeature(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	if feature, ok := UnimplementedFeature(UnimplementedCounter("#9148")); !ok || feature != "#9148" {
+		t.Errorf("expected #9148, got %q, %t", feature, ok)
+	}
+	if feature, ok := UnimplementedFeature(StatementCounter("SELECT")); ok {
+		t.Errorf("expected no unimplemented feature, got %q", feature)
+	}
+}
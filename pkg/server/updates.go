@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/server/diagnosticspb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
@@ -236,7 +237,7 @@ func (s *Server) maybeReportDiagnostics(
 		s.reportDiagnostics(running)
 	}
 	s.sqlExecutor.ResetStatementStats(ctx)
-	s.sqlExecutor.ResetUnimplementedCounts()
+	s.resetReportedFeatureCounts()
 
 	return scheduled.Add(diagnosticReportFrequency.Get(&s.st.SV))
 }
@@ -266,11 +267,36 @@ func (s *Server) getReportingInfo(ctx context.Context) *diagnosticspb.Diagnostic
 	}
 	info.Schema = schema
 	info.SqlStats = s.sqlExecutor.GetScrubbedStmtStats()
-	info.UnimplementedErrors = make(map[string]int64)
-	s.sqlExecutor.FillUnimplementedErrorCounts(info.UnimplementedErrors)
+	info.UnimplementedErrors = s.unimplementedErrorCounts()
 	return &info
 }
 
+// unimplementedErrorCounts returns how many times each unimplemented
+// feature was asked for since the last diagnostics report, from the feature
+// usage counts of the telemetry package.
+func (s *Server) unimplementedErrorCounts() map[string]int64 {
+	s.reportedFeatureCounts.Lock()
+	defer s.reportedFeatureCounts.Unlock()
+	counts := make(map[string]int64)
+	for counter, count := range telemetry.GetFeatureCounts() {
+		if feature, ok := telemetry.UnimplementedFeature(counter); ok {
+			if n := count - s.reportedFeatureCounts.counts[counter]; n > 0 {
+				counts[feature] = n
+			}
+		}
+	}
+	return counts
+}
+
+// resetReportedFeatureCounts makes the next diagnostics report only count
+// the feature uses from now on. The telemetry counts themselves are never
+// reset, as crdb_internal.feature_usage shows them since the node started.
+func (s *Server) resetReportedFeatureCounts() {
+	s.reportedFeatureCounts.Lock()
+	defer s.reportedFeatureCounts.Unlock()
+	s.reportedFeatureCounts.counts = telemetry.GetFeatureCounts()
+}
+
 func (s *Server) reportDiagnostics(runningTime time.Duration) {
 	ctx, span := s.AnnotateCtxWithSpan(context.Background(), "usageReport")
 	defer span.Finish()
@@ -44,6 +44,7 @@ const (
 	VersionRecomputeStats
 	VersionNoRaftProposalKeys
 	VersionTxnSpanRefresh
+	VersionRoleSettingsOptionsAndDefaultPrivileges
 
 	// Add new versions here (step one of two).
 
@@ -164,6 +165,13 @@ var versionsSingleton = keyedVersions([]keyedVersion{
 		Key:     VersionTxnSpanRefresh,
 		Version: roachpb.Version{Major: 1, Minor: 1, Unstable: 12},
 	},
+	{
+		// VersionRoleSettingsOptionsAndDefaultPrivileges is the version from
+		// which the system.role_settings, system.role_options and
+		// system.default_privileges tables exist on all the nodes.
+		Key:     VersionRoleSettingsOptionsAndDefaultPrivileges,
+		Version: roachpb.Version{Major: 1, Minor: 1, Unstable: 13},
+	},
 
 	// Add new versions here (step two of two).
 
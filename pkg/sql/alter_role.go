@@ -0,0 +1,294 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+// alterRoleSetNode represents an ALTER ROLE ... SET, ALTER ROLE ... RESET,
+// ALTER DATABASE ... SET or ALTER DATABASE ... RESET statement.
+type alterRoleSetNode struct {
+	// name is the name of the altered user or role, or nil if the defaults
+	// of the database with ID databaseID are altered instead.
+	name       func() (string, error)
+	isRole     bool
+	databaseID sqlbase.ID
+	// createRoleOnly is set if the user can only alter users and roles
+	// through the CREATEROLE option.
+	createRoleOnly bool
+	// variable is the name of the variable, or empty for a RESET ALL. set
+	// holds its values, or is nil for a RESET.
+	variable string
+	set      *setVarNode
+}
+
+// AlterRoleSet sets or resets the default value of a session variable for
+// a user or role, which is applied when it logs in.
+// Privileges: UPDATE on the users table, or the CREATEROLE option.
+func (p *planner) AlterRoleSet(ctx context.Context, n *tree.AlterRoleSet) (planNode, error) {
+	createRoleOnly, err := p.checkCreateRolePrivilege(ctx, privilege.UPDATE)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := p.TypeAsString(n.Name, n.StatementTag())
+	if err != nil {
+		return nil, err
+	}
+	node := &alterRoleSetNode{name: name, isRole: n.IsRole, createRoleOnly: createRoleOnly}
+	if err := p.planRoleSetting(ctx, node, n.SetVar, n.IsReset()); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// AlterDatabaseSet sets or resets the default value of a session variable
+// for a database, which is applied when a session starts in it.
+// Privileges: superuser.
+//   Notes: postgres requires superuser or db owner.
+func (p *planner) AlterDatabaseSet(
+	ctx context.Context, n *tree.AlterDatabaseSet,
+) (planNode, error) {
+	if n.Name == "" {
+		return nil, errEmptyDatabaseName
+	}
+	if err := p.RequireSuperUser(ctx, "ALTER DATABASE ... SET"); err != nil {
+		return nil, err
+	}
+	dbDesc, err := MustGetDatabaseDesc(ctx, p.txn, p.getVirtualTabler(), string(n.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	node := &alterRoleSetNode{databaseID: dbDesc.ID}
+	if err := p.planRoleSetting(ctx, node, n.SetVar, n.IsReset()); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// planRoleSetting checks the variable and the values of a SET or RESET of
+// a default value, like those of a SET, and records them in the node.
+func (p *planner) planRoleSetting(
+	ctx context.Context, node *alterRoleSetNode, setVar *tree.SetVar, isReset bool,
+) error {
+	if setVar == nil {
+		return nil
+	}
+
+	node.variable = strings.ToLower(setVar.Name)
+	switch node.variable {
+	case "transaction_isolation", "transaction_priority", "transaction_read_only",
+		"transaction_status", "tracing":
+		return errors.Errorf("variable %q cannot have a default value", node.variable)
+	}
+	if isReset {
+		if _, ok := varGen[node.variable]; !ok {
+			return fmt.Errorf("unknown variable: %q", node.variable)
+		}
+		return nil
+	}
+	plan, err := p.SetVar(ctx, setVar)
+	if err != nil {
+		return err
+	}
+	node.set = plan.(*setVarNode)
+	return nil
+}
+
+// lookupAlteredRole returns the normalized name of the user or role altered
+// by an ALTER USER or ALTER ROLE statement, checking that it exists.
+func lookupAlteredRole(params runParams, name func() (string, error), isRole bool) (string, error) {
+	username, err := name()
+	if err != nil {
+		return "", err
+	}
+	if username == "" {
+		return "", errNoUserNameSpecified
+	}
+	normalizedName, err := NormalizeAndValidateUsername(username)
+	if err != nil {
+		return "", err
+	}
+
+	internalExecutor := InternalExecutor{ExecCfg: params.extendedEvalCtx.ExecCfg}
+	row, err := internalExecutor.QueryRowInTransaction(
+		params.ctx,
+		"alter-role-lookup",
+		params.p.txn,
+		`SELECT "isRole" FROM system.users WHERE username = $1`,
+		normalizedName,
+	)
+	if err != nil {
+		return "", err
+	}
+	if row == nil || bool(tree.MustBeDBool(row[0])) != isRole {
+		entryType := "user"
+		if isRole {
+			entryType = "role"
+		}
+		return "", errors.Errorf("%s %s does not exist", entryType, normalizedName)
+	}
+	return normalizedName, nil
+}
+
+func (n *alterRoleSetNode) startExec(params runParams) error {
+	// The defaults of a database are stored with an empty role.
+	var normalizedName string
+	if n.name != nil {
+		var err error
+		normalizedName, err = lookupAlteredRole(params, n.name, n.isRole)
+		if err != nil {
+			return err
+		}
+		if n.createRoleOnly {
+			if err := params.p.checkCreateRoleTarget(params.ctx, normalizedName); err != nil {
+				return err
+			}
+		}
+	}
+	params.p.invalidateRoleSettingsOnCommit(params.ctx)
+
+	internalExecutor := InternalExecutor{ExecCfg: params.extendedEvalCtx.ExecCfg}
+	if n.set == nil {
+		query := `DELETE FROM system.role_settings WHERE role = $1 AND database_id = $2`
+		args := []interface{}{normalizedName, n.databaseID}
+		if n.variable != "" {
+			query += ` AND variable = $3`
+			args = append(args, n.variable)
+		}
+		_, err := internalExecutor.ExecuteStatementInTransaction(
+			params.ctx, "alter-role-reset", params.p.txn, query, args...,
+		)
+		return err
+	}
+
+	values := make(tree.Exprs, len(n.set.typedValues))
+	for i, v := range n.set.typedValues {
+		d, err := v.Eval(params.EvalContext())
+		if err != nil {
+			return err
+		}
+		n.set.typedValues[i] = d
+		values[i] = d
+	}
+	// The values are checked by setting them on a scratch session, so that
+	// the errors are reported now rather than when the user logs in.
+	scratch := params.p.sessionDataMutator
+	scratch.data = &sessiondata.SessionData{
+		Location:      params.p.SessionData().Location,
+		SearchPath:    params.p.SessionData().SearchPath,
+		SequenceState: sessiondata.NewSequenceState(),
+	}
+	scratch.curTxnReadOnly = new(bool)
+	scratch.sessionTracing = nil
+	scratch.applicationNameChanged = nil
+	if err := n.set.v.Set(params.ctx, scratch, params.extendedEvalCtx, n.set.typedValues); err != nil {
+		return err
+	}
+
+	_, err := internalExecutor.ExecuteStatementInTransaction(
+		params.ctx,
+		"alter-role-set",
+		params.p.txn,
+		`UPSERT INTO system.role_settings (role, database_id, variable, value) VALUES ($1, $2, $3, $4)`,
+		normalizedName,
+		n.databaseID,
+		n.variable,
+		tree.AsString(&values),
+	)
+	return err
+}
+
+// removeDatabaseSettings deletes the default session variables of the
+// database with the given ID.
+func (p *planner) removeDatabaseSettings(ctx context.Context, dbID sqlbase.ID) error {
+	p.invalidateRoleSettingsOnCommit(ctx)
+	internalExecutor := InternalExecutor{ExecCfg: p.ExecCfg()}
+	_, err := internalExecutor.ExecuteStatementInTransaction(
+		ctx,
+		"delete-database-settings",
+		p.txn,
+		`DELETE FROM system.role_settings WHERE role = '' AND database_id = $1`,
+		dbID,
+	)
+	return err
+}
+
+func (*alterRoleSetNode) Next(runParams) (bool, error) { return false, nil }
+func (*alterRoleSetNode) Values() tree.Datums          { return tree.Datums{} }
+func (*alterRoleSetNode) Close(context.Context)        {}
+
+// alterRoleOptionsNode represents an ALTER ROLE ... WITH <options> statement.
+type alterRoleOptionsNode struct {
+	name    func() (string, error)
+	isRole  bool
+	options []roleOptionUpdate
+	// createRoleOnly is set if the user can only alter users and roles
+	// through the CREATEROLE option.
+	createRoleOnly bool
+}
+
+// AlterRoleOptions changes the options of a user or role.
+// Privileges: UPDATE on the users table, or the CREATEROLE option.
+func (p *planner) AlterRoleOptions(
+	ctx context.Context, n *tree.AlterRoleOptions,
+) (planNode, error) {
+	createRoleOnly, err := p.checkCreateRolePrivilege(ctx, privilege.UPDATE)
+	if err != nil {
+		return nil, err
+	}
+
+	stmtTag := n.StatementTag()
+	name, err := p.TypeAsString(n.Name, stmtTag)
+	if err != nil {
+		return nil, err
+	}
+	options, err := p.makeRoleOptionUpdates(n.Options, n.IsRole, stmtTag)
+	if err != nil {
+		return nil, err
+	}
+	return &alterRoleOptionsNode{
+		name: name, isRole: n.IsRole, options: options, createRoleOnly: createRoleOnly,
+	}, nil
+}
+
+func (n *alterRoleOptionsNode) startExec(params runParams) error {
+	normalizedName, err := lookupAlteredRole(params, n.name, n.isRole)
+	if err != nil {
+		return err
+	}
+	if n.createRoleOnly {
+		if err := params.p.checkCreateRoleTarget(params.ctx, normalizedName); err != nil {
+			return err
+		}
+	}
+	return writeRoleOptions(params, normalizedName, n.options)
+}
+
+func (*alterRoleOptionsNode) Next(runParams) (bool, error) { return false, nil }
+func (*alterRoleOptionsNode) Values() tree.Datums          { return tree.Datums{} }
+func (*alterRoleOptionsNode) Close(context.Context)        {}
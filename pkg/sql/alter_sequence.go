@@ -60,6 +60,10 @@ func (n *alterSequenceNode) startExec(params runParams) error {
 		return err
 	}
 
+	if err := params.p.assignSequenceOwner(params.ctx, desc, n.n.Options); err != nil {
+		return err
+	}
+
 	if err := params.p.writeTableDesc(params.ctx, n.seqDesc); err != nil {
 		return err
 	}
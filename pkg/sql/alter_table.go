@@ -122,6 +122,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 
 			n.tableDesc.AddColumnMutation(*col, sqlbase.DescriptorMutation_ADD)
+			if err := params.p.checkColumnsPerTableLimit(n.tableDesc); err != nil {
+				return err
+			}
 			if idx != nil {
 				if err := n.tableDesc.AddIndexMutation(*idx, sqlbase.DescriptorMutation_ADD); err != nil {
 					return err
@@ -233,6 +236,17 @@ func (n *alterTableNode) startExec(params runParams) error {
 				}
 			}
 
+			// Sequences owned by the dropped column are dropped with it, unless
+			// something else still uses them.
+			if len(col.OwnsSequenceIds) > 0 {
+				if err := params.p.canRemoveOwnedSequences(params.ctx, &col, nil /* dropping */); err != nil {
+					return err
+				}
+				if _, err := params.p.dropSequencesOwnedByCol(params.ctx, &col); err != nil {
+					return err
+				}
+			}
+
 			// You can't drop a column depended on by a view unless CASCADE was
 			// specified.
 			for _, ref := range n.tableDesc.DependedOnBy {
@@ -375,6 +389,12 @@ func (n *alterTableNode) startExec(params runParams) error {
 				return fmt.Errorf("column %q in the middle of being added, try again later", t.Column)
 			}
 
+			if err := params.p.removeComment(
+				params.ctx, columnCommentType, n.tableDesc.ID, int64(col.ID),
+			); err != nil {
+				return err
+			}
+
 		case *tree.AlterTableDropConstraint:
 			info, err := n.tableDesc.GetConstraintInfo(params.ctx, nil)
 			if err != nil {
@@ -27,32 +27,32 @@ import (
 type alterUserSetPasswordNode struct {
 	userAuthInfo
 	ifExists bool
+	// createRoleOnly is set if the user can only alter users through the
+	// CREATEROLE option.
+	createRoleOnly bool
 
 	run alterUserSetPasswordRun
 }
 
 // AlterUserSetPassword changes a user's password.
-// Privileges: UPDATE on the users table.
+// Privileges: UPDATE on the users table, or the CREATEROLE option.
 func (p *planner) AlterUserSetPassword(
 	ctx context.Context, n *tree.AlterUserSetPassword,
 ) (planNode, error) {
-	tDesc, err := getTableDesc(ctx, p.txn, p.getVirtualTabler(), userTableName)
+	createRoleOnly, err := p.checkCreateRolePrivilege(ctx, privilege.UPDATE)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := p.CheckPrivilege(ctx, tDesc, privilege.UPDATE); err != nil {
-		return nil, err
-	}
-
 	ua, err := p.getUserAuthInfo(n.Name, n.Password, "ALTER USER")
 	if err != nil {
 		return nil, err
 	}
 
 	return &alterUserSetPasswordNode{
-		userAuthInfo: ua,
-		ifExists:     n.IfExists,
+		userAuthInfo:   ua,
+		ifExists:       n.IfExists,
+		createRoleOnly: createRoleOnly,
 	}, nil
 }
 
@@ -77,6 +77,12 @@ func (n *alterUserSetPasswordNode) startExec(params runParams) error {
 		return errors.New("cluster in insecure mode; user cannot use password authentication")
 	}
 
+	if n.createRoleOnly {
+		if err := params.p.checkCreateRoleTarget(params.ctx, normalizedUsername); err != nil {
+			return err
+		}
+	}
+
 	internalExecutor := InternalExecutor{ExecCfg: params.extendedEvalCtx.ExecCfg}
 	n.run.rowsAffected, err = internalExecutor.ExecuteStatementInTransaction(
 		params.ctx,
@@ -23,6 +23,7 @@ import (
 	"strconv"
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
@@ -51,6 +52,60 @@ type stmtStats struct {
 	syncutil.Mutex
 
 	data roachpb.StatementStatistics
+	// serviceLatBuckets counts the executions by service latency, to
+	// estimate its percentiles.
+	serviceLatBuckets serviceLatBuckets
+}
+
+// numServiceLatBuckets is the number of buckets of serviceLatBuckets.
+const numServiceLatBuckets = 24
+
+// serviceLatBucketBase is the upper bound, in seconds, of the latencies
+// counted in the first bucket of serviceLatBuckets.
+const serviceLatBucketBase = 100e-6
+
+// serviceLatBuckets counts latencies in exponential buckets: the upper
+// bound of each bucket is twice that of the previous one, and the last
+// bucket counts all the latencies above the bound of the previous one.
+// Unlike the mean and variance, the buckets of several nodes can be merged
+// by adding them up.
+type serviceLatBuckets [numServiceLatBuckets]int64
+
+// record counts the given latency, in seconds.
+func (b *serviceLatBuckets) record(lat float64) {
+	i := 0
+	for bound := serviceLatBucketBase; lat > bound && i < numServiceLatBuckets-1; bound *= 2 {
+		i++
+	}
+	b[i]++
+}
+
+// serviceLatPercentile estimates the q-th percentile (0 < q <= 100) of the
+// latencies counted in buckets, by interpolating linearly within the bucket
+// it falls in. The percentiles in the last bucket are estimated to be its
+// lower bound.
+func serviceLatPercentile(buckets []int64, q float64) float64 {
+	var total int64
+	for _, c := range buckets {
+		total += c
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := q / 100 * float64(total)
+	var cum int64
+	lower, upper := 0.0, serviceLatBucketBase
+	for i, c := range buckets {
+		if c > 0 && float64(cum+c) >= rank {
+			if i == len(buckets)-1 {
+				break
+			}
+			return lower + (upper-lower)*(rank-float64(cum))/float64(c)
+		}
+		cum += c
+		lower, upper = upper, upper*2
+	}
+	return lower
 }
 
 // stmtStatsEnable determines whether to collect per-statement
@@ -142,6 +197,7 @@ func (a *appStats) recordStatement(
 	s.data.RunLat.Record(s.data.Count, runLat)
 	s.data.ServiceLat.Record(s.data.Count, svcLat)
 	s.data.OverheadLat.Record(s.data.Count, ovhLat)
+	s.serviceLatBuckets.record(svcLat)
 	s.Unlock()
 }
 
@@ -172,6 +228,10 @@ type sqlStats struct {
 	// apps is the container for all the per-application statistics
 	// objects.
 	apps map[string]*appStats
+
+	// tables counts the activity of each table. It is not reset with the
+	// statement statistics.
+	tables *tableActivityStats
 }
 
 func (s *sqlStats) getStatsForApplication(appName string) *appStats {
@@ -318,6 +378,37 @@ func (e *Executor) GetScrubbedStmtStats() []roachpb.CollectedStatementStatistics
 	return ret
 }
 
+// GetStmtStats returns the statement statistics collected on this node.
+// Unlike GetScrubbedStmtStats, the queries, application names and errors
+// are returned as is, and the node ID is left to the caller.
+func (e *Executor) GetStmtStats() []serverpb.StatementsResponse_CollectedStatementStatistics {
+	var ret []serverpb.StatementsResponse_CollectedStatementStatistics
+	e.sqlStats.Lock()
+	for appName, a := range e.sqlStats.apps {
+		a.Lock()
+		for q, stats := range a.stmts {
+			stats.Lock()
+			data := stats.data
+			buckets := stats.serviceLatBuckets
+			stats.Unlock()
+
+			ret = append(ret, serverpb.StatementsResponse_CollectedStatementStatistics{
+				Key: roachpb.StatementStatisticsKey{
+					Query:   q.stmt,
+					App:     appName,
+					DistSQL: q.distSQLUsed,
+					Failed:  q.failed,
+				},
+				Stats:             data,
+				ServiceLatBuckets: buckets[:],
+			})
+		}
+		a.Unlock()
+	}
+	e.sqlStats.Unlock()
+	return ret
+}
+
 // HashAppName 1-way hashes an application names for use in stat reporting.
 func HashAppName(appName string) string {
 	hash := fnv.New64a()
@@ -332,32 +423,3 @@ func HashAppName(appName string) string {
 func (e *Executor) ResetStatementStats(ctx context.Context) {
 	e.sqlStats.resetStats(ctx)
 }
-
-// FillUnimplementedErrorCounts fills the passed map with the executor's current
-// counts of how often individual unimplemented features have been encountered.
-func (e *Executor) FillUnimplementedErrorCounts(fill map[string]int64) {
-	e.unimplementedErrors.Lock()
-	for k, v := range e.unimplementedErrors.counts {
-		fill[k] = v
-	}
-	e.unimplementedErrors.Unlock()
-}
-
-func (e *Executor) recordUnimplementedFeature(feature string) {
-	if feature == "" {
-		return
-	}
-	e.unimplementedErrors.Lock()
-	if e.unimplementedErrors.counts == nil {
-		e.unimplementedErrors.counts = make(map[string]int64)
-	}
-	e.unimplementedErrors.counts[feature]++
-	e.unimplementedErrors.Unlock()
-}
-
-// ResetUnimplementedCounts resets counting of unimplemented errors.
-func (e *Executor) ResetUnimplementedCounts() {
-	e.unimplementedErrors.Lock()
-	e.unimplementedErrors.counts = make(map[string]int64, len(e.unimplementedErrors.counts))
-	e.unimplementedErrors.Unlock()
-}
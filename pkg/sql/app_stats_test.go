@@ -0,0 +1,78 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestServiceLatPercentile(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var b serviceLatBuckets
+	if p := serviceLatPercentile(b[:], 50); p != 0 {
+		t.Fatalf("expected 0 without latencies, got %f", p)
+	}
+
+	// 100 latencies between 100µs and 200µs, and one very large latency
+	// counted in the last bucket.
+	for i := 0; i < 100; i++ {
+		b.record(150e-6)
+	}
+	b.record(1e6)
+	if b[1] != 100 || b[numServiceLatBuckets-1] != 1 {
+		t.Fatalf("unexpected buckets %v", b)
+	}
+
+	const epsilon = 1e-9
+	lastLower := serviceLatBucketBase * math.Pow(2, numServiceLatBuckets-2)
+	testData := []struct {
+		q        float64
+		expected float64
+	}{
+		{50, 150.5e-6},
+		{99, 199.99e-6},
+		{100, lastLower},
+	}
+	for _, d := range testData {
+		if p := serviceLatPercentile(b[:], d.q); math.Abs(p-d.expected) > epsilon {
+			t.Errorf("p%.0f: expected %g, got %g", d.q, d.expected, p)
+		}
+	}
+
+	// Merging the buckets of two nodes gives the same percentiles as
+	// counting the latencies of both in a single set of buckets.
+	var merged serviceLatBuckets
+	for i := 0; i < 10; i++ {
+		merged.record(3e-3)
+	}
+	for i := range b {
+		merged[i] += b[i]
+	}
+	var all serviceLatBuckets
+	for i := 0; i < 100; i++ {
+		all.record(150e-6)
+	}
+	all.record(1e6)
+	for i := 0; i < 10; i++ {
+		all.record(3e-3)
+	}
+	if merged != all {
+		t.Fatalf("expected merged buckets %v, got %v", all, merged)
+	}
+}
@@ -370,3 +370,85 @@ func TestShowTraceAsOfTime(t *testing.T) {
 		t.Fatalf("expected to find one matching row, got %v", i)
 	}
 }
+
+// Test that the virtual tables describe the schema as of the time given by
+// AS OF SYSTEM TIME, including what they read from system tables, and that
+// the tables reporting the current state of the nodes can't be queried AS OF
+// SYSTEM TIME.
+func TestVirtualTablesAsOfTime(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.TODO())
+
+	if _, err := db.Exec(`
+		CREATE DATABASE d;
+		CREATE TABLE d.t (a INT);
+		COMMENT ON TABLE d.t IS 'before';
+		CREATE USER u;
+	`); err != nil {
+		t.Fatal(err)
+	}
+	var ts string
+	if err := db.QueryRow("SELECT cluster_logical_timestamp()").Scan(&ts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`
+		ALTER TABLE d.t ADD COLUMN b INT;
+		COMMENT ON TABLE d.t IS 'after';
+		CREATE TABLE d.u (c INT);
+		ALTER USER u WITH VALID UNTIL '2100-01-01';
+		ALTER USER u SET timezone = 'UTC';
+		ALTER DEFAULT PRIVILEGES IN DATABASE d GRANT SELECT ON TABLES TO u;
+		INSERT INTO system.table_statistics ("tableID", "columnIDs", "rowCount", "distinctCount", "nullCount")
+		  SELECT id, ARRAY[1], 10, 10, 0 FROM system.namespace WHERE name = 't';
+	`); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range []struct {
+		query    string
+		asOf     string
+		expected string
+	}{
+		{`SELECT concat_agg(column_name) FROM d.information_schema.columns
+		  %s WHERE table_name = 't'`, `a`, `ab`},
+		{`SELECT concat_agg(table_name) FROM d.information_schema.tables
+		  %s WHERE table_schema = 'd'`, `t`, `tu`},
+		{`SELECT description FROM d.pg_catalog.pg_description
+		  %s WHERE objsubid = 0`, `before`, `after`},
+		{`SELECT count(table_rows) FROM d.information_schema.tables
+		  %s WHERE table_name = 't'`, `0`, `1`},
+		{`SELECT count(rolvaliduntil) FROM d.pg_catalog.pg_roles
+		  %s WHERE rolname = 'u'`, `0`, `1`},
+		{`SELECT count(*) FROM d.pg_catalog.pg_db_role_setting %s`, `0`, `1`},
+		{`SELECT count(*) FROM crdb_internal.default_privileges
+		  %s WHERE database_name = 'd'`, `0`, `1`},
+	} {
+		var res string
+		query := fmt.Sprintf(d.query, "AS OF SYSTEM TIME "+ts)
+		if err := db.QueryRow(query).Scan(&res); err != nil {
+			t.Fatal(err)
+		} else if res != d.asOf {
+			t.Errorf("%s: expected %q, got %q", query, d.asOf, res)
+		}
+		query = fmt.Sprintf(d.query, "")
+		if err := db.QueryRow(query).Scan(&res); err != nil {
+			t.Fatal(err)
+		} else if res != d.expected {
+			t.Errorf("%s: expected %q, got %q", query, d.expected, res)
+		}
+	}
+
+	for _, table := range []string{
+		`crdb_internal.node_metrics`,
+		`crdb_internal.cluster_sessions`,
+		`pg_catalog.pg_stat_activity`,
+	} {
+		query := fmt.Sprintf(`SELECT count(*) FROM %s AS OF SYSTEM TIME %s`, table, ts)
+		expected := table + " reports the current state of the cluster and cannot be queried AS OF SYSTEM TIME"
+		if _, err := db.Exec(query); !testutils.IsError(err, expected) {
+			t.Errorf("%s: expected %q, got %v", query, expected, err)
+		}
+	}
+}
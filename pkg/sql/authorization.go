@@ -86,6 +86,31 @@ func (p *planner) CheckPrivilege(
 		user, privilege, descriptor.TypeName(), descriptor.GetName())
 }
 
+// CheckColumnsPrivilege is like CheckPrivilege, but is also satisfied when
+// the privilege is held on each of the given columns of the table. The error
+// of the table-level check is returned otherwise.
+func (p *planner) CheckColumnsPrivilege(
+	ctx context.Context,
+	desc *sqlbase.TableDescriptor,
+	cols []sqlbase.ColumnDescriptor,
+	privilege privilege.Kind,
+) error {
+	err := p.CheckPrivilege(ctx, desc, privilege)
+	if err == nil || len(cols) == 0 {
+		return err
+	}
+	c, cErr := p.makePrivilegeChecker(ctx)
+	if cErr != nil {
+		return cErr
+	}
+	for i := range cols {
+		if !c.columnPrivilege(&cols[i], privilege) {
+			return err
+		}
+	}
+	return nil
+}
+
 // CheckAnyPrivilege implements the AuthorizationAccessor interface.
 func (p *planner) CheckAnyPrivilege(ctx context.Context, descriptor sqlbase.DescriptorProto) error {
 	if isVirtualDescriptor(descriptor) {
@@ -117,6 +142,137 @@ func (p *planner) CheckAnyPrivilege(ctx context.Context, descriptor sqlbase.Desc
 		p.SessionData().User, descriptor.TypeName(), descriptor.GetName())
 }
 
+// privilegeChecker checks the privileges of a user on descriptors. It is a
+// snapshot of the user and of the roles the user is a member of, so unlike
+// the planner it needs no transaction and is safe for concurrent use, e.g. by
+// goroutines populating a virtual table in parallel. The privileges
+// themselves are read from the descriptors, which must not be modified
+// while they are checked.
+type privilegeChecker struct {
+	user string
+	// roles are the roles the user is a member of, directly or indirectly.
+	roles []string
+}
+
+// makePrivilegeChecker expands the role memberships of the session user
+// into a privilegeChecker.
+func (p *planner) makePrivilegeChecker(ctx context.Context) (*privilegeChecker, error) {
+	user := p.SessionData().User
+	memberOf, err := p.MemberOfWithAdminOption(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	c := &privilegeChecker{user: user, roles: make([]string, 0, len(memberOf))}
+	// We don't care about the admin option.
+	for role := range memberOf {
+		c.roles = append(c.roles, role)
+	}
+	return c, nil
+}
+
+// anyPrivilege returns whether the user or one of its roles has any
+// privilege on the descriptor, like CheckAnyPrivilege.
+func (c *privilegeChecker) anyPrivilege(descriptor sqlbase.DescriptorProto) bool {
+	if isVirtualDescriptor(descriptor) {
+		return true
+	}
+	return c.anyPrivilegeIn(descriptor.GetPrivileges())
+}
+
+// anyColumnPrivilege returns whether the user or one of its roles has any
+// privilege granted on the column specifically. The privileges on its table
+// are not considered.
+func (c *privilegeChecker) anyColumnPrivilege(column *sqlbase.ColumnDescriptor) bool {
+	if column.Privileges == nil {
+		return false
+	}
+	return c.anyPrivilegeIn(column.Privileges)
+}
+
+// columnPrivilege returns whether the user or one of its roles has been
+// granted the privilege on the column specifically. The privileges on its
+// table are not considered.
+func (c *privilegeChecker) columnPrivilege(
+	column *sqlbase.ColumnDescriptor, priv privilege.Kind,
+) bool {
+	privs := column.Privileges
+	if privs == nil {
+		return false
+	}
+	if privs.CheckPrivilege(c.user, priv) {
+		return true
+	}
+	for _, role := range c.roles {
+		if privs.CheckPrivilege(role, priv) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *privilegeChecker) anyPrivilegeIn(privs *sqlbase.PrivilegeDescriptor) bool {
+	if privs.AnyPrivilege(c.user) {
+		return true
+	}
+	for _, role := range c.roles {
+		if privs.AnyPrivilege(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPrivilege implements the tree.EvalPlanner interface.
+func (p *planner) HasPrivilege(
+	ctx context.Context,
+	user string,
+	tn *tree.TableName,
+	column string,
+	priv privilege.Kind,
+	grantOption bool,
+) (bool, error) {
+	desc, err := MustGetTableOrViewDesc(
+		ctx, p.txn, p.getVirtualTabler(), tn, true /* allowAdding */)
+	if err != nil {
+		return false, err
+	}
+	var colPrivs *sqlbase.PrivilegeDescriptor
+	if column != "" {
+		col, _, err := desc.FindColumnByName(tree.Name(column))
+		if err != nil {
+			return false, err
+		}
+		colPrivs = col.Privileges
+	}
+	if isVirtualDescriptor(desc) {
+		// Everyone can read the virtual tables, and do nothing else.
+		return priv == privilege.SELECT && !grantOption, nil
+	}
+
+	holds := func(privs *sqlbase.PrivilegeDescriptor, user string) bool {
+		if privs == nil {
+			return false
+		}
+		if grantOption {
+			return privs.CheckPrivilege(user, privilege.GRANT) || privs.CheckGrantOption(user, priv)
+		}
+		return privs.CheckPrivilege(user, priv)
+	}
+	if holds(desc.Privileges, user) || holds(colPrivs, user) {
+		return true, nil
+	}
+	memberOf, err := p.MemberOfWithAdminOption(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	for role := range memberOf {
+		if holds(desc.Privileges, role) || holds(colPrivs, role) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // RequireSuperUser implements the AuthorizationAccessor interface.
 func (p *planner) RequireSuperUser(ctx context.Context, action string) error {
 	user := p.SessionData().User
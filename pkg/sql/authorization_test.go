@@ -0,0 +1,141 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package sql
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestPrivilegeCheckerAnyPrivilege(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	byUser := &sqlbase.DatabaseDescriptor{
+		Name: "by_user", ID: 100,
+		Privileges: sqlbase.NewPrivilegeDescriptor("testuser", privilege.List{privilege.SELECT}),
+	}
+	byRole := &sqlbase.TableDescriptor{
+		Name: "by_role", ID: 101,
+		Privileges: sqlbase.NewPrivilegeDescriptor("testrole", privilege.List{privilege.INSERT}),
+	}
+	byOther := &sqlbase.TableDescriptor{
+		Name: "by_other", ID: 102,
+		Privileges: sqlbase.NewPrivilegeDescriptor("other", privilege.List{privilege.ALL}),
+	}
+	virtual := &sqlbase.TableDescriptor{
+		Name: "virtual", ID: keys.VirtualDescriptorID,
+		Privileges: sqlbase.NewPrivilegeDescriptor("other", privilege.List{privilege.ALL}),
+	}
+
+	testCases := []struct {
+		desc     sqlbase.DescriptorProto
+		expected bool
+	}{
+		{byUser, true},
+		{byRole, true},
+		{byOther, false},
+		{virtual, true},
+	}
+
+	c := &privilegeChecker{user: "testuser", roles: []string{"testrole"}}
+	// The checker is used concurrently, as by the parallel population of a
+	// virtual table.
+	var wg sync.WaitGroup
+	for _, tc := range testCases {
+		wg.Add(1)
+		go func(desc sqlbase.DescriptorProto, expected bool) {
+			defer wg.Done()
+			if actual := c.anyPrivilege(desc); actual != expected {
+				t.Errorf("%s: expected %t, got %t", desc.GetName(), expected, actual)
+			}
+		}(tc.desc, tc.expected)
+	}
+	wg.Wait()
+}
+
+func TestUserCanSeeColumn(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	table := &sqlbase.TableDescriptor{
+		Name: "t", ID: 100, State: sqlbase.TableDescriptor_PUBLIC,
+		Privileges: sqlbase.NewPrivilegeDescriptor("other", privilege.List{privilege.ALL}),
+		Columns: []sqlbase.ColumnDescriptor{
+			{Name: "by_user", ID: 1,
+				Privileges: sqlbase.NewPrivilegeDescriptor("testuser", privilege.List{privilege.SELECT})},
+			{Name: "by_role", ID: 2,
+				Privileges: sqlbase.NewPrivilegeDescriptor("testrole", privilege.List{privilege.UPDATE})},
+			{Name: "by_other", ID: 3,
+				Privileges: sqlbase.NewPrivilegeDescriptor("other", privilege.List{privilege.INSERT})},
+			{Name: "none", ID: 4},
+		},
+	}
+
+	c := &privilegeChecker{user: "testuser", roles: []string{"testrole"}}
+	if !userCanSeeTable(c, table, false /* allowAdding */) {
+		t.Fatal("expected the table to be visible through the privileges on its columns")
+	}
+	var visible []string
+	if err := forEachColumnInTableWithHidden(table, false /* includeHidden */, c,
+		func(position int, column *sqlbase.ColumnDescriptor) error {
+			if position != int(column.ID) {
+				t.Errorf("%s: expected position %d, got %d", column.Name, column.ID, position)
+			}
+			visible = append(visible, column.Name)
+			return nil
+		}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"by_user", "by_role"}; !reflect.DeepEqual(visible, expected) {
+		t.Errorf("expected the columns %v to be visible, got %v", expected, visible)
+	}
+
+	// The columns of the indexes are filtered the same way.
+	index := &sqlbase.IndexDescriptor{ColumnIDs: []sqlbase.ColumnID{3, 2}}
+	visible = nil
+	if err := forEachColumnInIndex(table, index, c,
+		func(position int, column *sqlbase.ColumnDescriptor) error {
+			if position != 2 {
+				t.Errorf("%s: expected position 2, got %d", column.Name, position)
+			}
+			visible = append(visible, column.Name)
+			return nil
+		}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"by_role"}; !reflect.DeepEqual(visible, expected) {
+		t.Errorf("expected the index columns %v to be visible, got %v", expected, visible)
+	}
+
+	// The privileges on the table make all the columns visible.
+	table.Privileges.Grant("testrole", privilege.List{privilege.SELECT})
+	for i := range table.Columns {
+		if !userCanSeeColumn(c, table, &table.Columns[i]) {
+			t.Errorf("%s: expected the column to be visible", table.Columns[i].Name)
+		}
+	}
+
+	// Without privileges on the columns, the table is not visible.
+	c = &privilegeChecker{user: "nobody"}
+	if userCanSeeTable(c, table, false /* allowAdding */) {
+		t.Error("expected the table not to be visible")
+	}
+}
@@ -20,7 +20,9 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
@@ -87,3 +89,70 @@ func (n *cancelQueryNode) startExec(params runParams) error {
 func (n *cancelQueryNode) Next(runParams) (bool, error) { return false, nil }
 func (*cancelQueryNode) Values() tree.Datums            { return nil }
 func (*cancelQueryNode) Close(context.Context)          {}
+
+// errMustBeSessionOwner is returned when a user other than root signals the
+// session of another user through pg_cancel_backend or pg_terminate_backend.
+var errMustBeSessionOwner = pgerror.NewError(pgerror.CodeInsufficientPrivilegeError,
+	"must be root or the user of the session to signal it")
+
+// CancelBackend implements the tree.EvalPlanner interface.
+func (p *planner) CancelBackend(ctx context.Context, pid int64) (bool, error) {
+	// The sessions that couldn't be given a pid are listed with pid 0.
+	if pid <= 0 {
+		return false, nil
+	}
+	statusServer := p.extendedEvalCtx.StatusServer
+	user := p.SessionData().User
+
+	// All the sessions are listed, so that the session of another user can be
+	// told from one that doesn't exist.
+	response, err := statusServer.ListSessions(
+		ctx, &serverpb.ListSessionsRequest{Username: security.RootUser})
+	if err != nil {
+		return false, err
+	}
+	nodeID := backendPIDNodeID(pid)
+	for _, rpcErr := range response.Errors {
+		if rpcErr.NodeID == nodeID {
+			return false, errors.Errorf("could not list the sessions of node %d: %s",
+				nodeID, rpcErr.Message)
+		}
+	}
+	for _, session := range response.Sessions {
+		if session.BackendPID != pid {
+			continue
+		}
+		if !(user == security.RootUser || user == session.Username) {
+			return false, errMustBeSessionOwner
+		}
+		// The queries that finished since the sessions were listed can't be
+		// canceled anymore, which isn't an error.
+		for _, query := range session.ActiveQueries {
+			request := &serverpb.CancelQueryRequest{
+				NodeId:   fmt.Sprintf("%d", session.NodeID),
+				QueryID:  query.ID,
+				Username: user,
+			}
+			if _, err := statusServer.CancelQuery(ctx, request); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// TerminateBackend implements the tree.EvalPlanner interface. Only the
+// sessions of the node the statement runs on can be terminated.
+func (p *planner) TerminateBackend(ctx context.Context, pid int64) (bool, error) {
+	if pid <= 0 {
+		return false, nil
+	}
+	execCfg := p.ExecCfg()
+	if nodeID := backendPIDNodeID(pid); nodeID != execCfg.NodeID.Get() {
+		return false, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+			"session %d is on node %d: sessions can only be terminated from their own node",
+			pid, nodeID)
+	}
+	return execCfg.SessionRegistry.TerminateSession(pid, p.SessionData().User)
+}
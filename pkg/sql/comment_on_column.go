@@ -0,0 +1,95 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+type commentOnColumnNode struct {
+	n         *tree.CommentOnColumn
+	tableDesc *sqlbase.TableDescriptor
+	column    sqlbase.ColumnDescriptor
+}
+
+// CommentOnColumn adds, replaces or removes the comment on a column.
+// Privileges: CREATE on table.
+//   notes: postgres requires ownership of the table.
+func (p *planner) CommentOnColumn(ctx context.Context, n *tree.CommentOnColumn) (planNode, error) {
+	if n.ColumnItem.TableName.TableName == "" {
+		return nil, pgerror.NewErrorf(pgerror.CodeSyntaxError,
+			"column name must be qualified: %s", tree.ErrString(n.ColumnItem))
+	}
+	tn := n.ColumnItem.TableName
+	if err := tn.QualifyWithDatabase(p.SessionData().Database); err != nil {
+		return nil, err
+	}
+
+	tableDesc, err := getTableOrViewDesc(ctx, p.txn, p.getVirtualTabler(), &tn)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		return nil, sqlbase.NewUndefinedRelationError(&tn)
+	}
+	if tableDesc.IsVirtualTable() {
+		return nil, pgerror.NewErrorf(pgerror.CodeWrongObjectTypeError,
+			"cannot comment on columns of virtual table %q", tn.String())
+	}
+
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	column, err := tableDesc.FindActiveColumnByName(string(n.ColumnItem.ColumnName))
+	if err != nil {
+		return nil, err
+	}
+
+	return &commentOnColumnNode{n: n, tableDesc: tableDesc, column: column}, nil
+}
+
+func (n *commentOnColumnNode) startExec(params runParams) error {
+	if err := params.p.setComment(
+		params.ctx, columnCommentType, n.tableDesc.ID, int64(n.column.ID), n.n.Comment,
+	); err != nil {
+		return err
+	}
+
+	// Record this comment change in the event log. This is an auditable log
+	// event and is recorded in the same transaction as the comment update.
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		params.ctx,
+		params.p.txn,
+		EventLogCommentOnColumn,
+		int32(n.tableDesc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			TableName  string
+			ColumnName string
+			Statement  string
+			User       string
+		}{n.tableDesc.Name, n.column.Name, n.n.String(), params.SessionData().User},
+	)
+}
+
+func (n *commentOnColumnNode) Next(runParams) (bool, error) { return false, nil }
+func (n *commentOnColumnNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *commentOnColumnNode) Close(context.Context)        {}
@@ -0,0 +1,83 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+type commentOnDatabaseNode struct {
+	n      *tree.CommentOnDatabase
+	dbDesc *sqlbase.DatabaseDescriptor
+}
+
+// CommentOnDatabase adds, replaces or removes the comment on a database.
+// Privileges: CREATE on database.
+//   notes: postgres requires ownership of the database.
+func (p *planner) CommentOnDatabase(
+	ctx context.Context, n *tree.CommentOnDatabase,
+) (planNode, error) {
+	if n.Name == "" {
+		return nil, errEmptyDatabaseName
+	}
+	dbDesc, err := MustGetDatabaseDesc(ctx, p.txn, p.getVirtualTabler(), string(n.Name))
+	if err != nil {
+		return nil, err
+	}
+	if dbDesc.ID == keys.VirtualDescriptorID {
+		return nil, pgerror.NewErrorf(pgerror.CodeWrongObjectTypeError,
+			"cannot comment on virtual schema %q", dbDesc.Name)
+	}
+
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	return &commentOnDatabaseNode{n: n, dbDesc: dbDesc}, nil
+}
+
+func (n *commentOnDatabaseNode) startExec(params runParams) error {
+	if err := params.p.setComment(
+		params.ctx, databaseCommentType, n.dbDesc.ID, 0 /* subID */, n.n.Comment,
+	); err != nil {
+		return err
+	}
+
+	// Record this comment change in the event log. This is an auditable log
+	// event and is recorded in the same transaction as the comment update.
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		params.ctx,
+		params.p.txn,
+		EventLogCommentOnDatabase,
+		int32(n.dbDesc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			DatabaseName string
+			Statement    string
+			User         string
+		}{n.dbDesc.Name, n.n.String(), params.SessionData().User},
+	)
+}
+
+func (n *commentOnDatabaseNode) Next(runParams) (bool, error) { return false, nil }
+func (n *commentOnDatabaseNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *commentOnDatabaseNode) Close(context.Context)        {}
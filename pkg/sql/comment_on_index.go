@@ -0,0 +1,84 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+type commentOnIndexNode struct {
+	n         *tree.CommentOnIndex
+	tableDesc *sqlbase.TableDescriptor
+	index     sqlbase.IndexDescriptor
+}
+
+// CommentOnIndex adds, replaces or removes the comment on an index.
+// Privileges: CREATE on table.
+//   notes: postgres requires ownership of the index.
+func (p *planner) CommentOnIndex(ctx context.Context, n *tree.CommentOnIndex) (planNode, error) {
+	tn, err := p.expandIndexName(ctx, n.Index, true /* requireTable */)
+	if err != nil {
+		return nil, err
+	}
+
+	tableDesc, err := MustGetTableDesc(ctx, p.txn, p.getVirtualTabler(), tn, true /*allowAdding*/)
+	if err != nil {
+		return nil, err
+	}
+
+	index, _, err := tableDesc.FindIndexByName(string(n.Index.Index))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	return &commentOnIndexNode{n: n, tableDesc: tableDesc, index: index}, nil
+}
+
+func (n *commentOnIndexNode) startExec(params runParams) error {
+	if err := params.p.setComment(
+		params.ctx, indexCommentType, n.tableDesc.ID, int64(n.index.ID), n.n.Comment,
+	); err != nil {
+		return err
+	}
+
+	// Record this comment change in the event log. This is an auditable log
+	// event and is recorded in the same transaction as the comment update.
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		params.ctx,
+		params.p.txn,
+		EventLogCommentOnIndex,
+		int32(n.tableDesc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			TableName string
+			IndexName string
+			Statement string
+			User      string
+		}{n.tableDesc.Name, n.index.Name, n.n.String(), params.SessionData().User},
+	)
+}
+
+func (n *commentOnIndexNode) Next(runParams) (bool, error) { return false, nil }
+func (n *commentOnIndexNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *commentOnIndexNode) Close(context.Context)        {}
@@ -0,0 +1,86 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+type commentOnTableNode struct {
+	n         *tree.CommentOnTable
+	tableDesc *sqlbase.TableDescriptor
+}
+
+// CommentOnTable adds, replaces or removes the comment on a table, view or
+// sequence.
+// Privileges: CREATE on table.
+//   notes: postgres requires ownership of the table.
+func (p *planner) CommentOnTable(ctx context.Context, n *tree.CommentOnTable) (planNode, error) {
+	tn, err := n.Table.NormalizeWithDatabaseName(p.SessionData().Database)
+	if err != nil {
+		return nil, err
+	}
+
+	tableDesc, err := getTableOrViewDesc(ctx, p.txn, p.getVirtualTabler(), tn)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		return nil, sqlbase.NewUndefinedRelationError(tn)
+	}
+	if tableDesc.IsVirtualTable() {
+		return nil, pgerror.NewErrorf(pgerror.CodeWrongObjectTypeError,
+			"cannot comment on virtual table %q", tn.String())
+	}
+
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	return &commentOnTableNode{n: n, tableDesc: tableDesc}, nil
+}
+
+func (n *commentOnTableNode) startExec(params runParams) error {
+	if err := params.p.setComment(
+		params.ctx, tableCommentType, n.tableDesc.ID, 0 /* subID */, n.n.Comment,
+	); err != nil {
+		return err
+	}
+
+	// Record this comment change in the event log. This is an auditable log
+	// event and is recorded in the same transaction as the comment update.
+	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
+		params.ctx,
+		params.p.txn,
+		EventLogCommentOnTable,
+		int32(n.tableDesc.ID),
+		int32(params.extendedEvalCtx.NodeID),
+		struct {
+			TableName string
+			Statement string
+			User      string
+		}{n.tableDesc.Name, n.n.String(), params.SessionData().User},
+	)
+}
+
+func (n *commentOnTableNode) Next(runParams) (bool, error) { return false, nil }
+func (n *commentOnTableNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *commentOnTableNode) Close(context.Context)        {}
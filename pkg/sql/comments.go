@@ -0,0 +1,147 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+// commentType identifies the kind of object described by a row of
+// system.comments.
+type commentType int
+
+// The kinds of objects that can be commented on. For all of them,
+// object_id holds the ID of the database or table. For comments on
+// columns and indexes, sub_id holds the ID of the column or index in the
+// table; it is 0 otherwise.
+const (
+	databaseCommentType commentType = 0
+	tableCommentType    commentType = 1
+	columnCommentType   commentType = 2
+	indexCommentType    commentType = 3
+)
+
+// commentKey identifies a commented object in the map returned by
+// getComments.
+type commentKey struct {
+	typ      commentType
+	objectID sqlbase.ID
+	subID    int64
+}
+
+// getComments returns all the comments visible to the transaction of the
+// given planner.
+func getComments(ctx context.Context, origPlanner *planner) (map[commentKey]string, error) {
+	query := `SELECT type, object_id, sub_id, comment FROM system.comments`
+	p, cleanup := newInternalPlanner(
+		"get-comments", origPlanner.txn, security.RootUser,
+		origPlanner.extendedEvalCtx.MemMetrics, origPlanner.ExecCfg(),
+	)
+	defer cleanup()
+	rows, _ /* cols */, err := p.queryRows(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make(map[commentKey]string, len(rows))
+	for _, row := range rows {
+		key := commentKey{
+			typ:      commentType(tree.MustBeDInt(row[0])),
+			objectID: sqlbase.ID(tree.MustBeDInt(row[1])),
+			subID:    int64(tree.MustBeDInt(row[2])),
+		}
+		comments[key] = string(tree.MustBeDString(row[3]))
+	}
+	return comments, nil
+}
+
+// setComment adds or replaces the comment on the given object, or removes
+// it if comment is nil.
+func (p *planner) setComment(
+	ctx context.Context, typ commentType, objectID sqlbase.ID, subID int64, comment *string,
+) error {
+	if comment == nil {
+		return p.removeComment(ctx, typ, objectID, subID)
+	}
+	internalExecutor := InternalExecutor{ExecCfg: p.ExecCfg()}
+	_, err := internalExecutor.ExecuteStatementInTransaction(
+		ctx,
+		"set-comment",
+		p.txn,
+		"UPSERT INTO system.comments VALUES ($1, $2, $3, $4)",
+		typ,
+		objectID,
+		subID,
+		*comment,
+	)
+	return err
+}
+
+// removeComment deletes the comment on the given object, if any.
+func (p *planner) removeComment(
+	ctx context.Context, typ commentType, objectID sqlbase.ID, subID int64,
+) error {
+	internalExecutor := InternalExecutor{ExecCfg: p.ExecCfg()}
+	_, err := internalExecutor.ExecuteStatementInTransaction(
+		ctx,
+		"delete-comment",
+		p.txn,
+		"DELETE FROM system.comments WHERE type=$1 AND object_id=$2 AND sub_id=$3",
+		typ,
+		objectID,
+		subID,
+	)
+	return err
+}
+
+// removeTableComments deletes the comments on the given table and on all
+// its columns and indexes.
+func (p *planner) removeTableComments(ctx context.Context, tableID sqlbase.ID) error {
+	internalExecutor := InternalExecutor{ExecCfg: p.ExecCfg()}
+	_, err := internalExecutor.ExecuteStatementInTransaction(
+		ctx,
+		"delete-table-comments",
+		p.txn,
+		"DELETE FROM system.comments WHERE type IN ($1, $2, $3) AND object_id=$4",
+		tableCommentType,
+		columnCommentType,
+		indexCommentType,
+		tableID,
+	)
+	return err
+}
+
+// reassignTableComments moves the comments on the table with ID oldID and
+// on its columns and indexes to the table with ID newID. This is used by
+// TRUNCATE, which replaces a table by a copy with a new ID.
+func (p *planner) reassignTableComments(ctx context.Context, oldID, newID sqlbase.ID) error {
+	internalExecutor := InternalExecutor{ExecCfg: p.ExecCfg()}
+	_, err := internalExecutor.ExecuteStatementInTransaction(
+		ctx,
+		"reassign-table-comments",
+		p.txn,
+		"UPDATE system.comments SET object_id=$1 WHERE type IN ($2, $3, $4) AND object_id=$5",
+		newID,
+		tableCommentType,
+		columnCommentType,
+		indexCommentType,
+		oldID,
+	)
+	return err
+}
@@ -20,8 +20,11 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"runtime"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -30,12 +33,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/build"
 	"github.com/cockroachdb/cockroach/pkg/config"
 	"github.com/cockroachdb/cockroach/pkg/gossip"
-	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/jobs"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -45,6 +49,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 )
 
@@ -56,32 +62,47 @@ var crdbInternal = virtualSchema{
 		crdbInternalBackwardDependenciesTable,
 		crdbInternalBuildInfoTable,
 		crdbInternalBuiltinFunctionsTable,
+		crdbInternalClusterMetricsTable,
 		crdbInternalClusterQueriesTable,
 		crdbInternalClusterSessionsTable,
 		crdbInternalClusterSettingsTable,
+		crdbInternalClusterStmtStatsTable,
 		crdbInternalCreateStmtsTable,
+		crdbInternalDDLHistoryTable,
+		crdbInternalDefaultPrivilegesTable,
+		crdbInternalDeprecatedColumnsTable,
+		crdbInternalFeatureUsageTable,
 		crdbInternalForwardDependenciesTable,
 		crdbInternalGossipNodesTable,
 		crdbInternalGossipLivenessTable,
 		crdbInternalIndexColumnsTable,
+		crdbInternalInvalidDescriptorsTable,
 		crdbInternalJobsTable,
 		crdbInternalKVNodeStatusTable,
 		crdbInternalKVStoreStatusTable,
 		crdbInternalLeasesTable,
 		crdbInternalLocalQueriesTable,
 		crdbInternalLocalSessionsTable,
+		crdbInternalNodeMetricsTable,
 		crdbInternalPartitionsTable,
 		crdbInternalRangesTable,
+		crdbInternalRolesTable,
 		crdbInternalRuntimeInfoTable,
 		crdbInternalSchemaChangesTable,
 		crdbInternalSessionTraceTable,
 		crdbInternalSessionVariablesTable,
 		crdbInternalStmtStatsTable,
 		crdbInternalTableColumnsTable,
+		crdbInternalTableGCTTLsTable,
 		crdbInternalTableIndexesTable,
 		crdbInternalTablesTable,
+		crdbInternalVirtualTablesTable,
 		crdbInternalZonesTable,
 	},
+	functions: []virtualSchemaFunction{
+		crdbInternalFindObjectsFunction,
+		crdbInternalTableColumnsFunction,
+	},
 }
 
 var crdbInternalBuildInfoTable = virtualSchemaTable{
@@ -92,6 +113,7 @@ CREATE TABLE crdb_internal.node_build_info (
   value   STRING NOT NULL
 );
 `,
+	currentOnly: true,
 	populate: func(_ context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
 		execCfg := p.ExecCfg()
 		nodeID := tree.NewDInt(tree.DInt(int64(execCfg.NodeID.Get())))
@@ -125,6 +147,7 @@ CREATE TABLE crdb_internal.node_runtime_info (
   value     STRING NOT NULL
 );
 `,
+	currentOnly: true,
 	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
 		if err := p.RequireSuperUser(ctx, "access the node runtime information"); err != nil {
 			return err
@@ -171,10 +194,72 @@ CREATE TABLE crdb_internal.node_runtime_info (
 				}
 			}
 		}
+
+		var mem runtime.MemStats
+		runtime.ReadMemStats(&mem)
+		var startedAt time.Time
+		if recorder := p.ExecCfg().MetricsRecorder; recorder != nil {
+			startedAt = timeutil.Unix(0, recorder.StartedAt())
+		}
+		for _, kv := range [][2]string{
+			{"GoVersion", runtime.Version()},
+			{"Revision", build.GetInfo().Revision},
+			{"NumCPU", strconv.Itoa(runtime.NumCPU())},
+			{"GOMAXPROCS", strconv.Itoa(runtime.GOMAXPROCS(0))},
+			{"Goroutines", strconv.Itoa(runtime.NumGoroutine())},
+			{"MemoryAllocated", strconv.FormatUint(mem.Alloc, 10)},
+			{"MemoryTotal", strconv.FormatUint(mem.Sys, 10)},
+			{"StartedAt", startedAt.UTC().Format(time.RFC3339)},
+			{"Uptime", timeutil.Since(startedAt).Round(time.Second).String()},
+		} {
+			k, v := kv[0], kv[1]
+			if err := addRow(
+				nodeID,
+				tree.NewDString("Runtime"),
+				tree.NewDString(k),
+				tree.NewDString(v),
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
 }
 
+// crdbInternalNodeMetricsTable exposes the current values of the gauges
+// and counters registered on the node and on its stores. store_id is NULL
+// for the metrics of the node. Histograms and rates, which don't hold a
+// single value, are omitted.
+var crdbInternalNodeMetricsTable = virtualSchemaTable{
+	schema:      fmt.Sprintf(metricsSchemaPattern, "node_metrics"),
+	currentOnly: true,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		if err := p.RequireSuperUser(ctx, "read crdb_internal.node_metrics"); err != nil {
+			return err
+		}
+		return populateNodeMetrics(p.ExecCfg(), addRow)
+	},
+}
+
+// crdbInternalClusterMetricsTable exposes the metrics of node_metrics of all
+// the nodes of the cluster. The nodes that could not be reached are left
+// out.
+var crdbInternalClusterMetricsTable = virtualSchemaTable{
+	schema:      fmt.Sprintf(metricsSchemaPattern, "cluster_metrics"),
+	currentOnly: true,
+	gather:      "crdb_internal.node_metrics",
+}
+
+const metricsSchemaPattern = `
+CREATE TABLE crdb_internal.%s (
+  node_id  INT NOT NULL,
+  store_id INT,
+  name     STRING NOT NULL,
+  type     STRING NOT NULL,
+  value    FLOAT NOT NULL
+);
+`
+
 var crdbInternalTablesTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE crdb_internal.tables (
@@ -255,6 +340,137 @@ CREATE TABLE crdb_internal.tables (
 	},
 }
 
+// ddlHistoryEventTypes are the event log types recorded for schema
+// changes. Their target is the ID of the database or table changed.
+var ddlHistoryEventTypes = []EventLogType{
+	EventLogCreateDatabase,
+	EventLogDropDatabase,
+	EventLogCreateTable,
+	EventLogDropTable,
+	EventLogAlterTable,
+	EventLogCreateIndex,
+	EventLogDropIndex,
+	EventLogAlterIndex,
+	EventLogCreateView,
+	EventLogDropView,
+	EventLogCreateSequence,
+	EventLogDropSequence,
+	EventLogAlterSequence,
+	EventLogCommentOnDatabase,
+	EventLogCommentOnTable,
+	EventLogCommentOnColumn,
+	EventLogCommentOnIndex,
+	EventLogReverseSchemaChange,
+	EventLogFinishSchemaChange,
+	EventLogFinishSchemaRollback,
+}
+
+// crdbInternalDDLHistoryTable exposes the schema change events of the event
+// log, joined to the descriptors of the objects they changed. Objects whose
+// descriptor was deleted since are named after the event that dropped them.
+var crdbInternalDDLHistoryTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE crdb_internal.ddl_history (
+  timestamp         TIMESTAMP NOT NULL,
+  event_type        STRING NOT NULL,
+  object_id         INT NOT NULL,
+  object_type       STRING,
+  database_name     STRING,
+  object_name       STRING,
+  dropped           BOOL NOT NULL,
+  username          STRING,
+  statement         STRING,
+  reporting_node_id INT NOT NULL
+);
+`,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		if err := p.RequireSuperUser(ctx, "read crdb_internal.ddl_history"); err != nil {
+			return err
+		}
+		descs, err := p.Tables().getAllDescriptors(ctx, p.txn)
+		if err != nil {
+			return err
+		}
+		dbNames := make(map[sqlbase.ID]string)
+		tables := make(map[sqlbase.ID]*sqlbase.TableDescriptor)
+		for _, desc := range descs {
+			switch d := desc.(type) {
+			case *sqlbase.DatabaseDescriptor:
+				dbNames[d.ID] = d.Name
+			case *sqlbase.TableDescriptor:
+				tables[d.ID] = d
+			}
+		}
+
+		eventTypes := make([]string, len(ddlHistoryEventTypes))
+		for i, t := range ddlHistoryEventTypes {
+			eventTypes[i] = fmt.Sprintf("'%s'", t)
+		}
+		ip, cleanup := newInternalPlanner(
+			"ddl-history", p.txn, p.SessionData().User, p.extendedEvalCtx.MemMetrics, p.ExecCfg())
+		defer cleanup()
+		rows, _ /* cols */, err := ip.queryRows(ctx, fmt.Sprintf(`
+SELECT timestamp, "eventType", "targetID", "reportingID",
+       info::JSONB->>'User', info::JSONB->>'Statement',
+       COALESCE(info::JSONB->>'TableName', info::JSONB->>'ViewName',
+                info::JSONB->>'SequenceName', info::JSONB->>'DatabaseName')
+  FROM system.eventlog
+ WHERE "eventType" IN (%s)
+ ORDER BY timestamp, "uniqueID"`, strings.Join(eventTypes, ", ")))
+		if err != nil {
+			return err
+		}
+
+		for _, r := range rows {
+			ts, eventType, targetID, reportingID := r[0], r[1], r[2], r[3]
+			user, stmt, eventObjectName := r[4], r[5], r[6]
+
+			objectType := tree.DNull
+			dbName := tree.DNull
+			objectName := eventObjectName
+			dropped := tree.DBoolTrue
+			id := sqlbase.ID(tree.MustBeDInt(targetID))
+			if table, ok := tables[id]; ok {
+				switch {
+				case table.IsView():
+					objectType = tree.NewDString("view")
+				case table.IsSequence():
+					objectType = tree.NewDString("sequence")
+				default:
+					objectType = tree.NewDString("table")
+				}
+				if name, ok := dbNames[table.ParentID]; ok {
+					dbName = tree.NewDString(name)
+				}
+				objectName = tree.NewDString(table.Name)
+				dropped = tree.MakeDBool(tree.DBool(table.Dropped()))
+			} else if name, ok := dbNames[id]; ok {
+				objectType = tree.NewDString("database")
+				objectName = tree.NewDString(name)
+				dropped = tree.DBoolFalse
+			}
+			if err := addRow(
+				ts,
+				eventType,
+				targetID,
+				objectType,
+				dbName,
+				objectName,
+				dropped,
+				user,
+				stmt,
+				reportingID,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// crdbInternalSchemaChangesTable exposes the pending mutations of the
+// tables, with the schema change job executing them, if any. The mutations
+// of a schema change share its mutation ID.
 var crdbInternalSchemaChangesTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE crdb_internal.schema_changes (
@@ -265,7 +481,9 @@ CREATE TABLE crdb_internal.schema_changes (
   target_id     INT,
   target_name   STRING,
   state         STRING NOT NULL,
-  direction     STRING NOT NULL
+  direction     STRING NOT NULL,
+  mutation_id   INT NOT NULL,
+  job_id        INT
 );
 `,
 	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
@@ -283,7 +501,15 @@ CREATE TABLE crdb_internal.schema_changes (
 			tableID := tree.NewDInt(tree.DInt(int64(table.ID)))
 			parentID := tree.NewDInt(tree.DInt(int64(table.GetParentID())))
 			tableName := tree.NewDString(table.Name)
+			jobIDs := make(map[sqlbase.MutationID]int64, len(table.MutationJobs))
+			for _, j := range table.MutationJobs {
+				jobIDs[j.MutationID] = j.JobID
+			}
 			for _, mut := range table.Mutations {
+				jobID := tree.DNull
+				if id, ok := jobIDs[mut.MutationID]; ok {
+					jobID = tree.NewDInt(tree.DInt(id))
+				}
 				mutType := "UNKNOWN"
 				targetID := tree.DNull
 				targetName := tree.DNull
@@ -306,6 +532,70 @@ CREATE TABLE crdb_internal.schema_changes (
 					targetName,
 					tree.NewDString(mut.State.String()),
 					tree.NewDString(mut.Direction.String()),
+					tree.NewDInt(tree.DInt(mut.MutationID)),
+					jobID,
+				); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
+
+// crdbInternalInvalidDescriptorsTable validates the database and table
+// descriptors, and exposes the errors found, so that a corruption of the
+// catalog can be detected before it breaks statements. Besides the checks of
+// Validate, among which that the parent database and the tables referenced
+// by foreign keys and interleaves exist, the mutation jobs of the tables must
+// refer to pending mutations and to jobs in system.jobs.
+var crdbInternalInvalidDescriptorsTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE crdb_internal.invalid_descriptors (
+  descriptor_id   INT NOT NULL,
+  descriptor_name STRING NOT NULL,
+  error           STRING NOT NULL
+)
+`,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		if err := p.RequireSuperUser(ctx, "read crdb_internal.invalid_descriptors"); err != nil {
+			return err
+		}
+		descs, err := p.Tables().getAllDescriptors(ctx, p.txn)
+		if err != nil {
+			return err
+		}
+
+		ip, cleanup := newInternalPlanner(
+			"invalid-descriptors", p.txn, p.SessionData().User, p.extendedEvalCtx.MemMetrics, p.ExecCfg())
+		defer cleanup()
+		rows, _ /* cols */, err := ip.queryRows(ctx, `SELECT id FROM system.jobs`)
+		if err != nil {
+			return err
+		}
+		jobIDs := make(map[int64]struct{}, len(rows))
+		for _, r := range rows {
+			jobIDs[int64(tree.MustBeDInt(r[0]))] = struct{}{}
+		}
+
+		for _, desc := range descs {
+			var errs []string
+			switch desc := desc.(type) {
+			case *sqlbase.DatabaseDescriptor:
+				if err := desc.Validate(); err != nil {
+					errs = append(errs, err.Error())
+				}
+			case *sqlbase.TableDescriptor:
+				if err := desc.Validate(ctx, p.txn); err != nil {
+					errs = append(errs, err.Error())
+				}
+				errs = append(errs, validateMutationJobs(desc, jobIDs)...)
+			}
+			for _, e := range errs {
+				if err := addRow(
+					tree.NewDInt(tree.DInt(desc.GetID())),
+					tree.NewDString(desc.GetName()),
+					tree.NewDString(e),
 				); err != nil {
 					return err
 				}
@@ -315,6 +605,30 @@ CREATE TABLE crdb_internal.schema_changes (
 	},
 }
 
+// validateMutationJobs returns the errors of the mutation jobs of the given
+// table, given the IDs of the jobs in system.jobs.
+func validateMutationJobs(table *sqlbase.TableDescriptor, jobIDs map[int64]struct{}) []string {
+	mutationIDs := make(map[sqlbase.MutationID]struct{}, len(table.Mutations))
+	for _, m := range table.Mutations {
+		mutationIDs[m.MutationID] = struct{}{}
+	}
+	var errs []string
+	for _, j := range table.MutationJobs {
+		if _, ok := mutationIDs[j.MutationID]; !ok {
+			errs = append(errs, fmt.Sprintf(
+				"mutation job %d refers to mutation %d, which does not exist", j.JobID, j.MutationID))
+		}
+		if _, ok := jobIDs[j.JobID]; !ok {
+			errs = append(errs, fmt.Sprintf(
+				"mutation job %d of mutation %d does not exist in system.jobs", j.JobID, j.MutationID))
+		}
+	}
+	return errs
+}
+
+// crdbInternalLeasesTable exposes the table descriptor leases held by the
+// node, with the version of each and the number of transactions using it,
+// to debug schema changes waiting on leases of old versions to be released.
 var crdbInternalLeasesTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE crdb_internal.leases (
@@ -322,10 +636,13 @@ CREATE TABLE crdb_internal.leases (
   table_id    INT NOT NULL,
   name        STRING NOT NULL,
   parent_id   INT NOT NULL,
+  version     INT NOT NULL,
   expiration  TIMESTAMP NOT NULL,
+  refcount    INT NOT NULL,
   deleted     BOOL NOT NULL
 );
 `,
+	currentOnly: true,
 	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
 		leaseMgr := p.LeaseMgr()
 		nodeID := tree.NewDInt(tree.DInt(int64(leaseMgr.execCfg.NodeID.Get())))
@@ -347,7 +664,10 @@ CREATE TABLE crdb_internal.leases (
 						continue
 					}
 
-					if !state.leased {
+					state.mu.Lock()
+					leased, refcount := state.leased, state.refcount
+					state.mu.Unlock()
+					if !leased {
 						continue
 					}
 					expCopy := state.leaseExpiration()
@@ -356,7 +676,9 @@ CREATE TABLE crdb_internal.leases (
 						tableID,
 						tree.NewDString(state.Name),
 						tree.NewDInt(tree.DInt(int64(state.GetParentID()))),
+						tree.NewDInt(tree.DInt(int64(state.Version))),
 						&expCopy,
+						tree.NewDInt(tree.DInt(int64(refcount))),
 						dropped,
 					); err != nil {
 						return err
@@ -373,6 +695,9 @@ CREATE TABLE crdb_internal.leases (
 	},
 }
 
+// crdbInternalJobsTable exposes the schema change, backup, restore and
+// import jobs recorded in system.jobs, with their progress and error, so
+// that long-running jobs can be monitored with SQL.
 var crdbInternalJobsTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE crdb_internal.jobs (
@@ -482,9 +807,13 @@ CREATE TABLE crdb_internal.node_statement_statistics (
   service_lat_avg     FLOAT NOT NULL,
   service_lat_var     FLOAT NOT NULL,
   overhead_lat_avg    FLOAT NOT NULL,
-  overhead_lat_var    FLOAT NOT NULL
+  overhead_lat_var    FLOAT NOT NULL,
+  service_lat_p50     FLOAT NOT NULL,
+  service_lat_p90     FLOAT NOT NULL,
+  service_lat_p99     FLOAT NOT NULL
 );
 `,
+	currentOnly: true,
 	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
 		if err := p.RequireSuperUser(ctx, "access application statistics"); err != nil {
 			return err
@@ -558,6 +887,9 @@ CREATE TABLE crdb_internal.node_statement_statistics (
 					tree.NewDFloat(tree.DFloat(s.data.ServiceLat.GetVariance(s.data.Count))),
 					tree.NewDFloat(tree.DFloat(s.data.OverheadLat.Mean)),
 					tree.NewDFloat(tree.DFloat(s.data.OverheadLat.GetVariance(s.data.Count))),
+					tree.NewDFloat(tree.DFloat(serviceLatPercentile(s.serviceLatBuckets[:], 50))),
+					tree.NewDFloat(tree.DFloat(serviceLatPercentile(s.serviceLatBuckets[:], 90))),
+					tree.NewDFloat(tree.DFloat(serviceLatPercentile(s.serviceLatBuckets[:], 99))),
 				)
 				s.Unlock()
 				if err != nil {
@@ -569,8 +901,147 @@ CREATE TABLE crdb_internal.node_statement_statistics (
 	},
 }
 
+// crdbInternalClusterStmtStatsTable aggregates the statistics of the
+// statements executed on all the live nodes of the cluster, by application
+// and statement. The latency percentiles are estimated from the latency
+// buckets of all the nodes.
+var crdbInternalClusterStmtStatsTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE crdb_internal.cluster_statement_statistics (
+  application_name    STRING NOT NULL,
+  flags               STRING NOT NULL,
+  key                 STRING NOT NULL,
+  anonymized          STRING,
+  nodes               INT NOT NULL,
+  count               INT NOT NULL,
+  first_attempt_count INT NOT NULL,
+  max_retries         INT NOT NULL,
+  last_error          STRING,
+  rows_avg            FLOAT NOT NULL,
+  rows_var            FLOAT NOT NULL,
+  parse_lat_avg       FLOAT NOT NULL,
+  parse_lat_var       FLOAT NOT NULL,
+  plan_lat_avg        FLOAT NOT NULL,
+  plan_lat_var        FLOAT NOT NULL,
+  run_lat_avg         FLOAT NOT NULL,
+  run_lat_var         FLOAT NOT NULL,
+  service_lat_avg     FLOAT NOT NULL,
+  service_lat_var     FLOAT NOT NULL,
+  overhead_lat_avg    FLOAT NOT NULL,
+  overhead_lat_var    FLOAT NOT NULL,
+  service_lat_p50     FLOAT NOT NULL,
+  service_lat_p90     FLOAT NOT NULL,
+  service_lat_p99     FLOAT NOT NULL
+);
+`,
+	currentOnly: true,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		if err := p.RequireSuperUser(ctx, "access application statistics"); err != nil {
+			return err
+		}
+
+		response, err := p.extendedEvalCtx.StatusServer.Statements(ctx, &serverpb.StatementsRequest{})
+		if err != nil {
+			return err
+		}
+		// Like cluster_queries, the statistics of the nodes that could not be
+		// reached are left out.
+		for _, rpcErr := range response.Errors {
+			log.Warning(ctx, rpcErr.Message)
+		}
+
+		type appStmtKey struct {
+			app string
+			stmtKey
+		}
+		type aggregate struct {
+			nodes   int
+			stats   roachpb.StatementStatistics
+			buckets []int64
+		}
+		aggregates := make(map[appStmtKey]*aggregate)
+		var keys []appStmtKey
+		for _, stmt := range response.Statements {
+			key := appStmtKey{
+				app: stmt.Key.App,
+				stmtKey: stmtKey{
+					stmt:        stmt.Key.Query,
+					failed:      stmt.Key.Failed,
+					distSQLUsed: stmt.Key.DistSQL,
+				},
+			}
+			agg, ok := aggregates[key]
+			if !ok {
+				agg = &aggregate{}
+				aggregates[key] = agg
+				keys = append(keys, key)
+			}
+			agg.nodes++
+			agg.stats.Add(&stmt.Stats)
+			if len(agg.buckets) < len(stmt.ServiceLatBuckets) {
+				agg.buckets = append(agg.buckets,
+					make([]int64, len(stmt.ServiceLatBuckets)-len(agg.buckets))...)
+			}
+			for i, c := range stmt.ServiceLatBuckets {
+				agg.buckets[i] += c
+			}
+		}
+
+		// Sort the statements to ensure the output is deterministic.
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].app != keys[j].app {
+				return keys[i].app < keys[j].app
+			}
+			return keys[i].String() < keys[j].String()
+		})
+
+		for _, key := range keys {
+			agg := aggregates[key]
+			s := &agg.stats
+			anonymized := tree.DNull
+			if anonStr, ok := scrubStmtStatKey(p.getVirtualTabler(), key.stmt); ok {
+				anonymized = tree.NewDString(anonStr)
+			}
+			errString := tree.DNull
+			if s.LastErr != "" {
+				errString = tree.NewDString(s.LastErr)
+			}
+			if err := addRow(
+				tree.NewDString(key.app),
+				tree.NewDString(key.flags()),
+				tree.NewDString(key.stmt),
+				anonymized,
+				tree.NewDInt(tree.DInt(agg.nodes)),
+				tree.NewDInt(tree.DInt(s.Count)),
+				tree.NewDInt(tree.DInt(s.FirstAttemptCount)),
+				tree.NewDInt(tree.DInt(s.MaxRetries)),
+				errString,
+				tree.NewDFloat(tree.DFloat(s.NumRows.Mean)),
+				tree.NewDFloat(tree.DFloat(s.NumRows.GetVariance(s.Count))),
+				tree.NewDFloat(tree.DFloat(s.ParseLat.Mean)),
+				tree.NewDFloat(tree.DFloat(s.ParseLat.GetVariance(s.Count))),
+				tree.NewDFloat(tree.DFloat(s.PlanLat.Mean)),
+				tree.NewDFloat(tree.DFloat(s.PlanLat.GetVariance(s.Count))),
+				tree.NewDFloat(tree.DFloat(s.RunLat.Mean)),
+				tree.NewDFloat(tree.DFloat(s.RunLat.GetVariance(s.Count))),
+				tree.NewDFloat(tree.DFloat(s.ServiceLat.Mean)),
+				tree.NewDFloat(tree.DFloat(s.ServiceLat.GetVariance(s.Count))),
+				tree.NewDFloat(tree.DFloat(s.OverheadLat.Mean)),
+				tree.NewDFloat(tree.DFloat(s.OverheadLat.GetVariance(s.Count))),
+				tree.NewDFloat(tree.DFloat(serviceLatPercentile(agg.buckets, 50))),
+				tree.NewDFloat(tree.DFloat(serviceLatPercentile(agg.buckets, 90))),
+				tree.NewDFloat(tree.DFloat(serviceLatPercentile(agg.buckets, 99))),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
 // crdbInternalSessionTraceTable exposes the latest trace collected on this
-// session (via SET TRACING={ON/OFF})
+// session (via SET TRACING = on/off, with kv to also trace the KV requests
+// of the statements). SHOW TRACE FOR SESSION is a view over it.
 var crdbInternalSessionTraceTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE crdb_internal.session_trace (
@@ -617,6 +1088,7 @@ CREATE TABLE crdb_internal.cluster_settings (
   description   STRING NOT NULL
 );
 `,
+	currentOnly: true,
 	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
 		if err := p.RequireSuperUser(ctx, "read crdb_internal.cluster_settings"); err != nil {
 			return err
@@ -636,6 +1108,36 @@ CREATE TABLE crdb_internal.cluster_settings (
 	},
 }
 
+// crdbInternalFeatureUsageTable exposes the counts of the uses of features
+// by the clients of the node since it started, to audit which statements,
+// errors and experimental features the applications run into.
+var crdbInternalFeatureUsageTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE crdb_internal.feature_usage (
+  feature_name STRING NOT NULL,
+  usage_count  INT NOT NULL
+);
+`,
+	currentOnly: true,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		counts := telemetry.GetFeatureCounts()
+		features := make([]string, 0, len(counts))
+		for feature := range counts {
+			features = append(features, feature)
+		}
+		sort.Strings(features)
+		for _, feature := range features {
+			if err := addRow(
+				tree.NewDString(feature),
+				tree.NewDInt(tree.DInt(counts[feature])),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
 // crdbInternalSessionVariablesTable exposes the session variables.
 var crdbInternalSessionVariablesTable = virtualSchemaTable{
 	schema: `
@@ -676,7 +1178,8 @@ CREATE TABLE crdb_internal.%s (
 // crdbInternalLocalQueriesTable exposes the list of running queries
 // on the current node. The results are dependent on the current user.
 var crdbInternalLocalQueriesTable = virtualSchemaTable{
-	schema: fmt.Sprintf(queriesSchemaPattern, "node_queries"),
+	schema:      fmt.Sprintf(queriesSchemaPattern, "node_queries"),
+	currentOnly: true,
 	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
 		req := serverpb.ListSessionsRequest{Username: p.SessionData().User}
 		response, err := p.extendedEvalCtx.StatusServer.ListLocalSessions(ctx, &req)
@@ -689,16 +1192,12 @@ var crdbInternalLocalQueriesTable = virtualSchemaTable{
 
 // crdbInternalClusterQueriesTable exposes the list of running queries
 // on the entire cluster. The result is dependent on the current user.
+// The nodes that could not be reached are listed with a row holding only
+// their node_id.
 var crdbInternalClusterQueriesTable = virtualSchemaTable{
-	schema: fmt.Sprintf(queriesSchemaPattern, "cluster_queries"),
-	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
-		req := serverpb.ListSessionsRequest{Username: p.SessionData().User}
-		response, err := p.extendedEvalCtx.StatusServer.ListSessions(ctx, &req)
-		if err != nil {
-			return err
-		}
-		return populateQueriesTable(ctx, addRow, response)
-	},
+	schema:      fmt.Sprintf(queriesSchemaPattern, "cluster_queries"),
+	currentOnly: true,
+	gather:      "crdb_internal.node_queries",
 }
 
 func populateQueriesTable(
@@ -769,7 +1268,8 @@ CREATE TABLE crdb_internal.%s (
 // crdbInternalLocalSessionsTable exposes the list of running sessions
 // on the current node. The results are dependent on the current user.
 var crdbInternalLocalSessionsTable = virtualSchemaTable{
-	schema: fmt.Sprintf(sessionsSchemaPattern, "node_sessions"),
+	schema:      fmt.Sprintf(sessionsSchemaPattern, "node_sessions"),
+	currentOnly: true,
 	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
 		req := serverpb.ListSessionsRequest{Username: p.SessionData().User}
 		response, err := p.extendedEvalCtx.StatusServer.ListLocalSessions(ctx, &req)
@@ -782,16 +1282,12 @@ var crdbInternalLocalSessionsTable = virtualSchemaTable{
 
 // crdbInternalClusterSessionsTable exposes the list of running sessions
 // on the entire cluster. The result is dependent on the current user.
+// The nodes that could not be reached are listed with a row holding only
+// their node_id.
 var crdbInternalClusterSessionsTable = virtualSchemaTable{
-	schema: fmt.Sprintf(sessionsSchemaPattern, "cluster_sessions"),
-	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
-		req := serverpb.ListSessionsRequest{Username: p.SessionData().User}
-		response, err := p.extendedEvalCtx.StatusServer.ListSessions(ctx, &req)
-		if err != nil {
-			return err
-		}
-		return populateSessionsTable(ctx, addRow, response)
-	},
+	schema:      fmt.Sprintf(sessionsSchemaPattern, "cluster_sessions"),
+	currentOnly: true,
+	gather:      "crdb_internal.node_sessions",
 }
 
 func populateSessionsTable(
@@ -892,42 +1388,174 @@ CREATE TABLE crdb_internal.builtin_functions (
 	},
 }
 
+// crdbInternalDeprecatedColumnsTable exposes the columns of the virtual
+// tables which are deprecated and will be removed in a future release.
+// Clients referencing them receive a notice once per session.
+var crdbInternalDeprecatedColumnsTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE crdb_internal.deprecated_columns (
+  schema_name STRING NOT NULL,
+  table_name  STRING NOT NULL,
+  column_name STRING NOT NULL,
+  hint        STRING NOT NULL
+);
+`,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		entries := p.getVirtualTabler().getEntries()
+		schemaNames := make([]string, 0, len(entries))
+		for schemaName := range entries {
+			schemaNames = append(schemaNames, schemaName)
+		}
+		sort.Strings(schemaNames)
+		for _, schemaName := range schemaNames {
+			schema := entries[schemaName]
+			for _, tableName := range schema.orderedTableNames {
+				table := schema.tables[tableName]
+				if !table.isActive() {
+					continue
+				}
+				for i := range table.desc.Columns {
+					col := &table.desc.Columns[i]
+					hint, ok := table.tableDef.deprecatedColumns[col.Name]
+					if !ok {
+						continue
+					}
+					if err := addRow(
+						tree.NewDString(schemaName),
+						tree.NewDString(tableName),
+						tree.NewDString(col.Name),
+						tree.NewDString(hint),
+					); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	},
+}
+
+// crdbInternalVirtualTablesTable exposes the gates of the virtual tables,
+// including the tables that are hidden because the cluster does not run
+// their minimum version yet or their feature flag is off.
+var crdbInternalVirtualTablesTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE crdb_internal.virtual_tables (
+  schema_name  STRING NOT NULL,
+  table_name   STRING NOT NULL,
+  min_version  STRING,
+  feature_flag STRING,
+  active       BOOL NOT NULL
+);
+`,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		entries := p.getVirtualTabler().getEntries()
+		schemaNames := make([]string, 0, len(entries))
+		for schemaName := range entries {
+			schemaNames = append(schemaNames, schemaName)
+		}
+		sort.Strings(schemaNames)
+		for _, schemaName := range schemaNames {
+			schema := entries[schemaName]
+			for _, tableName := range schema.orderedTableNames {
+				table := schema.tables[tableName]
+				minVersion := tree.DNull
+				if v := table.tableDef.minVersion; v != cluster.VersionBase {
+					minVersion = tree.NewDString(cluster.VersionByKey(v).String())
+				}
+				featureFlag := tree.DNull
+				if table.tableDef.featureFlag != "" {
+					featureFlag = tree.NewDString(table.tableDef.featureFlag)
+				}
+				if err := addRow(
+					tree.NewDString(schemaName),
+					tree.NewDString(tableName),
+					minVersion,
+					featureFlag,
+					tree.MakeDBool(tree.DBool(table.isActive())),
+				); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
+
 // crdbInternalCreateStmtsTable exposes the CREATE TABLE/CREATE VIEW
-// statements.
+// statements. To recreate a schema with plain SQL, as cockroach dump
+// --dump-mode=schema does, the create_nofks statements can be run in any
+// order, followed by the alter_statements adding the foreign keys and the
+// zone_configuration_statements.
 var crdbInternalCreateStmtsTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE crdb_internal.create_statements (
-  database_id      INT,
-  database_name    STRING NOT NULL,
-  descriptor_id    INT,
-  descriptor_type  STRING NOT NULL,
-  descriptor_name  STRING NOT NULL,
-  create_statement STRING NOT NULL,
-  state            STRING NOT NULL
+  database_id                   INT,
+  database_name                 STRING NOT NULL,
+  descriptor_id                 INT,
+  descriptor_type               STRING NOT NULL,
+  descriptor_name               STRING NOT NULL,
+  create_statement              STRING NOT NULL,
+  state                         STRING NOT NULL,
+  create_nofks                  STRING NOT NULL,
+  alter_statements              STRING[] NOT NULL,
+  zone_configuration_statements STRING[] NOT NULL
 )
 `,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		zones, err := getAllZoneConfigsRaw(ctx, p.txn)
+		if err != nil {
+			return err
+		}
 		return forEachTableDescAll(ctx, p, prefix,
 			func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
 				var descType tree.Datum
-				var stmt string
+				var stmt, createNofk string
+				var alterStmts, zoneStmts []string
 				var err error
 				typeView := tree.DString("view")
 				typeTable := tree.DString("table")
 				typeSequence := tree.DString("sequence")
+				tn := (*tree.Name)(&table.Name)
 				if table.IsView() {
 					descType = &typeView
-					stmt, err = p.showCreateView(ctx, (*tree.Name)(&table.Name), table)
+					stmt, err = p.showCreateView(ctx, tn, table)
+					createNofk = stmt
 				} else if table.IsSequence() {
 					descType = &typeSequence
-					stmt, err = p.showCreateSequence(ctx, (*tree.Name)(&table.Name), table)
+					stmt, err = p.showCreateSequence(ctx, tn, table)
+					createNofk = stmt
 				} else {
 					descType = &typeTable
-					stmt, err = p.showCreateTable(ctx, (*tree.Name)(&table.Name), prefix, table)
+					stmt, err = p.showCreateTable(ctx, tn, prefix, table, false /* ignoreFKs */)
+					if err != nil {
+						return err
+					}
+					createNofk, err = p.showCreateTable(ctx, tn, prefix, table, true /* ignoreFKs */)
+					if err != nil {
+						return err
+					}
+					alterStmts, err = p.showForeignKeyAlters(ctx, tn, prefix, table)
+					if err != nil {
+						return err
+					}
+					zoneStmts, err = showZoneConfigStmts(tn, table, zones)
 				}
 				if err != nil {
 					return err
 				}
+				alterArr := tree.NewDArray(types.String)
+				for _, s := range alterStmts {
+					if err := alterArr.Append(tree.NewDString(s)); err != nil {
+						return err
+					}
+				}
+				zoneArr := tree.NewDArray(types.String)
+				for _, s := range zoneStmts {
+					if err := zoneArr.Append(tree.NewDString(s)); err != nil {
+						return err
+					}
+				}
 
 				descID := tree.DNull
 				if table.ID != keys.VirtualDescriptorID {
@@ -945,14 +1573,15 @@ CREATE TABLE crdb_internal.create_statements (
 					tree.NewDString(table.Name),
 					tree.NewDString(stmt),
 					tree.NewDString(table.State.String()),
+					tree.NewDString(createNofk),
+					alterArr,
+					zoneArr,
 				)
 			})
 	},
 }
 
-// crdbInternalTableColumnsTable exposes the column descriptors.
-var crdbInternalTableColumnsTable = virtualSchemaTable{
-	schema: `
+const crdbInternalTableColumnsSchema = `
 CREATE TABLE crdb_internal.table_columns (
   descriptor_id    INT,
   descriptor_name  STRING NOT NULL,
@@ -963,34 +1592,154 @@ CREATE TABLE crdb_internal.table_columns (
   default_expr     STRING,
   hidden           BOOL NOT NULL
 )
-`,
+`
+
+// crdbInternalTableColumnsTable exposes the column descriptors.
+var crdbInternalTableColumnsTable = virtualSchemaTable{
+	schema: crdbInternalTableColumnsSchema,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		privs, err := p.makePrivilegeChecker(ctx)
+		if err != nil {
+			return err
+		}
 		return forEachTableDescAll(ctx, p, prefix,
 			func(_ *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+				return addTableColumnsRows(table, privs, addRow)
+			})
+	},
+}
+
+// crdbInternalTableColumnsFunction exposes the column descriptors of a
+// single table, given its name. Unlike crdbInternalTableColumnsTable, it
+// only looks up the descriptor of the requested table.
+var crdbInternalTableColumnsFunction = virtualSchemaFunction{
+	schema:   crdbInternalTableColumnsSchema,
+	argTypes: []types.T{types.String},
+	populate: func(ctx context.Context, p *planner, args tree.Datums, addRow func(...tree.Datum) error) error {
+		if args[0] == tree.DNull {
+			return nil
+		}
+		tn, err := p.ParseQualifiedTableName(ctx, string(tree.MustBeDString(args[0])))
+		if err != nil {
+			return err
+		}
+		table, err := MustGetTableOrViewDesc(
+			ctx, p.txn, p.getVirtualTabler(), tn, true /* allowAdding */)
+		if err != nil {
+			return err
+		}
+		privs, err := p.makePrivilegeChecker(ctx)
+		if err != nil {
+			return err
+		}
+		if !userCanSeeTable(privs, table, true /* allowAdding */) {
+			return sqlbase.NewUndefinedRelationError(tn)
+		}
+		return addTableColumnsRows(table, privs, addRow)
+	},
+}
+
+// addTableColumnsRows adds the rows of crdb_internal.table_columns for the
+// columns of the table the user can see, including the hidden ones.
+func addTableColumnsRows(
+	table *sqlbase.TableDescriptor, privs *privilegeChecker, addRow func(...tree.Datum) error,
+) error {
+	tableID := tree.DNull
+	if table.ID != keys.VirtualDescriptorID {
+		tableID = tree.NewDInt(tree.DInt(table.ID))
+	}
+	tableName := tree.NewDString(table.Name)
+	for i := range table.Columns {
+		col := &table.Columns[i]
+		if !userCanSeeColumn(privs, table, col) {
+			continue
+		}
+		defStr := tree.DNull
+		if col.DefaultExpr != nil {
+			defStr = tree.NewDString(*col.DefaultExpr)
+		}
+		if err := addRow(
+			tableID,
+			tableName,
+			tree.NewDInt(tree.DInt(col.ID)),
+			tree.NewDString(col.Name),
+			tree.NewDString(col.Type.String()),
+			tree.MakeDBool(tree.DBool(col.Nullable)),
+			defStr,
+			tree.MakeDBool(tree.DBool(col.Hidden)),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// crdbInternalFindObjectsFunction searches the databases visible to the user
+// for the tables, views, sequences, columns and indexes whose name matches a
+// pattern, e.g. `SELECT * FROM crdb_internal.find_objects('%user%')`. The
+// pattern has the syntax of LIKE, and is matched regardless of case.
+var crdbInternalFindObjectsFunction = virtualSchemaFunction{
+	schema: `
+CREATE TABLE crdb_internal.find_objects (
+  database_name    STRING NOT NULL,
+  descriptor_id    INT,
+  descriptor_name  STRING NOT NULL,
+  object_type      STRING NOT NULL,
+  object_name      STRING NOT NULL
+)
+`,
+	argTypes: []types.T{types.String},
+	populate: func(ctx context.Context, p *planner, args tree.Datums, addRow func(...tree.Datum) error) error {
+		if args[0] == tree.DNull {
+			return nil
+		}
+		match, err := tree.LikeMatcher(
+			p.EvalContext(), string(tree.MustBeDString(args[0])), true /* caseInsensitive */)
+		if err != nil {
+			return err
+		}
+		privs, err := p.makePrivilegeChecker(ctx)
+		if err != nil {
+			return err
+		}
+		columnType := tree.NewDString("column")
+		indexType := tree.NewDString("index")
+		// The objects of all the databases are searched, whatever the
+		// current database.
+		return forEachTableDesc(ctx, p, "",
+			func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+				dbName := tree.NewDString(db.Name)
 				tableID := tree.DNull
 				if table.ID != keys.VirtualDescriptorID {
 					tableID = tree.NewDInt(tree.DInt(table.ID))
 				}
 				tableName := tree.NewDString(table.Name)
-				for _, col := range table.Columns {
-					defStr := tree.DNull
-					if col.DefaultExpr != nil {
-						defStr = tree.NewDString(*col.DefaultExpr)
-					}
+				if match(table.Name) {
 					if err := addRow(
-						tableID,
-						tableName,
-						tree.NewDInt(tree.DInt(col.ID)),
-						tree.NewDString(col.Name),
-						tree.NewDString(col.Type.String()),
-						tree.MakeDBool(tree.DBool(col.Nullable)),
-						defStr,
-						tree.MakeDBool(tree.DBool(col.Hidden)),
+						dbName, tableID, tableName, tree.NewDString(table.Kind()), tableName,
 					); err != nil {
 						return err
 					}
 				}
-				return nil
+				for i := range table.Columns {
+					col := &table.Columns[i]
+					if match(col.Name) && userCanSeeColumn(privs, table, col) {
+						if err := addRow(
+							dbName, tableID, tableName, columnType, tree.NewDString(col.Name),
+						); err != nil {
+							return err
+						}
+					}
+				}
+				if !table.IsTable() {
+					return nil
+				}
+				return table.ForeachNonDropIndex(func(idx *sqlbase.IndexDescriptor) error {
+					if !match(idx.Name) {
+						return nil
+					}
+					return addRow(dbName, tableID, tableName, indexType, tree.NewDString(idx.Name))
+				})
 			})
 	},
 }
@@ -1044,7 +1793,13 @@ CREATE TABLE crdb_internal.table_indexes (
 	},
 }
 
-// crdbInternalIndexColumnsTable exposes the index columns.
+// crdbInternalIndexColumnsTable exposes the index columns. The key columns
+// are the ones the index is declared on; the storing columns are the ones
+// of its STORING clause; the extra columns are the columns of the primary key
+// implicitly added to a secondary index to identify the rows; the composite
+// columns are the key columns whose values are also stored, as their
+// encodings lose information (e.g. decimals). The columns other than the key
+// columns have no direction.
 var crdbInternalIndexColumnsTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE crdb_internal.index_columns (
@@ -1074,6 +1829,13 @@ CREATE TABLE crdb_internal.index_columns (
 					tableID = tree.NewDInt(tree.DInt(table.ID))
 				}
 				tableName := tree.NewDString(table.Name)
+				columnName := func(id sqlbase.ColumnID) tree.Datum {
+					col, err := table.FindColumnByID(id)
+					if err != nil {
+						return tree.DNull
+					}
+					return tree.NewDString(col.Name)
+				}
 				reportIndex := func(idx *sqlbase.IndexDescriptor) error {
 					idxID := tree.NewDInt(tree.DInt(idx.ID))
 					idxName := tree.NewDString(idx.Name)
@@ -1113,7 +1875,7 @@ CREATE TABLE crdb_internal.index_columns (
 					for _, c := range idx.StoreColumnIDs {
 						if err := addRow(
 							tableID, tableName, idxID, idxName,
-							storing, tree.NewDInt(tree.DInt(c)), tree.DNull, tree.DNull,
+							storing, tree.NewDInt(tree.DInt(c)), columnName(c), tree.DNull,
 						); err != nil {
 							return err
 						}
@@ -1123,7 +1885,7 @@ CREATE TABLE crdb_internal.index_columns (
 					for _, c := range idx.ExtraColumnIDs {
 						if err := addRow(
 							tableID, tableName, idxID, idxName,
-							extra, tree.NewDInt(tree.DInt(c)), tree.DNull, tree.DNull,
+							extra, tree.NewDInt(tree.DInt(c)), columnName(c), tree.DNull,
 						); err != nil {
 							return err
 						}
@@ -1133,7 +1895,7 @@ CREATE TABLE crdb_internal.index_columns (
 					for _, c := range idx.CompositeColumnIDs {
 						if err := addRow(
 							tableID, tableName, idxID, idxName,
-							composite, tree.NewDInt(tree.DInt(c)), tree.DNull, tree.DNull,
+							composite, tree.NewDInt(tree.DInt(c)), columnName(c), tree.DNull,
 						); err != nil {
 							return err
 						}
@@ -1381,22 +2143,33 @@ CREATE TABLE crdb_internal.forward_dependencies (
 	},
 }
 
-// crdbInternalRangesTable exposes system ranges.
+// crdbInternalRangesTable exposes system ranges. The replicas, in increasing
+// order of store ID, and the lease holder are identified by their store IDs,
+// and by their node IDs in replica_nodes and lease_holder_node. The lease
+// holder is the one of the most recent lease reported by the nodes holding
+// replicas of the range, or NULL if none of them could report it.
+// approximate_size is the size in bytes of the keys and values of the range
+// as reported by its lease holder, or NULL if the lease holder could not
+// report it.
 var crdbInternalRangesTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE crdb_internal.ranges (
-  range_id     INT NOT NULL,
-  start_key    BYTES NOT NULL,
-  start_pretty STRING NOT NULL,
-  end_key      BYTES NOT NULL,
-  end_pretty   STRING NOT NULL,
-  database     STRING NOT NULL,
-  "table"      STRING NOT NULL,
-  "index"      STRING NOT NULL,
-  replicas     INT[] NOT NULL,
-  lease_holder INT NOT NULL
+  range_id          INT NOT NULL,
+  start_key         BYTES NOT NULL,
+  start_pretty      STRING NOT NULL,
+  end_key           BYTES NOT NULL,
+  end_pretty        STRING NOT NULL,
+  database          STRING NOT NULL,
+  "table"           STRING NOT NULL,
+  "index"           STRING NOT NULL,
+  replicas          INT[] NOT NULL,
+  lease_holder      INT,
+  replica_nodes     INT[] NOT NULL,
+  lease_holder_node INT,
+  approximate_size  INT
 )
 `,
+	currentOnly: true,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
 		if err := p.RequireSuperUser(ctx, "read crdb_internal.ranges"); err != nil {
 			return err
@@ -1430,16 +2203,28 @@ CREATE TABLE crdb_internal.ranges (
 		if err != nil {
 			return err
 		}
+		// The lease holders and the sizes of the ranges are asked for to the
+		// nodes holding their replicas once all the ranges are known, so the
+		// rows are only added then.
+		rows := make([]tree.Datums, 0, len(ranges))
+		rangeIDs := make([]roachpb.RangeID, 0, len(ranges))
+		rangesByNode := make(map[roachpb.NodeID][]roachpb.RangeID)
 		var desc roachpb.RangeDescriptor
 		for _, r := range ranges {
 			if err := r.ValueProto(&desc); err != nil {
 				return err
 			}
+			replicas := sortedReplicas(&desc)
 			arr := tree.NewDArray(types.Int)
-			for _, replica := range desc.Replicas {
+			nodeArr := tree.NewDArray(types.Int)
+			for _, replica := range replicas {
 				if err := arr.Append(tree.NewDInt(tree.DInt(replica.StoreID))); err != nil {
 					return err
 				}
+				if err := nodeArr.Append(tree.NewDInt(tree.DInt(replica.NodeID))); err != nil {
+					return err
+				}
+				rangesByNode[replica.NodeID] = append(rangesByNode[replica.NodeID], desc.RangeID)
 			}
 			var dbName, tableName, indexName string
 			if _, id, err := keys.DecodeTablePrefix(desc.StartKey.AsRawKey()); err == nil {
@@ -1454,22 +2239,9 @@ CREATE TABLE crdb_internal.ranges (
 					dbName = dbNames[id]
 				}
 			}
+			rangeIDs = append(rangeIDs, desc.RangeID)
 
-			// Get the lease holder.
-			// TODO(radu): this will be slow if we have a lot of ranges; find a way to
-			// make this part optional.
-			b := &client.Batch{}
-			b.AddRawRequest(&roachpb.LeaseInfoRequest{
-				Span: roachpb.Span{
-					Key: desc.StartKey.AsRawKey(),
-				},
-			})
-			if err := p.txn.Run(ctx, b); err != nil {
-				return errors.Wrap(err, "error getting lease info")
-			}
-			resp := b.RawResponse().Responses[0].GetInner().(*roachpb.LeaseInfoResponse)
-
-			if err := addRow(
+			rows = append(rows, tree.Datums{
 				tree.NewDInt(tree.DInt(desc.RangeID)),
 				tree.NewDBytes(tree.DBytes(desc.StartKey)),
 				tree.NewDString(keys.PrettyPrint(nil /* valDirs */, desc.StartKey.AsRawKey())),
@@ -1479,7 +2251,273 @@ CREATE TABLE crdb_internal.ranges (
 				tree.NewDString(tableName),
 				tree.NewDString(indexName),
 				arr,
-				tree.NewDInt(tree.DInt(resp.Lease.Replica.StoreID)),
+				// The lease holder columns and approximate_size are filled in
+				// below.
+				tree.DNull,
+				nodeArr,
+				tree.DNull,
+				tree.DNull,
+			})
+		}
+
+		reports, err := rangeReports(
+			ctx, p.ExecCfg().DistSQLSrv.Stopper, p.ExecCfg().StatusServer, rangesByNode)
+		if err != nil {
+			return err
+		}
+		for i, row := range rows {
+			if r, ok := reports[rangeIDs[i]]; ok {
+				holder := r.lease.Replica
+				row[9] = tree.NewDInt(tree.DInt(holder.StoreID))
+				row[11] = tree.NewDInt(tree.DInt(holder.NodeID))
+				if size, ok := r.sizes[holder.StoreID]; ok {
+					row[12] = tree.NewDInt(tree.DInt(size))
+				}
+			}
+			if err := addRow(row...); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// rangeReport is what the nodes holding replicas of a range report about it.
+type rangeReport struct {
+	// lease is the most recent lease of the range reported by its replicas.
+	lease roachpb.Lease
+	// sizes are the sizes in bytes of the keys and values of the range, as
+	// reported by each of its replicas.
+	sizes map[roachpb.StoreID]int64
+}
+
+// rangeReports returns the leases and the sizes of the given ranges, grouped
+// by the nodes holding their replicas, as reported by these nodes with the
+// Ranges RPC of the status server. The ranges for which no node could report
+// a lease are missing.
+//
+// The nodes are asked in parallel, at most maxConcurrentNodeRequests at
+// once, so that the latency of the table doesn't grow with the size of the
+// cluster. An error is returned if the requests can't be started, e.g.
+// because the server is shutting down.
+func rangeReports(
+	ctx context.Context,
+	stopper *stop.Stopper,
+	statusServer serverpb.StatusServer,
+	rangesByNode map[roachpb.NodeID][]roachpb.RangeID,
+) (map[roachpb.RangeID]rangeReport, error) {
+	var mu syncutil.Mutex
+	reports := make(map[roachpb.RangeID]rangeReport)
+	sem := make(chan struct{}, maxConcurrentNodeRequests)
+	var wg sync.WaitGroup
+	for nodeID, rangeIDs := range rangesByNode {
+		nodeID, rangeIDs := nodeID, rangeIDs
+		wg.Add(1)
+		if err := stopper.RunLimitedAsyncTask(
+			ctx, "crdb_internal.ranges: requesting ranges", sem, true /* wait */, func(ctx context.Context) {
+				defer wg.Done()
+				resp, err := statusServer.Ranges(ctx, &serverpb.RangesRequest{
+					NodeId:   nodeID.String(),
+					RangeIDs: rangeIDs,
+				})
+				if err != nil {
+					log.Warningf(ctx, "failed to get the ranges from node %d: %v", nodeID, err)
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				for _, info := range resp.Ranges {
+					if info.State.Desc == nil || info.State.Lease == nil ||
+						info.State.Lease.Replica.StoreID == 0 {
+						continue
+					}
+					rangeID := info.State.Desc.RangeID
+					r, ok := reports[rangeID]
+					if !ok {
+						r.sizes = make(map[roachpb.StoreID]int64)
+					}
+					// The replicas that are behind may still report a lease
+					// that has since been replaced; leases only start later
+					// than the ones they replace.
+					if !ok || r.lease.Start.Less(info.State.Lease.Start) {
+						r.lease = *info.State.Lease
+					}
+					if info.State.Stats != nil {
+						r.sizes[info.SourceStoreID] = info.State.Stats.Total()
+					}
+					reports[rangeID] = r
+				}
+			},
+		); err != nil {
+			// The requests already started write to reports.
+			wg.Done()
+			wg.Wait()
+			return nil, err
+		}
+	}
+	wg.Wait()
+	return reports, nil
+}
+
+// maxConcurrentNodeRequests is the maximum number of requests the virtual
+// tables gathering data from every node have in flight at once.
+const maxConcurrentNodeRequests = 100
+
+// crdbInternalRolesTable exposes the users and roles with their options,
+// as set with CREATE ROLE or ALTER ROLE.
+var crdbInternalRolesTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE crdb_internal.roles (
+  username    STRING NOT NULL,
+  is_role     BOOL NOT NULL,
+  can_login   BOOL NOT NULL,
+  create_role BOOL NOT NULL,
+  valid_until TIMESTAMPTZ
+)
+`,
+	minVersion: cluster.VersionRoleSettingsOptionsAndDefaultPrivileges,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		options, err := getRoleOptions(ctx, p.txn, p.ExecCfg())
+		if err != nil {
+			return err
+		}
+		return forEachRole(ctx, p, func(username string, isRole bool) error {
+			isRoot := username == security.RootUser || username == sqlbase.AdminRole
+			o := options[username]
+			return addRow(
+				tree.NewDString(username),
+				tree.MakeDBool(tree.DBool(isRole)),
+				tree.MakeDBool(tree.DBool(!isRole && !o.noLogin)),
+				tree.MakeDBool(tree.DBool(isRoot || o.createRole)),
+				o.validUntilDatum(),
+			)
+		})
+	},
+}
+
+// crdbInternalDefaultPrivilegesTable exposes the privileges granted on the
+// tables, views and sequences that roles create in the databases, as set
+// with ALTER DEFAULT PRIVILEGES.
+var crdbInternalDefaultPrivilegesTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE crdb_internal.default_privileges (
+  database_name  STRING NOT NULL,
+  role           STRING NOT NULL,
+  grantee        STRING NOT NULL,
+  privilege_type STRING NOT NULL
+)
+`,
+	minVersion: cluster.VersionRoleSettingsOptionsAndDefaultPrivileges,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		internalExecutor := InternalExecutor{ExecCfg: p.ExecCfg()}
+		rows, _ /* cols */, err := internalExecutor.QueryRowsInTransaction(
+			ctx,
+			"crdb-internal-default-privileges",
+			p.txn,
+			`SELECT database_id, role, grantee, privileges FROM system.default_privileges
+			 ORDER BY database_id, role, grantee`,
+		)
+		if err != nil {
+			return err
+		}
+		rowsByDB := make(map[sqlbase.ID][]tree.Datums)
+		for _, row := range rows {
+			id := sqlbase.ID(tree.MustBeDInt(row[0]))
+			rowsByDB[id] = append(rowsByDB[id], row)
+		}
+		return forEachDatabaseDesc(ctx, p, func(db *sqlbase.DatabaseDescriptor) error {
+			dbName := tree.NewDString(db.Name)
+			for _, row := range rowsByDB[db.ID] {
+				for _, priv := range strings.Split(string(tree.MustBeDString(row[3])), ",") {
+					if err := addRow(
+						dbName,
+						row[1],
+						row[2],
+						tree.NewDString(priv),
+					); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+	},
+}
+
+// crdbInternalTableGCTTLsTable exposes the GC TTL that applies to the data
+// of each table, resolved through the inheritance of zone configs, and the
+// zone config it is inherited from.
+var crdbInternalTableGCTTLsTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE crdb_internal.table_gc_ttls (
+  table_id       INT NOT NULL,
+  database_name  STRING NOT NULL,
+  table_name     STRING NOT NULL,
+  state          STRING NOT NULL,
+  gc_ttl_seconds INT NOT NULL,
+  zone_id        INT NOT NULL,
+  zone_specifier STRING,
+  gc_deadline    TIMESTAMP
+)
+`,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		namespace, err := p.getAllNames(ctx)
+		if err != nil {
+			return err
+		}
+		resolveID := func(id uint32) (parentID uint32, name string, err error) {
+			if entry, ok := namespace[sqlbase.ID(id)]; ok {
+				return uint32(entry.parentID), entry.name, nil
+			}
+			return 0, "", fmt.Errorf("object with ID %d does not exist", id)
+		}
+
+		descs, err := p.Tables().getAllDescriptors(ctx, p.txn)
+		if err != nil {
+			return err
+		}
+		dbNames := make(map[sqlbase.ID]string)
+		for _, desc := range descs {
+			if db, ok := desc.(*sqlbase.DatabaseDescriptor); ok {
+				dbNames[db.ID] = db.Name
+			}
+		}
+		// Note: we do not use forEachTableDesc() here because the data of
+		// dropped tables is retained until their GC deadline.
+		for _, desc := range descs {
+			table, ok := desc.(*sqlbase.TableDescriptor)
+			if !ok || table.IsView() || p.CheckAnyPrivilege(ctx, table) != nil {
+				continue
+			}
+			zoneID, zone, _, err := GetZoneConfigInTxn(ctx, p.txn, uint32(table.ID), nil, "")
+			if err == errNoZoneConfigApplies {
+				continue
+			} else if err != nil {
+				return err
+			}
+			dbName := dbNames[table.GetParentID()]
+			if dbName == "" {
+				dbName = fmt.Sprintf("[%d]", table.GetParentID())
+			}
+			zoneSpecifier := tree.DNull
+			if zs, err := config.ZoneSpecifierFromID(zoneID, resolveID); err == nil {
+				zoneSpecifier = tree.NewDString(config.CLIZoneSpecifier(&zs))
+			}
+			gcDeadlineDatum := tree.DNull
+			if table.GCDeadline != 0 {
+				gcDeadlineDatum = tree.MakeDTimestamp(
+					timeutil.Unix(0, table.GCDeadline), time.Nanosecond,
+				)
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(int64(table.ID))),
+				tree.NewDString(dbName),
+				tree.NewDString(table.Name),
+				tree.NewDString(table.State.String()),
+				tree.NewDInt(tree.DInt(zone.GC.TTLSeconds)),
+				tree.NewDInt(tree.DInt(int64(zoneID))),
+				zoneSpecifier,
+				gcDeadlineDatum,
 			); err != nil {
 				return err
 			}
@@ -1489,14 +2527,25 @@ CREATE TABLE crdb_internal.ranges (
 }
 
 // crdbInternalZonesTable decodes and exposes the zone configs in the
-// system.zones table.
+// system.zones table. Besides the encoded configs, it breaks out the target
+// of each zone and its main fields, so that they can be queried without
+// decoding the configs. The target columns are NULL for the zones of tables
+// dropped but not yet cleaned up, which can't be referred to anymore.
 var crdbInternalZonesTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE crdb_internal.zones (
-  id            INT NOT NULL,
-  cli_specifier STRING NOT NULL,
-  config_yaml   BYTES NOT NULL,
-  config_proto  BYTES NOT NULL
+  id             INT NOT NULL,
+  cli_specifier  STRING NOT NULL,
+  config_yaml    BYTES NOT NULL,
+  config_proto   BYTES NOT NULL,
+  target         STRING,
+  database_name  STRING,
+  table_name     STRING,
+  index_name     STRING,
+  partition_name STRING,
+  num_replicas   INT NOT NULL,
+  constraints    STRING[] NOT NULL,
+  gc_ttl_seconds INT NOT NULL
 )
 `,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
@@ -1522,13 +2571,16 @@ CREATE TABLE crdb_internal.zones (
 			id := uint32(tree.MustBeDInt(r[0]))
 			zs, err := config.ZoneSpecifierFromID(id, resolveID)
 			var cliSpecifier tree.Datum
+			var target tree.Datums
 			if err == nil {
 				cliSpecifier = tree.NewDString(config.CLIZoneSpecifier(&zs))
+				target = zoneTargetDatums(&zs)
 			} else {
 				// The table was deleted but hasn't yet been cleaned up by the schema
 				// changer. The user has no way to refer to the zone, so provide a NULL
 				// CLI specifier.
 				cliSpecifier = tree.DNull
+				target = tree.Datums{tree.DNull, tree.DNull, tree.DNull, tree.DNull, tree.DNull}
 			}
 
 			configBytes := []byte(*r[1].(*tree.DBytes))
@@ -1550,12 +2602,17 @@ CREATE TABLE crdb_internal.zones (
 				if err != nil {
 					return err
 				}
-				if err := addRow(
+				row := append(tree.Datums{
 					r[0], // id
 					cliSpecifier,
 					tree.NewDBytes(tree.DBytes(configYAML)),
 					tree.NewDBytes(tree.DBytes(configBytes)),
-				); err != nil {
+				}, target...)
+				row, err = appendZoneFieldDatums(row, &configProto)
+				if err != nil {
+					return err
+				}
+				if err := addRow(row...); err != nil {
 					return err
 				}
 			}
@@ -1575,6 +2632,7 @@ CREATE TABLE crdb_internal.zones (
 						zs.TableOrIndex.Index = tree.UnrestrictedName(index.Name)
 						zs.Partition = tree.Name(s.PartitionName)
 						cliSpecifier = tree.NewDString(config.CLIZoneSpecifier(&zs))
+						target = zoneTargetDatums(&zs)
 					}
 					configYAML, err := yaml.Marshal(s.Config)
 					if err != nil {
@@ -1584,12 +2642,17 @@ CREATE TABLE crdb_internal.zones (
 					if err != nil {
 						return err
 					}
-					if err := addRow(
+					row := append(tree.Datums{
 						r[0], // id
 						cliSpecifier,
 						tree.NewDBytes(tree.DBytes(configYAML)),
 						tree.NewDBytes(tree.DBytes(configBytes)),
-					); err != nil {
+					}, target...)
+					row, err = appendZoneFieldDatums(row, &s.Config)
+					if err != nil {
+						return err
+					}
+					if err := addRow(row...); err != nil {
 						return err
 					}
 				}
@@ -1599,7 +2662,55 @@ CREATE TABLE crdb_internal.zones (
 	},
 }
 
+// zoneTargetDatums returns the target, database_name, table_name, index_name
+// and partition_name columns of crdb_internal.zones for the zone identified
+// by zs. The target is one of range, database, table, index and partition;
+// the names that don't apply to it are NULL.
+func zoneTargetDatums(zs *tree.ZoneSpecifier) tree.Datums {
+	datums := tree.Datums{tree.DNull, tree.DNull, tree.DNull, tree.DNull, tree.DNull}
+	switch {
+	case zs.NamedZone != "":
+		datums[0] = tree.NewDString("range")
+	case zs.Database != "":
+		datums[0] = tree.NewDString("database")
+		datums[1] = tree.NewDString(string(zs.Database))
+	default:
+		tn := zs.TableOrIndex.Table.TableName()
+		datums[0] = tree.NewDString("table")
+		datums[1] = tree.NewDString(tn.Schema())
+		datums[2] = tree.NewDString(tn.Table())
+		if zs.TableOrIndex.Index != "" {
+			datums[0] = tree.NewDString("index")
+			datums[3] = tree.NewDString(string(zs.TableOrIndex.Index))
+		}
+		if zs.Partition != "" {
+			datums[0] = tree.NewDString("partition")
+			datums[4] = tree.NewDString(string(zs.Partition))
+		}
+	}
+	return datums
+}
+
+// appendZoneFieldDatums appends the num_replicas, constraints and
+// gc_ttl_seconds columns of crdb_internal.zones for the given zone config to
+// row.
+func appendZoneFieldDatums(row tree.Datums, zone *config.ZoneConfig) (tree.Datums, error) {
+	constraints := tree.NewDArray(types.String)
+	for _, c := range zone.Constraints.Constraints {
+		if err := constraints.Append(tree.NewDString(c.String())); err != nil {
+			return nil, err
+		}
+	}
+	return append(row,
+		tree.NewDInt(tree.DInt(zone.NumReplicas)),
+		constraints,
+		tree.NewDInt(tree.DInt(zone.GC.TTLSeconds)),
+	), nil
+}
+
 // crdbInternalGossipNodesTable exposes local information about the cluster nodes.
+// The node descriptors are the ones gossiped to the node serving the query,
+// as shown by the /_status/gossip debug endpoint.
 var crdbInternalGossipNodesTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE crdb_internal.gossip_nodes (
@@ -1611,6 +2722,7 @@ CREATE TABLE crdb_internal.gossip_nodes (
   server_version  STRING NOT NULL
 )
 	`,
+	currentOnly: true,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
 		if err := p.RequireSuperUser(ctx, "read crdb_internal.gossip_nodes"); err != nil {
 			return err
@@ -1665,6 +2777,9 @@ CREATE TABLE crdb_internal.gossip_nodes (
 }
 
 // crdbInternalGossipLivenessTable exposes local information about the nodes' liveness.
+// The liveness records, with their epochs and decommissioning status, are the
+// ones gossiped to the node serving the query, so that cluster membership can
+// be checked with SQL.
 var crdbInternalGossipLivenessTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE crdb_internal.gossip_liveness (
@@ -1675,6 +2790,7 @@ CREATE TABLE crdb_internal.gossip_liveness (
   decommissioning BOOL NOT NULL
 )
 	`,
+	currentOnly: true,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
 		if err := p.RequireSuperUser(ctx, "read crdb_internal.gossip_liveness"); err != nil {
 			return err
@@ -1717,44 +2833,103 @@ CREATE TABLE crdb_internal.gossip_liveness (
 	},
 }
 
-func addPartitioningRows(
+// partitionDesc describes a partition of an index.
+type partitionDesc struct {
+	// parentName is the name of the partition this one subpartitions, or
+	// empty if it partitions the index itself.
+	parentName string
+	name       string
+	// ordinal is the 1-indexed position of the partition among the
+	// partitions of its parent.
+	ordinal int
+	// method is either LIST or RANGE.
+	method string
+	// columnNames are the index columns the partition is defined on.
+	columnNames []string
+	// values are the tuples of a list partition, e.g. (1), (2), or the
+	// bounds of a range partition, e.g. (1) TO (10), formatted as in
+	// SHOW CREATE TABLE.
+	values string
+}
+
+// forEachPartition calls fn for each partition of the given partitioning
+// of an index, each one followed by its subpartitions.
+func forEachPartition(
+	a *sqlbase.DatumAlloc,
 	table *sqlbase.TableDescriptor,
 	index *sqlbase.IndexDescriptor,
 	partitioning *sqlbase.PartitioningDescriptor,
-	parentName tree.Datum,
+	parentName string,
 	colOffset int,
-	addRow func(...tree.Datum) error,
+	fn func(partitionDesc) error,
 ) error {
-	tableID := tree.NewDInt(tree.DInt(table.ID))
-	indexID := tree.NewDInt(tree.DInt(index.ID))
-	numColumns := tree.NewDInt(tree.DInt(partitioning.NumColumns))
+	if partitioning.NumColumns == 0 {
+		return nil
+	}
+	numColumns := int(partitioning.NumColumns)
+	if colOffset+numColumns > len(index.ColumnNames) {
+		return errors.Errorf("not enough columns in index %q for its partitioning", index.Name)
+	}
+	columnNames := index.ColumnNames[colOffset : colOffset+numColumns]
 
-	for _, l := range partitioning.List {
-		name := tree.NewDString(l.Name)
-		if err := addRow(
-			tableID,
-			indexID,
-			parentName,
-			name,
-			numColumns,
-		); err != nil {
+	// We don't need real prefixes in the DecodePartitionTuple calls because we
+	// only use the tree.Datums part of the output.
+	fakePrefixDatums := make([]tree.Datum, colOffset)
+	for i := range fakePrefixDatums {
+		fakePrefixDatums[i] = tree.DNull
+	}
+	decode := func(valueEncBuf []byte) (string, error) {
+		t, _, err := sqlbase.DecodePartitionTuple(
+			a, table, index, partitioning, valueEncBuf, fakePrefixDatums)
+		if err != nil {
+			return "", err
+		}
+		return t.String(), nil
+	}
+
+	for i := range partitioning.List {
+		l := &partitioning.List[i]
+		values := make([]string, len(l.Values))
+		for j := range l.Values {
+			var err error
+			if values[j], err = decode(l.Values[j]); err != nil {
+				return err
+			}
+		}
+		if err := fn(partitionDesc{
+			parentName:  parentName,
+			name:        l.Name,
+			ordinal:     i + 1,
+			method:      "LIST",
+			columnNames: columnNames,
+			values:      strings.Join(values, ", "),
+		}); err != nil {
 			return err
 		}
-		err := addPartitioningRows(table, index, &l.Subpartitioning, name,
-			colOffset+int(partitioning.NumColumns), addRow)
-		if err != nil {
+		if err := forEachPartition(a, table, index, &l.Subpartitioning, l.Name,
+			colOffset+numColumns, fn); err != nil {
 			return err
 		}
 	}
 
-	for _, r := range partitioning.Range {
-		if err := addRow(
-			tableID,
-			indexID,
-			parentName,
-			tree.NewDString(r.Name),
-			numColumns,
-		); err != nil {
+	for i := range partitioning.Range {
+		r := &partitioning.Range[i]
+		from, err := decode(r.FromInclusive)
+		if err != nil {
+			return err
+		}
+		to, err := decode(r.ToExclusive)
+		if err != nil {
+			return err
+		}
+		if err := fn(partitionDesc{
+			parentName:  parentName,
+			name:        r.Name,
+			ordinal:     i + 1,
+			method:      "RANGE",
+			columnNames: columnNames,
+			values:      from + " TO " + to,
+		}); err != nil {
 			return err
 		}
 	}
@@ -1763,23 +2938,75 @@ func addPartitioningRows(
 }
 
 // crdbInternalPartitionsTable decodes and exposes the partitions of each
-// table.
+// table, with the zone config set on each partition, if any.
 var crdbInternalPartitionsTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE crdb_internal.partitions (
-	table_id    INT NOT NULL,
-	index_id    INT NOT NULL,
-	parent_name STRING,
-	name        STRING NOT NULL,
-	columns     INT NOT NULL
+	table_id     INT NOT NULL,
+	index_id     INT NOT NULL,
+	parent_name  STRING,
+	name         STRING NOT NULL,
+	columns      INT NOT NULL,
+	column_names STRING NOT NULL,
+	list_value   STRING,
+	range_value  STRING,
+	config_yaml  STRING
 )
 	`,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		var a sqlbase.DatumAlloc
 		return forEachTableDescAll(ctx, p, prefix,
 			func(_ *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+				tableID := tree.NewDInt(tree.DInt(table.ID))
+				// The zone config of the table holds the subzones of its
+				// partitions. It is only read for tables with partitions.
+				var zone *config.ZoneConfig
+				partitionConfig := func(indexID sqlbase.IndexID, name string) (tree.Datum, error) {
+					if zone == nil {
+						z, err := getZoneConfigRaw(ctx, p.txn, table.ID)
+						if err != nil {
+							return nil, err
+						}
+						zone = &z
+					}
+					for _, s := range zone.Subzones {
+						if s.IndexID != uint32(indexID) || s.PartitionName != name {
+							continue
+						}
+						configYAML, err := yaml.Marshal(s.Config)
+						if err != nil {
+							return nil, err
+						}
+						return tree.NewDString(string(configYAML)), nil
+					}
+					return tree.DNull, nil
+				}
 				return table.ForeachNonDropIndex(func(index *sqlbase.IndexDescriptor) error {
-					return addPartitioningRows(table, index, &index.Partitioning,
-						tree.DNull /* parentName */, 0 /* colOffset */, addRow)
+					indexID := tree.NewDInt(tree.DInt(index.ID))
+					return forEachPartition(&a, table, index, &index.Partitioning,
+						"" /* parentName */, 0 /* colOffset */, func(part partitionDesc) error {
+							listValue, rangeValue := tree.DNull, tree.DNull
+							if part.method == "LIST" {
+								listValue = tree.NewDString(part.values)
+							} else {
+								rangeValue = tree.NewDString(part.values)
+							}
+							configYAML, err := partitionConfig(index.ID, part.name)
+							if err != nil {
+								return err
+							}
+							return addRow(
+								tableID,
+								indexID,
+								dStringOrNull(part.parentName),
+								tree.NewDString(part.name),
+								tree.NewDInt(tree.DInt(len(part.columnNames))),
+								tree.NewDString(strings.Join(part.columnNames, ", ")),
+								listValue,
+								rangeValue,
+								configYAML,
+							)
+						})
 				})
 			})
 	},
@@ -1812,6 +3039,7 @@ CREATE TABLE crdb_internal.kv_node_status (
   activity       JSON NOT NULL
 )
 	`,
+	currentOnly: true,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
 		if err := p.RequireSuperUser(ctx, "read crdb_internal.kv_node_status"); err != nil {
 			return err
@@ -1917,6 +3145,7 @@ CREATE TABLE crdb_internal.kv_store_status (
   metrics            JSON NOT NULL
 )
 	`,
+	currentOnly: true,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
 		if err := p.RequireSuperUser(ctx, "read crdb_internal.kv_store_status"); err != nil {
 			return err
@@ -0,0 +1,59 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/tests"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestInvalidDescriptors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	params, _ := tests.CreateTestServerParams()
+	s, sqlDB, kvDB := serverutils.StartServer(t, params)
+	defer s.Stopper().Stop(context.TODO())
+	db := sqlutils.MakeSQLRunner(sqlDB)
+
+	db.Exec(t, `CREATE DATABASE t; CREATE TABLE t.test (k INT PRIMARY KEY)`)
+	db.CheckQueryResults(t, `SELECT * FROM crdb_internal.invalid_descriptors`, [][]string{})
+
+	// Corrupt the descriptor of the table: its database doesn't exist, and
+	// it refers to the job of a mutation it doesn't have.
+	tableDesc := sqlbase.GetTableDescriptor(kvDB, "t", "test")
+	tableDesc.ParentID = 1000
+	tableDesc.MutationJobs = append(tableDesc.MutationJobs, sqlbase.TableDescriptor_MutationJob{
+		MutationID: 7, JobID: 12345,
+	})
+	if err := writeTableDesc(context.TODO(), kvDB, tableDesc); err != nil {
+		t.Fatal(err)
+	}
+
+	id := fmt.Sprint(tableDesc.ID)
+	db.CheckQueryResults(t,
+		`SELECT * FROM crdb_internal.invalid_descriptors ORDER BY error`,
+		[][]string{
+			{id, "test", "mutation job 12345 of mutation 7 does not exist in system.jobs"},
+			{id, "test", "mutation job 12345 refers to mutation 7, which does not exist"},
+			{id, "test", "parentID 1000 does not exist"},
+		})
+}
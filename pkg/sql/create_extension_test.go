@@ -0,0 +1,36 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+// TestBuiltinExtensionFunctions checks that the functions of the extensions
+// accepted by CREATE EXTENSION exist.
+func TestBuiltinExtensionFunctions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	for name, functions := range builtinExtensions {
+		for _, fn := range functions {
+			if _, ok := builtins.Builtins[fn]; !ok {
+				t.Errorf("extension %s: function %s does not exist", name, fn)
+			}
+		}
+	}
+}
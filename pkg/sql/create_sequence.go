@@ -64,6 +64,10 @@ func (n *createSequenceNode) startExec(params runParams) error {
 		return err
 	}
 
+	if err := params.p.checkTablesPerDatabaseLimit(params.ctx, n.dbDesc); err != nil {
+		return err
+	}
+
 	id, err := GenerateUniqueDescID(params.ctx, params.p.ExecCfg().DB)
 	if err != nil {
 		return err
@@ -77,6 +81,13 @@ func (n *createSequenceNode) startExec(params runParams) error {
 		return err
 	}
 
+	if err := params.p.assignSequenceOwner(params.ctx, &desc, n.n.Options); err != nil {
+		return err
+	}
+	if err := params.p.applyDefaultPrivileges(params.ctx, &desc); err != nil {
+		return err
+	}
+
 	if err = desc.ValidateTable(); err != nil {
 		return err
 	}
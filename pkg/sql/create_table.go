@@ -114,6 +114,10 @@ func (n *createTableNode) startExec(params runParams) error {
 		return err
 	}
 
+	if err := params.p.checkTablesPerDatabaseLimit(params.ctx, n.dbDesc); err != nil {
+		return err
+	}
+
 	id, err := GenerateUniqueDescID(params.ctx, params.extendedEvalCtx.ExecCfg.DB)
 	if err != nil {
 		return err
@@ -140,6 +144,14 @@ func (n *createTableNode) startExec(params runParams) error {
 	if err != nil {
 		return err
 	}
+	if err := params.p.checkColumnsPerTableLimit(&desc); err != nil {
+		return err
+	}
+	if n.dbDesc.ID != keys.SystemDatabaseID {
+		if err := params.p.applyDefaultPrivileges(params.ctx, &desc); err != nil {
+			return err
+		}
+	}
 
 	// We need to validate again after adding the FKs.
 	// Only validate the table because backreferences aren't created yet.
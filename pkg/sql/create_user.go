@@ -31,6 +31,7 @@ type CreateUserNode struct {
 	ifNotExists bool
 	isRole      bool
 	userAuthInfo
+	options []roleOptionUpdate
 
 	run createUserRun
 }
@@ -38,27 +39,33 @@ type CreateUserNode struct {
 var userTableName = tree.NewTableName("system", "users")
 
 // CreateUser creates a user.
-// Privileges: INSERT on system.users.
+// Privileges: INSERT on system.users, or the CREATEROLE option.
 //   notes: postgres allows the creation of users with an empty password. We do
 //          as well, but disallow password authentication for these users.
 func (p *planner) CreateUser(ctx context.Context, n *tree.CreateUser) (planNode, error) {
-	return p.CreateUserNode(ctx, n.Name, n.Password, n.IfNotExists, false /* isRole */, "CREATE USER")
+	return p.CreateUserNode(
+		ctx, n.Name, n.Password, n.Options, n.IfNotExists, false /* isRole */, "CREATE USER")
 }
 
 // CreateUserNode creates a "create user" plan node. This can be called from CREATE USER or CREATE ROLE.
 func (p *planner) CreateUserNode(
-	ctx context.Context, nameE, passwordE tree.Expr, ifNotExists bool, isRole bool, opName string,
+	ctx context.Context,
+	nameE, passwordE tree.Expr,
+	options tree.RoleOptions,
+	ifNotExists bool,
+	isRole bool,
+	opName string,
 ) (*CreateUserNode, error) {
-	tDesc, err := getTableDesc(ctx, p.txn, p.getVirtualTabler(), userTableName)
-	if err != nil {
+	if _, err := p.checkCreateRolePrivilege(ctx, privilege.INSERT); err != nil {
 		return nil, err
 	}
 
-	if err := p.CheckPrivilege(ctx, tDesc, privilege.INSERT); err != nil {
+	ua, err := p.getUserAuthInfo(nameE, passwordE, opName)
+	if err != nil {
 		return nil, err
 	}
 
-	ua, err := p.getUserAuthInfo(nameE, passwordE, opName)
+	updates, err := p.makeRoleOptionUpdates(options, isRole, opName)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +74,7 @@ func (p *planner) CreateUserNode(
 		userAuthInfo: ua,
 		ifNotExists:  ifNotExists,
 		isRole:       isRole,
+		options:      updates,
 	}, nil
 }
 
@@ -132,7 +140,7 @@ func (n *CreateUserNode) startExec(params runParams) error {
 		)
 	}
 
-	return nil
+	return writeRoleOptions(params, normalizedUsername, n.options)
 }
 
 type createUserRun struct {
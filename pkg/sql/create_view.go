@@ -121,6 +121,10 @@ func (n *createViewNode) startExec(params runParams) error {
 		return err
 	}
 
+	if err := params.p.checkTablesPerDatabaseLimit(params.ctx, n.dbDesc); err != nil {
+		return err
+	}
+
 	id, err := GenerateUniqueDescID(params.ctx, params.extendedEvalCtx.ExecCfg.DB)
 	if err != nil {
 		return err
@@ -141,6 +145,9 @@ func (n *createViewNode) startExec(params runParams) error {
 	if err != nil {
 		return err
 	}
+	if err := params.p.applyDefaultPrivileges(params.ctx, &desc); err != nil {
+		return err
+	}
 
 	if err = desc.ValidateTable(); err != nil {
 		return err
@@ -136,6 +136,11 @@ type dataSourceInfo struct {
 	// The number of backfill source columns. The backfill columns are
 	// always the last columns from sourceColumns.
 	numBackfillColumns int
+
+	// deprecationNotices maps the indexes of the deprecated columns of
+	// virtual tables to the notice to send to the client when they are
+	// referenced by name. See virtualSchemaTable.deprecatedColumns.
+	deprecationNotices map[int]*pgerror.Error
 }
 
 // planDataSource contains the data source information for data
@@ -242,6 +247,17 @@ func newSourceInfoForSingleTable(tn tree.TableName, columns sqlbase.ResultColumn
 	}
 }
 
+// copyDeprecationNotices adds the deprecation notices of the columns of
+// from to src, column col of from being column colMap(col) of src.
+func (src *dataSourceInfo) copyDeprecationNotices(from *dataSourceInfo, colMap func(int) int) {
+	for col, notice := range from.deprecationNotices {
+		if src.deprecationNotices == nil {
+			src.deprecationNotices = make(map[int]*pgerror.Error, len(from.deprecationNotices))
+		}
+		src.deprecationNotices[colMap(col)] = notice
+	}
+}
+
 // getSources combines zero or more FROM sources into cross-joins.
 func (p *planner) getSources(
 	ctx context.Context, sources []tree.TableExpr, scanVisibility scanVisibility,
@@ -280,7 +296,15 @@ func (p *planner) getVirtualDataSource(
 		return planDataSource{}, false, err
 	}
 	if virtual.desc != nil {
-		columns, constructor := virtual.getPlanInfo(ctx)
+		// The name visible in EXPLAIN once the virtual table is expanded
+		// does not depend on the catalog.
+		name := tree.NewTableName(tn.SchemaName, tree.Name(virtual.desc.Name)).String()
+		if virtual.tableDef.currentOnly && p.asOfSystemTime {
+			return planDataSource{}, false, pgerror.NewErrorf(pgerror.CodeFeatureNotSupportedError,
+				"%s reports the current state of the cluster and cannot be queried AS OF SYSTEM TIME",
+				name)
+		}
+		columns, constructor := virtual.getPlanInfo(name)
 
 		// The virtual table constructor takes the target database
 		// as "prefix" argument. This is either the prefix in the
@@ -298,11 +322,22 @@ func (p *planner) getVirtualDataSource(
 		// case where there is no current database is thus safer.
 		//
 		// Meanwhile the root user probably would be inconvenienced by
-		// this.
+		// this, unless they restricted the virtual tables to the current
+		// database with the catalog_scope session variable.
+		//
+		// Clients asking for MySQL's information_schema with the
+		// information_schema_compat session variable expect it to describe
+		// all the databases instead, each as a TABLE_SCHEMA. This is subject
+		// to the same restriction for non-root users as the empty prefix.
 		catalog := string(tn.CatalogName)
 		if !tn.ExplicitCatalog {
 			catalog = p.SessionData().Database
-			if catalog == "" && p.RequireSuperUser(ctx, "access virtual tables across all databases") != nil {
+			if p.infoSchemaDialect().describesAllDatabases() && !p.SessionData().CurrentCatalogOnly &&
+				string(tn.SchemaName) == informationSchemaName &&
+				p.RequireSuperUser(ctx, "access virtual tables across all databases") == nil {
+				catalog = ""
+			} else if catalog == "" && (p.SessionData().CurrentCatalogOnly ||
+				p.RequireSuperUser(ctx, "access virtual tables across all databases") != nil) {
 				catalog = sqlbase.SystemDB.Name
 			}
 		}
@@ -311,21 +346,95 @@ func (p *planner) getVirtualDataSource(
 		sourceName := tree.MakeTableNameWithCatalog(
 			tree.Name(catalog), tn.SchemaName, tree.Name(virtual.desc.Name))
 
+		info := newSourceInfoForSingleTable(sourceName, columns)
+		info.deprecationNotices = virtual.deprecationNotices
+
+		// The constraints of the filters on the key columns of the table, if
+		// any, are collected during filter propagation.
+		var constraints virtualConstraints
+		var addFilter func(*tree.EvalContext, tree.TypedExpr)
+		if keyColumns := virtual.tableDef.keyColumns; len(keyColumns) > 0 {
+			constraints = make(virtualConstraints)
+			addFilter = func(evalCtx *tree.EvalContext, filter tree.TypedExpr) {
+				constraints.addFilter(evalCtx, filter, columns, keyColumns)
+			}
+		}
+
 		// The resulting node.
 		return planDataSource{
-			info: newSourceInfoForSingleTable(sourceName, columns),
+			info: info,
 			plan: &delayedNode{
 				name:    sourceName.String(),
 				columns: columns,
 				constructor: func(ctx context.Context, p *planner) (planNode, error) {
-					return constructor(ctx, p, catalog)
+					return constructor(ctx, p, catalog, constraints)
 				},
+				addFilter: addFilter,
 			},
 		}, true, nil
 	}
 	return planDataSource{}, false, nil
 }
 
+// getVirtualFunctionDataSource attempts to find a virtual table
+// function with the given name. Table functions are only found when
+// called with their virtual schema as prefix, for example
+// crdb_internal.table_columns('t').
+func (p *planner) getVirtualFunctionDataSource(
+	ctx context.Context, t *tree.FuncExpr,
+) (planDataSource, bool, error) {
+	un, ok := t.Func.FunctionReference.(*tree.UnresolvedName)
+	if !ok || un.Star || un.NumParts != 2 {
+		return planDataSource{}, false, nil
+	}
+	funcName, schemaName := un.Parts[0], un.Parts[1]
+	virtual, ok := p.getVirtualTabler().getVirtualFunctionEntry(schemaName, funcName)
+	if !ok {
+		return planDataSource{}, false, nil
+	}
+
+	argTypes := virtual.funcDef.argTypes
+	if len(t.Exprs) != len(argTypes) {
+		return planDataSource{}, false, pgerror.NewErrorf(pgerror.CodeUndefinedFunctionError,
+			"%s.%s(): expected %d argument(s), got %d",
+			schemaName, funcName, len(argTypes), len(t.Exprs))
+	}
+	typingContext := fmt.Sprintf("%s.%s()", schemaName, funcName)
+	typedArgs := make([]tree.TypedExpr, len(t.Exprs))
+	for i, expr := range t.Exprs {
+		typedArg, err := p.analyzeExpr(
+			ctx, expr, nil, tree.IndexedVarHelper{}, argTypes[i], true /* requireType */, typingContext)
+		if err != nil {
+			return planDataSource{}, false, err
+		}
+		typedArgs[i] = typedArg
+	}
+
+	// Define the name of the source visible in EXPLAIN.
+	sourceName := tree.MakeTableName(tree.Name(schemaName), tree.Name(virtual.desc.Name))
+
+	columns, constructor := virtual.getPlanInfo(sourceName.String())
+
+	return planDataSource{
+		info: newSourceInfoForSingleTable(sourceName, columns),
+		plan: &delayedNode{
+			name:    sourceName.String(),
+			columns: columns,
+			constructor: func(ctx context.Context, p *planner) (planNode, error) {
+				args := make(tree.Datums, len(typedArgs))
+				for i, typedArg := range typedArgs {
+					arg, err := typedArg.Eval(p.EvalContext())
+					if err != nil {
+						return nil, err
+					}
+					args[i] = arg
+				}
+				return constructor(ctx, p, args)
+			},
+		},
+	}, true, nil
+}
+
 // getDataSourceAsOneColumn builds a planDataSource from a data source
 // clause and ensures that it returns one column. If the plan would
 // return zero or more than one column, the columns are grouped into
@@ -394,6 +503,14 @@ func (p *planner) getDataSource(
 		return p.getTableScanOrSequenceOrViewPlan(ctx, tn, hints, scanVisibility)
 
 	case *tree.FuncExpr:
+		// Check if this is a virtual table function.
+		ds, foundVirtual, err := p.getVirtualFunctionDataSource(ctx, t)
+		if err != nil {
+			return planDataSource{}, err
+		}
+		if foundVirtual {
+			return ds, nil
+		}
 		return p.getGeneratorPlan(ctx, t)
 
 	case *tree.Subquery:
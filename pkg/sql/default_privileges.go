@@ -0,0 +1,259 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+)
+
+// alterDefaultPrivilegesNode represents an ALTER DEFAULT PRIVILEGES
+// statement.
+type alterDefaultPrivilegesNode struct {
+	n *tree.AlterDefaultPrivileges
+	// roles and dbIDs are the resolved roles and databases of the statement.
+	roles []string
+	dbIDs []sqlbase.ID
+}
+
+// AlterDefaultPrivileges changes the privileges granted on the tables, views
+// and sequences that roles create in databases afterwards.
+// Privileges: GRANT on the databases; or CREATE on the databases, and
+// membership in the roles, or superuser, for other roles than the current
+// user.
+//   Notes: postgres requires membership in the roles.
+func (p *planner) AlterDefaultPrivileges(
+	ctx context.Context, n *tree.AlterDefaultPrivileges,
+) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(cluster.VersionRoleSettingsOptionsAndDefaultPrivileges) {
+		return nil, errors.New("cluster version does not support default privileges")
+	}
+
+	users, err := p.GetAllUsersAndRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, grantee := range n.Grantees {
+		if _, ok := users[string(grantee)]; !ok {
+			return nil, errors.Errorf("user or role %s does not exist", &grantee)
+		}
+	}
+
+	user := p.SessionData().User
+	node := &alterDefaultPrivilegesNode{n: n, roles: []string{user}}
+	if len(n.Roles) > 0 {
+		node.roles = n.Roles.ToStrings()
+		for _, role := range node.roles {
+			if _, ok := users[role]; !ok && role != user && role != security.RootUser {
+				return nil, errors.Errorf("user or role %s does not exist", role)
+			}
+		}
+	}
+
+	dbNames := n.Databases.ToStrings()
+	if len(dbNames) == 0 {
+		if p.SessionData().Database == "" {
+			return nil, errNoDatabase
+		}
+		dbNames = []string{p.SessionData().Database}
+	}
+	// Whoever can grant privileges on a database can grant them on the
+	// tables created in it afterwards, which get the privileges of the
+	// database. Otherwise, the default privileges can only be changed for
+	// the roles which can create tables in the database.
+	canGrant := true
+	for _, name := range dbNames {
+		if p.getVirtualTabler().isVirtualDatabase(name) {
+			return nil, errors.Errorf("cannot alter the default privileges of virtual database %q", name)
+		}
+		dbDesc, err := MustGetDatabaseDesc(ctx, p.txn, p.getVirtualTabler(), name)
+		if err != nil {
+			return nil, err
+		}
+		if p.CheckPrivilege(ctx, dbDesc, privilege.GRANT) != nil {
+			canGrant = false
+			if p.CheckPrivilege(ctx, dbDesc, privilege.CREATE) != nil {
+				return nil, errors.Errorf("user %s does not have CREATE or GRANT privilege on database %s",
+					user, dbDesc.Name)
+			}
+		}
+		node.dbIDs = append(node.dbIDs, dbDesc.ID)
+	}
+
+	if !canGrant {
+		var memberOf map[string]bool
+		for _, role := range node.roles {
+			if role == user {
+				continue
+			}
+			if memberOf == nil {
+				if memberOf, err = p.MemberOfWithAdminOption(ctx, user); err != nil {
+					return nil, err
+				}
+			}
+			if _, ok := memberOf[role]; ok {
+				continue
+			}
+			if err := p.RequireSuperUser(ctx, "alter the default privileges of other roles"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return node, nil
+}
+
+func (n *alterDefaultPrivilegesNode) startExec(params runParams) error {
+	for _, dbID := range n.dbIDs {
+		for _, role := range n.roles {
+			privs, err := getDefaultPrivileges(params.ctx, params.p.txn, params.p.ExecCfg(), dbID, role)
+			if err != nil {
+				return err
+			}
+			for _, grantee := range n.n.Grantees {
+				if n.n.IsGrant {
+					privs.Grant(string(grantee), n.n.Privileges)
+				} else {
+					privs.Revoke(string(grantee), n.n.Privileges)
+				}
+			}
+			if err := writeDefaultPrivileges(params, dbID, role, privs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (*alterDefaultPrivilegesNode) Next(runParams) (bool, error) { return false, nil }
+func (*alterDefaultPrivilegesNode) Values() tree.Datums          { return tree.Datums{} }
+func (*alterDefaultPrivilegesNode) Close(context.Context)        {}
+
+// getDefaultPrivileges returns the privileges granted on the tables created
+// by role in the database with the given ID, as stored in
+// system.default_privileges.
+func getDefaultPrivileges(
+	ctx context.Context, txn *client.Txn, execCfg *ExecutorConfig, dbID sqlbase.ID, role string,
+) (*sqlbase.PrivilegeDescriptor, error) {
+	internalExecutor := InternalExecutor{ExecCfg: execCfg}
+	rows, _ /* cols */, err := internalExecutor.QueryRowsInTransaction(
+		ctx,
+		"get-default-privileges",
+		txn,
+		`SELECT grantee, privileges FROM system.default_privileges WHERE database_id = $1 AND role = $2`,
+		dbID,
+		role,
+	)
+	if err != nil {
+		return nil, err
+	}
+	privs := &sqlbase.PrivilegeDescriptor{}
+	for _, row := range rows {
+		list, err := privilege.ListFromStrings(
+			strings.Split(string(tree.MustBeDString(row[1])), ","))
+		if err != nil {
+			return nil, err
+		}
+		privs.Grant(string(tree.MustBeDString(row[0])), list)
+	}
+	return privs, nil
+}
+
+// writeDefaultPrivileges replaces the privileges granted on the tables
+// created by role in the database with the given ID.
+func writeDefaultPrivileges(
+	params runParams, dbID sqlbase.ID, role string, privs *sqlbase.PrivilegeDescriptor,
+) error {
+	internalExecutor := InternalExecutor{ExecCfg: params.extendedEvalCtx.ExecCfg}
+	if _, err := internalExecutor.ExecuteStatementInTransaction(
+		params.ctx,
+		"delete-default-privileges",
+		params.p.txn,
+		`DELETE FROM system.default_privileges WHERE database_id = $1 AND role = $2`,
+		dbID,
+		role,
+	); err != nil {
+		return err
+	}
+	for _, u := range privs.Users {
+		if u.Privileges == 0 {
+			continue
+		}
+		if _, err := internalExecutor.ExecuteStatementInTransaction(
+			params.ctx,
+			"insert-default-privileges",
+			params.p.txn,
+			`INSERT INTO system.default_privileges (database_id, role, grantee, privileges) VALUES ($1, $2, $3, $4)`,
+			dbID,
+			role,
+			u.User,
+			privilege.ListFromBitField(u.Privileges).SortedString(),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyDefaultPrivileges grants on a new table, view or sequence the default
+// privileges of the current user in its database. There are none until the
+// whole cluster can read system.default_privileges.
+func (p *planner) applyDefaultPrivileges(ctx context.Context, desc *sqlbase.TableDescriptor) error {
+	if !p.ExecCfg().Settings.Version.IsActive(cluster.VersionRoleSettingsOptionsAndDefaultPrivileges) {
+		return nil
+	}
+	defaults, err := getDefaultPrivileges(ctx, p.txn, p.ExecCfg(), desc.ParentID, p.SessionData().User)
+	if err != nil {
+		return err
+	}
+	if len(defaults.Users) == 0 {
+		return nil
+	}
+	// The privileges of the new descriptor are those of its database, which
+	// must not change.
+	privs := protoutil.Clone(desc.Privileges).(*sqlbase.PrivilegeDescriptor)
+	for _, u := range defaults.Users {
+		privs.Grant(u.User, privilege.ListFromBitField(u.Privileges))
+	}
+	desc.Privileges = privs
+	return nil
+}
+
+// removeDefaultPrivileges deletes the default privileges configured in the
+// database with the given ID.
+func (p *planner) removeDefaultPrivileges(ctx context.Context, dbID sqlbase.ID) error {
+	if !p.ExecCfg().Settings.Version.IsActive(cluster.VersionRoleSettingsOptionsAndDefaultPrivileges) {
+		return nil
+	}
+	internalExecutor := InternalExecutor{ExecCfg: p.ExecCfg()}
+	_, err := internalExecutor.ExecuteStatementInTransaction(
+		ctx,
+		"delete-database-default-privileges",
+		p.txn,
+		`DELETE FROM system.default_privileges WHERE database_id = $1`,
+		dbID,
+	)
+	return err
+}
@@ -29,6 +29,11 @@ type delayedNode struct {
 	columns     sqlbase.ResultColumns
 	constructor nodeConstructor
 	plan        planNode
+
+	// addFilter, if set, is passed the filters applied to the rows of the
+	// node, which are propagated to it before it is constructed. The filters
+	// are still applied by the nodes above. Optional.
+	addFilter func(evalCtx *tree.EvalContext, filter tree.TypedExpr)
 }
 
 // delayedNode implements the autoCommitNode interface.
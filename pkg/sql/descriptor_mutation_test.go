@@ -1022,6 +1022,43 @@ CREATE TABLE t.test (k CHAR PRIMARY KEY, v CHAR UNIQUE);
 			t.Errorf("%d entry: state %s, expected %s", i, m.State, state)
 		}
 	}
+
+	// The mutations are listed in crdb_internal.schema_changes with the jobs
+	// executing them.
+	jobIDs := make(map[sqlbase.MutationID]int64)
+	for _, j := range tableDesc.MutationJobs {
+		jobIDs[j.MutationID] = j.JobID
+	}
+	rows, err := sqlDB.Query(`
+SELECT target_name, mutation_id, job_id FROM crdb_internal.schema_changes WHERE name = 'test'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var i int
+	for ; rows.Next(); i++ {
+		var name string
+		var id sqlbase.MutationID
+		var jobID gosql.NullInt64
+		if err := rows.Scan(&name, &id, &jobID); err != nil {
+			t.Fatal(err)
+		}
+		if i >= len(expected) {
+			continue
+		}
+		if e := expected[i]; name != e.name || id != e.id {
+			t.Errorf("%d row: mutation %s (%d), expected %s (%d)", i, name, id, e.name, e.id)
+		}
+		if expectedJobID, ok := jobIDs[id]; jobID.Valid != ok || jobID.Int64 != expectedJobID {
+			t.Errorf("%d row: job %v, expected %d (%t)", i, jobID, expectedJobID, ok)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if i != len(expected) {
+		t.Fatalf("%d rows in crdb_internal.schema_changes, instead of expected %d", i, len(expected))
+	}
 }
 
 // TestAddingFKs checks the behavior of a table in the non-public `ADD` state.
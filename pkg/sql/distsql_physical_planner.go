@@ -395,6 +395,13 @@ func (dsp *DistSQLPlanner) checkSupportForNode(node planNode) (distRecommendatio
 	case *createStatsNode:
 		return shouldDistribute, nil
 
+	case *virtualTableNode:
+		if n.nodeLocal == nil {
+			return 0, newQueryNotSupportedErrorf("unsupported node %T", node)
+		}
+		// The rows of the node-local tables are gathered from every node.
+		return shouldDistribute, nil
+
 	case *insertNode, *updateNode, *deleteNode:
 		// This is a potential hot path.
 		return 0, mutationsNotSupportedError
@@ -591,50 +598,7 @@ func (dsp *DistSQLPlanner) partitionSpans(
 				addr, inAddrMap := planCtx.nodeAddresses[nodeID]
 				if !inAddrMap {
 					addr = replInfo.NodeDesc.Address.String()
-					checkNodeHealth := func() error {
-						// Check if the node is still in gossip - i.e. if it hasn't been
-						// decommissioned or overridden by another node at the same address.
-						if _, err := dsp.gossip.GetNodeIDAddress(nodeID); err != nil {
-							log.VEventf(ctx, 1, "not using n%d because gossip doesn't know about it. "+
-								"It might have gone away from the cluster. Gossip said: %s.", nodeID, err)
-							return err
-						}
-
-						var err error
-						if dsp.testingKnobs.OverrideHealthCheck != nil {
-							err = dsp.testingKnobs.OverrideHealthCheck(replInfo.NodeDesc.NodeID, addr)
-						} else {
-							err = dsp.rpcContext.ConnHealth(addr)
-						}
-						if err != nil && err != rpc.ErrNotConnected && err != rpc.ErrNotHeartbeated {
-							// This host is known to be unhealthy. Don't use it (use the gateway
-							// instead). Note: this can never happen for our nodeID (which
-							// always has its address in the nodeMap).
-							log.VEventf(ctx, 1, "marking n%d as unhealthy for this plan: %v", nodeID, err)
-							return err
-						}
-
-						// Check that the node is not draining.
-						drainingInfo := &distsqlrun.DistSQLDrainingInfo{}
-						if err := dsp.gossip.GetInfoProto(gossip.MakeDistSQLDrainingKey(nodeID), drainingInfo); err != nil {
-							// Because draining info has no expiration, an error
-							// implies that we have not yet received a node's
-							// draining information. Since this information is
-							// written on startup, the most likely scenario is
-							// that the node is ready. We therefore return no
-							// error.
-							return nil
-						}
-
-						if drainingInfo.Draining {
-							errMsg := fmt.Sprintf("not using n%d because it is draining", nodeID)
-							log.VEvent(ctx, 1, errMsg)
-							return errors.New(errMsg)
-						}
-
-						return nil
-					}
-					if err := checkNodeHealth(); err != nil {
+					if err := dsp.checkNodeHealth(ctx, nodeID, addr); err != nil {
 						addr = ""
 					}
 					if err == nil && addr != "" {
@@ -691,6 +655,55 @@ func (dsp *DistSQLPlanner) partitionSpans(
 	return partitions, nil
 }
 
+// checkNodeHealth returns an error when the node, whose address is addr,
+// shouldn't be used in a plan: gossip doesn't know it anymore, its connection
+// is unhealthy or it is draining.
+func (dsp *DistSQLPlanner) checkNodeHealth(
+	ctx context.Context, nodeID roachpb.NodeID, addr string,
+) error {
+	// Check if the node is still in gossip - i.e. if it hasn't been
+	// decommissioned or overridden by another node at the same address.
+	if _, err := dsp.gossip.GetNodeIDAddress(nodeID); err != nil {
+		log.VEventf(ctx, 1, "not using n%d because gossip doesn't know about it. "+
+			"It might have gone away from the cluster. Gossip said: %s.", nodeID, err)
+		return err
+	}
+
+	var err error
+	if dsp.testingKnobs.OverrideHealthCheck != nil {
+		err = dsp.testingKnobs.OverrideHealthCheck(nodeID, addr)
+	} else {
+		err = dsp.rpcContext.ConnHealth(addr)
+	}
+	if err != nil && err != rpc.ErrNotConnected && err != rpc.ErrNotHeartbeated {
+		// This host is known to be unhealthy. Don't use it (use the gateway
+		// instead). Note: this can never happen for our nodeID (which
+		// always has its address in the nodeMap).
+		log.VEventf(ctx, 1, "marking n%d as unhealthy for this plan: %v", nodeID, err)
+		return err
+	}
+
+	// Check that the node is not draining.
+	drainingInfo := &distsqlrun.DistSQLDrainingInfo{}
+	if err := dsp.gossip.GetInfoProto(gossip.MakeDistSQLDrainingKey(nodeID), drainingInfo); err != nil {
+		// Because draining info has no expiration, an error
+		// implies that we have not yet received a node's
+		// draining information. Since this information is
+		// written on startup, the most likely scenario is
+		// that the node is ready. We therefore return no
+		// error.
+		return nil
+	}
+
+	if drainingInfo.Draining {
+		errMsg := fmt.Sprintf("not using n%d because it is draining", nodeID)
+		log.VEvent(ctx, 1, errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
 // nodeVersionIsCompatible decides whether a particular node's DistSQL version
 // is compatible with planVer. It uses gossip to find out the node's version
 // range.
@@ -709,6 +722,9 @@ func (dsp *DistSQLPlanner) nodeVersionIsCompatible(
 func initTableReaderSpec(
 	n *scanNode, evalCtx *tree.EvalContext,
 ) (distsqlrun.TableReaderSpec, distsqlrun.PostProcessSpec, error) {
+	if err := n.uncheckedSelectPrivilegeErr(); err != nil {
+		return distsqlrun.TableReaderSpec{}, distsqlrun.PostProcessSpec{}, err
+	}
 	s := distsqlrun.TableReaderSpec{
 		Table:   *n.desc,
 		Reverse: n.reverse,
@@ -1835,6 +1851,9 @@ func (dsp *DistSQLPlanner) createPlanForNode(
 	case *createStatsNode:
 		return dsp.createPlanForCreateStats(planCtx, n)
 
+	case *virtualTableNode:
+		return dsp.createPlanForVirtualTable(planCtx, n.nodeLocal)
+
 	default:
 		panic(fmt.Sprintf("unsupported node type %T", n))
 	}
@@ -0,0 +1,170 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/gossip"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/distsqlplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/distsqlrun"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// createPlanForVirtualTable plans a VirtualTableReader reading the node-local
+// virtual table of the spec on every node the processor can run on, so that
+// the rows of all the nodes are gathered in parallel. The other nodes are
+// listed as unreachable in the spec of the reader of the gateway.
+func (dsp *DistSQLPlanner) createPlanForVirtualTable(
+	planCtx *planningCtx, spec *distsqlrun.VirtualTableReaderSpec,
+) (physicalPlan, error) {
+	nodes, unreachable, err := dsp.virtualTableNodes(planCtx)
+	if err != nil {
+		return physicalPlan{}, err
+	}
+
+	var p physicalPlan
+	stageID := p.NewStageID()
+
+	p.ResultRouters = make([]distsqlplan.ProcessorIdx, len(nodes))
+	for i, nodeID := range nodes {
+		vr := &distsqlrun.VirtualTableReaderSpec{}
+		*vr = *spec
+		if nodeID == dsp.nodeDesc.NodeID {
+			vr.UnreachableNodes = unreachable
+		}
+
+		proc := distsqlplan.Processor{
+			Node: nodeID,
+			Spec: distsqlrun.ProcessorSpec{
+				Core:    distsqlrun.ProcessorCoreUnion{VirtualTableReader: vr},
+				Output:  []distsqlrun.OutputRouterSpec{{Type: distsqlrun.OutputRouterSpec_PASS_THROUGH}},
+				StageID: stageID,
+			},
+		}
+
+		pIdx := p.AddProcessor(proc)
+		p.ResultRouters[i] = pIdx
+	}
+	p.ResultTypes = spec.ColumnTypes
+	p.planToStreamColMap = identityMapInPlace(make([]int, len(spec.ColumnTypes)))
+	return p, nil
+}
+
+// virtualTableNodes returns the nodes a VirtualTableReader can be planned on,
+// starting with the gateway, and the other nodes known to gossip: those which
+// are not live or healthy, or which run a DistSQL version that is not
+// compatible with the plan.
+func (dsp *DistSQLPlanner) virtualTableNodes(
+	planCtx *planningCtx,
+) (nodes, unreachable []roachpb.NodeID, _ error) {
+	ctx := planCtx.ctx
+
+	livenesses := make(map[roachpb.NodeID]storage.Liveness)
+	if err := dsp.gossip.IterateInfos(gossip.KeyNodeLivenessPrefix, func(key string, i gossip.Info) error {
+		var l storage.Liveness
+		if err := i.Value.GetProto(&l); err != nil {
+			return errors.Wrapf(err, "failed to parse value for key %q", key)
+		}
+		livenesses[l.NodeID] = l
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	var descriptors []roachpb.NodeDescriptor
+	if err := dsp.gossip.IterateInfos(gossip.KeyNodeIDPrefix, func(key string, i gossip.Info) error {
+		var d roachpb.NodeDescriptor
+		if err := i.Value.GetProto(&d); err != nil {
+			return errors.Wrapf(err, "failed to parse value for key %q", key)
+		}
+		descriptors = append(descriptors, d)
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(descriptors, func(i, j int) bool {
+		return descriptors[i].NodeID < descriptors[j].NodeID
+	})
+
+	clock := dsp.rpcContext.LocalClock
+	now, maxOffset := clock.Now(), clock.MaxOffset()
+	nodes = []roachpb.NodeID{dsp.nodeDesc.NodeID}
+	for _, d := range descriptors {
+		nodeID := d.NodeID
+		if nodeID == dsp.nodeDesc.NodeID {
+			continue
+		}
+		if l, ok := livenesses[nodeID]; !ok || !l.IsLive(now, maxOffset) {
+			log.VEventf(ctx, 1, "not using n%d because it is not live", nodeID)
+			unreachable = append(unreachable, nodeID)
+			continue
+		}
+		addr := d.Address.String()
+		if err := dsp.checkNodeHealth(ctx, nodeID, addr); err != nil {
+			unreachable = append(unreachable, nodeID)
+			continue
+		}
+		if !dsp.nodeVersionIsCompatible(nodeID, dsp.planVersion) {
+			log.VEventf(ctx, 1, "not using n%d because of its incompatible DistSQL version", nodeID)
+			unreachable = append(unreachable, nodeID)
+			continue
+		}
+		planCtx.nodeAddresses[nodeID] = addr
+		nodes = append(nodes, nodeID)
+	}
+	return nodes, unreachable, nil
+}
+
+// gatherNodeLocalRows reads the rows of the node-local virtual table of the
+// spec on every node, by running the plan of createPlanForVirtualTable, and
+// passes them to addRow. It is used to populate the virtual tables gathering
+// these rows when they are not part of a distributed plan.
+func (p *planner) gatherNodeLocalRows(
+	ctx context.Context,
+	spec *distsqlrun.VirtualTableReaderSpec,
+	addRow func(...tree.Datum) error,
+) error {
+	dsp := p.ExecCfg().DistSQLPlanner
+	planCtx := dsp.newPlanningCtx(ctx, &p.extendedEvalCtx, p.txn)
+	plan, err := dsp.createPlanForVirtualTable(&planCtx, spec)
+	if err != nil {
+		return err
+	}
+	dsp.FinalizePlan(&planCtx, &plan)
+
+	rowResultWriter := newCallbackResultWriter(func(ctx context.Context, row tree.Datums) error {
+		// The receiver reuses the row, which the consumers of addRow may
+		// hold on to.
+		return addRow(append(tree.Datums(nil), row...)...)
+	})
+	recv := makeDistSQLReceiver(
+		ctx,
+		&rowResultWriter,
+		tree.Rows,
+		nil, /* rangeCache */
+		nil, /* leaseCache */
+		nil, /* txn - the flow does not read or write the database */
+		func(ts hlc.Timestamp) {},
+	)
+	dsp.Run(&planCtx, p.txn, &plan, recv, &p.extendedEvalCtx)
+	return rowResultWriter.Err()
+}
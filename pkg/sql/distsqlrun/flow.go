@@ -94,6 +94,9 @@ type FlowCtx struct {
 
 	// JobRegistry is used during backfill to load jobs which keep state.
 	JobRegistry *jobs.Registry
+
+	// virtualTables produces the rows read by virtualTableReaders.
+	virtualTables VirtualTableRowSource
 }
 
 // NewEvalCtx returns a modifiable copy of the FlowCtx's EvalContext.
@@ -380,6 +380,11 @@ func (post *PostProcessSpec) summaryWithPrefix(prefix string) []string {
 	return res
 }
 
+// summary implements the diagramCellType interface.
+func (v *VirtualTableReaderSpec) summary() (string, []string) {
+	return "VirtualTableReader", []string{v.Table}
+}
+
 // summary implements the diagramCellType interface.
 func (c *ReadCSVSpec) summary() (string, []string) {
 	return "ReadCSV", c.Uri
@@ -666,6 +666,12 @@ func newProcessor(
 		}
 		return newSampleAggregator(flowCtx, core.SampleAggregator, inputs[0], post, outputs[0])
 	}
+	if core.VirtualTableReader != nil {
+		if err := checkNumInOut(inputs, outputs, 0, 1); err != nil {
+			return nil, err
+		}
+		return newVirtualTableReader(flowCtx, core.VirtualTableReader, post, outputs[0])
+	}
 	if core.ReadCSV != nil {
 		if err := checkNumInOut(inputs, outputs, 0, 1); err != nil {
 			return nil, err
@@ -73,7 +73,7 @@ type DistSQLVersion uint32
 //
 // ATTENTION: When updating these fields, add to version_history.txt explaining
 // what changed.
-const Version DistSQLVersion = 8
+const Version DistSQLVersion = 9
 
 // MinAcceptedVersion is the oldest version that the server is
 // compatible with; see above.
@@ -147,6 +147,10 @@ type ServerConfig struct {
 	// A handle to gossip used to broadcast the node's DistSQL version and
 	// draining state.
 	Gossip *gossip.Gossip
+
+	// VirtualTables produces the rows of the node-local virtual tables read by
+	// VirtualTableReader processors.
+	VirtualTables VirtualTableRowSource
 }
 
 // ServerImpl implements the server for the distributed SQL APIs.
@@ -349,6 +353,7 @@ func (ds *ServerImpl) setupFlow(
 		TempStorage:    ds.TempStorage,
 		diskMonitor:    ds.DiskMonitor,
 		JobRegistry:    ds.ServerConfig.JobRegistry,
+		virtualTables:  ds.VirtualTables,
 	}
 
 	ctx = flowCtx.AnnotateCtx(ctx)
@@ -0,0 +1,101 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package distsqlrun
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+)
+
+// VirtualTableRowSource produces the rows of the virtual tables which are local
+// to a node. It is implemented by the sql package, which can't be imported from
+// here.
+type VirtualTableRowSource interface {
+	// NodeLocalRows calls addRow with each row of the table named by the spec.
+	// An error returned by addRow stops the iteration and is returned.
+	NodeLocalRows(
+		ctx context.Context, spec *VirtualTableReaderSpec, addRow func(tree.Datums) error,
+	) error
+}
+
+// virtualTableReader is a processor that has no inputs and outputs the rows of
+// a virtual table as seen by the node it runs on.
+type virtualTableReader struct {
+	processorBase
+
+	spec *VirtualTableReaderSpec
+}
+
+var _ Processor = &virtualTableReader{}
+
+// errVirtualTableReaderDone is used to stop the iteration over the rows of the
+// table once the consumer doesn't need any more of them.
+var errVirtualTableReaderDone = errors.New("virtual table reader done")
+
+func newVirtualTableReader(
+	flowCtx *FlowCtx, spec *VirtualTableReaderSpec, post *PostProcessSpec, output RowReceiver,
+) (*virtualTableReader, error) {
+	if flowCtx.virtualTables == nil {
+		return nil, errors.Errorf("virtual table %s unavailable", spec.Table)
+	}
+	v := &virtualTableReader{spec: spec}
+	if err := v.init(post, spec.ColumnTypes, flowCtx, nil /* evalCtx */, output); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Run is part of the processor interface.
+func (v *virtualTableReader) Run(wg *sync.WaitGroup) {
+	if wg != nil {
+		defer wg.Done()
+	}
+	ctx := log.WithLogTag(v.flowCtx.Ctx, "VirtualTableReader", v.spec.Table)
+	ctx, span := processorSpan(ctx, "virtual table reader")
+	defer tracing.FinishSpan(span)
+
+	row := make(sqlbase.EncDatumRow, len(v.spec.ColumnTypes))
+	err := v.flowCtx.virtualTables.NodeLocalRows(ctx, v.spec, func(datums tree.Datums) error {
+		if len(datums) != len(row) {
+			return errors.Errorf(
+				"%s: expected %d columns, got %d", v.spec.Table, len(row), len(datums),
+			)
+		}
+		for i, d := range datums {
+			row[i] = sqlbase.DatumToEncDatum(v.spec.ColumnTypes[i], d)
+		}
+		status, err := v.out.EmitRow(ctx, row)
+		if err != nil {
+			return err
+		}
+		if status != NeedMoreRows {
+			return errVirtualTableReaderDone
+		}
+		return nil
+	})
+	if err != nil && err != errVirtualTableReaderDone {
+		DrainAndClose(ctx, v.out.output, err)
+		return
+	}
+	sendTraceData(ctx, v.out.output)
+	v.out.Close()
+}
@@ -0,0 +1,162 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package distsqlrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+// intVirtualTable is a VirtualTableRowSource producing the rows (i, i*10) for i
+// in [0, numRows), and then failing with err, if set.
+type intVirtualTable struct {
+	numRows int
+	err     error
+	// produced is the number of rows handed out.
+	produced int
+}
+
+var _ VirtualTableRowSource = &intVirtualTable{}
+
+// NodeLocalRows is part of the VirtualTableRowSource interface.
+func (t *intVirtualTable) NodeLocalRows(
+	_ context.Context, _ *VirtualTableReaderSpec, addRow func(tree.Datums) error,
+) error {
+	for i := 0; i < t.numRows; i++ {
+		t.produced++
+		if err := addRow(tree.Datums{tree.NewDInt(tree.DInt(i)), tree.NewDInt(tree.DInt(i * 10))}); err != nil {
+			return err
+		}
+	}
+	return t.err
+}
+
+func TestVirtualTableReader(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		numRows  int
+		post     PostProcessSpec
+		err      error
+		expected string
+		produced int
+		// expectedErr is the regexp the error sent to the consumer must match.
+		expectedErr string
+	}{
+		{
+			numRows:  3,
+			expected: "[[0 0] [1 10] [2 20]]",
+			produced: 3,
+		},
+		{
+			numRows:  0,
+			expected: "[]",
+		},
+		{
+			numRows:  3,
+			post:     PostProcessSpec{Projection: true, OutputColumns: []uint32{1}},
+			expected: "[[0] [10] [20]]",
+			produced: 3,
+		},
+		{
+			// The table stops being read once the limit is hit.
+			numRows:  10,
+			post:     PostProcessSpec{Limit: 2},
+			expected: "[[0 0] [1 10]]",
+			produced: 2,
+		},
+		{
+			numRows:     2,
+			err:         errors.New("node unavailable"),
+			expected:    "[[0 0] [1 10]]",
+			produced:    2,
+			expectedErr: "node unavailable",
+		},
+	}
+
+	for _, c := range testCases {
+		t.Run("", func(t *testing.T) {
+			vt := &intVirtualTable{numRows: c.numRows, err: c.err}
+			flowCtx := FlowCtx{
+				Ctx:           context.Background(),
+				Settings:      cluster.MakeTestingClusterSettings(),
+				virtualTables: vt,
+			}
+			spec := VirtualTableReaderSpec{
+				Table:       "crdb_internal.test",
+				ColumnTypes: twoIntCols,
+			}
+			out := &RowBuffer{}
+			v, err := newVirtualTableReader(&flowCtx, &spec, &c.post, out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v.Run(nil)
+			if !out.ProducerClosed {
+				t.Fatalf("output RowReceiver not closed")
+			}
+
+			var res sqlbase.EncDatumRows
+			var resErr error
+			for {
+				row, meta := out.Next()
+				if meta != nil {
+					if meta.Err == nil {
+						t.Fatalf("unexpected metadata: %v", meta)
+					}
+					resErr = meta.Err
+					continue
+				}
+				if row == nil {
+					break
+				}
+				res = append(res, row)
+			}
+
+			if !testutils.IsError(resErr, c.expectedErr) {
+				t.Fatalf("expected error %q, got %v", c.expectedErr, resErr)
+			}
+			if result := res.String(v.OutputTypes()); result != c.expected {
+				t.Errorf("expected %s, got %s", c.expected, result)
+			}
+			if vt.produced != c.produced {
+				t.Errorf("expected %d rows to be produced, got %d", c.produced, vt.produced)
+			}
+		})
+	}
+}
+
+func TestVirtualTableReaderUnavailable(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	flowCtx := FlowCtx{
+		Ctx:      context.Background(),
+		Settings: cluster.MakeTestingClusterSettings(),
+	}
+	spec := VirtualTableReaderSpec{Table: "crdb_internal.test", ColumnTypes: twoIntCols}
+	if _, err := newVirtualTableReader(
+		&flowCtx, &spec, &PostProcessSpec{}, &RowBuffer{},
+	); !testutils.IsError(err, "virtual table crdb_internal.test unavailable") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
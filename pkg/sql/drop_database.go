@@ -155,6 +155,16 @@ func (n *dropDatabaseNode) startExec(params runParams) error {
 		return err
 	}
 
+	if err := p.removeComment(ctx, databaseCommentType, n.dbDesc.ID, 0 /* subID */); err != nil {
+		return err
+	}
+	if err := p.removeDefaultPrivileges(ctx, n.dbDesc.ID); err != nil {
+		return err
+	}
+	if err := p.removeDatabaseSettings(ctx, n.dbDesc.ID); err != nil {
+		return err
+	}
+
 	// Log Drop Database event. This is an auditable log event and is recorded
 	// in the same transaction as the table descriptor update.
 	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
@@ -213,5 +223,11 @@ func (p *planner) accumulateDependentTables(
 			return err
 		}
 	}
+	// Sequences owned by the table's columns are dropped along with it.
+	for _, col := range desc.Columns {
+		for _, sequenceID := range col.OwnsSequenceIds {
+			dependentTables[sequenceID] = true
+		}
+	}
 	return nil
 }
@@ -212,6 +212,9 @@ func (p *planner) dropIndexByName(
 	if !found {
 		return fmt.Errorf("index %q in the middle of being added, try again later", idxName)
 	}
+	if err := p.removeComment(ctx, indexCommentType, tableDesc.ID, int64(idx.ID)); err != nil {
+		return err
+	}
 
 	if err := tableDesc.Validate(ctx, p.txn); err != nil {
 		return err
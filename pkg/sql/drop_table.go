@@ -95,6 +95,11 @@ func (p *planner) DropTable(ctx context.Context, n *tree.DropTable) (planNode, e
 				}
 			}
 		}
+		for i := range droppedDesc.Columns {
+			if err := p.canRemoveOwnedSequences(ctx, &droppedDesc.Columns[i], dropping); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	if len(td) == 0 {
@@ -245,7 +250,8 @@ func (p *planner) removeInterleave(ctx context.Context, ref sqlbase.ForeignKeyRe
 
 // dropTableImpl does the work of dropping a table (and everything that depends
 // on it if `cascade` is enabled). It returns a list of view names that were
-// dropped due to `cascade` behavior.
+// dropped due to `cascade` behavior, along with the names of the sequences
+// owned by the table's columns.
 func (p *planner) dropTableImpl(
 	params runParams, tableDesc *sqlbase.TableDescriptor,
 ) ([]string, error) {
@@ -285,6 +291,15 @@ func (p *planner) dropTableImpl(
 		}
 	}
 
+	// Drop the sequences owned by the table's columns.
+	for i := range tableDesc.Columns {
+		droppedSequences, err := p.dropSequencesOwnedByCol(ctx, &tableDesc.Columns[i])
+		if err != nil {
+			return droppedViews, err
+		}
+		droppedViews = append(droppedViews, droppedSequences...)
+	}
+
 	// Drop all views that depend on this table, assuming that we wouldn't have
 	// made it to this point if `cascade` wasn't enabled.
 	for _, ref := range tableDesc.DependedOnBy {
@@ -306,6 +321,11 @@ func (p *planner) dropTableImpl(
 		droppedViews = append(droppedViews, viewDesc.Name)
 	}
 
+	// Remove the comments on the table and its columns and indexes.
+	if err := p.removeTableComments(ctx, tableDesc.ID); err != nil {
+		return droppedViews, err
+	}
+
 	err := p.initiateDropTable(ctx, tableDesc, true /* drain name */)
 	return droppedViews, err
 }
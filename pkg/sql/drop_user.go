@@ -20,6 +20,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -33,12 +34,15 @@ type DropUserNode struct {
 	ifExists bool
 	isRole   bool
 	names    func() ([]string, error)
+	// createRoleOnly is set if the user can only drop users and roles
+	// through the CREATEROLE option.
+	createRoleOnly bool
 
 	run dropUserRun
 }
 
 // DropUser drops a list of users.
-// Privileges: DELETE on system.users.
+// Privileges: DELETE on system.users, or the CREATEROLE option.
 func (p *planner) DropUser(ctx context.Context, n *tree.DropUser) (planNode, error) {
 	return p.DropUserNode(ctx, n.Names, n.IfExists, false /* isRole */, "DROP USER")
 }
@@ -47,24 +51,21 @@ func (p *planner) DropUser(ctx context.Context, n *tree.DropUser) (planNode, err
 func (p *planner) DropUserNode(
 	ctx context.Context, namesE tree.Exprs, ifExists bool, isRole bool, opName string,
 ) (*DropUserNode, error) {
-	tDesc, err := getTableDesc(ctx, p.txn, p.getVirtualTabler(), userTableName)
+	createRoleOnly, err := p.checkCreateRolePrivilege(ctx, privilege.DELETE)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := p.CheckPrivilege(ctx, tDesc, privilege.DELETE); err != nil {
-		return nil, err
-	}
-
 	names, err := p.TypeAsStringArray(namesE, opName)
 	if err != nil {
 		return nil, err
 	}
 
 	return &DropUserNode{
-		ifExists: ifExists,
-		isRole:   isRole,
-		names:    names,
+		ifExists:       ifExists,
+		isRole:         isRole,
+		names:          names,
+		createRoleOnly: createRoleOnly,
 	}, nil
 }
 
@@ -115,15 +116,14 @@ func (n *DropUserNode) startExec(params runParams) error {
 	}
 	if err := forEachTableDescAll(params.ctx, params.p, "",
 		func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
-			for _, u := range table.GetPrivileges().Users {
-				if _, ok := userNames[u.User]; ok {
-					if f.Len() > 0 {
-						f.WriteString(", ")
-					}
-					tn := tree.MakeTableName(tree.Name(db.Name), tree.Name(table.Name))
-					f.FormatNode(&tn)
-				}
+			if !hasPrivilegesFor(table, userNames) {
+				return nil
 			}
+			if f.Len() > 0 {
+				f.WriteString(", ")
+			}
+			tn := tree.MakeTableName(tree.Name(db.Name), tree.Name(table.Name))
+			f.FormatNode(&tn)
 			return nil
 		}); err != nil {
 		return err
@@ -154,6 +154,11 @@ func (n *DropUserNode) startExec(params runParams) error {
 		if normalizedUsername == security.RootUser {
 			return errors.Errorf("user %s cannot be dropped", security.RootUser)
 		}
+		if n.createRoleOnly {
+			if err := params.p.checkCreateRoleTarget(params.ctx, normalizedUsername); err != nil {
+				return err
+			}
+		}
 
 		internalExecutor := InternalExecutor{ExecCfg: params.extendedEvalCtx.ExecCfg}
 		rowsAffected, err := internalExecutor.ExecuteStatementInTransaction(
@@ -184,6 +189,47 @@ func (n *DropUserNode) startExec(params runParams) error {
 		if err != nil {
 			return err
 		}
+
+		// Drop the default session variables of the user/role.
+		params.p.invalidateRoleSettingsOnCommit(params.ctx)
+		_, err = internalExecutor.ExecuteStatementInTransaction(
+			params.ctx,
+			"drop-role-settings",
+			params.p.txn,
+			`DELETE FROM system.role_settings WHERE role = $1`,
+			normalizedUsername,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Drop the options of the user/role.
+		_, err = internalExecutor.ExecuteStatementInTransaction(
+			params.ctx,
+			"drop-role-options",
+			params.p.txn,
+			`DELETE FROM system.role_options WHERE username = $1`,
+			normalizedUsername,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Drop the default privileges of the user/role, and those granted to
+		// it. There are none until the whole cluster can read
+		// system.default_privileges.
+		if params.p.ExecCfg().Settings.Version.IsActive(cluster.VersionRoleSettingsOptionsAndDefaultPrivileges) {
+			_, err = internalExecutor.ExecuteStatementInTransaction(
+				params.ctx,
+				"drop-default-privileges",
+				params.p.txn,
+				`DELETE FROM system.default_privileges WHERE role = $1 OR grantee = $1`,
+				normalizedUsername,
+			)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	n.run.numDeleted = numDeleted
@@ -202,3 +248,22 @@ func (*DropUserNode) Close(context.Context) {}
 
 // FastPathResults implements the planNodeFastPath interface.
 func (n *DropUserNode) FastPathResults() (int, bool) { return n.run.numDeleted, true }
+
+// hasPrivilegesFor returns whether any of the users holds privileges on the
+// table, or on one of its columns.
+func hasPrivilegesFor(table *sqlbase.TableDescriptor, userNames map[string]struct{}) bool {
+	privs := []*sqlbase.PrivilegeDescriptor{table.GetPrivileges()}
+	for i := range table.Columns {
+		if table.Columns[i].Privileges != nil {
+			privs = append(privs, table.Columns[i].Privileges)
+		}
+	}
+	for _, p := range privs {
+		for _, u := range p.Users {
+			if _, ok := userNames[u.User]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -220,6 +220,10 @@ func (p *planner) dropViewImpl(
 		}
 	}
 
+	if err := p.removeTableComments(ctx, viewDesc.ID); err != nil {
+		return cascadeDroppedViews, err
+	}
+
 	if err := p.initiateDropTable(ctx, viewDesc, true /* drainName */); err != nil {
 		return cascadeDroppedViews, err
 	}
@@ -62,6 +62,19 @@ const (
 	// EventLogAlterSequence is recorded when a sequence is altered.
 	EventLogAlterSequence EventLogType = "alter_sequence"
 
+	// EventLogCommentOnDatabase is recorded when the comment on a database is
+	// added, changed or removed.
+	EventLogCommentOnDatabase EventLogType = "comment_on_database"
+	// EventLogCommentOnTable is recorded when the comment on a table or view
+	// is added, changed or removed.
+	EventLogCommentOnTable EventLogType = "comment_on_table"
+	// EventLogCommentOnColumn is recorded when the comment on a column is
+	// added, changed or removed.
+	EventLogCommentOnColumn EventLogType = "comment_on_column"
+	// EventLogCommentOnIndex is recorded when the comment on an index is
+	// added, changed or removed.
+	EventLogCommentOnIndex EventLogType = "comment_on_index"
+
 	// EventLogReverseSchemaChange is recorded when an in-progress schema change
 	// encounters a problem and is reversed.
 	EventLogReverseSchemaChange EventLogType = "reverse_schema_change"
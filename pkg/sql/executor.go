@@ -35,6 +35,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/rpc"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/server/status"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/distsqlplan"
@@ -226,12 +228,6 @@ type Executor struct {
 
 	// Application-level SQL statistics
 	sqlStats sqlStats
-
-	// Attempts to use unimplemented features.
-	unimplementedErrors struct {
-		syncutil.Mutex
-		counts map[string]int64
-	}
 }
 
 // NodeInfo contains metadata about the executing node and cluster.
@@ -262,6 +258,10 @@ type ExecutorConfig struct {
 	JobRegistry     *jobs.Registry
 	VirtualSchemas  *VirtualSchemaHolder
 	DistSQLPlanner  *DistSQLPlanner
+	// MetricsRecorder holds the registries of the metrics of the node and
+	// its stores, which crdb_internal.node_metrics exposes, and the start
+	// time of the node.
+	MetricsRecorder *status.MetricsRecorder
 
 	TestingKnobs              *ExecutorTestingKnobs
 	SchemaChangerTestingKnobs *SchemaChangerTestingKnobs
@@ -272,6 +272,10 @@ type ExecutorConfig struct {
 	// Caches updated by DistSQL.
 	RangeDescriptorCache *kv.RangeDescriptorCache
 	LeaseHolderCache     *kv.LeaseHolderCache
+
+	// roleSettings caches the default session variables of the users and
+	// databases. It is set by NewExecutor.
+	roleSettings *roleSettingsCache
 }
 
 // Organization returns the value of cluster.organization.
@@ -334,6 +338,10 @@ type ExecutorTestingKnobs struct {
 	// optimization). This is only called when the Executor is the one doing the
 	// committing.
 	BeforeAutoCommit func(ctx context.Context, stmt string) error
+
+	// VisitVirtualTableDesc, if set, is called for each table descriptor that
+	// the virtual tables populated from the descriptors produce rows for.
+	VisitVirtualTableDesc func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor)
 }
 
 // DistSQLPlannerTestingKnobs is used to control internals of the DistSQLPlanner
@@ -347,6 +355,7 @@ type DistSQLPlannerTestingKnobs struct {
 // NewExecutor creates an Executor and registers a callback on the
 // system config.
 func NewExecutor(cfg ExecutorConfig, stopper *stop.Stopper) *Executor {
+	cfg.roleSettings = &roleSettingsCache{}
 	return &Executor{
 		cfg:     cfg,
 		stopper: stopper,
@@ -375,7 +384,9 @@ func NewExecutor(cfg ExecutorConfig, stopper *stop.Stopper) *Executor {
 		DdlCount:    metric.NewCounter(MetaDdl),
 		MiscCount:   metric.NewCounter(MetaMisc),
 		QueryCount:  metric.NewCounter(MetaQuery),
-		sqlStats:    sqlStats{st: cfg.Settings, apps: make(map[string]*appStats)},
+		sqlStats: sqlStats{
+			st: cfg.Settings, apps: make(map[string]*appStats), tables: newTableActivityStats(),
+		},
 		// The cache will be updated on Start() through gossip.
 		dbCache: newDatabaseCacheHolder(newDatabaseCache(config.SystemConfig{})),
 	}
@@ -386,6 +397,10 @@ func (e *Executor) Start(ctx context.Context, dsp *DistSQLPlanner) {
 	ctx = e.AnnotateCtx(ctx)
 	e.distSQLPlanner = dsp
 
+	e.cfg.Gossip.RegisterCallback(gossip.KeyRoleSettings, func(string, roachpb.Value) {
+		e.cfg.roleSettings.invalidate()
+	})
+
 	gossipUpdateC := e.cfg.Gossip.RegisterSystemConfigChannel()
 	e.stopper.RunWorker(ctx, func(ctx context.Context) {
 		for {
@@ -709,8 +724,9 @@ func (e *Executor) RecordError(err error) {
 		return
 	}
 	if pgErr, ok := pgerror.GetPGCause(err); ok {
-		if pgErr.Code == pgerror.CodeFeatureNotSupportedError {
-			e.recordUnimplementedFeature(pgErr.InternalCommand)
+		telemetry.Count(telemetry.ErrorCodeCounter(pgErr.Code))
+		if pgErr.Code == pgerror.CodeFeatureNotSupportedError && pgErr.InternalCommand != "" {
+			telemetry.Count(telemetry.UnimplementedCounter(pgErr.InternalCommand))
 		}
 	}
 }
@@ -2189,6 +2205,7 @@ func (e *Executor) execStmtInParallel(
 // statements have been received by this node.
 func (e *Executor) updateStmtCounts(stmt Statement) {
 	e.QueryCount.Inc(1)
+	telemetry.Count(telemetry.StatementCounter(stmt.AST.StatementTag()))
 	switch stmt.AST.(type) {
 	case *tree.BeginTransaction:
 		e.TxnBeginCount.Inc(1)
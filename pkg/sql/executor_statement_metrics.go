@@ -137,6 +137,9 @@ func recordStatementSummary(
 		stmt, distSQLUsed, automaticRetryCount, numRows, err,
 		parseLat, planLat, runLat, svcLat, execOverhead,
 	)
+	if sqlStats := planner.statsCollector.SQLStats(); sqlStats != nil && err == nil {
+		sqlStats.tables.recordPlan(planner.EvalContext().Ctx(), planner.txn, planner.curPlan.plan)
+	}
 
 	if log.V(2) {
 		// ages since significant epochs
@@ -222,6 +222,9 @@ func doExpandPlan(
 		}
 		plan = newPlan
 
+	case *setSessionVarsNode:
+		n.source, err = doExpandPlan(ctx, p, noParams, n.source)
+
 	case *splitNode:
 		n.rows, err = doExpandPlan(ctx, p, noParams, n.rows)
 
@@ -229,11 +232,19 @@ func doExpandPlan(
 		n.rows, err = doExpandPlan(ctx, p, noParams, n.rows)
 
 	case *valuesNode:
+	case *virtualTableNode:
 	case *alterIndexNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *alterRoleSetNode:
+	case *alterRoleOptionsNode:
+	case *alterDefaultPrivilegesNode:
 	case *alterUserSetPasswordNode:
 	case *cancelQueryNode:
+	case *commentOnColumnNode:
+	case *commentOnDatabaseNode:
+	case *commentOnIndexNode:
+	case *commentOnTableNode:
 	case *scrubNode:
 	case *controlJobNode:
 	case *createDatabaseNode:
@@ -256,7 +267,6 @@ func doExpandPlan(
 	case *setVarNode:
 	case *setClusterSettingNode:
 	case *setZoneConfigNode:
-	case *showZoneConfigNode:
 	case *showRangesNode:
 	case *showFingerprintsNode:
 	case *scatterNode:
@@ -610,6 +620,9 @@ func (p *planner) simplifyOrderings(plan planNode, usefulOrdering sqlbase.Column
 	case *delayedNode:
 		n.plan = p.simplifyOrderings(n.plan, usefulOrdering)
 
+	case *setSessionVarsNode:
+		n.source = p.simplifyOrderings(n.source, nil)
+
 	case *splitNode:
 		n.rows = p.simplifyOrderings(n.rows, nil)
 
@@ -617,11 +630,19 @@ func (p *planner) simplifyOrderings(plan planNode, usefulOrdering sqlbase.Column
 		n.rows = p.simplifyOrderings(n.rows, nil)
 
 	case *valuesNode:
+	case *virtualTableNode:
 	case *alterIndexNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *alterRoleSetNode:
+	case *alterRoleOptionsNode:
+	case *alterDefaultPrivilegesNode:
 	case *alterUserSetPasswordNode:
 	case *cancelQueryNode:
+	case *commentOnColumnNode:
+	case *commentOnDatabaseNode:
+	case *commentOnIndexNode:
+	case *commentOnTableNode:
 	case *scrubNode:
 	case *controlJobNode:
 	case *createDatabaseNode:
@@ -644,7 +665,6 @@ func (p *planner) simplifyOrderings(plan planNode, usefulOrdering sqlbase.Column
 	case *setVarNode:
 	case *setClusterSettingNode:
 	case *setZoneConfigNode:
-	case *showZoneConfigNode:
 	case *showRangesNode:
 	case *showFingerprintsNode:
 	case *scatterNode:
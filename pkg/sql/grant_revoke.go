@@ -16,9 +16,12 @@ package sql
 
 import (
 	"context"
+	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/pkg/errors"
 )
@@ -29,34 +32,225 @@ import (
 // TODO(marc): open questions:
 // - should we have root always allowed and not present in the permissions list?
 // - should we make users case-insensitive?
-// Privileges: GRANT on database/table/view.
+// Privileges: GRANT on database/table/view, or the granted privileges WITH
+// GRANT OPTION.
 //   Notes: postgres requires the object owner.
 //          mysql requires the "grant option" and the same privileges, and sometimes superuser.
 func (p *planner) Grant(ctx context.Context, n *tree.Grant) (planNode, error) {
-	return p.changePrivileges(ctx, n.Targets, n.Grantees, func(privDesc *sqlbase.PrivilegeDescriptor, grantee string) {
-		privDesc.Grant(grantee, n.Privileges)
+	privs, err := privilegesForColumns(n.Privileges, n.Columns)
+	if err != nil {
+		return nil, err
+	}
+	grantor := p.SessionData().User
+	if n.Until == nil {
+		return p.changePrivileges(ctx, n.Targets, n.Columns, n.Grantees, privs, false /* revoke */, func(privDesc *sqlbase.PrivilegeDescriptor, grantee string) {
+			privDesc.Grant(grantee, privs)
+			privDesc.SetGrantor(grantee, privs, grantor)
+			if n.WithGrantOption {
+				privDesc.SetGrantOption(grantee, privs)
+			}
+		})
+	}
+
+	expiresAt, err := p.evalGrantUntil(ctx, n.Until)
+	if err != nil {
+		return nil, err
+	}
+	return p.changePrivileges(ctx, n.Targets, n.Columns, n.Grantees, privs, false /* revoke */, func(privDesc *sqlbase.PrivilegeDescriptor, grantee string) {
+		privDesc.GrantUntil(grantee, privs, expiresAt)
+		privDesc.SetGrantor(grantee, privs, grantor)
+		if n.WithGrantOption {
+			privDesc.SetGrantOption(grantee, privs)
+		}
 	})
 }
 
+// privilegesForColumns returns the privileges to grant or revoke. When
+// columns are specified, only the privileges that can be granted on columns
+// are allowed, and ALL stands for all of them.
+func privilegesForColumns(privs privilege.List, columns tree.NameList) (privilege.List, error) {
+	if len(columns) == 0 {
+		return privs, nil
+	}
+	allowed := sqlbase.ColumnPrivileges.ToBitField()
+	for _, priv := range privs {
+		if priv == privilege.ALL {
+			return sqlbase.ColumnPrivileges, nil
+		}
+		if allowed&priv.Mask() == 0 {
+			return nil, pgerror.NewErrorf(pgerror.CodeInvalidGrantOperationError,
+				"invalid privilege type %s for column", priv)
+		}
+	}
+	return privs, nil
+}
+
+// evalGrantUntil evaluates the expiration time of a GRANT ... UNTIL
+// statement.
+func (p *planner) evalGrantUntil(ctx context.Context, until tree.Expr) (time.Time, error) {
+	typedUntil, err := p.analyzeExpr(
+		ctx,
+		until,
+		nil,
+		tree.IndexedVarHelper{},
+		types.TimestampTZ,
+		true, /* requireType */
+		"GRANT ... UNTIL",
+	)
+	if err != nil {
+		return time.Time{}, err
+	}
+	d, err := typedUntil.Eval(p.EvalContext())
+	if err != nil {
+		return time.Time{}, err
+	}
+	ts, ok := d.(*tree.DTimestampTZ)
+	if !ok {
+		return time.Time{}, pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError,
+			"GRANT ... UNTIL requires a timestamp, got %s", d)
+	}
+	return ts.Time, nil
+}
+
 // Revoke removes privileges from users.
 // Current status:
 // - Target: single database, table, or view.
 // TODO(marc): open questions:
 // - should we have root always allowed and not present in the permissions list?
 // - should we make users case-insensitive?
-// Privileges: GRANT on database/table/view.
+// Privileges: GRANT on database/table/view, or the revoked privileges WITH
+// GRANT OPTION if the user granted them.
 //   Notes: postgres requires the object owner.
 //          mysql requires the "grant option" and the same privileges, and sometimes superuser.
 func (p *planner) Revoke(ctx context.Context, n *tree.Revoke) (planNode, error) {
-	return p.changePrivileges(ctx, n.Targets, n.Grantees, func(privDesc *sqlbase.PrivilegeDescriptor, grantee string) {
-		privDesc.Revoke(grantee, n.Privileges)
+	privs, err := privilegesForColumns(n.Privileges, n.Columns)
+	if err != nil {
+		return nil, err
+	}
+	if n.GrantOptionFor {
+		return p.changePrivileges(ctx, n.Targets, n.Columns, n.Grantees, privs, true /* revoke */, func(privDesc *sqlbase.PrivilegeDescriptor, grantee string) {
+			privDesc.RevokeGrantOption(grantee, privs)
+		})
+	}
+	return p.changePrivileges(ctx, n.Targets, n.Columns, n.Grantees, privs, true /* revoke */, func(privDesc *sqlbase.PrivilegeDescriptor, grantee string) {
+		privDesc.Revoke(grantee, privs)
 	})
 }
 
+// checkCanGrant returns an error unless the current user may grant or revoke
+// the privileges in privList on the descriptor, or on the given columns of
+// it, either because it holds the GRANT privilege or because it holds each
+// of the privileges WITH GRANT OPTION, directly or through one of its roles.
+// The grant option on a column only allows granting on that column. When
+// revoking from the users in revokeFrom, the grant option only allows
+// revoking the privileges granted by the current user or one of its roles.
+func (p *planner) checkCanGrant(
+	ctx context.Context,
+	descriptor sqlbase.DescriptorProto,
+	columns []*sqlbase.ColumnDescriptor,
+	privList privilege.List,
+	revokeFrom tree.NameList,
+) error {
+	grantErr := p.CheckPrivilege(ctx, descriptor, privilege.GRANT)
+	if grantErr == nil {
+		return nil
+	}
+
+	user := p.SessionData().User
+	memberOf, err := p.MemberOfWithAdminOption(ctx, user)
+	if err != nil {
+		return err
+	}
+	isUserOrRole := func(name string) bool {
+		_, ok := memberOf[name]
+		return name == user || ok
+	}
+	hasGrantOption := func(privs *sqlbase.PrivilegeDescriptor, priv privilege.Kind) bool {
+		if privs == nil {
+			return false
+		}
+		if privs.CheckGrantOption(user, priv) {
+			return true
+		}
+		for role := range memberOf {
+			if privs.CheckGrantOption(role, priv) {
+				return true
+			}
+		}
+		return false
+	}
+	// grantedByUser returns whether the privilege, if held by the users it is
+	// revoked from, was granted by the current user or one of its roles.
+	// Privileges held through ALL are granted along with it.
+	grantedByUser := func(privs *sqlbase.PrivilegeDescriptor, priv privilege.Kind) bool {
+		for _, grantee := range revokeFrom {
+			grantor, ok := privs.Grantor(string(grantee), priv)
+			if !ok {
+				grantor, ok = privs.Grantor(string(grantee), privilege.ALL)
+			}
+			if ok && !isUserOrRole(grantor) {
+				return false
+			}
+			if !ok && privs.CheckPrivilege(string(grantee), priv) {
+				// The privilege was granted implicitly, e.g. when the object
+				// was created.
+				return false
+			}
+		}
+		return true
+	}
+	for _, priv := range privList {
+		if privs := descriptor.GetPrivileges(); hasGrantOption(privs, priv) {
+			if !grantedByUser(privs, priv) {
+				return grantErr
+			}
+			continue
+		}
+		onColumns := len(columns) > 0
+		for _, col := range columns {
+			if !hasGrantOption(col.Privileges, priv) || !grantedByUser(col.Privileges, priv) {
+				onColumns = false
+				break
+			}
+		}
+		if !onColumns {
+			return grantErr
+		}
+	}
+	return nil
+}
+
+// findGrantColumns returns the columns of the descriptor on which privileges
+// are granted or revoked.
+func findGrantColumns(
+	descriptor sqlbase.DescriptorProto, names tree.NameList,
+) ([]*sqlbase.ColumnDescriptor, error) {
+	tableDesc, ok := descriptor.(*sqlbase.TableDescriptor)
+	if !ok {
+		return nil, pgerror.NewErrorf(pgerror.CodeInvalidGrantOperationError,
+			"column privileges can only be granted on tables, not on %s %s",
+			descriptor.TypeName(), descriptor.GetName())
+	}
+	columns := make([]*sqlbase.ColumnDescriptor, len(names))
+	for i, name := range names {
+		col, err := tableDesc.FindActiveColumnByName(string(name))
+		if err != nil {
+			return nil, err
+		}
+		if columns[i], err = tableDesc.FindColumnByID(col.ID); err != nil {
+			return nil, err
+		}
+	}
+	return columns, nil
+}
+
 func (p *planner) changePrivileges(
 	ctx context.Context,
 	targets tree.TargetList,
+	columnNames tree.NameList,
 	grantees tree.NameList,
+	privList privilege.List,
+	revoke bool,
 	changePrivilege func(*sqlbase.PrivilegeDescriptor, string),
 ) (planNode, error) {
 	// Check whether grantees exists
@@ -77,12 +271,35 @@ func (p *planner) changePrivileges(
 	}
 
 	for _, descriptor := range descriptors {
-		if err := p.CheckPrivilege(ctx, descriptor, privilege.GRANT); err != nil {
+		var columns []*sqlbase.ColumnDescriptor
+		if len(columnNames) > 0 {
+			if columns, err = findGrantColumns(descriptor, columnNames); err != nil {
+				return nil, err
+			}
+		}
+		var revokeFrom tree.NameList
+		if revoke {
+			revokeFrom = grantees
+		}
+		if err := p.checkCanGrant(ctx, descriptor, columns, privList, revokeFrom); err != nil {
 			return nil, err
 		}
-		privileges := descriptor.GetPrivileges()
-		for _, grantee := range grantees {
-			changePrivilege(privileges, string(grantee))
+		if columns == nil {
+			privileges := descriptor.GetPrivileges()
+			for _, grantee := range grantees {
+				changePrivilege(privileges, string(grantee))
+			}
+		}
+		for _, col := range columns {
+			if col.Privileges == nil {
+				col.Privileges = &sqlbase.PrivilegeDescriptor{}
+			}
+			for _, grantee := range grantees {
+				changePrivilege(col.Privileges, string(grantee))
+			}
+			if len(col.Privileges.Users) == 0 {
+				col.Privileges = nil
+			}
 		}
 
 		switch d := descriptor.(type) {
@@ -110,5 +327,16 @@ func (p *planner) changePrivileges(
 	if err := p.txn.Run(ctx, b); err != nil {
 		return nil, err
 	}
+	// Make the new privileges visible to the rest of the transaction.
+	for _, descriptor := range descriptors {
+		switch d := descriptor.(type) {
+		case *sqlbase.TableDescriptor:
+			p.Tables().addUncommittedTable(*d)
+		case *sqlbase.DatabaseDescriptor:
+			// Databases are not tracked as uncommitted descriptors, but
+			// the cached descriptors must not hide their new privileges.
+			p.Tables().releaseAllDescriptors()
+		}
+	}
 	return &zeroNode{}, nil
 }
@@ -16,16 +16,27 @@ package sql
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strconv"
+	"strings"
+	"time"
+	"unsafe"
 
 	"github.com/pkg/errors"
 
+	"github.com/cockroachdb/cockroach/pkg/config"
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 )
 
 const (
@@ -36,18 +47,31 @@ const (
 var informationSchema = virtualSchema{
 	name: informationSchemaName,
 	tables: []virtualSchemaTable{
+		informationSchemaCheckConstraints,
 		informationSchemaColumnPrivileges,
 		informationSchemaColumnsTable,
+		informationSchemaElementTypesTable,
+		informationSchemaForeignDataWrappersTable,
+		informationSchemaForeignServersTable,
+		informationSchemaForeignTablesTable,
+		informationSchemaGlobalVariablesTable,
 		informationSchemaKeyColumnUsageTable,
+		informationSchemaObjectLimitsTable,
+		informationSchemaPartitionsTable,
+		informationSchemaProcesslistTable,
 		informationSchemaReferentialConstraintsTable,
+		informationSchemaRoleTableGrants,
 		informationSchemaSchemataTable,
+		informationSchemaSchemataSettingsTable,
 		informationSchemaSchemataTablePrivileges,
 		informationSchemaSequences,
+		informationSchemaSessionVariablesTable,
 		informationSchemaStatisticsTable,
 		informationSchemaTableConstraintTable,
 		informationSchemaTablePrivileges,
 		informationSchemaTablesTable,
 		informationSchemaViewsTable,
+		informationSchemaUserMappingsTable,
 		informationSchemaUserPrivileges,
 	},
 	tableValidator: validateInformationSchemaTable,
@@ -114,6 +138,45 @@ func validateInformationSchemaTable(table *sqlbase.TableDescriptor) error {
 	return nil
 }
 
+// Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-check-constraints.html
+// MySQL:    missing
+var informationSchemaCheckConstraints = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.check_constraints (
+	CONSTRAINT_CATALOG STRING NOT NULL,
+	CONSTRAINT_SCHEMA STRING NOT NULL,
+	CONSTRAINT_NAME STRING NOT NULL,
+	CHECK_CLAUSE STRING NOT NULL
+);`,
+	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		return forEachTableDesc(ctx, p, prefix, func(
+			db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor,
+		) error {
+			dbNameStr := tree.NewDString(db.Name)
+			for _, c := range table.Checks {
+				if err := addRow(
+					defString,               // constraint_catalog
+					dbNameStr,               // constraint_schema
+					tree.NewDString(c.Name), // constraint_name
+					tree.NewDString(c.Expr), // check_clause
+				); err != nil {
+					return err
+				}
+			}
+			return forEachNotNullColumn(table, func(col *sqlbase.ColumnDescriptor) error {
+				conName := notNullConstraintName(db, table, col)
+				clause := tree.NameString(col.Name) + " IS NOT NULL"
+				return addRow(
+					defString,                // constraint_catalog
+					dbNameStr,                // constraint_schema
+					tree.NewDString(conName), // constraint_name
+					tree.NewDString(clause),  // check_clause
+				)
+			})
+		})
+	},
+}
+
 // Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-column-privileges.html
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/column-privileges-table.html
 var informationSchemaColumnPrivileges = virtualSchemaTable{
@@ -130,24 +193,36 @@ CREATE TABLE information_schema.column_privileges (
 );
 `,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		privs, err := p.makePrivilegeChecker(ctx)
+		if err != nil {
+			return err
+		}
 		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
-			columndata := privilege.List{privilege.SELECT, privilege.INSERT, privilege.UPDATE} // privileges for column level granularity
-			for _, u := range table.Privileges.Users {
-				for _, priv := range columndata {
-					if priv.Mask()&u.Privileges != 0 {
-						for _, cd := range table.Columns {
-							if err := addRow(
-								tree.DNull,                     // grantor
-								tree.NewDString(u.User),        // grantee
-								defString,                      // table_catalog
-								tree.NewDString(db.Name),       // table_schema
-								tree.NewDString(table.Name),    // table_name
-								tree.NewDString(cd.Name),       // column_name
-								tree.NewDString(priv.String()), // privilege_type
-								tree.DNull,                     // is_grantable
-							); err != nil {
-								return err
-							}
+			for i := range table.Columns {
+				cd := &table.Columns[i]
+				if cd.Privileges == nil || !userCanSeeColumn(privs, table, cd) {
+					continue
+				}
+				for _, u := range cd.Privileges.Show() {
+					for _, priv := range u.Privileges {
+						grantor := privilegeGrantor(cd.Privileges, u.User, priv)
+						// The privileges on the table allow granting the
+						// privileges on its columns.
+						isGrantable := privilegeIsGrantable(table.Privileges, u.User, priv)
+						if isGrantable == noString {
+							isGrantable = privilegeIsGrantable(cd.Privileges, u.User, priv)
+						}
+						if err := addRow(
+							grantor,                     // grantor
+							tree.NewDString(u.User),     // grantee
+							defString,                   // table_catalog
+							tree.NewDString(db.Name),    // table_schema
+							tree.NewDString(table.Name), // table_name
+							tree.NewDString(cd.Name),    // column_name
+							tree.NewDString(priv),       // privilege_type
+							isGrantable,                 // is_grantable
+						); err != nil {
+							return err
 						}
 					}
 				}
@@ -159,6 +234,11 @@ CREATE TABLE information_schema.column_privileges (
 
 // Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-columns.html
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/columns-table.html
+//
+// The hidden columns are only reported when the show_hidden_columns session
+// variable is on; IS_HIDDEN tells them apart. DATA_TYPE follows the
+// information_schema_compat dialect, while CRDB_SQL_TYPE is always the type
+// as written in CREATE TABLE.
 var informationSchemaColumnsTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE information_schema.columns (
@@ -177,32 +257,76 @@ CREATE TABLE information_schema.columns (
 	DATETIME_PRECISION INT,
 	CHARACTER_SET_CATALOG STRING,
 	CHARACTER_SET_SCHEMA STRING,
-	CHARACTER_SET_NAME STRING
+	CHARACTER_SET_NAME STRING,
+	COLLATION_CATALOG STRING,
+	COLLATION_SCHEMA STRING,
+	COLLATION_NAME STRING,
+	UDT_CATALOG STRING,
+	UDT_SCHEMA STRING,
+	UDT_NAME STRING,
+	DTD_IDENTIFIER STRING,
+	COLUMN_COMMENT STRING,
+	IS_HIDDEN STRING NOT NULL,
+	CRDB_SQL_TYPE STRING NOT NULL
 );
 `,
-	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
-		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
-			// Table descriptors already holds columns in-order.
-			visible := 0
-			return forEachColumnInTable(table, func(column *sqlbase.ColumnDescriptor) error {
-				visible++
+	keyColumns: []string{"table_schema", "table_name"},
+	populateConstrained: func(
+		ctx context.Context,
+		p *planner,
+		prefix string,
+		constraints virtualConstraints,
+		addRow func(...tree.Datum) error,
+	) error {
+		comments, err := getComments(ctx, p)
+		if err != nil {
+			return err
+		}
+		privs, err := p.makePrivilegeChecker(ctx)
+		if err != nil {
+			return err
+		}
+		var filter tableDescFilter
+		filter.dbName, _ = constraints.getString("table_schema")
+		filter.tableName, _ = constraints.getString("table_name")
+		return forEachTableDescFiltered(ctx, p, prefix, filter, func(
+			db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor,
+		) error {
+			showHidden := p.SessionData().ShowHiddenColumns
+			dialect := p.infoSchemaDialect()
+			return forEachColumnInTableWithHidden(table, showHidden, privs, func(position int, column *sqlbase.ColumnDescriptor) error {
+				comment := tree.DNull
+				if c, ok := comments[commentKey{columnCommentType, table.ID, int64(column.ID)}]; ok {
+					comment = tree.NewDString(c)
+				}
+				sqlType := tree.NewDString(column.Type.SQLString())
 				return addRow(
-					defString,                                // table_catalog
-					tree.NewDString(db.Name),                 // table_schema
-					tree.NewDString(table.Name),              // table_name
-					tree.NewDString(column.Name),             // column_name
-					tree.NewDInt(tree.DInt(visible)),         // ordinal_position, 1-indexed
-					dStringPtrOrNull(column.DefaultExpr),     // column_default
-					yesOrNoDatum(column.Nullable),            // is_nullable
-					tree.NewDString(column.Type.SQLString()), // data_type
-					characterMaximumLength(column.Type),      // character_maximum_length
-					characterOctetLength(column.Type),        // character_octet_length
-					numericPrecision(column.Type),            // numeric_precision
-					numericScale(column.Type),                // numeric_scale
-					datetimePrecision(column.Type),           // datetime_precision
-					tree.DNull,                               // character_set_catalog
-					tree.DNull,                               // character_set_schema
-					tree.DNull,                               // character_set_name
+					defString,                            // table_catalog
+					tree.NewDString(db.Name),             // table_schema
+					tree.NewDString(table.Name),          // table_name
+					tree.NewDString(column.Name),         // column_name
+					tree.NewDInt(tree.DInt(position)),    // ordinal_position, 1-indexed
+					dStringPtrOrNull(column.DefaultExpr), // column_default
+					yesOrNoDatum(column.Nullable),        // is_nullable
+					dialect.dataType(column.Type),        // data_type
+					characterMaximumLength(column.Type),  // character_maximum_length
+					characterOctetLength(column.Type),    // character_octet_length
+					numericPrecision(column.Type),        // numeric_precision
+					numericScale(column.Type),            // numeric_scale
+					datetimePrecision(column.Type),       // datetime_precision
+					tree.DNull,                           // character_set_catalog
+					tree.DNull,                           // character_set_schema
+					tree.DNull,                           // character_set_name
+					collationCatalog(column.Type),        // collation_catalog
+					collationSchema(column.Type),         // collation_schema
+					collationName(column.Type),           // collation_name
+					defString,                            // udt_catalog
+					tree.NewDString(pgCatalogName),       // udt_schema
+					udtName(column.Type),                 // udt_name
+					dtdIdentifier(position),              // dtd_identifier
+					comment,                              // column_comment
+					yesOrNoDatum(column.Hidden),          // is_hidden
+					sqlType,                              // crdb_sql_type
 				)
 			})
 		})
@@ -226,8 +350,205 @@ func numericScale(colType sqlbase.ColumnType) tree.Datum {
 }
 
 func datetimePrecision(colType sqlbase.ColumnType) tree.Datum {
-	// We currently do not support a datetime precision.
-	return tree.DNull
+	return dIntFnOrNull(colType.DatetimePrecision)
+}
+
+// udtName returns the name of the type in pg_catalog.pg_type.
+func udtName(colType sqlbase.ColumnType) tree.Datum {
+	return tree.NewDString(types.PGDisplayName(colType.ToDatumType()))
+}
+
+// dtdIdentifier returns the identifier of the descriptor of the type of the
+// column at the given position, which is unique among the descriptors of the
+// table. Like in PostgreSQL, it is the position of the column.
+func dtdIdentifier(position int) *tree.DString {
+	return tree.NewDString(strconv.Itoa(position))
+}
+
+// Collations are listed in pg_catalog.pg_collation.
+func collationCatalog(colType sqlbase.ColumnType) tree.Datum {
+	if colType.Locale == nil {
+		return tree.DNull
+	}
+	return defString
+}
+
+func collationSchema(colType sqlbase.ColumnType) tree.Datum {
+	if colType.Locale == nil {
+		return tree.DNull
+	}
+	return tree.NewDString(pgCatalogName)
+}
+
+// collationName returns the canonical name of the collation of the column,
+// which is the name crdb_internal.normalize_collation returns for it.
+func collationName(colType sqlbase.ColumnType) tree.Datum {
+	if colType.Locale == nil {
+		return tree.DNull
+	}
+	locale, err := tree.CanonicalCollationLocale(*colType.Locale)
+	if err != nil {
+		// The locale was validated when the column was created.
+		locale = *colType.Locale
+	}
+	return tree.NewDString(locale)
+}
+
+// Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-element-types.html
+// MySQL:    missing
+//
+// The elements of the array columns are described by their own data type
+// descriptor, which is found from the column through its DTD_IDENTIFIER.
+var informationSchemaElementTypesTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.element_types (
+	OBJECT_CATALOG STRING NOT NULL,
+	OBJECT_SCHEMA STRING NOT NULL,
+	OBJECT_NAME STRING NOT NULL,
+	OBJECT_TYPE STRING NOT NULL,
+	COLLECTION_TYPE_IDENTIFIER STRING NOT NULL,
+	DATA_TYPE STRING NOT NULL,
+	CHARACTER_MAXIMUM_LENGTH INT,
+	CHARACTER_OCTET_LENGTH INT,
+	COLLATION_CATALOG STRING,
+	COLLATION_SCHEMA STRING,
+	COLLATION_NAME STRING,
+	NUMERIC_PRECISION INT,
+	NUMERIC_SCALE INT,
+	DATETIME_PRECISION INT,
+	UDT_CATALOG STRING,
+	UDT_SCHEMA STRING,
+	UDT_NAME STRING,
+	DTD_IDENTIFIER STRING NOT NULL
+);
+`,
+	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		dialect := p.infoSchemaDialect()
+		showHidden := p.SessionData().ShowHiddenColumns
+		privs, err := p.makePrivilegeChecker(ctx)
+		if err != nil {
+			return err
+		}
+		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+			// The positions are those of information_schema.columns, so that
+			// the identifiers match.
+			return forEachColumnInTableWithHidden(table, showHidden, privs, func(position int, column *sqlbase.ColumnDescriptor) error {
+				elemType := column.Type.ElementColumnType()
+				if elemType == nil {
+					return nil
+				}
+				collectionID := dtdIdentifier(position)
+				// As in PostgreSQL, the identifier of the descriptor of the
+				// elements is derived from that of the array.
+				elemID := tree.NewDString("a" + string(*collectionID))
+				return addRow(
+					defString,                         // object_catalog
+					tree.NewDString(db.Name),          // object_schema
+					tree.NewDString(table.Name),       // object_name
+					objectTypeTable,                   // object_type
+					collectionID,                      // collection_type_identifier
+					dialect.dataType(*elemType),       // data_type
+					characterMaximumLength(*elemType), // character_maximum_length
+					characterOctetLength(*elemType),   // character_octet_length
+					collationCatalog(*elemType),       // collation_catalog
+					collationSchema(*elemType),        // collation_schema
+					collationName(*elemType),          // collation_name
+					numericPrecision(*elemType),       // numeric_precision
+					numericScale(*elemType),           // numeric_scale
+					datetimePrecision(*elemType),      // datetime_precision
+					defString,                         // udt_catalog
+					tree.NewDString(pgCatalogName),    // udt_schema
+					udtName(*elemType),                // udt_name
+					elemID,                            // dtd_identifier
+				)
+			})
+		})
+	},
+}
+
+var objectTypeTable = tree.NewDString("TABLE")
+
+// Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-foreign-data-wrappers.html
+// MySQL:    missing
+var informationSchemaForeignDataWrappersTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.foreign_data_wrappers (
+	FOREIGN_DATA_WRAPPER_CATALOG STRING NOT NULL,
+	FOREIGN_DATA_WRAPPER_NAME STRING NOT NULL,
+	AUTHORIZATION_IDENTIFIER STRING,
+	LIBRARY_NAME STRING,
+	FOREIGN_DATA_WRAPPER_LANGUAGE STRING
+);`,
+	populate: func(_ context.Context, _ *planner, _ string, _ func(...tree.Datum) error) error {
+		// Foreign data wrappers are not supported.
+		return nil
+	},
+}
+
+// Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-foreign-servers.html
+// MySQL:    missing
+var informationSchemaForeignServersTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.foreign_servers (
+	FOREIGN_SERVER_CATALOG STRING NOT NULL,
+	FOREIGN_SERVER_NAME STRING NOT NULL,
+	FOREIGN_DATA_WRAPPER_CATALOG STRING NOT NULL,
+	FOREIGN_DATA_WRAPPER_NAME STRING NOT NULL,
+	FOREIGN_SERVER_TYPE STRING,
+	FOREIGN_SERVER_VERSION STRING,
+	AUTHORIZATION_IDENTIFIER STRING
+);`,
+	populate: func(_ context.Context, _ *planner, _ string, _ func(...tree.Datum) error) error {
+		// Foreign servers are not supported.
+		return nil
+	},
+}
+
+// Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-foreign-tables.html
+// MySQL:    missing
+var informationSchemaForeignTablesTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.foreign_tables (
+	FOREIGN_TABLE_CATALOG STRING NOT NULL,
+	FOREIGN_TABLE_SCHEMA STRING NOT NULL,
+	FOREIGN_TABLE_NAME STRING NOT NULL,
+	FOREIGN_SERVER_CATALOG STRING NOT NULL,
+	FOREIGN_SERVER_NAME STRING NOT NULL
+);`,
+	populate: func(_ context.Context, _ *planner, _ string, _ func(...tree.Datum) error) error {
+		// Foreign tables are not supported.
+		return nil
+	},
+}
+
+// Postgres: missing
+// MySQL:    https://dev.mysql.com/doc/refman/5.7/en/variables-table.html
+//
+// global_variables lists the cluster settings, like SHOW ALL CLUSTER
+// SETTINGS.
+var informationSchemaGlobalVariablesTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.global_variables (
+	VARIABLE_NAME STRING NOT NULL,
+	VARIABLE_VALUE STRING NOT NULL
+);`,
+	currentOnly: true,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		if err := p.RequireSuperUser(ctx, "read information_schema.global_variables"); err != nil {
+			return err
+		}
+		for _, k := range settings.Keys() {
+			setting, _ := settings.Lookup(k)
+			value := setting.String(&p.ExecCfg().Settings.SV)
+			if err := addRow(
+				tree.NewDString(k),     // variable_name
+				tree.NewDString(value), // variable_value
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
 }
 
 // Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-key-column-usage.html
@@ -246,6 +567,10 @@ CREATE TABLE information_schema.key_column_usage (
 	POSITION_IN_UNIQUE_CONSTRAINT INT
 );`,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		privs, err := p.makePrivilegeChecker(ctx)
+		if err != nil {
+			return err
+		}
 		return forEachTableDescWithTableLookup(ctx, p, prefix, func(
 			db *sqlbase.DatabaseDescriptor,
 			table *sqlbase.TableDescriptor,
@@ -267,6 +592,9 @@ CREATE TABLE information_schema.key_column_usage (
 				}
 
 				for pos, column := range c.Columns {
+					if !userCanSeeColumnByName(privs, table, column) {
+						continue
+					}
 					ordinalPos := tree.NewDInt(tree.DInt(pos + 1))
 					uniquePos := tree.DNull
 					if c.Kind == sqlbase.ConstraintTypeFK {
@@ -292,6 +620,116 @@ CREATE TABLE information_schema.key_column_usage (
 	},
 }
 
+// Postgres: missing
+// MySQL:    missing
+//
+// object_limits lists the current usage of the object count limits set
+// by the cluster settings named by LIMIT_NAME: a row per database for
+// sql.schema.max_tables_per_database, and a row per table for
+// sql.schema.max_columns_per_table. MAX_USAGE is NULL when the limit is
+// disabled.
+var informationSchemaObjectLimitsTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.object_limits (
+	OBJECT_CATALOG STRING NOT NULL,
+	OBJECT_SCHEMA STRING NOT NULL,
+	OBJECT_NAME STRING,
+	LIMIT_NAME STRING NOT NULL,
+	CURRENT_USAGE INT NOT NULL,
+	MAX_USAGE INT
+);`,
+	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		limitDatum := func(s *settings.IntSetting) tree.Datum {
+			if v := s.Get(&p.ExecCfg().Settings.SV); v != 0 {
+				return tree.NewDInt(tree.DInt(v))
+			}
+			return tree.DNull
+		}
+		tablesLimit := tree.NewDString(maxTablesPerDatabaseSetting)
+		columnsLimit := tree.NewDString(maxColumnsPerTableSetting)
+		maxTables := limitDatum(maxTablesPerDatabase)
+		maxColumns := limitDatum(maxColumnsPerTable)
+		if err := forEachDatabaseDesc(ctx, p, func(db *sqlbase.DatabaseDescriptor) error {
+			if db.ID == keys.VirtualDescriptorID || (prefix != "" && db.Name != prefix) {
+				return nil
+			}
+			n, err := countTablesInDatabase(ctx, p.txn, db.ID)
+			if err != nil {
+				return err
+			}
+			return addRow(
+				defString,                  // object_catalog
+				tree.NewDString(db.Name),   // object_schema
+				tree.DNull,                 // object_name
+				tablesLimit,                // limit_name
+				tree.NewDInt(tree.DInt(n)), // current_usage
+				maxTables,                  // max_usage
+			)
+		}); err != nil {
+			return err
+		}
+		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+			if isVirtualDescriptor(table) || table.IsView() || table.IsSequence() {
+				return nil
+			}
+			return addRow(
+				defString,                   // object_catalog
+				tree.NewDString(db.Name),    // object_schema
+				tree.NewDString(table.Name), // object_name
+				columnsLimit,                // limit_name
+				tree.NewDInt(tree.DInt(countVisibleColumns(table))), // current_usage
+				maxColumns, // max_usage
+			)
+		})
+	},
+}
+
+// Postgres: missing
+// MySQL:    https://dev.mysql.com/doc/refman/5.7/en/partitions-table.html
+//
+// Unlike in MySQL, partitions can be nested to any depth and secondary
+// indexes can be partitioned too, so subpartitions are listed like
+// partitions with the name of their parent partition, and each row names
+// the index it partitions.
+var informationSchemaPartitionsTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.partitions (
+	TABLE_CATALOG STRING NOT NULL,
+	TABLE_SCHEMA STRING NOT NULL,
+	TABLE_NAME STRING NOT NULL,
+	INDEX_NAME STRING NOT NULL,
+	PARTITION_NAME STRING NOT NULL,
+	PARENT_PARTITION_NAME STRING,
+	PARTITION_ORDINAL_POSITION INT NOT NULL,
+	PARTITION_METHOD STRING NOT NULL,
+	PARTITION_EXPRESSION STRING NOT NULL,
+	PARTITION_DESCRIPTION STRING NOT NULL
+);`,
+	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		var a sqlbase.DatumAlloc
+		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+			return forEachIndexInTable(table, func(index *sqlbase.IndexDescriptor) error {
+				return forEachPartition(&a, table, index, &index.Partitioning,
+					"" /* parentName */, 0 /* colOffset */, func(part partitionDesc) error {
+						expr := strings.Join(part.columnNames, ", ")
+						return addRow(
+							defString,                             // table_catalog
+							tree.NewDString(db.Name),              // table_schema
+							tree.NewDString(table.Name),           // table_name
+							tree.NewDString(index.Name),           // index_name
+							tree.NewDString(part.name),            // partition_name
+							dStringOrNull(part.parentName),        // parent_partition_name
+							tree.NewDInt(tree.DInt(part.ordinal)), // partition_ordinal_position
+							tree.NewDString(part.method),          // partition_method
+							tree.NewDString(expr),                 // partition_expression
+							tree.NewDString(part.values),          // partition_description
+						)
+					})
+			})
+		})
+	},
+}
+
 var (
 	matchOptionFull    = tree.NewDString("FULL")
 	matchOptionPartial = tree.NewDString("PARTIAL")
@@ -324,6 +762,93 @@ func dStringForFKAction(action sqlbase.ForeignKeyReference_Action) tree.Datum {
 	panic(errors.Errorf("unexpected ForeignKeyReference_Action: %v", action))
 }
 
+// Postgres: missing
+// MySQL:    https://dev.mysql.com/doc/refman/5.7/en/processlist-table.html
+//
+// processlist has a row per session of the cluster, reporting its oldest
+// running query, if any. ID is the ID of that query, which can be passed to
+// CANCEL QUERY, and TIME the number of seconds it has been running for.
+// Sessions are not attached to a database, so DB is always NULL. Users who
+// are not root only see their own sessions. USER is a reserved keyword, so
+// the column has to be quoted, as in SELECT "user" FROM processlist.
+var informationSchemaProcesslistTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.processlist (
+	ID STRING,
+	NODE_ID INT NOT NULL,
+	"user" STRING,
+	HOST STRING,
+	DB STRING,
+	COMMAND STRING,
+	TIME INT,
+	STATE STRING,
+	INFO STRING,
+	QUERY_START TIMESTAMP
+);`,
+	currentOnly: true,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		req := serverpb.ListSessionsRequest{Username: p.SessionData().User}
+		response, err := p.extendedEvalCtx.StatusServer.ListSessions(ctx, &req)
+		if err != nil {
+			return err
+		}
+		now := timeutil.Now()
+		for _, session := range response.Sessions {
+			command := commandSleep
+			id, elapsed, state, info, start := tree.DNull, tree.DNull, tree.DNull, tree.DNull, tree.DNull
+			var oldest *serverpb.ActiveQuery
+			for i := range session.ActiveQueries {
+				if q := &session.ActiveQueries[i]; oldest == nil || q.Start.Before(oldest.Start) {
+					oldest = q
+				}
+			}
+			if oldest != nil {
+				id = tree.NewDString(oldest.ID)
+				command = commandQuery
+				elapsed = tree.NewDInt(tree.DInt(now.Sub(oldest.Start).Seconds()))
+				state = tree.NewDString(strings.ToLower(oldest.Phase.String()))
+				info = tree.NewDString(oldest.Sql)
+				start = tree.MakeDTimestamp(oldest.Start, time.Microsecond)
+			}
+			if err := addRow(
+				id,                                      // id
+				tree.NewDInt(tree.DInt(session.NodeID)), // node_id
+				tree.NewDString(session.Username),       // user
+				tree.NewDString(session.ClientAddress),  // host
+				tree.DNull,                              // db
+				command,                                 // command
+				elapsed,                                 // time
+				state,                                   // state
+				info,                                    // info
+				start,                                   // query_start
+			); err != nil {
+				return err
+			}
+		}
+
+		for _, rpcErr := range response.Errors {
+			log.Warning(ctx, rpcErr.Message)
+			if rpcErr.NodeID != 0 {
+				// Add a row with this node ID, and nulls for all other columns.
+				row := make(tree.Datums, 10)
+				for i := range row {
+					row[i] = tree.DNull
+				}
+				row[1] = tree.NewDInt(tree.DInt(rpcErr.NodeID))
+				if err := addRow(row...); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
+
+var (
+	commandQuery = tree.NewDString("Query")
+	commandSleep = tree.NewDString("Sleep")
+)
+
 // Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-referential-constraints.html
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/referential-constraints-table.html
 var informationSchemaReferentialConstraintsTable = virtualSchemaTable{
@@ -402,6 +927,77 @@ CREATE TABLE information_schema.schemata (
 	},
 }
 
+// Postgres: missing
+// MySQL:    missing
+//
+// schemata_settings is a companion of schemata listing the configuration
+// that applies to each database by default. For each setting, SOURCE is
+// the object the value is inherited from, in the syntax of the CLI zone
+// specifiers: the database name when the database has its own zone
+// config, or .default for the cluster-wide default.
+var informationSchemaSchemataSettingsTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.schemata_settings (
+	CATALOG_NAME STRING NOT NULL,
+	SCHEMA_NAME STRING NOT NULL,
+	SETTING_NAME STRING NOT NULL,
+	SETTING_VALUE STRING,
+	SOURCE STRING NOT NULL
+);`,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		return forEachDatabaseDesc(ctx, p, func(db *sqlbase.DatabaseDescriptor) error {
+			if db.ID == keys.VirtualDescriptorID {
+				// Virtual schemas store no data, so no zone config applies.
+				return nil
+			}
+			zoneID, zone, _, err := GetZoneConfigInTxn(ctx, p.txn, uint32(db.ID), nil, "")
+			if err == errNoZoneConfigApplies {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			source := tree.NewDString("." + config.DefaultZoneName)
+			if zoneID == uint32(db.ID) {
+				source = tree.NewDString(tree.NameString(db.Name))
+			}
+			dbNameStr := tree.NewDString(db.Name)
+			for _, s := range zoneConfigSettings(&zone) {
+				if err := addRow(
+					defString,                // catalog_name
+					dbNameStr,                // schema_name
+					tree.NewDString(s.name),  // setting_name
+					tree.NewDString(s.value), // setting_value
+					source,                   // source
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+}
+
+type schemaSetting struct {
+	name, value string
+}
+
+// zoneConfigSettings summarizes a zone config as schemata_settings rows.
+// The setting names are the YAML field names of the zone config, prefixed
+// with "zone.".
+func zoneConfigSettings(zone *config.ZoneConfig) []schemaSetting {
+	constraints := make([]string, len(zone.Constraints.Constraints))
+	for i, c := range zone.Constraints.Constraints {
+		constraints[i] = c.String()
+	}
+	return []schemaSetting{
+		{"zone.constraints", "[" + strings.Join(constraints, ", ") + "]"},
+		{"zone.gc.ttlseconds", strconv.Itoa(int(zone.GC.TTLSeconds))},
+		{"zone.num_replicas", strconv.Itoa(int(zone.NumReplicas))},
+		{"zone.range_max_bytes", strconv.FormatInt(zone.RangeMaxBytes, 10)},
+		{"zone.range_min_bytes", strconv.FormatInt(zone.RangeMinBytes, 10)},
+	}
+}
+
 // Postgres: missing
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/schema-privileges-table.html
 var informationSchemaSchemataTablePrivileges = virtualSchemaTable{
@@ -411,19 +1007,23 @@ CREATE TABLE information_schema.schema_privileges (
 	TABLE_CATALOG STRING NOT NULL,
 	TABLE_SCHEMA STRING NOT NULL,
 	PRIVILEGE_TYPE STRING NOT NULL,
-	IS_GRANTABLE STRING NOT NULL
+	IS_GRANTABLE STRING NOT NULL,
+	EXPIRES_AT TIMESTAMPTZ
 );
 `,
 	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
 		return forEachDatabaseDesc(ctx, p, func(db *sqlbase.DatabaseDescriptor) error {
 			for _, u := range db.Privileges.Show() {
 				for _, priv := range u.Privileges {
+					isGrantable := privilegeIsGrantable(db.Privileges, u.User, priv)
+					expiresAt := privilegeExpiresAt(db.Privileges, u.User, priv)
 					if err := addRow(
 						tree.NewDString(u.User),  // grantee
 						defString,                // table_catalog
 						tree.NewDString(db.Name), // table_schema
 						tree.NewDString(priv),    // privilege_type
-						tree.DNull,               // is_grantable
+						isGrantable,              // is_grantable
+						expiresAt,                // expires_at
 					); err != nil {
 						return err
 					}
@@ -434,6 +1034,47 @@ CREATE TABLE information_schema.schema_privileges (
 	},
 }
 
+// privilegeExpiresAt returns the expiration time of the given privilege of
+// the given user, or NULL if the privilege does not expire. Grants that have
+// already expired are still reported so that temporary access remains
+// auditable until it is revoked.
+func privilegeExpiresAt(privs *sqlbase.PrivilegeDescriptor, user, priv string) tree.Datum {
+	kind, ok := privilege.ByName[priv]
+	if !ok {
+		return tree.DNull
+	}
+	expiresAt, ok := privs.Expiration(user, kind)
+	if !ok {
+		return tree.DNull
+	}
+	return tree.MakeDTimestampTZ(expiresAt, time.Microsecond)
+}
+
+// privilegeIsGrantable returns YES if the given user holds the given
+// privilege WITH GRANT OPTION, and NO otherwise. The GRANT privilege
+// doesn't make the other privileges grantable.
+func privilegeIsGrantable(privs *sqlbase.PrivilegeDescriptor, user, priv string) tree.Datum {
+	kind, ok := privilege.ByName[priv]
+	if !ok {
+		return noString
+	}
+	return yesOrNoDatum(privs.CheckGrantOption(user, kind))
+}
+
+// privilegeGrantor returns the user who granted the given privilege to the
+// given user, or NULL if it was not recorded.
+func privilegeGrantor(privs *sqlbase.PrivilegeDescriptor, user, priv string) tree.Datum {
+	kind, ok := privilege.ByName[priv]
+	if !ok {
+		return tree.DNull
+	}
+	grantor, ok := privs.Grantor(user, kind)
+	if !ok {
+		return tree.DNull
+	}
+	return tree.NewDString(grantor)
+}
+
 var (
 	indexDirectionNA   = tree.NewDString("N/A")
 	indexDirectionAsc  = tree.NewDString(sqlbase.IndexDescriptor_ASC.String())
@@ -466,13 +1107,28 @@ CREATE TABLE information_schema.sequences (
     MINIMUM_VALUE STRING NOT NULL,
     MAXIMUM_VALUE STRING NOT NULL,
     INCREMENT STRING NOT NULL,
-    CYCLE_OPTION STRING NOT NULL
+    CYCLE_OPTION STRING NOT NULL,
+    OWNER_TABLE_NAME STRING,
+    OWNER_COLUMN_NAME STRING
 );`,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
-		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+		return forEachTableDescWithTableLookup(ctx, p, prefix, func(
+			db *sqlbase.DatabaseDescriptor,
+			table *sqlbase.TableDescriptor,
+			tableLookup tableLookupFn,
+		) error {
 			if !table.IsSequence() {
 				return nil
 			}
+			ownerTable, ownerColumn := tree.DNull, tree.DNull
+			if owner := table.SequenceOpts.SequenceOwner; owner.OwnerTableID != 0 {
+				if _, t := tableLookup(owner.OwnerTableID); t != nil {
+					ownerTable = tree.NewDString(t.Name)
+					if col, err := t.FindActiveColumnByID(owner.OwnerColumnID); err == nil {
+						ownerColumn = tree.NewDString(col.Name)
+					}
+				}
+			}
 			return addRow(
 				defString,                        // catalog
 				tree.NewDString(db.GetName()),    // schema
@@ -485,12 +1141,38 @@ CREATE TABLE information_schema.sequences (
 				tree.NewDString(strconv.FormatInt(table.SequenceOpts.MinValue, 10)),  // min value
 				tree.NewDString(strconv.FormatInt(table.SequenceOpts.MaxValue, 10)),  // max value
 				tree.NewDString(strconv.FormatInt(table.SequenceOpts.Increment, 10)), // increment
-				noString, // cycle
+				noString,    // cycle
+				ownerTable,  // owner table name
+				ownerColumn, // owner column name
 			)
 		})
 	},
 }
 
+// Postgres: missing
+// MySQL:    https://dev.mysql.com/doc/refman/5.7/en/variables-table.html
+//
+// session_variables lists the session variables, like SHOW ALL.
+var informationSchemaSessionVariablesTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.session_variables (
+	VARIABLE_NAME STRING NOT NULL,
+	VARIABLE_VALUE STRING NOT NULL
+);`,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		for _, vName := range varNames {
+			value := varGen[vName].Get(&p.extendedEvalCtx)
+			if err := addRow(
+				tree.NewDString(vName), // variable_name
+				tree.NewDString(value), // variable_value
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
 // Postgres: missing
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/statistics-table.html
 var informationSchemaStatisticsTable = virtualSchemaTable{
@@ -508,13 +1190,21 @@ CREATE TABLE information_schema.statistics (
 	CARDINALITY INT NOT NULL,
 	DIRECTION STRING NOT NULL,
 	STORING STRING NOT NULL,
-	IMPLICIT STRING NOT NULL
+	IMPLICIT STRING NOT NULL,
+	INDEX_TYPE STRING NOT NULL
 );`,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		privs, err := p.makePrivilegeChecker(ctx)
+		if err != nil {
+			return err
+		}
 		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
 			appendRow := func(index *sqlbase.IndexDescriptor, colName string, sequence int,
 				direction tree.Datum, isStored, isImplicit bool,
 			) error {
+				if !userCanSeeColumnByName(privs, table, colName) {
+					return nil
+				}
 				return addRow(
 					defString,                         // table_catalog
 					tree.NewDString(db.GetName()),     // table_schema
@@ -529,6 +1219,7 @@ CREATE TABLE information_schema.statistics (
 					direction,                         // direction
 					yesOrNoDatum(isStored),            // storing
 					yesOrNoDatum(isImplicit),          // implicit
+					indexTypeDatum(index),             // index_type
 				)
 			}
 
@@ -585,8 +1276,28 @@ CREATE TABLE information_schema.statistics (
 	},
 }
 
+var (
+	indexTypeForward  = tree.NewDString("FORWARD")
+	indexTypeInverted = tree.NewDString("INVERTED")
+)
+
+// indexTypeDatum returns the type of an index: FORWARD for the indexes
+// ordered by the values of their columns, like the B-tree indexes of other
+// databases, and INVERTED for the inverted indexes on JSONB columns.
+func indexTypeDatum(index *sqlbase.IndexDescriptor) tree.Datum {
+	if index.Type == sqlbase.IndexDescriptor_INVERTED {
+		return indexTypeInverted
+	}
+	return indexTypeForward
+}
+
 // Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-table-constraints.html
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/table-constraints-table.html
+//
+// VALIDATED is an extension telling whether the existing rows are known to
+// satisfy the constraint. It is NO for the constraints added to an existing
+// table, like the ones added with NOT VALID, until ALTER TABLE ... VALIDATE
+// CONSTRAINT checks them.
 var informationSchemaTableConstraintTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE information_schema.table_constraints (
@@ -598,7 +1309,8 @@ CREATE TABLE information_schema.table_constraints (
 	TABLE_NAME STRING NOT NULL,
 	CONSTRAINT_TYPE STRING NOT NULL,
 	IS_DEFERRABLE STRING NOT NULL,
-	INITIALLY_DEFERRED STRING NOT NULL
+	INITIALLY_DEFERRED STRING NOT NULL,
+	VALIDATED STRING NOT NULL
 );`,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
 		return forEachTableDescWithTableLookup(ctx, p, prefix, func(
@@ -611,17 +1323,36 @@ CREATE TABLE information_schema.table_constraints (
 				return err
 			}
 
+			constraints := make([]tableConstraint, 0, len(info))
 			for name, c := range info {
+				constraints = append(constraints, tableConstraint{
+					name: name, kind: c.Kind, unvalidated: c.Unvalidated,
+				})
+			}
+			// Like Postgres, report NOT NULL columns as CHECK constraints.
+			if err := forEachNotNullColumn(table, func(col *sqlbase.ColumnDescriptor) error {
+				constraints = append(constraints, tableConstraint{
+					name:        notNullConstraintName(db, table, col),
+					kind:        sqlbase.ConstraintTypeCheck,
+					synthesized: true,
+				})
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			for _, c := range sortTableConstraints(constraints) {
 				if err := addRow(
 					defString,                       // constraint_catalog
 					tree.NewDString(db.Name),        // constraint_schema
-					dStringOrNull(name),             // constraint_name
+					dStringOrNull(c.name),           // constraint_name
 					defString,                       // table_catalog
 					tree.NewDString(db.Name),        // table_schema
 					tree.NewDString(table.Name),     // table_name
-					tree.NewDString(string(c.Kind)), // constraint_type
+					tree.NewDString(string(c.kind)), // constraint_type
 					yesOrNoDatum(false),             // is_deferrable
 					yesOrNoDatum(false),             // initially_deferred
+					yesOrNoDatum(!c.unvalidated),    // validated
 				); err != nil {
 					return err
 				}
@@ -631,6 +1362,86 @@ CREATE TABLE information_schema.table_constraints (
 	},
 }
 
+// tableConstraint is a row of information_schema.table_constraints.
+type tableConstraint struct {
+	name string
+	kind sqlbase.ConstraintType
+	// unvalidated is set for the constraints that the existing rows were not
+	// checked against yet.
+	unvalidated bool
+	// synthesized is set for the constraints that don't exist in the table
+	// descriptor, like the ones reporting NOT NULL columns.
+	synthesized bool
+}
+
+// sortTableConstraints sorts the constraints of a table by name and removes
+// the synthesized constraints named like another constraint, so that the rows
+// of information_schema.table_constraints are the same across executions and
+// constraint names are unique within a table.
+func sortTableConstraints(constraints []tableConstraint) []tableConstraint {
+	sort.Slice(constraints, func(i, j int) bool {
+		if constraints[i].name != constraints[j].name {
+			return constraints[i].name < constraints[j].name
+		}
+		return !constraints[i].synthesized && constraints[j].synthesized
+	})
+	res := constraints[:0]
+	for _, c := range constraints {
+		if n := len(res); n > 0 && res[n-1].name == c.name && c.synthesized {
+			continue
+		}
+		res = append(res, c)
+	}
+	return res
+}
+
+// forEachNotNullColumn calls fn for each visible NOT NULL column of a
+// table. Views, sequences and virtual tables have no NOT NULL constraints
+// to report.
+func forEachNotNullColumn(
+	table *sqlbase.TableDescriptor, fn func(*sqlbase.ColumnDescriptor) error,
+) error {
+	if !table.IsTable() || table.IsVirtualTable() {
+		return nil
+	}
+	for i := range table.Columns {
+		col := &table.Columns[i]
+		if col.Nullable || col.Hidden {
+			continue
+		}
+		if err := fn(col); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notNullConstraintName returns the name of the CHECK constraint that
+// reports a NOT NULL column. Postgres does not name NOT NULL constraints
+// either and synthesizes names of the form
+// <namespace oid>_<table oid>_<column number>_not_null; we use the
+// descriptor IDs instead.
+func notNullConstraintName(
+	db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor, col *sqlbase.ColumnDescriptor,
+) string {
+	return fmt.Sprintf("%d_%d_%d_not_null", db.ID, table.ID, col.ID)
+}
+
+// Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-user-mappings.html
+// MySQL:    missing
+var informationSchemaUserMappingsTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.user_mappings (
+	AUTHORIZATION_IDENTIFIER STRING NOT NULL,
+	FOREIGN_SERVER_CATALOG STRING NOT NULL,
+	FOREIGN_SERVER_NAME STRING NOT NULL
+);`,
+	populate: func(_ context.Context, _ *planner, _ string, _ func(...tree.Datum) error) error {
+		// User mappings only exist for foreign servers, which are not supported.
+		return nil
+	},
+}
+
 // Postgres: missing
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/user-privileges-table.html
 var informationSchemaUserPrivileges = virtualSchemaTable{
@@ -649,7 +1460,7 @@ CREATE TABLE information_schema.user_privileges (
 					grantee,            // grantee
 					defString,          // table_catalog
 					tree.NewDString(p), // privilege_type
-					tree.DNull,         // is_grantable
+					yesString,          // is_grantable
 				); err != nil {
 					return err
 				}
@@ -671,21 +1482,81 @@ CREATE TABLE information_schema.table_privileges (
 	TABLE_NAME STRING NOT NULL,
 	PRIVILEGE_TYPE STRING NOT NULL,
 	IS_GRANTABLE STRING NOT NULL,
-	WITH_HIERARCHY STRING NOT NULL
+	WITH_HIERARCHY STRING NOT NULL,
+	EXPIRES_AT TIMESTAMPTZ
+);
+`,
+	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+			for _, u := range table.Privileges.Show() {
+				for _, priv := range u.Privileges {
+					grantor := privilegeGrantor(table.Privileges, u.User, priv)
+					isGrantable := privilegeIsGrantable(table.Privileges, u.User, priv)
+					expiresAt := privilegeExpiresAt(table.Privileges, u.User, priv)
+					if err := addRow(
+						grantor,                     // grantor
+						tree.NewDString(u.User),     // grantee
+						defString,                   // table_catalog
+						tree.NewDString(db.Name),    // table_schema
+						tree.NewDString(table.Name), // table_name
+						tree.NewDString(priv),       // privilege_type
+						isGrantable,                 // is_grantable
+						tree.DNull,                  // with_hierarchy
+						expiresAt,                   // expires_at
+					); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+	},
+}
+
+// Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-role-table-grants.html
+// MySQL:    missing
+var informationSchemaRoleTableGrants = virtualSchemaTable{
+	schema: `
+CREATE TABLE information_schema.role_table_grants (
+	GRANTOR STRING,
+	GRANTEE STRING NOT NULL,
+	TABLE_CATALOG STRING NOT NULL,
+	TABLE_SCHEMA STRING NOT NULL,
+	TABLE_NAME STRING NOT NULL,
+	PRIVILEGE_TYPE STRING NOT NULL,
+	IS_GRANTABLE STRING,
+	WITH_HIERARCHY STRING
 );
 `,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		// As in Postgres, only the privileges granted by or to the current
+		// user or one of its roles are listed.
+		user := p.SessionData().User
+		roles, err := p.MemberOfWithAdminOption(ctx, user)
+		if err != nil {
+			return err
+		}
+		isEnabledRole := func(name string) bool {
+			_, ok := roles[name]
+			return ok || name == user
+		}
 		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
 			for _, u := range table.Privileges.Show() {
 				for _, priv := range u.Privileges {
+					grantor := privilegeGrantor(table.Privileges, u.User, priv)
+					if !isEnabledRole(u.User) &&
+						(grantor == tree.DNull || !isEnabledRole(string(tree.MustBeDString(grantor)))) {
+						continue
+					}
+					isGrantable := privilegeIsGrantable(table.Privileges, u.User, priv)
 					if err := addRow(
-						tree.DNull,                  // grantor
+						grantor,                     // grantor
 						tree.NewDString(u.User),     // grantee
 						defString,                   // table_catalog
 						tree.NewDString(db.Name),    // table_schema
 						tree.NewDString(table.Name), // table_name
 						tree.NewDString(priv),       // privilege_type
-						tree.DNull,                  // is_grantable
+						isGrantable,                 // is_grantable
 						tree.DNull,                  // with_hierarchy
 					); err != nil {
 						return err
@@ -712,30 +1583,158 @@ CREATE TABLE information_schema.tables (
 	TABLE_SCHEMA STRING NOT NULL,
 	TABLE_NAME STRING NOT NULL,
 	TABLE_TYPE STRING NOT NULL,
-	VERSION INT
+	VERSION INT,
+	TABLE_ROWS INT,
+	CREATE_TIME TIMESTAMP,
+	ENGINE STRING,
+	AUTO_INCREMENT INT
 );`,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
-		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+		rowCounts, err := tableRowCounts(ctx, p)
+		if err != nil {
+			return err
+		}
+		createTimes, err := tableCreateTimes(ctx, p)
+		if err != nil {
+			return err
+		}
+		dialect := p.infoSchemaDialect()
+		return forEachTableDescWithTableLookup(ctx, p, prefix, func(
+			db *sqlbase.DatabaseDescriptor,
+			table *sqlbase.TableDescriptor,
+			tableLookup tableLookupFn,
+		) error {
 			if table.IsSequence() {
 				return nil
 			}
 			tableType := tableTypeBaseTable
 			if isVirtualDescriptor(table) {
-				tableType = tableTypeSystemView
+				tableType = dialect.systemViewType()
 			} else if table.IsView() {
 				tableType = tableTypeView
 			}
+			version := tree.NewDInt(tree.DInt(table.Version))
+			tableRows := tree.DNull
+			if !isVirtualDescriptor(table) {
+				if n, ok := rowCounts[table.ID]; ok {
+					tableRows = n.rows
+				}
+			}
+			// CREATE_TIME is NULL for the tables whose creation event isn't
+			// in the event log, e.g. the system tables.
+			createTime := tree.DNull
+			if t, ok := createTimes[table.ID]; ok {
+				createTime = t
+			}
+			// The MySQL-only columns are only filled in for the clients that
+			// expect them, as AUTO_INCREMENT reads the sequences.
+			engine, autoIncrement := tree.DNull, tree.DNull
+			if dialect.fillsMySQLColumns() && tableType == tableTypeBaseTable {
+				engine = engineRocksDB
+				if autoIncrement, err = nextSequenceValue(ctx, p, table, tableLookup); err != nil {
+					return err
+				}
+			}
 			return addRow(
 				defString,                   // table_catalog
 				tree.NewDString(db.Name),    // table_schema
 				tree.NewDString(table.Name), // table_name
 				tableType,                   // table_type
-				tree.NewDInt(tree.DInt(table.Version)), // version
+				version,                     // version
+				tableRows,                   // table_rows
+				createTime,                  // create_time
+				engine,                      // engine
+				autoIncrement,               // auto_increment
 			)
 		})
 	},
 }
 
+// engineRocksDB is the storage engine reported in MySQL's ENGINE column.
+var engineRocksDB = tree.NewDString("RocksDB")
+
+// nextSequenceValue returns the value that the first column of the table
+// whose default uses a sequence would get in the next inserted row, like
+// MySQL's AUTO_INCREMENT, or NULL if there is no such column or the user
+// may not read the sequence.
+func nextSequenceValue(
+	ctx context.Context, p *planner, table *sqlbase.TableDescriptor, tableLookup tableLookupFn,
+) (tree.Datum, error) {
+	for i := range table.Columns {
+		col := &table.Columns[i]
+		if len(col.UsesSequenceIds) == 0 {
+			continue
+		}
+		_, seq := tableLookup(col.UsesSequenceIds[0])
+		if seq == nil || seq.SequenceOpts == nil {
+			return tree.DNull, nil
+		}
+		if p.CheckPrivilege(ctx, seq, privilege.SELECT) != nil {
+			return tree.DNull, nil
+		}
+		val, err := p.GetSequenceValue(ctx, seq)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDInt(tree.DInt(val + seq.SequenceOpts.Increment)), nil
+	}
+	return tree.DNull, nil
+}
+
+// tableRowCount is the row count estimate of a table, from its latest
+// statistic.
+type tableRowCount struct {
+	rows      tree.Datum
+	createdAt time.Time
+}
+
+// tableRowCounts returns the row count estimates of the tables that have
+// statistics, taken from their most recent statistic. It reads
+// system.table_statistics once instead of scanning every table.
+func tableRowCounts(ctx context.Context, p *planner) (map[sqlbase.ID]tableRowCount, error) {
+	// The statistics are read as root, as the estimates are only reported
+	// for the tables the user can see.
+	ip, cleanup := newInternalPlanner(
+		"table-row-counts", p.txn, security.RootUser, p.extendedEvalCtx.MemMetrics, p.ExecCfg())
+	defer cleanup()
+	rows, _ /* cols */, err := ip.queryRows(ctx, `
+SELECT "tableID", "rowCount", "createdAt" FROM system.table_statistics
+ORDER BY "tableID", "createdAt"`)
+	if err != nil {
+		return nil, err
+	}
+	rowCounts := make(map[sqlbase.ID]tableRowCount)
+	for _, r := range rows {
+		// Later statistics override the earlier ones.
+		rowCounts[sqlbase.ID(tree.MustBeDInt(r[0]))] = tableRowCount{
+			rows:      r[1],
+			createdAt: r[2].(*tree.DTimestamp).Time,
+		}
+	}
+	return rowCounts, nil
+}
+
+// tableCreateTimes returns the times the tables and views were created at,
+// keyed by ID, as recorded in the event log.
+func tableCreateTimes(ctx context.Context, p *planner) (map[sqlbase.ID]tree.Datum, error) {
+	// The event log is read as root, as the times are only reported for the
+	// tables the user can see.
+	ip, cleanup := newInternalPlanner(
+		"table-create-times", p.txn, security.RootUser, p.extendedEvalCtx.MemMetrics, p.ExecCfg())
+	defer cleanup()
+	rows, _ /* cols */, err := ip.queryRows(ctx,
+		`SELECT "targetID", timestamp FROM system.eventlog WHERE "eventType" IN ($1, $2)`,
+		string(EventLogCreateTable), string(EventLogCreateView))
+	if err != nil {
+		return nil, err
+	}
+	createTimes := make(map[sqlbase.ID]tree.Datum, len(rows))
+	for _, r := range rows {
+		createTimes[sqlbase.ID(tree.MustBeDInt(r[0]))] = r[1]
+	}
+	return createTimes, nil
+}
+
 // Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-views.html
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/views-table.html
 var informationSchemaViewsTable = virtualSchemaTable{
@@ -753,29 +1752,28 @@ CREATE TABLE information_schema.views (
     IS_TRIGGER_INSERTABLE_INTO STRING NOT NULL
 );`,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
-		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+		return forEachTableDescWithTableLookup(ctx, p, prefix, func(
+			db *sqlbase.DatabaseDescriptor,
+			table *sqlbase.TableDescriptor,
+			tableLookup tableLookupFn,
+		) error {
 			if !table.IsView() {
 				return nil
 			}
-			// Note that the view query printed will not include any column aliases
-			// specified outside the initial view query into the definition returned,
-			// unlike Postgres. For example, for the view created via
-			//  `CREATE VIEW (a) AS SELECT b FROM foo`
-			// we'll only print `SELECT b FROM foo` as the view definition here,
-			// while Postgres would more accurately print `SELECT b AS a FROM foo`.
-			// TODO(a-robinson): Insert column aliases into view query once we
-			// have a semantic query representation to work with (#10083).
+			// As in Postgres, a view is insertable into iff it is updatable.
+			updatable := yesOrNoDatum(p.isUpdatableView(table, tableLookup))
+			definition := viewDefinition(table, p.SessionData().SearchPath)
 			return addRow(
-				defString,                        // table_catalog
-				tree.NewDString(db.Name),         // table_schema
-				tree.NewDString(table.Name),      // table_name
-				tree.NewDString(table.ViewQuery), // view_definition
-				tree.DNull,                       // check_option
-				tree.DNull,                       // is_updatable
-				tree.DNull,                       // is_insertable_into
-				tree.DNull,                       // is_trigger_updatable
-				tree.DNull,                       // is_trigger_deletable
-				tree.DNull,                       // is_trigger_insertable_into
+				defString,                   // table_catalog
+				tree.NewDString(db.Name),    // table_schema
+				tree.NewDString(table.Name), // table_name
+				tree.NewDString(definition), // view_definition
+				tree.DNull,                  // check_option
+				updatable,                   // is_updatable
+				updatable,                   // is_insertable_into
+				tree.DNull,                  // is_trigger_updatable
+				tree.DNull,                  // is_trigger_deletable
+				tree.DNull,                  // is_trigger_insertable_into
 			)
 		})
 	},
@@ -814,9 +1812,13 @@ func forEachDatabaseDesc(
 		dbDescs = append(dbDescs, schema.desc)
 	}
 
+	privs, err := p.makePrivilegeChecker(ctx)
+	if err != nil {
+		return err
+	}
 	sort.Sort(sortedDBDescs(dbDescs))
 	for _, db := range dbDescs {
-		if userCanSeeDatabase(ctx, p, db) {
+		if userCanSeeDatabase(privs, db) {
 			if err := fn(db); err != nil {
 				return err
 			}
@@ -858,13 +1860,14 @@ func forEachTableDescAll(
 	prefix string,
 	fn func(*sqlbase.DatabaseDescriptor, *sqlbase.TableDescriptor) error,
 ) error {
-	return forEachTableDescWithTableLookupInternal(ctx, p, prefix, true /* allowAdding */, func(
-		db *sqlbase.DatabaseDescriptor,
-		table *sqlbase.TableDescriptor,
-		_ tableLookupFn,
-	) error {
-		return fn(db, table)
-	})
+	return forEachTableDescWithTableLookupInternal(
+		ctx, p, prefix, true /* allowAdding */, tableDescFilter{}, func(
+			db *sqlbase.DatabaseDescriptor,
+			table *sqlbase.TableDescriptor,
+			_ tableLookupFn,
+		) error {
+			return fn(db, table)
+		})
 }
 
 // tableLookupFn can be used to retrieve a table descriptor and its corresponding
@@ -907,50 +1910,133 @@ func forEachTableDescWithTableLookup(
 	prefix string,
 	fn func(*sqlbase.DatabaseDescriptor, *sqlbase.TableDescriptor, tableLookupFn) error,
 ) error {
-	return forEachTableDescWithTableLookupInternal(ctx, p, prefix, false /* allowAdding */, fn)
+	return forEachTableDescWithTableLookupInternal(
+		ctx, p, prefix, false /* allowAdding */, tableDescFilter{}, fn)
+}
+
+// tableDescFilter restricts the tables forEachTableDescFiltered calls its
+// function for. Its zero value doesn't restrict them.
+type tableDescFilter struct {
+	// dbName and tableName, if not empty, are the names of the database and
+	// of the tables.
+	dbName, tableName string
+	// id, if not 0, is the ID of the table, which can't be a virtual table.
+	id sqlbase.ID
+}
+
+func (f tableDescFilter) matches(
+	db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor,
+) bool {
+	if f.dbName != "" && db.Name != f.dbName {
+		return false
+	}
+	if f.tableName != "" && table.Name != f.tableName {
+		return false
+	}
+	return f.id == 0 || (table.ID == f.id && !isVirtualDescriptor(table))
+}
+
+// forEachTableDescFiltered is like forEachTableDesc, but only calls fn for
+// the tables matching the given filter.
+func forEachTableDescFiltered(
+	ctx context.Context,
+	p *planner,
+	prefix string,
+	filter tableDescFilter,
+	fn func(*sqlbase.DatabaseDescriptor, *sqlbase.TableDescriptor) error,
+) error {
+	return forEachTableDescWithTableLookupInternal(
+		ctx, p, prefix, false /* allowAdding */, filter, func(
+			db *sqlbase.DatabaseDescriptor,
+			table *sqlbase.TableDescriptor,
+			_ tableLookupFn,
+		) error {
+			return fn(db, table)
+		})
 }
 
+type dbDescTables struct {
+	desc   *sqlbase.DatabaseDescriptor
+	tables map[string]*sqlbase.TableDescriptor
+}
+
+// sizeOfDBDescTables and sizeOfTableMapEntries estimate the memory used by
+// the maps built while iterating over the descriptors, respectively per
+// database and per table, not counting the names and descriptors.
+const sizeOfDBDescTables = int64(unsafe.Sizeof(dbDescTables{}) +
+	unsafe.Sizeof("") + unsafe.Sizeof(sqlbase.ID(0)))
+const sizeOfTableMapEntries = int64(2*unsafe.Sizeof((*sqlbase.TableDescriptor)(nil)) +
+	2*unsafe.Sizeof("") + unsafe.Sizeof(sqlbase.ID(0)))
+
 // forEachTableDescWithTableLookupInternal is the logic that supports
 // forEachTableDescWithTableLookup.
 //
 // The allowAdding argument if true includes newly added tables that
-// are not yet public.
+// are not yet public. The filter argument restricts the tables fn is
+// called for.
 func forEachTableDescWithTableLookupInternal(
 	ctx context.Context,
 	p *planner,
 	prefix string,
 	allowAdding bool,
+	filter tableDescFilter,
 	fn func(*sqlbase.DatabaseDescriptor, *sqlbase.TableDescriptor, tableLookupFn) error,
 ) error {
-	type dbDescTables struct {
-		desc       *sqlbase.DatabaseDescriptor
-		tables     map[string]*sqlbase.TableDescriptor
-		tablesByID map[sqlbase.ID]*sqlbase.TableDescriptor
-	}
 	databases := make(map[string]dbDescTables)
 
+	// The descriptors and the maps indexing them are held for the whole
+	// population of the virtual table, so they are accounted against the
+	// memory budget of the query along with the rows produced.
+	acc := p.EvalContext().Mon.MakeBoundAccount()
+	defer acc.Close(ctx)
+
 	// Handle real schemas.
 	descs, err := p.Tables().getAllDescriptors(ctx, p.txn)
 	if err != nil {
 		return err
 	}
 	dbIDsToName := make(map[sqlbase.ID]string)
+	// tablesByID indexes the tables of all the databases, so that looking
+	// up the tables referenced by foreign keys doesn't scan every database.
+	tablesByID := make(map[sqlbase.ID]*sqlbase.TableDescriptor)
 	// First, iterate through all database descriptors, constructing dbDescTables
 	// objects and populating a mapping from sqlbase.ID to database name.
 	for _, desc := range descs {
 		if db, ok := desc.(*sqlbase.DatabaseDescriptor); ok {
+			sz := sizeOfDBDescTables + 2*int64(len(db.Name)) + int64(db.Size())
+			if err := acc.Grow(ctx, sz); err != nil {
+				return err
+			}
 			dbIDsToName[db.GetID()] = db.GetName()
 			databases[db.GetName()] = dbDescTables{
-				desc:       db,
-				tables:     make(map[string]*sqlbase.TableDescriptor),
-				tablesByID: make(map[sqlbase.ID]*sqlbase.TableDescriptor),
+				desc:   db,
+				tables: make(map[string]*sqlbase.TableDescriptor),
 			}
 		}
 	}
+	// The transaction sees its own schema changes: the descriptors it wrote
+	// take precedence over the scanned ones, the tables it is creating are
+	// visible even if they are not public yet, and the columns and indexes it
+	// is adding are reported as they will be once it commits.
+	uncommitted := make(map[sqlbase.ID]*sqlbase.TableDescriptor)
+	for _, table := range p.Tables().uncommittedTables {
+		uncommitted[table.ID] = table
+	}
+	mutationIDs := txnMutationIDs(p)
 	// Next, iterate through all table descriptors, using the mapping from sqlbase.ID
 	// to database name to add descriptors to a dbDescTables' tables map.
 	for _, desc := range descs {
-		if table, ok := desc.(*sqlbase.TableDescriptor); ok && !table.Dropped() {
+		table, ok := desc.(*sqlbase.TableDescriptor)
+		if !ok {
+			continue
+		}
+		if u, ok := uncommitted[table.ID]; ok {
+			table = u
+		}
+		if ids, ok := mutationIDs[table.ID]; ok {
+			table = withTxnMutations(table, ids)
+		}
+		if !table.Dropped() {
 			dbName, ok := dbIDsToName[table.GetParentID()]
 			if !ok {
 				// Contrary to `crdb_internal.tables`, which for debugging
@@ -961,17 +2047,35 @@ func forEachTableDescWithTableLookupInternal(
 				// parent database must always exist.
 				return errors.Errorf("no database with ID %d found", table.GetParentID())
 			}
-			dbTables := databases[dbName]
-			dbTables.tables[table.Name] = table
-			dbTables.tablesByID[table.ID] = table
+			sz := sizeOfTableMapEntries + int64(len(table.Name)+table.Size())
+			if err := acc.Grow(ctx, sz); err != nil {
+				return err
+			}
+			databases[dbName].tables[table.Name] = table
+			tablesByID[table.ID] = table
 		}
 	}
 
 	// Handle virtual schemas.
 	for dbName, schema := range p.getVirtualTabler().getEntries() {
+		sz := sizeOfDBDescTables + int64(len(dbName)) +
+			int64(len(schema.tables))*sizeOfTableMapEntries
+		if err := acc.Grow(ctx, sz); err != nil {
+			return err
+		}
+		// Like MySQL, report the tables and columns of information_schema
+		// with upper-cased names when asked to.
+		upper := p.infoSchemaDialect().upperCasesOwnNames() && dbName == informationSchemaName
 		dbTables := make(map[string]*sqlbase.TableDescriptor, len(schema.tables))
 		for tableName, entry := range schema.tables {
-			dbTables[tableName] = entry.desc
+			if !entry.isActive() {
+				continue
+			}
+			if upper {
+				dbTables[tableName] = upperCaseTableDesc(entry.desc)
+			} else {
+				dbTables[tableName] = entry.desc
+			}
 		}
 		databases[dbName] = dbDescTables{
 			desc:   schema.desc,
@@ -982,28 +2086,33 @@ func forEachTableDescWithTableLookupInternal(
 	// Create table lookup function, which some callers of this function, like those
 	// dealing with foreign keys, will need.
 	tableLookup := func(id sqlbase.ID) (*sqlbase.DatabaseDescriptor, *sqlbase.TableDescriptor) {
-		for _, db := range databases {
-			table, ok := db.tablesByID[id]
-			if ok {
-				return db.desc, table
-			}
+		table, ok := tablesByID[id]
+		if !ok {
+			return nil, nil
 		}
-		return nil, nil
+		return databases[dbIDsToName[table.ParentID]].desc, table
 	}
 
 	// Below we use the same trick twice of sorting a slice of strings lexicographically
 	// and iterating through these strings to index into a map. Effectively, this allows
 	// us to iterate through a map in sorted order.
+	privs, err := p.makePrivilegeChecker(ctx)
+	if err != nil {
+		return err
+	}
 	dbNames := make([]string, 0, len(databases))
 	for dbName := range databases {
 		dbNames = append(dbNames, dbName)
 	}
 	sort.Strings(dbNames)
 	for _, dbName := range dbNames {
-		if !isDatabaseVisible(dbName, prefix, p.SessionData().User) {
+		if !isDatabaseVisible(dbName, prefix, p.SessionData().User, p.SessionData().CurrentCatalogOnly) {
 			continue
 		}
 		db := databases[dbName]
+		if filter.dbName != "" && db.desc.Name != filter.dbName {
+			continue
+		}
 		dbTableNames := make([]string, 0, len(db.tables))
 		for tableName := range db.tables {
 			dbTableNames = append(dbTableNames, tableName)
@@ -1011,7 +2120,14 @@ func forEachTableDescWithTableLookupInternal(
 		sort.Strings(dbTableNames)
 		for _, tableName := range dbTableNames {
 			tableDesc := db.tables[tableName]
-			if userCanSeeTable(ctx, p, tableDesc, allowAdding) {
+			if !filter.matches(db.desc, tableDesc) {
+				continue
+			}
+			_, own := uncommitted[tableDesc.ID]
+			if userCanSeeTable(privs, tableDesc, allowAdding || own) {
+				if knobs := p.ExecCfg().TestingKnobs; knobs != nil && knobs.VisitVirtualTableDesc != nil {
+					knobs.VisitVirtualTableDesc(db.desc, tableDesc)
+				}
 				if err := fn(db.desc, tableDesc, tableLookup); err != nil {
 					return err
 				}
@@ -1021,6 +2137,69 @@ func forEachTableDescWithTableLookupInternal(
 	return nil
 }
 
+// upperCaseTableDesc returns a copy of a virtual table descriptor in which
+// the names of the table and of its columns are upper-cased.
+func upperCaseTableDesc(desc *sqlbase.TableDescriptor) *sqlbase.TableDescriptor {
+	upper := *desc
+	upper.Name = strings.ToUpper(desc.Name)
+	upper.Columns = make([]sqlbase.ColumnDescriptor, len(desc.Columns))
+	for i, col := range desc.Columns {
+		col.Name = strings.ToUpper(col.Name)
+		upper.Columns[i] = col
+	}
+	return &upper
+}
+
+// txnMutationIDs returns the IDs of the mutations queued by the current
+// transaction, by table.
+func txnMutationIDs(p *planner) map[sqlbase.ID][]sqlbase.MutationID {
+	ids := make(map[sqlbase.ID][]sqlbase.MutationID)
+	// Internal planners don't run schema changes.
+	if scc := p.extendedEvalCtx.SchemaChangers; scc != nil {
+		for _, sc := range scc.schemaChangers {
+			if sc.mutationID != sqlbase.InvalidMutationID {
+				ids[sc.tableID] = append(ids[sc.tableID], sc.mutationID)
+			}
+		}
+	}
+	return ids
+}
+
+// withTxnMutations returns a copy of the table descriptor in which the
+// columns and indexes added by the given mutations are public, as they will
+// be once the transaction that queued the mutations commits.
+func withTxnMutations(
+	table *sqlbase.TableDescriptor, mutationIDs []sqlbase.MutationID,
+) *sqlbase.TableDescriptor {
+	desc := *table
+	desc.Columns = append([]sqlbase.ColumnDescriptor(nil), table.Columns...)
+	desc.Indexes = append([]sqlbase.IndexDescriptor(nil), table.Indexes...)
+	desc.Mutations = nil
+	for _, m := range table.Mutations {
+		if m.Direction == sqlbase.DescriptorMutation_ADD && containsMutationID(mutationIDs, m.MutationID) {
+			if col := m.GetColumn(); col != nil {
+				desc.Columns = append(desc.Columns, *col)
+				continue
+			}
+			if idx := m.GetIndex(); idx != nil {
+				desc.Indexes = append(desc.Indexes, *idx)
+				continue
+			}
+		}
+		desc.Mutations = append(desc.Mutations, m)
+	}
+	return &desc
+}
+
+func containsMutationID(ids []sqlbase.MutationID, id sqlbase.MutationID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
 func forEachIndexInTable(
 	table *sqlbase.TableDescriptor, fn func(*sqlbase.IndexDescriptor) error,
 ) error {
@@ -1037,34 +2216,67 @@ func forEachIndexInTable(
 	return nil
 }
 
+// forEachColumnInTable calls fn with the visible columns of the table the
+// user can see (see userCanSeeColumn) and their 1-indexed positions, which
+// are the same for all users.
 func forEachColumnInTable(
-	table *sqlbase.TableDescriptor, fn func(*sqlbase.ColumnDescriptor) error,
+	table *sqlbase.TableDescriptor,
+	privs *privilegeChecker,
+	fn func(int, *sqlbase.ColumnDescriptor) error,
+) error {
+	return forEachColumnInTableWithHidden(table, false /* includeHidden */, privs, fn)
+}
+
+// forEachColumnInTableWithHidden is like forEachColumnInTable, but also
+// calls fn with the hidden columns if includeHidden is set.
+func forEachColumnInTableWithHidden(
+	table *sqlbase.TableDescriptor,
+	includeHidden bool,
+	privs *privilegeChecker,
+	fn func(int, *sqlbase.ColumnDescriptor) error,
 ) error {
 	// Table descriptors already hold columns in-order.
+	position := 0
 	for i := range table.Columns {
-		if !table.Columns[i].Hidden {
-			if err := fn(&table.Columns[i]); err != nil {
-				return err
-			}
+		column := &table.Columns[i]
+		if !includeHidden && column.Hidden {
+			continue
+		}
+		position++
+		if !userCanSeeColumn(privs, table, column) {
+			continue
+		}
+		if err := fn(position, column); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// forEachColumnInIndex is like forEachColumnInTable for the columns of an
+// index, with their positions in the index.
 func forEachColumnInIndex(
 	table *sqlbase.TableDescriptor,
 	index *sqlbase.IndexDescriptor,
-	fn func(*sqlbase.ColumnDescriptor) error,
+	privs *privilegeChecker,
+	fn func(int, *sqlbase.ColumnDescriptor) error,
 ) error {
 	colMap := make(map[sqlbase.ColumnID]*sqlbase.ColumnDescriptor, len(table.Columns))
 	for i, column := range table.Columns {
 		colMap[column.ID] = &table.Columns[i]
 	}
+	position := 0
 	for _, columnID := range index.ColumnIDs {
-		if column := colMap[columnID]; !column.Hidden {
-			if err := fn(column); err != nil {
-				return err
-			}
+		column := colMap[columnID]
+		if column.Hidden {
+			continue
+		}
+		position++
+		if !userCanSeeColumn(privs, table, column) {
+			continue
+		}
+		if err := fn(position, column); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -1124,16 +2336,47 @@ func forEachRoleMembership(
 	return nil
 }
 
-func userCanSeeDatabase(ctx context.Context, p *planner, db *sqlbase.DatabaseDescriptor) bool {
-	return p.CheckAnyPrivilege(ctx, db) == nil
+func userCanSeeDatabase(privs *privilegeChecker, db *sqlbase.DatabaseDescriptor) bool {
+	return privs.anyPrivilege(db)
 }
 
 func userCanSeeTable(
-	ctx context.Context, p *planner, table *sqlbase.TableDescriptor, allowAdding bool,
+	privs *privilegeChecker, table *sqlbase.TableDescriptor, allowAdding bool,
 ) bool {
 	if !(table.State == sqlbase.TableDescriptor_PUBLIC ||
 		(allowAdding && table.State == sqlbase.TableDescriptor_ADD)) {
 		return false
 	}
-	return p.CheckAnyPrivilege(ctx, table) == nil
+	if privs.anyPrivilege(table) {
+		return true
+	}
+	// The privileges on some of the columns are enough to see the table, but
+	// not its other columns.
+	for i := range table.Columns {
+		if privs.anyColumnPrivilege(&table.Columns[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// userCanSeeColumn returns whether the user can see a column of a table it
+// can see: all the columns are visible with a privilege on the table, and
+// otherwise only the columns the user has privileges on.
+func userCanSeeColumn(
+	privs *privilegeChecker, table *sqlbase.TableDescriptor, column *sqlbase.ColumnDescriptor,
+) bool {
+	return privs.anyPrivilege(table) || privs.anyColumnPrivilege(column)
+}
+
+// userCanSeeColumnByName is like userCanSeeColumn for a column named by an
+// index or a constraint.
+func userCanSeeColumnByName(
+	privs *privilegeChecker, table *sqlbase.TableDescriptor, name string,
+) bool {
+	if privs.anyPrivilege(table) {
+		return true
+	}
+	column, _, err := table.FindColumnByName(tree.Name(name))
+	return err == nil && privs.anyColumnPrivilege(&column)
 }
@@ -0,0 +1,207 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+// infoSchemaDialect renders information_schema following the conventions of
+// a version of a database, for the clients that expect its quirks. The
+// dialect is selected per session with the information_schema_compat
+// session variable.
+type infoSchemaDialect interface {
+	// describesAllDatabases returns whether information_schema describes all
+	// the databases when it is not qualified with one, instead of only the
+	// current database.
+	describesAllDatabases() bool
+	// upperCasesOwnNames returns whether the names of the tables and columns
+	// of information_schema itself are reported upper-cased.
+	upperCasesOwnNames() bool
+	// fillsMySQLColumns returns whether the columns that only MySQL reports,
+	// like tables.ENGINE, are filled in.
+	fillsMySQLColumns() bool
+	// systemViewType returns the TABLE_TYPE of the virtual tables.
+	systemViewType() tree.Datum
+	// dataType returns the DATA_TYPE of a column of the given type.
+	dataType(colType sqlbase.ColumnType) tree.Datum
+}
+
+// Each dialect is registered under a name of the form db:version, where
+// db is also accepted as an alias of the registered version.
+const (
+	crdbInfoSchemaDialectName     = "crdb"
+	postgresInfoSchemaDialectName = "postgres:10"
+	mysqlInfoSchemaDialectName    = "mysql:5.7"
+)
+
+var infoSchemaDialects = map[string]infoSchemaDialect{
+	crdbInfoSchemaDialectName:     crdbInfoSchemaDialect{},
+	postgresInfoSchemaDialectName: postgresInfoSchemaDialect{},
+	mysqlInfoSchemaDialectName:    mysqlInfoSchemaDialect{},
+}
+
+var infoSchemaDialectAliases = map[string]string{
+	"cockroachdb": crdbInfoSchemaDialectName,
+	"postgres":    postgresInfoSchemaDialectName,
+	"mysql":       mysqlInfoSchemaDialectName,
+}
+
+// canonicalInfoSchemaDialectName returns the name the dialect with the given
+// name or alias is registered under.
+func canonicalInfoSchemaDialectName(name string) (string, error) {
+	name = strings.ToLower(name)
+	if canonical, ok := infoSchemaDialectAliases[name]; ok {
+		name = canonical
+	}
+	if _, ok := infoSchemaDialects[name]; !ok {
+		return "", fmt.Errorf("set information_schema_compat: \"%s\" not supported", name)
+	}
+	return name, nil
+}
+
+// infoSchemaDialect returns the dialect of information_schema selected by
+// the session.
+func (p *planner) infoSchemaDialect() infoSchemaDialect {
+	if d, ok := infoSchemaDialects[p.SessionData().InformationSchemaCompat]; ok {
+		return d
+	}
+	return crdbInfoSchemaDialect{}
+}
+
+// crdbInfoSchemaDialect is the native dialect, in which each database is a
+// TABLE_SCHEMA and the types are reported as in CREATE TABLE, except for the
+// JSON and array types: the standard type-mapping of the drivers only
+// recognizes them by their PostgreSQL names.
+type crdbInfoSchemaDialect struct{}
+
+func (crdbInfoSchemaDialect) describesAllDatabases() bool { return false }
+func (crdbInfoSchemaDialect) upperCasesOwnNames() bool    { return false }
+func (crdbInfoSchemaDialect) fillsMySQLColumns() bool     { return false }
+func (crdbInfoSchemaDialect) systemViewType() tree.Datum  { return tableTypeSystemView }
+
+func (crdbInfoSchemaDialect) dataType(colType sqlbase.ColumnType) tree.Datum {
+	switch colType.SemanticType {
+	case sqlbase.ColumnType_ARRAY:
+		return dataTypeArray
+	case sqlbase.ColumnType_JSON:
+		return dataTypeJSONB
+	}
+	return tree.NewDString(colType.SQLString())
+}
+
+// postgresInfoSchemaDialect follows PostgreSQL 10, which reports the
+// standard SQL names of the types and has no system views: its
+// information_schema is made of plain views.
+// See https://www.postgresql.org/docs/10/static/infoschema-columns.html
+type postgresInfoSchemaDialect struct{}
+
+func (postgresInfoSchemaDialect) describesAllDatabases() bool { return false }
+func (postgresInfoSchemaDialect) upperCasesOwnNames() bool    { return false }
+func (postgresInfoSchemaDialect) fillsMySQLColumns() bool     { return false }
+func (postgresInfoSchemaDialect) systemViewType() tree.Datum  { return tableTypeView }
+
+var (
+	dataTypeArray = tree.NewDString("ARRAY")
+	dataTypeJSONB = tree.NewDString("jsonb")
+)
+
+func (postgresInfoSchemaDialect) dataType(colType sqlbase.ColumnType) tree.Datum {
+	switch colType.SemanticType {
+	case sqlbase.ColumnType_ARRAY:
+		// The element type is reported by element_types.
+		return dataTypeArray
+	case sqlbase.ColumnType_INT:
+		switch colType.VisibleType {
+		case sqlbase.ColumnType_SMALLINT:
+			return tree.NewDString("smallint")
+		case sqlbase.ColumnType_INTEGER:
+			return tree.NewDString("integer")
+		case sqlbase.ColumnType_BIT:
+			return tree.NewDString("bit")
+		}
+	case sqlbase.ColumnType_FLOAT:
+		if colType.VisibleType == sqlbase.ColumnType_REAL {
+			return tree.NewDString("real")
+		}
+	case sqlbase.ColumnType_STRING, sqlbase.ColumnType_COLLATEDSTRING:
+		if colType.Width > 0 {
+			return tree.NewDString("character varying")
+		}
+	case sqlbase.ColumnType_JSON:
+		return dataTypeJSONB
+	}
+	return tree.NewDString(colType.ToDatumType().SQLName())
+}
+
+// mysqlInfoSchemaDialect follows MySQL 5.7, whose information_schema
+// describes all the databases, each as a TABLE_SCHEMA, and reports its own
+// identifiers upper-cased and the types with MySQL's names.
+// See https://dev.mysql.com/doc/refman/5.7/en/columns-table.html
+type mysqlInfoSchemaDialect struct{}
+
+func (mysqlInfoSchemaDialect) describesAllDatabases() bool { return true }
+func (mysqlInfoSchemaDialect) upperCasesOwnNames() bool    { return true }
+func (mysqlInfoSchemaDialect) fillsMySQLColumns() bool     { return true }
+func (mysqlInfoSchemaDialect) systemViewType() tree.Datum  { return tableTypeSystemView }
+
+func (mysqlInfoSchemaDialect) dataType(colType sqlbase.ColumnType) tree.Datum {
+	var name string
+	switch colType.SemanticType {
+	case sqlbase.ColumnType_BOOL:
+		name = "tinyint"
+	case sqlbase.ColumnType_INT:
+		switch colType.VisibleType {
+		case sqlbase.ColumnType_SMALLINT:
+			name = "smallint"
+		case sqlbase.ColumnType_INTEGER:
+			name = "int"
+		case sqlbase.ColumnType_BIT:
+			name = "bit"
+		default:
+			name = "bigint"
+		}
+	case sqlbase.ColumnType_FLOAT:
+		name = "double"
+		if colType.VisibleType == sqlbase.ColumnType_REAL {
+			name = "float"
+		}
+	case sqlbase.ColumnType_DECIMAL:
+		name = "decimal"
+	case sqlbase.ColumnType_STRING, sqlbase.ColumnType_COLLATEDSTRING:
+		name = "text"
+		if colType.Width > 0 {
+			name = "varchar"
+		}
+	case sqlbase.ColumnType_BYTES:
+		name = "longblob"
+	case sqlbase.ColumnType_TIMESTAMP:
+		name = "datetime"
+	case sqlbase.ColumnType_TIMESTAMPTZ:
+		name = "timestamp"
+	case sqlbase.ColumnType_JSON:
+		name = "json"
+	default:
+		// DATE and TIME have the same name in MySQL. The other types have no
+		// MySQL equivalent.
+		name = strings.ToLower(colType.ToDatumType().String())
+	}
+	return tree.NewDString(name)
+}
@@ -0,0 +1,238 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/storage/batcheval"
+	"github.com/cockroachdb/cockroach/pkg/storage/storagebase"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+// TestInformationSchemaMonitorsMemory verifies that populating the
+// information_schema tables records the memory used by the descriptors and
+// rows, so that introspecting a large schema fails cleanly when it exceeds
+// the memory budget.
+func TestInformationSchemaMonitorsMemory(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		SQLMemoryPoolSize: lowMemoryBudget,
+	})
+	defer s.Stopper().Stop(context.Background())
+
+	if _, err := sqlDB.Exec(`CREATE DATABASE d`); err != nil {
+		t.Fatal(err)
+	}
+	// Long column names make the descriptors, and the rows describing their
+	// columns, large enough to exhaust the budget.
+	const numTables, numCols, nameLen = 60, 10, 1000
+	for i := 0; i < numTables; i++ {
+		cols := make([]string, numCols)
+		for j := range cols {
+			cols[j] = fmt.Sprintf("%s%d INT", strings.Repeat("c", nameLen), j)
+		}
+		if _, err := sqlDB.Exec(fmt.Sprintf(
+			`CREATE TABLE d.t%d (%s)`, i, strings.Join(cols, ", ")),
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The rows of information_schema.tables are small, but the descriptors
+	// of the tables are accounted for while they are populated.
+	for _, table := range []string{`columns`, `tables`} {
+		query := fmt.Sprintf(`SELECT count(*) FROM d.information_schema.%s`, table)
+		_, err := sqlDB.Exec(query)
+		if pqErr, ok := err.(*pq.Error); !ok || pqErr.Code != pgerror.CodeOutOfMemoryError {
+			t.Fatalf("expected %q to consume too much memory, got %v", query, err)
+		}
+	}
+}
+
+// TestVirtualTablesShareDescriptors verifies that the virtual tables scan
+// the descriptors once per transaction, and again after the transaction
+// modified them.
+func TestVirtualTablesShareDescriptors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// This filter counts the scans of all the descriptors.
+	descsKey := sqlbase.MakeAllDescsMetadataKey()
+	var scans int64
+	filter := func(filterArgs storagebase.FilterArgs) *roachpb.Error {
+		if filterArgs.Req.Method() == roachpb.Scan && filterArgs.Req.Header().Key.Equal(descsKey) {
+			atomic.AddInt64(&scans, 1)
+		}
+		return nil
+	}
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		Knobs: base.TestingKnobs{Store: &storage.StoreTestingKnobs{
+			EvalKnobs: batcheval.TestingKnobs{
+				TestingEvalFilter: filter,
+			},
+		}},
+	})
+	defer s.Stopper().Stop(context.Background())
+
+	if _, err := sqlDB.Exec(`CREATE DATABASE d; CREATE TABLE d.t (a INT)`); err != nil {
+		t.Fatal(err)
+	}
+
+	atomic.StoreInt64(&scans, 0)
+	if _, err := sqlDB.Exec(`
+SELECT count(*)
+  FROM d.information_schema.tables
+  JOIN d.information_schema.columns USING (table_schema, table_name)
+  JOIN d.information_schema.table_constraints USING (table_schema, table_name)`,
+	); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt64(&scans); n != 1 {
+		t.Errorf("expected the join to scan the descriptors once, got %d scans", n)
+	}
+
+	txn, err := sqlDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	atomic.StoreInt64(&scans, 0)
+	for i, d := range []struct {
+		stmt          string
+		expectedScans int64
+	}{
+		{`SELECT count(*) FROM d.information_schema.tables`, 1},
+		{`SELECT count(*) FROM d.information_schema.columns`, 1},
+		{`ALTER TABLE d.t ADD COLUMN b INT`, 1},
+		{`SELECT count(*) FROM d.information_schema.columns`, 2},
+		// The transaction modifies the table a second time.
+		{`ALTER TABLE d.t ADD COLUMN c INT`, 2},
+		{`SELECT count(*) FROM d.information_schema.columns`, 3},
+		{`SELECT count(*) FROM crdb_internal.tables`, 3},
+	} {
+		if _, err := txn.Exec(d.stmt); err != nil {
+			t.Fatal(err)
+		}
+		if n := atomic.LoadInt64(&scans); n != d.expectedScans {
+			t.Errorf("%d: expected %d scans after %q, got %d", i, d.expectedScans, d.stmt, n)
+		}
+	}
+	if err := txn.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestVirtualTablesVisitConstrainedDescriptors verifies that the equality
+// filters on the key columns of a virtual table restrict the descriptors its
+// population visits.
+func TestVirtualTablesVisitConstrainedDescriptors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var visits int64
+	s, sqlDB, kvDB := serverutils.StartServer(t, base.TestServerArgs{
+		Knobs: base.TestingKnobs{SQLExecutor: &ExecutorTestingKnobs{
+			VisitVirtualTableDesc: func(db *sqlbase.DatabaseDescriptor, _ *sqlbase.TableDescriptor) {
+				if db.Name == "d" {
+					atomic.AddInt64(&visits, 1)
+				}
+			},
+		}},
+	})
+	defer s.Stopper().Stop(context.Background())
+
+	if _, err := sqlDB.Exec(`
+CREATE DATABASE d;
+CREATE TABLE d.a (x INT);
+CREATE TABLE d.b (x INT, y INT);
+CREATE TABLE d.c (x INT);
+`); err != nil {
+		t.Fatal(err)
+	}
+	id := sqlbase.GetTableDescriptor(kvDB, "d", "b").ID
+
+	for _, d := range []struct {
+		query          string
+		expectedRows   int
+		expectedVisits int64
+	}{
+		{`SELECT column_name FROM d.information_schema.columns`, 4, 3},
+		{`SELECT column_name FROM d.information_schema.columns WHERE table_name = 'b'`, 2, 1},
+		{`SELECT column_name FROM d.information_schema.columns WHERE table_schema = 'd' AND table_name = 'b' AND column_name = 'x'`, 1, 1},
+		{`SELECT column_name FROM d.information_schema.columns WHERE table_schema = 'e'`, 0, 0},
+		{`SELECT column_name FROM d.information_schema.columns WHERE table_name = 'z'`, 0, 0},
+		{fmt.Sprintf(`SELECT relname FROM d.pg_catalog.pg_class WHERE oid = %d`, id), 1, 1},
+		{fmt.Sprintf(`SELECT attname FROM d.pg_catalog.pg_attribute WHERE attrelid = %d`, id), 2, 1},
+		// The OIDs which aren't descriptor IDs, e.g. those of indexes, fall
+		// back to visiting every table.
+		{`SELECT relname FROM d.pg_catalog.pg_class WHERE oid = 1`, 0, 3},
+	} {
+		t.Run(d.query, func(t *testing.T) {
+			atomic.StoreInt64(&visits, 0)
+			rows, err := sqlDB.Query(d.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var count int
+			for rows.Next() {
+				count++
+			}
+			if err := rows.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if count != d.expectedRows {
+				t.Errorf("expected %d rows, got %d", d.expectedRows, count)
+			}
+			if n := atomic.LoadInt64(&visits); n != d.expectedVisits {
+				t.Errorf("expected %d descriptor visits, got %d", d.expectedVisits, n)
+			}
+		})
+	}
+}
+
+func TestSortTableConstraints(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	check := sqlbase.ConstraintTypeCheck
+	constraints := []tableConstraint{
+		{name: "primary", kind: sqlbase.ConstraintTypePK},
+		{name: "51_52_2_not_null", kind: check, synthesized: true},
+		{name: "51_52_1_not_null", kind: check, synthesized: true},
+		{name: "c", kind: check},
+		// A constraint named like a NOT NULL column hides the synthesized one.
+		{name: "51_52_2_not_null", kind: check},
+	}
+	expected := []tableConstraint{
+		{name: "51_52_1_not_null", kind: check, synthesized: true},
+		{name: "51_52_2_not_null", kind: check},
+		{name: "c", kind: check},
+		{name: "primary", kind: sqlbase.ConstraintTypePK},
+	}
+	if res := sortTableConstraints(constraints); !reflect.DeepEqual(res, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, res)
+	}
+}
@@ -64,7 +64,8 @@ type insertNode struct {
 var _ autoCommitNode = &insertNode{}
 
 // Insert inserts rows into the database.
-// Privileges: INSERT on table. Also requires UPDATE on "ON DUPLICATE KEY UPDATE".
+// Privileges: INSERT on table, or on the inserted columns. Also requires UPDATE
+// on "ON DUPLICATE KEY UPDATE".
 //   Notes: postgres requires INSERT. No "on duplicate key update" option.
 //          mysql requires INSERT. Also requires UPDATE on "ON DUPLICATE KEY UPDATE".
 func (p *planner) Insert(
@@ -82,7 +83,7 @@ func (p *planner) Insert(
 		return nil, err
 	}
 
-	en, err := p.makeEditNode(ctx, tn, privilege.INSERT)
+	en, err := p.makeColumnEditNode(ctx, tn, privilege.INSERT)
 	if err != nil {
 		return nil, err
 	}
@@ -108,6 +109,9 @@ func (p *planner) Insert(
 			return nil, err
 		}
 	}
+	if err := p.CheckColumnsPrivilege(ctx, en.tableDesc, cols, privilege.INSERT); err != nil {
+		return nil, err
+	}
 	// Number of columns expecting an input. This doesn't include the
 	// columns receiving a default value.
 	numInputColumns := len(cols)
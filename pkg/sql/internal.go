@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/distsqlrun"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
@@ -96,6 +97,61 @@ func (ie InternalExecutor) QueryRows(
 	return rows, cols, err
 }
 
+// QueryVirtualTableInTransaction returns the rows of a virtual table, e.g.
+// information_schema.tables, populated as part of the supplied transaction
+// on behalf of the given user: as when the user queries the table, the
+// objects it has no privileges on are left out. If dbName is not empty, only
+// the objects of that database are listed, as when the table is qualified
+// with it.
+func (ie *InternalExecutor) QueryVirtualTableInTransaction(
+	ctx context.Context, user string, txn *client.Txn, dbName, schemaName, tableName string,
+) ([]tree.Datums, sqlbase.ResultColumns, error) {
+	p, cleanup := newInternalPlanner(
+		"query-virtual-table", txn, user, ie.ExecCfg.LeaseManager.memMetrics, ie.ExecCfg)
+	defer cleanup()
+	ie.initSession(p)
+
+	tn := tree.MakeTableName(tree.Name(schemaName), tree.Name(tableName))
+	e, err := p.getVirtualTabler().getVirtualTableEntry(&tn)
+	if err != nil {
+		return nil, nil, err
+	}
+	if e.desc == nil {
+		return nil, nil, sqlbase.NewUndefinedRelationError(&tn)
+	}
+	var rows []tree.Datums
+	addRow := func(datums ...tree.Datum) error {
+		rows = append(rows, append(tree.Datums(nil), datums...))
+		return nil
+	}
+	if e.tableDef.gather != "" {
+		var spec distsqlrun.VirtualTableReaderSpec
+		if spec, err = e.gatherSpec(ctx, p, tn.String()); err == nil {
+			err = p.gatherNodeLocalRows(ctx, &spec, addRow)
+		}
+	} else {
+		err = e.tableDef.populateRows(ctx, p, dbName, nil /* constraints */, addRow)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return rows, virtualDescColumns(e.desc), nil
+}
+
+// RequireSuperUser returns an error unless the given user is root or a
+// member of the admin role, as when the user runs a statement only
+// superusers may run. The role memberships are read as part of the supplied
+// transaction.
+func (ie *InternalExecutor) RequireSuperUser(
+	ctx context.Context, user string, txn *client.Txn, action string,
+) error {
+	p, cleanup := newInternalPlanner(
+		"require-superuser", txn, user, ie.ExecCfg.LeaseManager.memMetrics, ie.ExecCfg)
+	defer cleanup()
+	ie.initSession(p)
+	return p.RequireSuperUser(ctx, action)
+}
+
 // GetTableSpan gets the key span for a SQL table, including any indices.
 func (ie *InternalExecutor) GetTableSpan(
 	ctx context.Context, user string, txn *client.Txn, dbName, tableName string,
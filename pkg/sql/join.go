@@ -300,6 +300,7 @@ func (p *planner) makeJoin(
 		r.addRenderColumn(expr, symbolicExprStr(expr), c)
 	}
 	rInfo.sourceColumns = r.columns
+	rInfo.copyDeprecationNotices(info, func(col int) int { return remapped[col] })
 
 	// Copy the aliases, remapping the columns as necessary. We extract any
 	// anonymous aliases for special handling.
@@ -272,6 +272,8 @@ func makeEqualityPredicate(
 		sourceColumns: columns,
 		sourceAliases: aliases,
 	}
+	info.copyDeprecationNotices(left, func(col int) int { return col })
+	info.copyDeprecationNotices(right, func(col int) int { return len(left.sourceColumns) + col })
 
 	pred := &joinPredicate{
 		joinType:             typ,
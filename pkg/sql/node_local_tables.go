@@ -0,0 +1,222 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/distsqlrun"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+)
+
+// nodeLocalTable describes a virtual table whose rows are local to the node
+// serving it, like the sessions of the node. The cluster-wide tables gathering
+// these rows from every node, like crdb_internal.cluster_sessions, are read by
+// a VirtualTableReader processor on each node.
+type nodeLocalTable struct {
+	// superuserOnly is set for the tables only superusers may read.
+	superuserOnly bool
+	// populate produces the rows of the table on the node of execCfg. The
+	// nodes listed as unreachable in the spec are reported by a row holding
+	// only their ID, by the tables listing them.
+	populate func(
+		ctx context.Context,
+		execCfg *ExecutorConfig,
+		spec *distsqlrun.VirtualTableReaderSpec,
+		addRow func(...tree.Datum) error,
+	) error
+}
+
+// nodeLocalTables maps the names of the node-local virtual tables to their
+// description.
+var nodeLocalTables = map[string]nodeLocalTable{
+	"crdb_internal.node_metrics": {
+		superuserOnly: true,
+		populate: func(
+			_ context.Context,
+			execCfg *ExecutorConfig,
+			_ *distsqlrun.VirtualTableReaderSpec,
+			addRow func(...tree.Datum) error,
+		) error {
+			return populateNodeMetrics(execCfg, addRow)
+		},
+	},
+	"crdb_internal.node_queries": {
+		populate: func(
+			ctx context.Context,
+			execCfg *ExecutorConfig,
+			spec *distsqlrun.VirtualTableReaderSpec,
+			addRow func(...tree.Datum) error,
+		) error {
+			response, err := listNodeLocalSessions(ctx, execCfg, spec)
+			if err != nil {
+				return err
+			}
+			return populateQueriesTable(ctx, addRow, response)
+		},
+	},
+	"crdb_internal.node_sessions": {
+		populate: func(
+			ctx context.Context,
+			execCfg *ExecutorConfig,
+			spec *distsqlrun.VirtualTableReaderSpec,
+			addRow func(...tree.Datum) error,
+		) error {
+			response, err := listNodeLocalSessions(ctx, execCfg, spec)
+			if err != nil {
+				return err
+			}
+			return populateSessionsTable(ctx, addRow, response)
+		},
+	},
+}
+
+// NodeLocalVirtualTables produces the rows of the node-local virtual tables
+// for the VirtualTableReader processors running on the node.
+type NodeLocalVirtualTables struct {
+	// ExecCfg is the configuration of the executor of the node. As it is
+	// created after the DistSQL server, it is set once the server is
+	// configured.
+	ExecCfg *ExecutorConfig
+}
+
+var _ distsqlrun.VirtualTableRowSource = &NodeLocalVirtualTables{}
+
+// NodeLocalRows is part of the distsqlrun.VirtualTableRowSource interface.
+func (t *NodeLocalVirtualTables) NodeLocalRows(
+	ctx context.Context,
+	spec *distsqlrun.VirtualTableReaderSpec,
+	addRow func(tree.Datums) error,
+) error {
+	table, ok := nodeLocalTables[spec.Table]
+	if !ok {
+		return errors.Errorf("unknown node-local virtual table %s", spec.Table)
+	}
+	return table.populate(ctx, t.ExecCfg, spec, func(datums ...tree.Datum) error {
+		return addRow(datums)
+	})
+}
+
+// makeVirtualTableReaderSpec returns the spec of the VirtualTableReaders
+// reading the node-local table for the given user.
+func makeVirtualTableReaderSpec(
+	table string, columns sqlbase.ResultColumns, user string,
+) (distsqlrun.VirtualTableReaderSpec, error) {
+	spec := distsqlrun.VirtualTableReaderSpec{
+		Table:       table,
+		ColumnTypes: make([]sqlbase.ColumnType, len(columns)),
+		User:        user,
+	}
+	for i, col := range columns {
+		colTyp, err := sqlbase.DatumTypeToColumnType(col.Typ)
+		if err != nil {
+			return distsqlrun.VirtualTableReaderSpec{}, err
+		}
+		spec.ColumnTypes[i] = colTyp
+	}
+	return spec, nil
+}
+
+// listNodeLocalSessions lists the sessions of the user of the spec on the
+// node of execCfg. The unreachable nodes of the spec are returned as errors.
+func listNodeLocalSessions(
+	ctx context.Context, execCfg *ExecutorConfig, spec *distsqlrun.VirtualTableReaderSpec,
+) (*serverpb.ListSessionsResponse, error) {
+	req := serverpb.ListSessionsRequest{Username: spec.User}
+	response, err := execCfg.StatusServer.ListLocalSessions(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	for _, nodeID := range spec.UnreachableNodes {
+		response.Errors = append(response.Errors, serverpb.ListSessionsError{
+			NodeID:  nodeID,
+			Message: fmt.Sprintf("node %d is not live or not healthy", nodeID),
+		})
+	}
+	return response, nil
+}
+
+// populateNodeMetrics produces the current values of the gauges and counters
+// registered on the node of execCfg and on its stores. Histograms and rates,
+// which don't hold a single value, are omitted.
+func populateNodeMetrics(execCfg *ExecutorConfig, addRow func(...tree.Datum) error) error {
+	recorder := execCfg.MetricsRecorder
+	if recorder == nil {
+		return nil
+	}
+	nodeRegistry, storeRegistries := recorder.Registries()
+	if nodeRegistry == nil {
+		return nil
+	}
+
+	nodeID := tree.NewDInt(tree.DInt(int64(execCfg.NodeID.Get())))
+	addRegistryRows := func(storeID tree.Datum, registry *metric.Registry) error {
+		type nodeMetric struct {
+			name, typ string
+			value     float64
+		}
+		var metrics []nodeMetric
+		// The registry is locked while it is iterated over, so the rows
+		// are added after collecting the values.
+		registry.Each(func(name string, val interface{}) {
+			switch m := val.(type) {
+			case *metric.Gauge:
+				metrics = append(metrics, nodeMetric{name, "gauge", float64(m.Value())})
+			case *metric.GaugeFloat64:
+				metrics = append(metrics, nodeMetric{name, "gauge", m.Value()})
+			case *metric.Counter:
+				metrics = append(metrics, nodeMetric{name, "counter", float64(m.Count())})
+			}
+		})
+		sort.Slice(metrics, func(i, j int) bool { return metrics[i].name < metrics[j].name })
+		for _, m := range metrics {
+			if err := addRow(
+				nodeID,
+				storeID,
+				tree.NewDString(m.name),
+				tree.NewDString(m.typ),
+				tree.NewDFloat(tree.DFloat(m.value)),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := addRegistryRows(tree.DNull, nodeRegistry); err != nil {
+		return err
+	}
+	storeIDs := make([]roachpb.StoreID, 0, len(storeRegistries))
+	for storeID := range storeRegistries {
+		storeIDs = append(storeIDs, storeID)
+	}
+	sort.Slice(storeIDs, func(i, j int) bool { return storeIDs[i] < storeIDs[j] })
+	for _, storeID := range storeIDs {
+		if err := addRegistryRows(
+			tree.NewDInt(tree.DInt(storeID)), storeRegistries[storeID],
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// SessionNotices collects the notices (non-error messages, such as
+// warnings about the use of deprecated features) to send to the client of
+// a session. Notices are raised while planning statements and are sent
+// along with the results of the next statement completing, or of the
+// statement preparation that raised them.
+//
+// Each distinct notice is only sent once per session, so that clients
+// running the same statements repeatedly are not flooded.
+//
+// SessionNotices is thread-safe, since statements of a session can be
+// planned concurrently when they are parallelized.
+type SessionNotices struct {
+	mu struct {
+		syncutil.Mutex
+		// pending are the notices not sent to the client yet.
+		pending []*pgerror.Error
+		// seen contains the messages of all the notices raised in the
+		// session.
+		seen map[string]struct{}
+	}
+}
+
+// addOnce queues the notice for sending, unless a notice with the same
+// message was already raised in the session.
+func (n *SessionNotices) addOnce(notice *pgerror.Error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.mu.seen[notice.Message]; ok {
+		return
+	}
+	if n.mu.seen == nil {
+		n.mu.seen = make(map[string]struct{})
+	}
+	n.mu.seen[notice.Message] = struct{}{}
+	n.mu.pending = append(n.mu.pending, notice)
+}
+
+// Drain returns the notices not sent to the client yet and forgets
+// them.
+func (n *SessionNotices) Drain() []*pgerror.Error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	pending := n.mu.pending
+	n.mu.pending = nil
+	return pending
+}
@@ -0,0 +1,102 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestDeprecatedColumnNotices(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	p := makeTestPlanner()
+	p.extendedEvalCtx = makeTestingExtendedEvalContext()
+	defer p.extendedEvalCtx.Stop(context.Background())
+	var notices SessionNotices
+	p.extendedEvalCtx.Notices = &notices
+
+	sel := makeSelectNode(t, p)
+	sel.sourceInfo[0].deprecationNotices = map[int]*pgerror.Error{
+		1: pgerror.NewError(pgerror.CodeWarningDeprecatedFeatureError, "column b is deprecated"),
+	}
+
+	testData := []struct {
+		expr     string
+		expected []string
+	}{
+		{`a + 1`, nil},
+		{`a + b`, []string{"column b is deprecated"}},
+		// Notices are only sent once per session.
+		{`b`, nil},
+		{`test.b = 1`, nil},
+	}
+	for _, d := range testData {
+		t.Run(d.expr, func(t *testing.T) {
+			expr, err := parser.ParseExpr(d.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, _, _, err := p.resolveNamesForRender(expr, sel); err != nil {
+				t.Fatal(err)
+			}
+			var messages []string
+			for _, notice := range notices.Drain() {
+				messages = append(messages, notice.Message)
+			}
+			if len(messages) != len(d.expected) {
+				t.Fatalf("expected notices %q, got %q", d.expected, messages)
+			}
+			for i := range messages {
+				if messages[i] != d.expected[i] {
+					t.Fatalf("expected notices %q, got %q", d.expected, messages)
+				}
+			}
+		})
+	}
+}
+
+func TestMakeDeprecationNotices(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	desc := testTableDesc()
+	notices, err := makeDeprecationNotices("s", desc, map[string]string{"c": "use d instead"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	notice, ok := notices[2]
+	if len(notices) != 1 || !ok {
+		t.Fatalf("expected a notice for column 2, got %v", notices)
+	}
+	if e := "column s.test.c is deprecated"; notice.Message != e {
+		t.Errorf("expected message %q, got %q", e, notice.Message)
+	}
+	if e := "use d instead"; notice.Hint != e {
+		t.Errorf("expected hint %q, got %q", e, notice.Hint)
+	}
+	if notice.Code != pgerror.CodeWarningDeprecatedFeatureError {
+		t.Errorf("expected code %s, got %s", pgerror.CodeWarningDeprecatedFeatureError, notice.Code)
+	}
+
+	_, err = makeDeprecationNotices("s", desc, map[string]string{"z": "use d instead"})
+	if !testutils.IsError(err, `deprecated column s\.test\.z does not exist`) {
+		t.Errorf("expected error for unknown column, got %v", err)
+	}
+}
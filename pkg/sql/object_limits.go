@@ -0,0 +1,126 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+// The object count limits bound the growth of the schema, for deployments
+// where the schema is controlled by untrusted clients. They are checked
+// when objects are created, so lowering a limit below the current usage
+// does not remove objects; the current usage and the limits are listed in
+// information_schema.object_limits.
+var (
+	maxTablesPerDatabase = settings.RegisterValidatedIntSetting(
+		maxTablesPerDatabaseSetting,
+		"maximum number of tables, views and sequences in a database (0 disables the limit)",
+		0, validateObjectLimit,
+	)
+	maxColumnsPerTable = settings.RegisterValidatedIntSetting(
+		maxColumnsPerTableSetting,
+		"maximum number of columns in a table, not counting hidden columns (0 disables the limit)",
+		0, validateObjectLimit,
+	)
+)
+
+// The names of the settings of the object count limits, used in errors and
+// in information_schema.object_limits.
+const (
+	maxTablesPerDatabaseSetting = "sql.schema.max_tables_per_database"
+	maxColumnsPerTableSetting   = "sql.schema.max_columns_per_table"
+)
+
+func validateObjectLimit(v int64) error {
+	if v < 0 {
+		return errors.Errorf("cannot set to a negative value: %d", v)
+	}
+	return nil
+}
+
+// countTablesInDatabase returns the number of tables, views and sequences
+// in the database, including the ones created by txn.
+func countTablesInDatabase(ctx context.Context, txn *client.Txn, dbID sqlbase.ID) (int64, error) {
+	prefix := sqlbase.MakeNameMetadataKey(dbID, "")
+	kvs, err := txn.Scan(ctx, prefix, prefix.PrefixEnd(), 0)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(kvs)), nil
+}
+
+// countVisibleColumns returns the number of columns of the table that are
+// not hidden, including the ones being added.
+func countVisibleColumns(desc *sqlbase.TableDescriptor) int64 {
+	var n int64
+	for _, col := range desc.Columns {
+		if !col.Hidden {
+			n++
+		}
+	}
+	for _, m := range desc.Mutations {
+		if col := m.GetColumn(); col != nil &&
+			m.Direction == sqlbase.DescriptorMutation_ADD && !col.Hidden {
+			n++
+		}
+	}
+	return n
+}
+
+// checkTablesPerDatabaseLimit returns an error if a table, view or
+// sequence cannot be created in the database without exceeding
+// sql.schema.max_tables_per_database.
+func (p *planner) checkTablesPerDatabaseLimit(
+	ctx context.Context, dbDesc *sqlbase.DatabaseDescriptor,
+) error {
+	limit := maxTablesPerDatabase.Get(&p.ExecCfg().Settings.SV)
+	if limit == 0 {
+		return nil
+	}
+	n, err := countTablesInDatabase(ctx, p.txn, dbDesc.ID)
+	if err != nil {
+		return err
+	}
+	if n >= limit {
+		return pgerror.NewErrorf(pgerror.CodeProgramLimitExceededError,
+			"database %q already contains %d tables, views and sequences, which is the maximum",
+			dbDesc.Name, n).SetHintf(
+			"the limit is set by the cluster setting %s", maxTablesPerDatabaseSetting)
+	}
+	return nil
+}
+
+// checkColumnsPerTableLimit returns an error if the table, including the
+// columns being added, exceeds sql.schema.max_columns_per_table.
+func (p *planner) checkColumnsPerTableLimit(desc *sqlbase.TableDescriptor) error {
+	limit := maxColumnsPerTable.Get(&p.ExecCfg().Settings.SV)
+	if limit == 0 {
+		return nil
+	}
+	if n := countVisibleColumns(desc); n > limit {
+		return pgerror.NewErrorf(pgerror.CodeTooManyColumnsError,
+			"table %q would have %d columns, but the maximum is %d", desc.Name, n, limit).SetHintf(
+			"the limit is set by the cluster setting %s", maxColumnsPerTableSetting)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestObjectLimits(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.TODO())
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	st := s.ClusterSettings()
+	st.Manual.Store(true)
+	maxTablesPerDatabase.Override(&st.SV, 2)
+	maxColumnsPerTable.Override(&st.SV, 2)
+
+	sqlDB.Exec(t, `CREATE DATABASE d`)
+	sqlDB.Exec(t, `CREATE TABLE d.t (a INT, b INT)`)
+	sqlDB.Exec(t, `CREATE SEQUENCE d.s`)
+
+	for _, stmt := range []string{
+		`CREATE TABLE d.u (a INT)`,
+		`CREATE VIEW d.v AS SELECT a FROM d.t`,
+		`CREATE SEQUENCE d.s2`,
+	} {
+		if _, err := db.Exec(stmt); !testutils.IsError(err,
+			`database "d" already contains 2 tables, views and sequences, which is the maximum`,
+		) {
+			t.Errorf(`%s: unexpected error %v`, stmt, err)
+		}
+	}
+
+	maxTablesPerDatabase.Override(&st.SV, 3)
+	if _, err := db.Exec(`CREATE TABLE d.u (a INT, b INT, c INT)`); !testutils.IsError(err,
+		`table "u" would have 3 columns, but the maximum is 2`,
+	) {
+		t.Errorf(`unexpected error %v`, err)
+	}
+	if _, err := db.Exec(`ALTER TABLE d.t ADD COLUMN c INT`); !testutils.IsError(err,
+		`table "t" would have 3 columns, but the maximum is 2`,
+	) {
+		t.Errorf(`unexpected error %v`, err)
+	}
+	// The hidden rowid column does not count.
+	sqlDB.Exec(t, `CREATE TABLE d.u (a INT, b INT)`)
+
+	sqlDB.CheckQueryResults(t, `
+SELECT object_schema, object_name, limit_name, current_usage, max_usage
+FROM information_schema.object_limits WHERE object_schema = 'd'`, [][]string{
+		{"d", "NULL", "sql.schema.max_tables_per_database", "3", "3"},
+		{"d", "t", "sql.schema.max_columns_per_table", "2", "2"},
+		{"d", "u", "sql.schema.max_columns_per_table", "2", "2"},
+	})
+
+	// Disabling the limits lifts them.
+	maxTablesPerDatabase.Override(&st.SV, 0)
+	maxColumnsPerTable.Override(&st.SV, 0)
+	sqlDB.Exec(t, `CREATE TABLE d.w (a INT, b INT, c INT)`)
+	sqlDB.Exec(t, `ALTER TABLE d.t ADD COLUMN c INT`)
+}
@@ -302,6 +302,15 @@ func (p *planner) propagateFilters(
 			if n.plan, err = p.triggerFilterPropagation(ctx, n.plan); err != nil {
 				return plan, extraFilter, err
 			}
+		} else if n.addFilter != nil && extraFilter != nil {
+			// The node, e.g. a virtual table, may use the filter to skip
+			// producing rows, but the filter remains to be applied.
+			n.addFilter(p.EvalContext(), extraFilter)
+		}
+
+	case *setSessionVarsNode:
+		if n.source, err = p.triggerFilterPropagation(ctx, n.source); err != nil {
+			return plan, extraFilter, err
 		}
 
 	case *splitNode:
@@ -317,8 +326,15 @@ func (p *planner) propagateFilters(
 	case *alterIndexNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *alterRoleSetNode:
+	case *alterRoleOptionsNode:
+	case *alterDefaultPrivilegesNode:
 	case *alterUserSetPasswordNode:
 	case *cancelQueryNode:
+	case *commentOnColumnNode:
+	case *commentOnDatabaseNode:
+	case *commentOnIndexNode:
+	case *commentOnTableNode:
 	case *scrubNode:
 	case *controlJobNode:
 	case *createDatabaseNode:
@@ -336,11 +352,11 @@ func (p *planner) propagateFilters(
 	case *hookFnNode:
 	case *valueGenerator:
 	case *valuesNode:
+	case *virtualTableNode:
 	case *sequenceSelectNode:
 	case *setVarNode:
 	case *setClusterSettingNode:
 	case *setZoneConfigNode:
-	case *showZoneConfigNode:
 	case *showRangesNode:
 	case *showFingerprintsNode:
 	case *scatterNode:
@@ -23,6 +23,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -129,6 +130,7 @@ func (p *planner) selectIndex(
 
 	if useExperimentalIndexConstraints {
 		if s.filter != nil {
+			telemetry.Count("sql.optimizer.experimental_index_constraints")
 			filterExpr, err := opt.BuildScalarExpr(s.filter, p.EvalContext())
 			if err != nil {
 				return nil, err
@@ -157,6 +157,9 @@ func (p *planner) applyLimit(plan planNode, numRows int64, soft bool) {
 			p.setUnlimited(n.plan)
 		}
 
+	case *setSessionVarsNode:
+		p.setUnlimited(n.source)
+
 	case *splitNode:
 		p.setUnlimited(n.rows)
 
@@ -164,11 +167,24 @@ func (p *planner) applyLimit(plan planNode, numRows int64, soft bool) {
 		p.setUnlimited(n.rows)
 
 	case *valuesNode:
+	case *virtualTableNode:
+		n.hardLimit = 0
+		if !soft && numRows != math.MaxInt64 {
+			n.hardLimit = numRows
+		}
+
 	case *alterIndexNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *alterRoleSetNode:
+	case *alterRoleOptionsNode:
+	case *alterDefaultPrivilegesNode:
 	case *alterUserSetPasswordNode:
 	case *cancelQueryNode:
+	case *commentOnColumnNode:
+	case *commentOnDatabaseNode:
+	case *commentOnIndexNode:
+	case *commentOnTableNode:
 	case *scrubNode:
 	case *controlJobNode:
 	case *createDatabaseNode:
@@ -191,7 +207,6 @@ func (p *planner) applyLimit(plan planNode, numRows int64, soft bool) {
 	case *setVarNode:
 	case *setClusterSettingNode:
 	case *setZoneConfigNode:
-	case *showZoneConfigNode:
 	case *showRangesNode:
 	case *showFingerprintsNode:
 	case *scatterNode:
@@ -83,6 +83,9 @@ func setNeededColumns(plan planNode, needed []bool) {
 	case *valuesNode:
 		markOmitted(n.columns, needed)
 
+	case *virtualTableNode:
+		markOmitted(n.columns, needed)
+
 	case *delayedNode:
 		if n.plan != nil {
 			setNeededColumns(n.plan, needed)
@@ -184,6 +187,9 @@ func setNeededColumns(plan planNode, needed []bool) {
 		// foreign key relations and that are not needed for RETURNING.
 		setNeededColumns(n.run.rows, allColumns(n.run.rows))
 
+	case *setSessionVarsNode:
+		setNeededColumns(n.source, allColumns(n.source))
+
 	case *splitNode:
 		setNeededColumns(n.rows, allColumns(n.rows))
 
@@ -193,8 +199,15 @@ func setNeededColumns(plan planNode, needed []bool) {
 	case *alterIndexNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *alterRoleSetNode:
+	case *alterRoleOptionsNode:
+	case *alterDefaultPrivilegesNode:
 	case *alterUserSetPasswordNode:
 	case *cancelQueryNode:
+	case *commentOnColumnNode:
+	case *commentOnDatabaseNode:
+	case *commentOnIndexNode:
+	case *commentOnTableNode:
 	case *controlJobNode:
 	case *scrubNode:
 	case *createDatabaseNode:
@@ -217,7 +230,6 @@ func setNeededColumns(plan planNode, needed []bool) {
 	case *setVarNode:
 	case *setClusterSettingNode:
 	case *setZoneConfigNode:
-	case *showZoneConfigNode:
 	case *showRangesNode:
 	case *showFingerprintsNode:
 	case *scatterNode:
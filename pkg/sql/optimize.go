@@ -17,6 +17,7 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 )
@@ -37,6 +38,14 @@ func (p *planner) optimizePlan(
 	// sub-expressions).
 	setNeededColumns(plan, needed)
 
+	// Now that the needed columns are known, check the privileges of the
+	// scans which may only read some columns. This must happen before
+	// expansion, which may introduce scans with different needed columns
+	// (e.g. for index joins).
+	if err := p.checkColumnPrivileges(ctx, plan); err != nil {
+		return plan, err
+	}
+
 	newPlan, err := p.triggerFilterPropagation(ctx, plan)
 	if err != nil {
 		return plan, err
@@ -95,3 +104,30 @@ func (p *planner) optimizeSubquery(ctx context.Context, sq *subquery) error {
 	sq.expanded = true
 	return nil
 }
+
+// checkColumnPrivileges checks that the scans of tables on which the user
+// only holds column-level SELECT privileges read no other columns.
+func (p *planner) checkColumnPrivileges(ctx context.Context, plan planNode) error {
+	var c *privilegeChecker
+	return walkPlan(ctx, plan, planObserver{
+		enterNode: func(ctx context.Context, _ string, plan planNode) (bool, error) {
+			n, ok := plan.(*scanNode)
+			if !ok || n.selectPrivilegeErr == nil {
+				return true, nil
+			}
+			if c == nil {
+				var err error
+				if c, err = p.makePrivilegeChecker(ctx); err != nil {
+					return false, err
+				}
+			}
+			for i := range n.cols {
+				if n.valNeededForCol.Contains(i) && !c.columnPrivilege(&n.cols[i], privilege.SELECT) {
+					return false, n.selectPrivilegeErr
+				}
+			}
+			n.selectPrivilegeChecked = true
+			return true, nil
+		},
+	})
+}
@@ -56,6 +56,8 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER DATABASE foo ??`, `ALTER DATABASE`},
 		{`ALTER DATABASE foo RENAME ??`, `ALTER DATABASE`},
 		{`ALTER DATABASE foo RENAME TO bar ??`, `ALTER DATABASE`},
+		{`ALTER DATABASE foo SET ??`, `ALTER DATABASE`},
+		{`ALTER DATABASE foo RESET ??`, `ALTER DATABASE`},
 
 		{`ALTER VIEW IF ??`, `ALTER VIEW`},
 		{`ALTER VIEW blah ??`, `ALTER VIEW`},
@@ -69,6 +71,16 @@ func TestContextualHelp(t *testing.T) {
 
 		{`ALTER USER IF ??`, `ALTER USER`},
 		{`ALTER USER foo WITH PASSWORD ??`, `ALTER USER`},
+		{`ALTER USER foo SET ??`, `ALTER USER`},
+
+		{`ALTER ROLE ??`, `ALTER ROLE`},
+		{`ALTER ROLE foo SET ??`, `ALTER ROLE`},
+		{`ALTER ROLE foo RESET ??`, `ALTER ROLE`},
+		{`ALTER ROLE foo WITH ??`, `ALTER ROLE`},
+
+		{`ALTER DEFAULT ??`, `ALTER DEFAULT PRIVILEGES`},
+		{`ALTER DEFAULT PRIVILEGES ??`, `ALTER DEFAULT PRIVILEGES`},
+		{`ALTER DEFAULT PRIVILEGES IN DATABASE d GRANT ??`, `ALTER DEFAULT PRIVILEGES`},
 
 		{`CANCEL ??`, `CANCEL`},
 		{`CANCEL JOB ??`, `CANCEL JOB`},
@@ -90,12 +102,15 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE USER blih WITH ??`, `CREATE USER`},
 
 		{`CREATE ROLE bleh ??`, `CREATE ROLE`},
+		{`CREATE ROLE bleh WITH ??`, `CREATE ROLE`},
 
 		{`CREATE VIEW blah (??`, `CREATE VIEW`},
 		{`CREATE VIEW blah AS (SELECT c FROM x) ??`, `CREATE VIEW`},
 		{`CREATE VIEW blah AS SELECT c FROM x ??`, `SELECT`},
 		{`CREATE VIEW blah AS (??`, `<SELECTCLAUSE>`},
 
+		{`CREATE EXTENSION ??`, `CREATE EXTENSION`},
+
 		{`CREATE SEQUENCE ??`, `CREATE SEQUENCE`},
 
 		{`CREATE STATISTICS ??`, `CREATE STATISTICS`},
@@ -72,6 +72,9 @@ func TestParse(t *testing.T) {
 		{`CREATE DATABASE IF NOT EXISTS a LC_CTYPE = 'INVALID'`},
 		{`CREATE DATABASE IF NOT EXISTS a TEMPLATE = 'template0' ENCODING = 'UTF8' LC_COLLATE = 'C.UTF-8' LC_CTYPE = 'INVALID'`},
 
+		{`CREATE EXTENSION pgcrypto`},
+		{`CREATE EXTENSION IF NOT EXISTS "uuid-ossp"`},
+
 		{`CREATE INDEX a ON b (c)`},
 		{`CREATE INDEX a ON b.c (d)`},
 		{`CREATE INDEX ON a (b)`},
@@ -243,6 +246,9 @@ func TestParse(t *testing.T) {
 		{`CREATE SEQUENCE a START 1000`},
 		{`CREATE SEQUENCE a START WITH 1000`},
 		{`CREATE SEQUENCE a INCREMENT 5 NO MAXVALUE MINVALUE 1 START 3`},
+		{`CREATE SEQUENCE a OWNED BY b.c`},
+		{`CREATE SEQUENCE a OWNED BY d.b.c`},
+		{`CREATE SEQUENCE a OWNED BY NONE`},
 
 		{`CREATE STATISTICS a ON col1 FROM t`},
 		{`CREATE STATISTICS a ON col1, col2 FROM t`},
@@ -349,12 +355,12 @@ func TestParse(t *testing.T) {
 		{`SHOW STATISTICS FOR TABLE t`},
 		{`SHOW STATISTICS FOR TABLE d.t`},
 		{`SHOW HISTOGRAM 123`},
-		{`SHOW TESTING_RANGES FROM TABLE d.t`},
-		{`SHOW TESTING_RANGES FROM TABLE t`},
-		{`SHOW TESTING_RANGES FROM INDEX d.t@i`},
-		{`SHOW TESTING_RANGES FROM INDEX t@i`},
-		{`SHOW TESTING_RANGES FROM INDEX d.i`},
-		{`SHOW TESTING_RANGES FROM INDEX i`},
+		{`SHOW EXPERIMENTAL_RANGES FROM TABLE d.t`},
+		{`SHOW EXPERIMENTAL_RANGES FROM TABLE t`},
+		{`SHOW EXPERIMENTAL_RANGES FROM INDEX d.t@i`},
+		{`SHOW EXPERIMENTAL_RANGES FROM INDEX t@i`},
+		{`SHOW EXPERIMENTAL_RANGES FROM INDEX d.i`},
+		{`SHOW EXPERIMENTAL_RANGES FROM INDEX i`},
 		{`SHOW EXPERIMENTAL_FINGERPRINTS FROM TABLE d.t`},
 		{`EXPERIMENTAL SHOW ZONE CONFIGURATIONS`},
 		{`EXPERIMENTAL SHOW ZONE CONFIGURATION FOR RANGE default`},
@@ -430,6 +436,12 @@ func TestParse(t *testing.T) {
 		{`GRANT SELECT, INSERT ON DATABASE bar TO foo, bar, baz`},
 		{`GRANT SELECT, INSERT ON DATABASE db1, db2 TO foo, bar, baz`},
 		{`GRANT SELECT, INSERT ON DATABASE db1, db2 TO "test-user"`},
+		{`GRANT SELECT ON foo TO root UNTIL '2100-01-01'`},
+		{`GRANT ALL ON DATABASE foo TO bar UNTIL now() + '1h'`},
+		{`GRANT SELECT, INSERT ON foo TO bar WITH GRANT OPTION`},
+		{`GRANT SELECT ON foo TO bar UNTIL '2100-01-01' WITH GRANT OPTION`},
+		{`GRANT SELECT, UPDATE (a, b) ON foo TO bar`},
+		{`GRANT ALL (a) ON foo, db.foo TO bar WITH GRANT OPTION`},
 		{`GRANT rolea, roleb TO usera, userb`},
 		{`GRANT rolea, roleb TO usera, userb WITH ADMIN OPTION`},
 
@@ -441,6 +453,14 @@ func TestParse(t *testing.T) {
 		{`REVOKE ALL ON DATABASE foo FROM root, test`},
 		{`REVOKE SELECT, INSERT ON DATABASE bar FROM foo, bar, baz`},
 		{`REVOKE SELECT, INSERT ON DATABASE db1, db2 FROM foo, bar, baz`},
+		{`REVOKE GRANT OPTION FOR SELECT ON foo FROM bar`},
+
+		{`ALTER DEFAULT PRIVILEGES GRANT SELECT ON TABLES TO foo`},
+		{`ALTER DEFAULT PRIVILEGES FOR ROLE a, b IN DATABASE d GRANT ALL ON TABLES TO foo, bar`},
+		{`ALTER DEFAULT PRIVILEGES IN DATABASE d1, d2 REVOKE INSERT, DELETE ON TABLES FROM foo`},
+		{`REVOKE GRANT OPTION FOR ALL ON DATABASE foo FROM bar`},
+		{`REVOKE INSERT (a, b) ON foo FROM bar`},
+		{`REVOKE GRANT OPTION FOR SELECT (a) ON foo FROM bar`},
 		{`REVOKE rolea, roleb FROM usera, userb`},
 		{`REVOKE ADMIN OPTION FOR rolea, roleb FROM usera, userb`},
 
@@ -880,6 +900,18 @@ func TestParse(t *testing.T) {
 		{`ALTER SEQUENCE IF EXISTS a RENAME TO b`},
 		{`ALTER SEQUENCE a INCREMENT BY 5 START WITH 1000`},
 		{`ALTER SEQUENCE IF EXISTS a INCREMENT BY 5 START WITH 1000`},
+		{`ALTER SEQUENCE a OWNED BY b.c`},
+		{`ALTER SEQUENCE a OWNED BY NONE`},
+
+		{`COMMENT ON COLUMN a.b IS 'a'`},
+		{`COMMENT ON COLUMN a.b IS NULL`},
+		{`COMMENT ON COLUMN a.b.c IS 'a'`},
+		{`COMMENT ON DATABASE a IS 'a'`},
+		{`COMMENT ON DATABASE a IS NULL`},
+		{`COMMENT ON TABLE a IS 'a'`},
+		{`COMMENT ON TABLE a.b IS NULL`},
+		{`COMMENT ON INDEX a@b IS 'a'`},
+		{`COMMENT ON INDEX b IS 'a'`},
 
 		{`EXPERIMENTAL SCRUB DATABASE x`},
 		{`EXPERIMENTAL SCRUB DATABASE x AS OF SYSTEM TIME 1`},
@@ -1189,6 +1221,8 @@ func TestParse2(t *testing.T) {
 		{`SHOW SESSION TIME ZONE`, `SHOW timezone`},
 		{`SHOW SESSION TIMEZONE`, `SHOW timezone`},
 		{`EXPERIMENTAL SHOW ALL ZONE CONFIGURATIONS`, `EXPERIMENTAL SHOW ZONE CONFIGURATIONS`},
+		{`SHOW TESTING_RANGES FROM TABLE d.t`, `SHOW EXPERIMENTAL_RANGES FROM TABLE d.t`},
+		{`SHOW TESTING_RANGES FROM INDEX t@i`, `SHOW EXPERIMENTAL_RANGES FROM INDEX t@i`},
 		{`BEGIN`,
 			`BEGIN TRANSACTION`},
 		{`START TRANSACTION`,
@@ -1257,21 +1291,51 @@ func TestParse2(t *testing.T) {
 			`CREATE USER IF NOT EXISTS 'foo'`},
 		{`CREATE USER foo PASSWORD bar`,
 			`CREATE USER 'foo' WITH PASSWORD 'bar'`},
+		{`CREATE USER foo WITH NOLOGIN PASSWORD bar VALID UNTIL '2019-01-01'`,
+			`CREATE USER 'foo' WITH PASSWORD 'bar' NOLOGIN VALID UNTIL '2019-01-01'`},
+		{`CREATE USER foo CREATEROLE`,
+			`CREATE USER 'foo' WITH CREATEROLE`},
 		{`DROP USER foo, bar`,
 			`DROP USER 'foo', 'bar'`},
 		{`DROP USER IF EXISTS foo, bar`,
 			`DROP USER IF EXISTS 'foo', 'bar'`},
 		{`ALTER USER foo WITH PASSWORD bar`,
 			`ALTER USER 'foo' WITH PASSWORD 'bar'`},
+		{`ALTER USER foo SET search_path TO a, b`,
+			`ALTER USER 'foo' SET search_path = a, b`},
+		{`ALTER USER foo RESET ALL`,
+			`ALTER USER 'foo' RESET ALL`},
+		{`ALTER USER foo NOLOGIN`,
+			`ALTER USER 'foo' WITH NOLOGIN`},
+		{`ALTER USER foo WITH LOGIN VALID UNTIL 'infinity'`,
+			`ALTER USER 'foo' WITH LOGIN VALID UNTIL 'infinity'`},
 
 		{`CREATE ROLE foo`,
 			`CREATE ROLE 'foo'`},
 		{`CREATE ROLE IF NOT EXISTS foo`,
 			`CREATE ROLE IF NOT EXISTS 'foo'`},
+		{`CREATE ROLE foo WITH CREATEROLE NOLOGIN`,
+			`CREATE ROLE 'foo' WITH CREATEROLE NOLOGIN`},
 		{`DROP ROLE foo, bar`,
 			`DROP ROLE 'foo', 'bar'`},
 		{`DROP ROLE IF EXISTS foo, bar`,
 			`DROP ROLE IF EXISTS 'foo', 'bar'`},
+		{`ALTER ROLE foo SET timezone = 'America/New_York'`,
+			`ALTER ROLE 'foo' SET timezone = 'America/New_York'`},
+		{`ALTER ROLE foo SET distsql TO DEFAULT`,
+			`ALTER ROLE 'foo' RESET distsql`},
+		{`ALTER ROLE foo RESET ALL`,
+			`ALTER ROLE 'foo' RESET ALL`},
+		{`ALTER DATABASE foo SET timezone TO 'UTC'`,
+			`ALTER DATABASE foo SET timezone = 'UTC'`},
+		{`ALTER DATABASE foo SET distsql TO DEFAULT`,
+			`ALTER DATABASE foo RESET distsql`},
+		{`ALTER DATABASE foo RESET ALL`,
+			`ALTER DATABASE foo RESET ALL`},
+		{`ALTER ROLE foo WITH NOCREATEROLE`,
+			`ALTER ROLE 'foo' WITH NOCREATEROLE`},
+		{`ALTER DEFAULT PRIVILEGES FOR USER a REVOKE SELECT ON TABLES FROM foo`,
+			`ALTER DEFAULT PRIVILEGES FOR ROLE a REVOKE SELECT ON TABLES FROM foo`},
 
 		{
 			`CREATE TABLE a (b INT, FOREIGN KEY (b) REFERENCES other ON UPDATE NO ACTION ON DELETE NO ACTION)`,
@@ -406,6 +406,12 @@ func (u *sqlSymUnion) referenceActions() tree.ReferenceActions {
     return u.val.(tree.ReferenceActions)
 }
 
+func (u *sqlSymUnion) roleOption() tree.RoleOption {
+    return u.val.(tree.RoleOption)
+}
+func (u *sqlSymUnion) roleOptions() tree.RoleOptions {
+    return u.val.(tree.RoleOptions)
+}
 func (u *sqlSymUnion) scrubOptions() tree.ScrubOptions {
     return u.val.(tree.ScrubOptions)
 }
@@ -454,7 +460,7 @@ func newNameFromStr(s string) *tree.Name {
 %token <str>   CHARACTER CHARACTERISTICS CHECK
 %token <str>   CLUSTER COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMIT
 %token <str>   COMMITTED COMPACT CONCAT CONFIGURATION CONFIGURATIONS CONFIGURE
-%token <str>   CONFLICT CONSTRAINT CONSTRAINTS CONTAINS COPY COVERING CREATE
+%token <str>   CONFLICT CONSTRAINT CONSTRAINTS CONTAINS COPY COVERING CREATE CREATEROLE
 %token <str>   CROSS CSV CUBE CURRENT CURRENT_CATALOG CURRENT_DATE CURRENT_SCHEMA
 %token <str>   CURRENT_ROLE CURRENT_TIME CURRENT_TIMESTAMP
 %token <str>   CURRENT_USER CYCLE
@@ -464,8 +470,9 @@ func newNameFromStr(s string) *tree.Name {
 %token <str>   DISCARD DISTINCT DO DOUBLE DROP
 
 %token <str>   ELSE ENCODING END ESCAPE EXCEPT
-%token <str>   EXISTS EXECUTE EXPERIMENTAL EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
-%token <str>   EXPLAIN EXTRACT EXTRACT_DURATION
+%token <str>   EXISTS EXECUTE EXPERIMENTAL EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_RANGES
+%token <str>   EXPERIMENTAL_REPLICA
+%token <str>   EXPLAIN EXTENSION EXTRACT EXTRACT_DURATION
 
 %token <str>   FALSE FAMILY FETCH FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH FILTER
 %token <str>   FIRST FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE_INDEX FOREIGN FROM FULL
@@ -485,11 +492,11 @@ func newNameFromStr(s string) *tree.Name {
 
 %token <str>   LATERAL LC_CTYPE LC_COLLATE
 %token <str>   LEADING LEAST LEFT LESS LEVEL LIKE LIMIT LIST LOCAL
-%token <str>   LOCALTIME LOCALTIMESTAMP LOW LSHIFT
+%token <str>   LOCALTIME LOCALTIMESTAMP LOGIN LOW LSHIFT
 
 %token <str>   MATCH MINVALUE MAXVALUE MINUTE MONTH
 
-%token <str>   NAN NAME NAMES NATURAL NEXT NO NO_INDEX_JOIN NORMAL
+%token <str>   NAN NAME NAMES NATURAL NEXT NO NOCREATEROLE NOLOGIN NO_INDEX_JOIN NORMAL
 %token <str>   NOT NOTHING NULL NULLIF
 %token <str>   NULLS NUMERIC
 
@@ -497,7 +504,7 @@ func newNameFromStr(s string) *tree.Name {
 %token <str>   ORDER ORDINALITY OUT OUTER OVER OVERLAPS OVERLAY OWNED
 
 %token <str>   PARENT PARTIAL PARTITION PASSWORD PAUSE PHYSICAL PLACING
-%token <str>   PLANS POSITION PRECEDING PRECISION PREPARE PRIMARY PRIORITY
+%token <str>   PLANS POSITION PRECEDING PRECISION PREPARE PRIMARY PRIORITY PRIVILEGES
 
 %token <str>   QUERIES QUERY
 
@@ -519,7 +526,7 @@ func newNameFromStr(s string) *tree.Name {
 %token <str>   TIME TIMESTAMP TIMESTAMPTZ TO TRAILING TRACE TRANSACTION TREAT TRIM TRUE
 %token <str>   TRUNCATE TYPE
 
-%token <str>   UNBOUNDED UNCOMMITTED UNION UNIQUE UNKNOWN
+%token <str>   UNBOUNDED UNCOMMITTED UNION UNIQUE UNKNOWN UNTIL
 %token <str>   UPDATE UPSERT USE USER USERS USING UUID
 
 %token <str>   VALID VALIDATE VALUE VALUES VARCHAR VARIADIC VIEW VARYING
@@ -559,6 +566,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> alter_sequence_stmt
 %type <tree.Statement> alter_database_stmt
 %type <tree.Statement> alter_user_stmt
+%type <tree.Statement> alter_role_stmt
 %type <tree.Statement> alter_range_stmt
 
 // ALTER RANGE
@@ -574,10 +582,17 @@ func newNameFromStr(s string) *tree.Name {
 
 // ALTER DATABASE
 %type <tree.Statement> alter_rename_database_stmt
+%type <tree.Statement> alter_database_set_stmt
 %type <tree.Statement> alter_zone_database_stmt
 
 // ALTER USER
 %type <tree.Statement> alter_user_password_stmt
+%type <tree.Statement> alter_user_set_stmt
+
+// ALTER ROLE
+%type <tree.Statement> alter_role_set_stmt
+%type <tree.Statement> alter_role_options_stmt
+%type <tree.Statement> alter_default_privileges_stmt
 
 // ALTER INDEX
 %type <tree.Statement> alter_oneindex_stmt
@@ -624,6 +639,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> create_view_stmt
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_stats_stmt
+%type <tree.Statement> create_extension_stmt
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
 
@@ -693,7 +709,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> show_zone_stmt
 
 %type <str> session_var
-%type <str> comment_text
+%type <*string> comment_text
 
 %type <tree.Statement> transaction_stmt
 %type <tree.Statement> truncate_stmt
@@ -727,7 +743,10 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.ValidationBehavior> opt_validate_behavior
 
 %type <str> opt_template_clause opt_encoding_clause opt_lc_collate_clause opt_lc_ctype_clause
-%type <tree.Expr> opt_password
+%type <tree.RoleOptions> opt_user_options opt_role_options user_option_list role_option_list
+%type <tree.RoleOption> user_option role_option
+%type <tree.Expr> opt_grant_until
+%type <bool> opt_with_grant_option
 
 %type <tree.IsolationLevel> transaction_iso_level
 %type <tree.UserPriority>  transaction_user_priority
@@ -920,6 +939,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <*tree.TargetList> on_privilege_target_clause
 %type <tree.NameList>       for_grantee_clause
 %type <privilege.List> privileges
+%type <tree.NameList> opt_default_privileges_for opt_default_privileges_in
 
 // Precedence: lowest to highest
 %nonassoc  VALUES              // see value_clause
@@ -1052,11 +1072,14 @@ stmt:
 
 // %Help: ALTER
 // %Category: Group
-// %Text: ALTER TABLE, ALTER INDEX, ALTER VIEW, ALTER SEQUENCE, ALTER DATABASE, ALTER USER
+// %Text: ALTER TABLE, ALTER INDEX, ALTER VIEW, ALTER SEQUENCE, ALTER DATABASE, ALTER USER, ALTER ROLE,
+// ALTER DEFAULT PRIVILEGES
 alter_stmt:
-  alter_ddl_stmt      // help texts in sub-rule
-| alter_user_stmt     // EXTEND WITH HELP: ALTER USER
-| ALTER error         // SHOW HELP: ALTER
+  alter_ddl_stmt                // help texts in sub-rule
+| alter_user_stmt               // EXTEND WITH HELP: ALTER USER
+| alter_role_stmt               // EXTEND WITH HELP: ALTER ROLE
+| alter_default_privileges_stmt // EXTEND WITH HELP: ALTER DEFAULT PRIVILEGES
+| ALTER error                   // SHOW HELP: ALTER
 
 alter_ddl_stmt:
   alter_table_stmt    // EXTEND WITH HELP: ALTER TABLE
@@ -1122,6 +1145,7 @@ alter_view_stmt:
 //   [MAXVALUE <maxvalue> | NO MAXVALUE]
 //   [START <start>]
 //   [[NO] CYCLE]
+//   [OWNED BY <table>.<column> | OWNED BY NONE]
 // ALTER SEQUENCE [IF EXISTS] <name> RENAME TO <newname>
 alter_sequence_stmt:
   alter_rename_sequence_stmt
@@ -1142,18 +1166,51 @@ alter_sequence_options_stmt:
 // %Category: Priv
 // %Text:
 // ALTER USER [IF EXISTS] <name> WITH PASSWORD <password>
-// %SeeAlso: CREATE USER
+// ALTER USER <name> [WITH] <option> [<option>...]
+// ALTER USER <name> SET <var> { TO | = } <values...>
+// ALTER USER <name> RESET { <var> | ALL }
+//
+// Options:
+//   LOGIN | NOLOGIN, CREATEROLE | NOCREATEROLE, VALID UNTIL <timestamp>
+//
+// %SeeAlso: CREATE USER, ALTER ROLE
 alter_user_stmt:
   alter_user_password_stmt
+| alter_user_set_stmt
+| ALTER USER string_or_placeholder opt_with role_option_list
+  {
+    $$.val = &tree.AlterRoleOptions{Name: $3.expr(), Options: $5.roleOptions()}
+  }
 | ALTER USER error // SHOW HELP: ALTER USER
 
+// %Help: ALTER ROLE - change the options or the default session variables of a role
+// %Category: Priv
+// %Text:
+// ALTER ROLE <name> [WITH] <option> [<option>...]
+// ALTER ROLE <name> SET <var> { TO | = } <values...>
+// ALTER ROLE <name> RESET { <var> | ALL }
+//
+// Options:
+//   LOGIN | NOLOGIN, CREATEROLE | NOCREATEROLE, VALID UNTIL <timestamp>
+//
+// The values become the defaults of the sessions of the role, or of the
+// user, when it logs in.
+// %SeeAlso: CREATE ROLE, ALTER USER, SET SESSION, RESET
+alter_role_stmt:
+  alter_role_set_stmt
+| alter_role_options_stmt
+| ALTER ROLE error // SHOW HELP: ALTER ROLE
+
 // %Help: ALTER DATABASE - change the definition of a database
 // %Category: DDL
 // %Text:
 // ALTER DATABASE <name> RENAME TO <newname>
+// ALTER DATABASE <name> SET <var> { TO | = } <value>
+// ALTER DATABASE <name> RESET { <var> | ALL }
 // %SeeAlso: WEBDOCS/alter-database.html
 alter_database_stmt:
   alter_rename_database_stmt
+| alter_database_set_stmt
 |  alter_zone_database_stmt
 // ALTER DATABASE has its error help token here because the ALTER DATABASE
 // prefix is spread over multiple non-terminals.
@@ -1678,20 +1735,44 @@ cancel_query_stmt:
 | CANCEL QUERY error // SHOW HELP: CANCEL QUERY
 
 comment_stmt:
-  COMMENT ON TABLE table_name IS comment_text
+  COMMENT ON DATABASE database_name IS comment_text
   {
-    /* SKIP DOC */
-    return unimplementedWithIssue(sqllex, 19472)
+    $$.val = &tree.CommentOnDatabase{Name: tree.Name($4), Comment: $6.strPtr()}
+  }
+| COMMENT ON TABLE table_name IS comment_text
+  {
+    $$.val = &tree.CommentOnTable{Table: $4.normalizableTableNameFromUnresolvedName(), Comment: $6.strPtr()}
+  }
+| COMMENT ON INDEX table_name_with_index IS comment_text
+  {
+    $$.val = &tree.CommentOnIndex{Index: $4.newTableWithIdx(), Comment: $6.strPtr()}
   }
 | COMMENT ON COLUMN column_path IS comment_text
   {
-    /* SKIP DOC */
-    return unimplementedWithIssue(sqllex, 19472)
+    varName, err := $4.unresolvedName().NormalizeVarName()
+    if err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    columnItem, ok := varName.(*tree.ColumnItem)
+    if !ok {
+      sqllex.Error(fmt.Sprintf("invalid column name: %q", tree.ErrString($4.unresolvedName())))
+      return 1
+    }
+    $$.val = &tree.CommentOnColumn{ColumnItem: columnItem, Comment: $6.strPtr()}
   }
 
 comment_text:
-  SCONST    { $$ = $1 }
-  | NULL    { $$ = "" }
+  SCONST
+  {
+    t := $1
+    $$.val = &t
+  }
+| NULL
+  {
+    var str *string
+    $$.val = str
+  }
 
 // %Help: CREATE
 // %Category: Group
@@ -1707,14 +1788,15 @@ create_stmt:
 | CREATE error         // SHOW HELP: CREATE
 
 create_ddl_stmt:
-  create_database_stmt // EXTEND WITH HELP: CREATE DATABASE
-| create_index_stmt    // EXTEND WITH HELP: CREATE INDEX
-| create_table_stmt    // EXTEND WITH HELP: CREATE TABLE
-| create_table_as_stmt // EXTEND WITH HELP: CREATE TABLE
+  create_database_stmt  // EXTEND WITH HELP: CREATE DATABASE
+| create_extension_stmt // EXTEND WITH HELP: CREATE EXTENSION
+| create_index_stmt     // EXTEND WITH HELP: CREATE INDEX
+| create_table_stmt     // EXTEND WITH HELP: CREATE TABLE
+| create_table_as_stmt  // EXTEND WITH HELP: CREATE TABLE
 // Error case for both CREATE TABLE and CREATE TABLE ... AS in one
-| CREATE TABLE error   // SHOW HELP: CREATE TABLE
-| create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
-| create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
+| CREATE TABLE error    // SHOW HELP: CREATE TABLE
+| create_view_stmt      // EXTEND WITH HELP: CREATE VIEW
+| create_sequence_stmt  // EXTEND WITH HELP: CREATE SEQUENCE
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
@@ -1949,6 +2031,7 @@ explain_stmt:
 
 preparable_stmt:
   alter_user_stmt   // EXTEND WITH HELP: ALTER USER
+| alter_role_stmt   // EXTEND WITH HELP: ALTER ROLE
 | backup_stmt       // EXTEND WITH HELP: BACKUP
 | cancel_stmt       // help texts in sub-rule
 | create_user_stmt  // EXTEND WITH HELP: CREATE USER
@@ -2069,7 +2152,8 @@ deallocate_stmt:
 // %Category: Priv
 // %Text:
 // Grant privileges:
-//   GRANT {ALL | <privileges...> } ON <targets...> TO <grantees...>
+//   GRANT {ALL | <privileges...> } [( <columns...> )] ON <targets...> TO <grantees...>
+//         [UNTIL <timestamp>] [WITH GRANT OPTION]
 // Grant role membership (CCL only):
 //   GRANT <roles...> TO <grantees...> [WITH ADMIN OPTION]
 //
@@ -2082,9 +2166,9 @@ deallocate_stmt:
 //
 // %SeeAlso: REVOKE, WEBDOCS/grant.html
 grant_stmt:
-  GRANT privileges ON targets TO name_list
+  GRANT privileges opt_column_list ON targets TO name_list opt_grant_until opt_with_grant_option
   {
-    $$.val = &tree.Grant{Privileges: $2.privilegeList(), Grantees: $6.nameList(), Targets: $4.targetList()}
+    $$.val = &tree.Grant{Privileges: $2.privilegeList(), Columns: $3.nameList(), Grantees: $7.nameList(), Targets: $5.targetList(), Until: $8.expr(), WithGrantOption: $9.bool()}
   }
 | GRANT privilege_list TO name_list
   {
@@ -2096,11 +2180,32 @@ grant_stmt:
   }
 | GRANT error // SHOW HELP: GRANT
 
+opt_grant_until:
+  UNTIL a_expr
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+opt_with_grant_option:
+  WITH GRANT OPTION
+  {
+    $$.val = true
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
 // %Help: REVOKE - remove access privileges and role memberships
 // %Category: Priv
 // %Text:
 // Revoke privileges:
-//   REVOKE {ALL | <privileges...> } ON <targets...> FROM <grantees...>
+//   REVOKE [GRANT OPTION FOR] {ALL | <privileges...> } [( <columns...> )] ON <targets...>
+//          FROM <grantees...>
 // Revoke role membership (CCL only):
 //   REVOKE [ADMIN OPTION FOR] <roles...> FROM <grantees...>
 //
@@ -2113,9 +2218,13 @@ grant_stmt:
 //
 // %SeeAlso: GRANT, WEBDOCS/revoke.html
 revoke_stmt:
-  REVOKE privileges ON targets FROM name_list
+  REVOKE privileges opt_column_list ON targets FROM name_list
+  {
+    $$.val = &tree.Revoke{Privileges: $2.privilegeList(), Columns: $3.nameList(), Grantees: $7.nameList(), Targets: $5.targetList()}
+  }
+| REVOKE GRANT OPTION FOR privileges opt_column_list ON targets FROM name_list
   {
-    $$.val = &tree.Revoke{Privileges: $2.privilegeList(), Grantees: $6.nameList(), Targets: $4.targetList()}
+    $$.val = &tree.Revoke{Privileges: $5.privilegeList(), Columns: $6.nameList(), Grantees: $10.nameList(), Targets: $8.targetList(), GrantOptionFor: true}
   }
 | REVOKE privilege_list FROM name_list
   {
@@ -2127,6 +2236,53 @@ revoke_stmt:
   }
 | REVOKE error // SHOW HELP: REVOKE
 
+// %Help: ALTER DEFAULT PRIVILEGES - define the privileges granted on new tables
+// %Category: Priv
+// %Text:
+// ALTER DEFAULT PRIVILEGES [FOR ROLE <roles...>] [IN DATABASE <databases...>]
+//   GRANT {ALL | <privileges...> } ON TABLES TO <grantees...>
+// ALTER DEFAULT PRIVILEGES [FOR ROLE <roles...>] [IN DATABASE <databases...>]
+//   REVOKE {ALL | <privileges...> } ON TABLES FROM <grantees...>
+//
+// The privileges are granted on the tables, views and sequences created
+// afterwards by the roles, or by the current user, in the databases, or in
+// the current database.
+// %SeeAlso: GRANT, REVOKE, SHOW GRANTS
+alter_default_privileges_stmt:
+  ALTER DEFAULT PRIVILEGES opt_default_privileges_for opt_default_privileges_in GRANT privileges ON TABLES TO name_list
+  {
+    $$.val = &tree.AlterDefaultPrivileges{Roles: $4.nameList(), Databases: $5.nameList(), IsGrant: true, Privileges: $7.privilegeList(), Grantees: $11.nameList()}
+  }
+| ALTER DEFAULT PRIVILEGES opt_default_privileges_for opt_default_privileges_in REVOKE privileges ON TABLES FROM name_list
+  {
+    $$.val = &tree.AlterDefaultPrivileges{Roles: $4.nameList(), Databases: $5.nameList(), Privileges: $7.privilegeList(), Grantees: $11.nameList()}
+  }
+| ALTER DEFAULT error // SHOW HELP: ALTER DEFAULT PRIVILEGES
+
+opt_default_privileges_for:
+  FOR ROLE name_list
+  {
+    $$.val = $3.nameList()
+  }
+| FOR USER name_list
+  {
+    $$.val = $3.nameList()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.NameList(nil)
+  }
+
+opt_default_privileges_in:
+  IN DATABASE name_list
+  {
+    $$.val = $3.nameList()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.NameList(nil)
+  }
+
 targets:
   table_pattern_list
   {
@@ -2969,7 +3125,17 @@ show_zone_stmt:
   }
 
 show_testing_stmt:
-  SHOW TESTING_RANGES FROM TABLE table_name
+  SHOW EXPERIMENTAL_RANGES FROM TABLE table_name
+  {
+    /* SKIP DOC */
+    $$.val = &tree.ShowRanges{Table: $5.newNormalizableTableNameFromUnresolvedName()}
+  }
+| SHOW EXPERIMENTAL_RANGES FROM INDEX table_name_with_index
+  {
+    /* SKIP DOC */
+    $$.val = &tree.ShowRanges{Index: $5.newTableWithIdx()}
+  }
+| SHOW TESTING_RANGES FROM TABLE table_name
   {
     /* SKIP DOC */
     $$.val = &tree.ShowRanges{Table: $5.newNormalizableTableNameFromUnresolvedName()}
@@ -3557,6 +3723,7 @@ numeric_only:
 //   [MAXVALUE <maxvalue> | NO MAXVALUE]
 //   [START <start>]
 //   [[NO] CYCLE]
+//   [OWNED BY <table>.<column> | OWNED BY NONE]
 //
 // %SeeAlso: CREATE TABLE
 create_sequence_stmt:
@@ -3591,7 +3758,29 @@ sequence_option_elem:
   AS typename                  { return unimplemented(sqllex, "create sequence AS option") }
 | CYCLE                        { return unimplemented(sqllex, "create sequence CYCLE option") }
 | NO CYCLE                     { return unimplemented(sqllex, "create sequence CYCLE option") }
-| OWNED BY column_path         { return unimplemented(sqllex, "create sequence OWNED BY option") }
+| OWNED BY column_path
+  {
+    varName, err := $3.unresolvedName().NormalizeVarName()
+    if err != nil {
+      sqllex.Error(err.Error())
+      return 1
+    }
+    columnItem, ok := varName.(*tree.ColumnItem)
+    if !ok {
+      sqllex.Error(fmt.Sprintf("invalid column name: %q", tree.ErrString($3.unresolvedName())))
+      return 1
+    }
+    // NONE is not a keyword; OWNED BY NONE parses as an unqualified column
+    // name and removes the sequence's owner.
+    if columnItem.TableName.TableName == "" {
+      if columnItem.ColumnName != "none" {
+        sqllex.Error("invalid OWNED BY option: specify OWNED BY table.column or OWNED BY NONE")
+        return 1
+      }
+      columnItem = nil
+    }
+    $$.val = tree.SequenceOption{Name: tree.SeqOptOwnedBy, ColumnItemVal: columnItem}
+  }
 | CACHE signed_iconst64        { return unimplemented(sqllex, "create sequence CACHE option") }
 | INCREMENT signed_iconst64    { x := $2.int64()
                                  $$.val = tree.SequenceOption{Name: tree.SeqOptIncrement, IntVal: &x} }
@@ -3621,41 +3810,112 @@ truncate_stmt:
 
 // %Help: CREATE USER - define a new user
 // %Category: Priv
-// %Text: CREATE USER [IF NOT EXISTS] <name> [ [WITH] PASSWORD <passwd> ]
-// %SeeAlso: DROP USER, SHOW USERS, WEBDOCS/create-user.html
+// %Text: CREATE USER [IF NOT EXISTS] <name> [ [WITH] <option> [<option>...] ]
+//
+// Options:
+//   PASSWORD <passwd>, LOGIN | NOLOGIN, CREATEROLE | NOCREATEROLE,
+//   VALID UNTIL <timestamp>
+//
+// %SeeAlso: DROP USER, SHOW USERS, ALTER USER, WEBDOCS/create-user.html
 create_user_stmt:
-  CREATE USER string_or_placeholder opt_password
+  CREATE USER string_or_placeholder opt_user_options
   {
-    $$.val = &tree.CreateUser{Name: $3.expr(), Password: $4.expr()}
+    password, options := $4.roleOptions().SplitPassword()
+    $$.val = &tree.CreateUser{Name: $3.expr(), Password: password, Options: options}
   }
-| CREATE USER IF NOT EXISTS string_or_placeholder opt_password
+| CREATE USER IF NOT EXISTS string_or_placeholder opt_user_options
   {
-    $$.val = &tree.CreateUser{Name: $6.expr(), Password: $7.expr(), IfNotExists: true}
+    password, options := $7.roleOptions().SplitPassword()
+    $$.val = &tree.CreateUser{Name: $6.expr(), Password: password, IfNotExists: true, Options: options}
   }
 | CREATE USER error // SHOW HELP: CREATE USER
 
-opt_password:
-  opt_with PASSWORD string_or_placeholder
+opt_user_options:
+  opt_with user_option_list
   {
-    $$.val = $3.expr()
+    $$.val = $2.roleOptions()
   }
 | /* EMPTY */
   {
-    $$.val = nil
+    $$.val = tree.RoleOptions(nil)
+  }
+
+user_option_list:
+  user_option
+  {
+    $$.val = tree.RoleOptions{$1.roleOption()}
+  }
+| user_option_list user_option
+  {
+    $$.val = append($1.roleOptions(), $2.roleOption())
+  }
+
+user_option:
+  PASSWORD string_or_placeholder
+  {
+    $$.val = tree.RoleOption{Name: tree.RoleOptionPassword, Value: $2.expr()}
+  }
+| role_option
+
+opt_role_options:
+  opt_with role_option_list
+  {
+    $$.val = $2.roleOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.RoleOptions(nil)
+  }
+
+role_option_list:
+  role_option
+  {
+    $$.val = tree.RoleOptions{$1.roleOption()}
+  }
+| role_option_list role_option
+  {
+    $$.val = append($1.roleOptions(), $2.roleOption())
+  }
+
+// https://www.postgresql.org/docs/10/static/sql-createrole.html
+role_option:
+  LOGIN
+  {
+    $$.val = tree.RoleOption{Name: tree.RoleOptionLogin}
+  }
+| NOLOGIN
+  {
+    $$.val = tree.RoleOption{Name: tree.RoleOptionNoLogin}
+  }
+| CREATEROLE
+  {
+    $$.val = tree.RoleOption{Name: tree.RoleOptionCreateRole}
+  }
+| NOCREATEROLE
+  {
+    $$.val = tree.RoleOption{Name: tree.RoleOptionNoCreateRole}
+  }
+| VALID UNTIL string_or_placeholder
+  {
+    $$.val = tree.RoleOption{Name: tree.RoleOptionValidUntil, Value: $3.expr()}
   }
 
 // %Help: CREATE ROLE - define a new role
 // %Category: Priv
-// %Text: CREATE ROLE [IF NOT EXISTS] <name>
-// %SeeAlso: DROP ROLE, SHOW ROLES
+// %Text: CREATE ROLE [IF NOT EXISTS] <name> [ [WITH] <option> [<option>...] ]
+//
+// Options:
+//   LOGIN | NOLOGIN, CREATEROLE | NOCREATEROLE, VALID UNTIL <timestamp>
+//
+// %SeeAlso: DROP ROLE, SHOW ROLES, ALTER ROLE
 create_role_stmt:
-  CREATE ROLE string_or_placeholder
+  CREATE ROLE string_or_placeholder opt_role_options
   {
-    $$.val = &tree.CreateRole{Name: $3.expr()}
+    $$.val = &tree.CreateRole{Name: $3.expr(), Options: $4.roleOptions()}
   }
-| CREATE ROLE IF NOT EXISTS string_or_placeholder
+| CREATE ROLE IF NOT EXISTS string_or_placeholder opt_role_options
   {
-    $$.val = &tree.CreateRole{Name: $6.expr(), IfNotExists: true}
+    $$.val = &tree.CreateRole{Name: $6.expr(), IfNotExists: true, Options: $7.roleOptions()}
   }
 | CREATE ROLE error // SHOW HELP: CREATE ROLE
 
@@ -3803,6 +4063,21 @@ alter_rename_database_stmt:
     $$.val = &tree.RenameDatabase{Name: tree.Name($3), NewName: tree.Name($6)}
   }
 
+// https://www.postgresql.org/docs/10/static/sql-alterdatabase.html
+alter_database_set_stmt:
+  ALTER DATABASE database_name SET generic_set
+  {
+    $$.val = &tree.AlterDatabaseSet{Name: tree.Name($3), SetVar: $5.stmt().(*tree.SetVar)}
+  }
+| ALTER DATABASE database_name RESET var_name
+  {
+    $$.val = &tree.AlterDatabaseSet{Name: tree.Name($3), SetVar: &tree.SetVar{Name: strings.Join($5.strs(), "."), Values: tree.Exprs{tree.DefaultVal{}}}}
+  }
+| ALTER DATABASE database_name RESET ALL
+  {
+    $$.val = &tree.AlterDatabaseSet{Name: tree.Name($3)}
+  }
+
 // https://www.postgresql.org/docs/10/static/sql-alteruser.html
 alter_user_password_stmt:
   ALTER USER string_or_placeholder WITH PASSWORD string_or_placeholder
@@ -3814,6 +4089,41 @@ alter_user_password_stmt:
     $$.val = &tree.AlterUserSetPassword{Name: $5.expr(), Password: $8.expr(), IfExists: true}
   }
 
+alter_user_set_stmt:
+  ALTER USER string_or_placeholder SET generic_set
+  {
+    $$.val = &tree.AlterRoleSet{Name: $3.expr(), SetVar: $5.stmt().(*tree.SetVar)}
+  }
+| ALTER USER string_or_placeholder RESET var_name
+  {
+    $$.val = &tree.AlterRoleSet{Name: $3.expr(), SetVar: &tree.SetVar{Name: strings.Join($5.strs(), "."), Values: tree.Exprs{tree.DefaultVal{}}}}
+  }
+| ALTER USER string_or_placeholder RESET ALL
+  {
+    $$.val = &tree.AlterRoleSet{Name: $3.expr()}
+  }
+
+// https://www.postgresql.org/docs/10/static/sql-alterrole.html
+alter_role_options_stmt:
+  ALTER ROLE string_or_placeholder opt_with role_option_list
+  {
+    $$.val = &tree.AlterRoleOptions{Name: $3.expr(), IsRole: true, Options: $5.roleOptions()}
+  }
+
+alter_role_set_stmt:
+  ALTER ROLE string_or_placeholder SET generic_set
+  {
+    $$.val = &tree.AlterRoleSet{Name: $3.expr(), IsRole: true, SetVar: $5.stmt().(*tree.SetVar)}
+  }
+| ALTER ROLE string_or_placeholder RESET var_name
+  {
+    $$.val = &tree.AlterRoleSet{Name: $3.expr(), IsRole: true, SetVar: &tree.SetVar{Name: strings.Join($5.strs(), "."), Values: tree.Exprs{tree.DefaultVal{}}}}
+  }
+| ALTER ROLE string_or_placeholder RESET ALL
+  {
+    $$.val = &tree.AlterRoleSet{Name: $3.expr(), IsRole: true}
+  }
+
 alter_rename_table_stmt:
   ALTER TABLE relation_expr RENAME TO table_name
   {
@@ -4088,6 +4398,23 @@ transaction_read_mode:
     $$.val = tree.ReadWrite
   }
 
+// %Help: CREATE EXTENSION - enable a PostgreSQL extension
+// %Category: DDL
+// %Text: CREATE EXTENSION [IF NOT EXISTS] <name>
+//
+// The extensions commonly created by migrations for functions that exist
+// natively are accepted and have no effect: pgcrypto.
+create_extension_stmt:
+  CREATE EXTENSION name
+  {
+    $$.val = &tree.CreateExtension{Name: tree.Name($3)}
+  }
+| CREATE EXTENSION IF NOT EXISTS name
+  {
+    $$.val = &tree.CreateExtension{IfNotExists: true, Name: tree.Name($6)}
+  }
+| CREATE EXTENSION error // SHOW HELP: CREATE EXTENSION
+
 // %Help: CREATE DATABASE - create a new database
 // %Category: DDL
 // %Text: CREATE DATABASE [IF NOT EXISTS] <name>
@@ -7353,6 +7680,7 @@ unreserved_keyword:
 | CONSTRAINTS
 | COPY
 | COVERING
+| CREATEROLE
 | CSV
 | CUBE
 | CURRENT
@@ -7370,8 +7698,10 @@ unreserved_keyword:
 | EXECUTE
 | EXPERIMENTAL
 | EXPERIMENTAL_FINGERPRINTS
+| EXPERIMENTAL_RANGES
 | EXPERIMENTAL_REPLICA
 | EXPLAIN
+| EXTENSION
 | FILTER
 | FIRST
 | FOLLOWING
@@ -7401,6 +7731,7 @@ unreserved_keyword:
 | LEVEL
 | LIST
 | LOCAL
+| LOGIN
 | LOW
 | MATCH
 | MINUTE
@@ -7409,6 +7740,8 @@ unreserved_keyword:
 | NAN
 | NEXT
 | NO
+| NOCREATEROLE
+| NOLOGIN
 | NORMAL
 | NO_INDEX_JOIN
 | NULLS
@@ -7430,6 +7763,7 @@ unreserved_keyword:
 | PRECEDING
 | PREPARE
 | PRIORITY
+| PRIVILEGES
 | QUERIES
 | QUERY
 | RANGE
@@ -7495,6 +7829,7 @@ unreserved_keyword:
 | UNBOUNDED
 | UNCOMMITTED
 | UNKNOWN
+| UNTIL
 | UPDATE
 | UPSERT
 | USE
@@ -20,22 +20,32 @@ import (
 	"fmt"
 	"hash"
 	"hash/fnv"
+	"net"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/lib/pq/oid"
 	"github.com/pkg/errors"
-	"golang.org/x/text/collate"
 
 	"bytes"
 
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
 )
 
 var (
@@ -61,10 +71,14 @@ var pgCatalog = virtualSchema{
 		pgCatalogAttrDefTable,
 		pgCatalogAttributeTable,
 		pgCatalogAuthMembersTable,
+		pgCatalogAuthIDTable,
+		pgCatalogAvailableExtensionsTable,
 		pgCatalogClassTable,
 		pgCatalogCollationTable,
 		pgCatalogConstraintTable,
+		pgCatalogCursorsTable,
 		pgCatalogDatabaseTable,
+		pgCatalogDbRoleSettingTable,
 		pgCatalogDependTable,
 		pgCatalogDescriptionTable,
 		pgCatalogEnumTable,
@@ -75,14 +89,21 @@ var pgCatalog = virtualSchema{
 		pgCatalogIndexTable,
 		pgCatalogIndexesTable,
 		pgCatalogInheritsTable,
+		pgCatalogLocksTable,
 		pgCatalogNamespaceTable,
 		pgCatalogOperatorTable,
+		pgCatalogPreparedStatementsTable,
 		pgCatalogProcTable,
 		pgCatalogRangeTable,
 		pgCatalogRewriteTable,
 		pgCatalogRolesTable,
+		pgCatalogSequenceTable,
 		pgCatalogSequencesTable,
 		pgCatalogSettingsTable,
+		pgCatalogShdescriptionTable,
+		pgCatalogStatActivityTable,
+		pgCatalogStatDatabaseTable,
+		pgCatalogStatUserTablesTable,
 		pgCatalogUserTable,
 		pgCatalogUserMappingTable,
 		pgCatalogTablesTable,
@@ -128,15 +149,24 @@ CREATE TABLE pg_catalog.pg_attrdef (
 `,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
 		h := makeOidHasher()
+		privs, err := p.makePrivilegeChecker(ctx)
+		if err != nil {
+			return err
+		}
 		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
-			colNum := 0
-			return forEachColumnInTable(table, func(column *sqlbase.ColumnDescriptor) error {
-				colNum++
-				if column.DefaultExpr == nil {
-					// pg_attrdef only expects rows for columns with default values.
+			return forEachColumnInTable(table, privs, func(colNum int, column *sqlbase.ColumnDescriptor) error {
+				// pg_attrdef only expects rows for columns with default values.
+				// Like Postgres, it also holds the expressions of the computed
+				// columns.
+				var defSrc *tree.DString
+				switch {
+				case column.DefaultExpr != nil:
+					defSrc = tree.NewDString(*column.DefaultExpr)
+				case column.ComputeExpr != nil:
+					defSrc = tree.NewDString(*column.ComputeExpr)
+				default:
 					return nil
 				}
-				defSrc := tree.NewDString(*column.DefaultExpr)
 				return addRow(
 					h.ColumnOid(db, table, column),  // oid
 					h.TableOid(db, table),           // adrelid
@@ -173,15 +203,38 @@ CREATE TABLE pg_catalog.pg_attribute (
 	attcollation OID,
 	attacl STRING[],
 	attoptions STRING[],
-	attfdwoptions STRING[]
+	attfdwoptions STRING[],
+	attidentity CHAR,
+	attgenerated CHAR
 );
 `,
-	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+	keyColumns: []string{"attrelid"},
+	populateConstrained: func(
+		ctx context.Context,
+		p *planner,
+		prefix string,
+		constraints virtualConstraints,
+		addRow func(...tree.Datum) error,
+	) error {
 		h := makeOidHasher()
-		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+		privs, err := p.makePrivilegeChecker(ctx)
+		if err != nil {
+			return err
+		}
+		return forEachTableDescByOid(ctx, p, prefix, constraints, "attrelid", func(
+			db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor,
+		) error {
 			// addColumn adds adds either a table or a index column to the pg_attribute table.
-			addColumn := func(column *sqlbase.ColumnDescriptor, attRelID tree.Datum, colNum int) error {
+			// Only the columns of the table have defaults or are generated.
+			addColumn := func(column *sqlbase.ColumnDescriptor, attRelID tree.Datum, colNum int, isIndex bool) error {
 				colTyp := column.Type.ToDatumType()
+				hasDef, generated := false, attGeneratedNone
+				if !isIndex {
+					hasDef = column.DefaultExpr != nil || column.ComputeExpr != nil
+					if column.ComputeExpr != nil {
+						generated = attGeneratedStored
+					}
+				}
 				return addRow(
 					attRelID,                        // attrelid
 					tree.NewDName(column.Name),      // attname
@@ -195,36 +248,34 @@ CREATE TABLE pg_catalog.pg_attribute (
 					tree.DNull, // attbyval (see pg_type.typbyval)
 					tree.DNull, // attstorage
 					tree.DNull, // attalign
-					tree.MakeDBool(tree.DBool(!column.Nullable)),          // attnotnull
-					tree.MakeDBool(tree.DBool(column.DefaultExpr != nil)), // atthasdef
-					tree.DBoolFalse,                                       // attisdropped
-					tree.DBoolTrue,                                        // attislocal
-					zeroVal,                                               // attinhcount
-					typColl(colTyp, h),                                    // attcollation
-					tree.DNull,                                            // attacl
-					tree.DNull,                                            // attoptions
-					tree.DNull,                                            // attfdwoptions
+					tree.MakeDBool(tree.DBool(!column.Nullable)), // attnotnull
+					tree.MakeDBool(tree.DBool(hasDef)),           // atthasdef
+					tree.DBoolFalse,                              // attisdropped
+					tree.DBoolTrue,                               // attislocal
+					zeroVal,                                      // attinhcount
+					typColl(colTyp, h),                           // attcollation
+					tree.DNull,                                   // attacl
+					tree.DNull,                                   // attoptions
+					tree.DNull,                                   // attfdwoptions
+					attIdentityNone,                              // attidentity
+					generated,                                    // attgenerated
 				)
 			}
 
 			// Columns for table.
-			colNum := 0
-			if err := forEachColumnInTable(table, func(column *sqlbase.ColumnDescriptor) error {
-				colNum++
-				tableID := h.TableOid(db, table)
-				return addColumn(column, tableID, colNum)
+			tableID := h.TableOid(db, table)
+			if err := forEachColumnInTable(table, privs, func(colNum int, column *sqlbase.ColumnDescriptor) error {
+				return addColumn(column, tableID, colNum, false /* isIndex */)
 			}); err != nil {
 				return err
 			}
 
 			// Columns for each index.
 			return forEachIndexInTable(table, func(index *sqlbase.IndexDescriptor) error {
-				colNum := 0
-				return forEachColumnInIndex(table, index,
-					func(column *sqlbase.ColumnDescriptor) error {
-						colNum++
+				return forEachColumnInIndex(table, index, privs,
+					func(colNum int, column *sqlbase.ColumnDescriptor) error {
 						idxID := h.IndexOid(db, table, index)
-						return addColumn(column, idxID, colNum)
+						return addColumn(column, idxID, colNum, true /* isIndex */)
 					},
 				)
 			})
@@ -232,6 +283,15 @@ CREATE TABLE pg_catalog.pg_attribute (
 	},
 }
 
+var (
+	// There are no identity columns: SERIAL columns are columns with a
+	// default, as in Postgres.
+	attIdentityNone = tree.NewDString("")
+
+	attGeneratedNone   = tree.NewDString("")
+	attGeneratedStored = tree.NewDString("s")
+)
+
 // See: https://www.postgresql.org/docs/9.6/static/catalog-pg-auth-members.html.
 var pgCatalogAuthMembersTable = virtualSchemaTable{
 	schema: `
@@ -256,6 +316,74 @@ CREATE TABLE pg_catalog.pg_auth_members (
 	},
 }
 
+// pg_authid lists the same users and roles as pg_roles. The hashed
+// passwords are not exposed.
+//
+// See: https://www.postgresql.org/docs/10/static/catalog-pg-authid.html.
+var pgCatalogAuthIDTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_authid (
+	oid OID,
+	rolname NAME,
+	rolsuper BOOL,
+	rolinherit BOOL,
+	rolcreaterole BOOL,
+	rolcreatedb BOOL,
+	rolcanlogin BOOL,
+	rolreplication BOOL,
+	rolbypassrls BOOL,
+	rolconnlimit INT,
+	rolpassword STRING,
+	rolvaliduntil TIMESTAMPTZ
+);
+`,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		options, err := getRoleOptions(ctx, p.txn, p.ExecCfg())
+		if err != nil {
+			return err
+		}
+		return forEachRole(ctx, p,
+			func(username string, isRole bool) error {
+				isRoot := username == security.RootUser || username == sqlbase.AdminRole
+				o := options[username]
+				return addRow(
+					h.UserOid(username),                                // oid
+					tree.NewDName(username),                            // rolname
+					tree.MakeDBool(tree.DBool(isRoot)),                 // rolsuper
+					tree.MakeDBool(tree.DBool(isRole)),                 // rolinherit. Roles inherit by default.
+					tree.MakeDBool(tree.DBool(isRoot || o.createRole)), // rolcreaterole
+					tree.MakeDBool(tree.DBool(isRoot)),                 // rolcreatedb
+					tree.MakeDBool(tree.DBool(!isRole && !o.noLogin)),  // rolcanlogin
+					tree.DBoolFalse,                                    // rolreplication
+					tree.DBoolFalse,                                    // rolbypassrls
+					negOneVal,                                          // rolconnlimit
+					tree.DNull,                                         // rolpassword
+					o.validUntilDatum(),                                // rolvaliduntil
+				)
+			})
+	},
+}
+
+// pg_available_extensions is empty: CREATE EXTENSION only accepts the
+// extensions that are built in, which are not installed as such.
+//
+// See: https://www.postgresql.org/docs/10/static/view-pg-available-extensions.html.
+var pgCatalogAvailableExtensionsTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_available_extensions (
+	name NAME,
+	default_version STRING,
+	installed_version STRING,
+	comment STRING
+);
+`,
+	populate: func(_ context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		// Extensions are not supported.
+		return nil
+	},
+}
+
 var (
 	relKindTable    = tree.NewDString("r")
 	relKindIndex    = tree.NewDString("i")
@@ -298,9 +426,18 @@ CREATE TABLE pg_catalog.pg_class (
 	reloptions STRING[]
 );
 `,
-	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+	keyColumns: []string{"oid"},
+	populateConstrained: func(
+		ctx context.Context,
+		p *planner,
+		prefix string,
+		constraints virtualConstraints,
+		addRow func(...tree.Datum) error,
+	) error {
 		h := makeOidHasher()
-		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+		return forEachTableDescByOid(ctx, p, prefix, constraints, "oid", func(
+			db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor,
+		) error {
 			// The only difference between tables, views and sequences is the relkind column.
 			relKind := relKindTable
 			if table.IsView() {
@@ -398,8 +535,7 @@ CREATE TABLE pg_catalog.pg_collation (
 `,
 	populate: func(_ context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
 		h := makeOidHasher()
-		for _, tag := range collate.Supported() {
-			collName := tag.String()
+		for _, collName := range tree.SupportedCollationLocales() {
 			if err := addRow(
 				h.CollationOid(collName),  // oid
 				tree.NewDString(collName), // collname
@@ -492,6 +628,37 @@ CREATE TABLE pg_catalog.pg_constraint (
 			table *sqlbase.TableDescriptor,
 			tableLookup tableLookupFn,
 		) error {
+			if table.IsSequence() {
+				owner := table.SequenceOpts.SequenceOwner
+				if owner.OwnerTableID == 0 {
+					return nil
+				}
+				ownerDB, ownerTable := tableLookup(owner.OwnerTableID)
+				if ownerTable == nil {
+					return nil
+				}
+				// refobjsubid is the owning column's attnum in pg_attribute.
+				colNum := 0
+				for _, column := range ownerTable.Columns {
+					if column.Hidden {
+						continue
+					}
+					colNum++
+					if column.ID == owner.OwnerColumnID {
+						break
+					}
+				}
+				ownerColNum := tree.NewDInt(tree.DInt(colNum))
+				return addRow(
+					pgClassTableOid,                 // classid
+					h.TableOid(db, table),           // objid
+					zeroVal,                         // objsubid
+					pgClassTableOid,                 // refclassid
+					h.TableOid(ownerDB, ownerTable), // refobjid
+					ownerColNum,                     // refobjsubid
+					depTypeAuto,                     // deptype
+				)
+			}
 			info, err := table.GetConstraintInfoWithLookup(tableLookup.tableOrErr)
 			if err != nil {
 				return err
@@ -643,6 +810,41 @@ func colIDArrayToVector(arr []sqlbase.ColumnID) (tree.Datum, error) {
 	return tree.NewDIntVectorFromDArray(tree.MustBeDArray(dArr)), nil
 }
 
+// pg_cursors lists the named portals of the session, which are created
+// through the wire protocol, as CockroachDB does not support DECLARE.
+//
+// See: https://www.postgresql.org/docs/10/static/view-pg-cursors.html
+var pgCatalogCursorsTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_cursors (
+	name STRING,
+	statement STRING,
+	is_holdable BOOL,
+	is_binary BOOL,
+	is_scrollable BOOL,
+	creation_time TIMESTAMPTZ
+);
+`,
+	populate: func(_ context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		return p.preparedStatements.VisitPortals(func(name string, portal *PreparedPortal) error {
+			isBinary := len(portal.OutFormats) > 0
+			for _, f := range portal.OutFormats {
+				if f != pgwirebase.FormatBinary {
+					isBinary = false
+				}
+			}
+			return addRow(
+				tree.NewDString(name),                // name
+				tree.NewDString(portal.Stmt.Str),     // statement
+				tree.DBoolFalse,                      // is_holdable
+				tree.MakeDBool(tree.DBool(isBinary)), // is_binary
+				tree.DBoolFalse,                      // is_scrollable
+				tree.MakeDTimestampTZ(portal.CreationTime, time.Microsecond), // creation_time
+			)
+		})
+	},
+}
+
 // See https://www.postgresql.org/docs/9.6/static/catalog-pg-database.html.
 var pgCatalogDatabaseTable = virtualSchemaTable{
 	schema: `
@@ -685,6 +887,47 @@ CREATE TABLE pg_catalog.pg_database (
 		})
 	},
 }
+
+// See https://www.postgresql.org/docs/10/static/catalog-pg-db-role-setting.html.
+// The default session variables set with ALTER ROLE ... SET apply to all
+// the databases, hence setdatabase is always 0.
+var pgCatalogDbRoleSettingTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_db_role_setting (
+	setdatabase OID,
+	setrole OID,
+	setconfig STRING[]
+);
+`,
+	minVersion: cluster.VersionRoleSettingsOptionsAndDefaultPrivileges,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		configs, err := getRoleConfigs(ctx, p)
+		if err != nil {
+			return err
+		}
+		for _, c := range configs {
+			// The defaults of a database apply to all the roles, and those of a
+			// role to all the databases.
+			setDatabase, setRole := oidZero, oidZero
+			if c.databaseID != 0 {
+				setDatabase = tree.NewDOid(tree.DInt(c.databaseID))
+			}
+			if c.role != "" {
+				setRole = h.UserOid(c.role)
+			}
+			if err := addRow(
+				setDatabase, // setdatabase
+				setRole,     // setrole
+				c.config,    // setconfig
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
 var (
 	depTypeNormal        = tree.NewDString("n")
 	depTypeAuto          = tree.NewDString("a")
@@ -694,7 +937,6 @@ var (
 	depTypePin           = tree.NewDString("p")
 
 	// Avoid unused warning for constants.
-	_ = depTypeAuto
 	_ = depTypeInternal
 	_ = depTypeExtension
 	_ = depTypeAutoExtension
@@ -710,7 +952,9 @@ var (
 // addition of the conindid column. To provide backward compatibility with
 // pgjdbc drivers before https://github.com/pgjdbc/pgjdbc/pull/689, we
 // provide those rows in pg_depend that track the dependency of foreign key
-// constraints on their supporting index entries in pg_class.
+// constraints on their supporting index entries in pg_class. We also provide
+// the rows that track the dependency of sequences on the columns that own them
+// (via OWNED BY), which clients use to find the sequence backing a column.
 var pgCatalogDependTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE pg_catalog.pg_depend (
@@ -799,12 +1043,90 @@ CREATE TABLE pg_catalog.pg_description (
 	description STRING
 );
 `,
-	populate: func(_ context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
-		// Comments on database objects are not currently supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		comments, err := getComments(ctx, p)
+		if err != nil {
+			return err
+		}
+		if len(comments) == 0 {
+			return nil
+		}
+
+		h := makeOidHasher()
+		pgClassTableOid, err := pgCatalogTableOid(ctx, p, h, "pg_class")
+		if err != nil {
+			return err
+		}
+		privs, err := p.makePrivilegeChecker(ctx)
+		if err != nil {
+			return err
+		}
+
+		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+			tableOid := h.TableOid(db, table)
+			if comment, ok := comments[commentKey{tableCommentType, table.ID, 0}]; ok {
+				if err := addRow(
+					tableOid,                 // objoid
+					pgClassTableOid,          // classoid
+					zeroVal,                  // objsubid
+					tree.NewDString(comment), // description
+				); err != nil {
+					return err
+				}
+			}
+			// The column number must match pg_attribute.attnum.
+			if err := forEachColumnInTable(table, privs, func(colNum int, column *sqlbase.ColumnDescriptor) error {
+				comment, ok := comments[commentKey{columnCommentType, table.ID, int64(column.ID)}]
+				if !ok {
+					return nil
+				}
+				return addRow(
+					tableOid,                        // objoid
+					pgClassTableOid,                 // classoid
+					tree.NewDInt(tree.DInt(colNum)), // objsubid
+					tree.NewDString(comment),        // description
+				)
+			}); err != nil {
+				return err
+			}
+			// Indexes are described by their own pg_class rows.
+			return forEachIndexInTable(table, func(index *sqlbase.IndexDescriptor) error {
+				comment, ok := comments[commentKey{indexCommentType, table.ID, int64(index.ID)}]
+				if !ok {
+					return nil
+				}
+				return addRow(
+					h.IndexOid(db, table, index), // objoid
+					pgClassTableOid,              // classoid
+					zeroVal,                      // objsubid
+					tree.NewDString(comment),     // description
+				)
+			})
+		})
 	},
 }
 
+// pgCatalogTableOid returns the OID of the pg_catalog table with the given
+// name, for use in the classoid columns.
+func pgCatalogTableOid(
+	ctx context.Context, p *planner, h oidHasher, name string,
+) (*tree.DOid, error) {
+	db, err := getDatabaseDesc(ctx, p.txn, p.getVirtualTabler(), pgCatalogName)
+	if err != nil {
+		return nil, errors.New("could not find pg_catalog")
+	}
+	desc, err := getTableDesc(
+		ctx,
+		p.txn,
+		p.getVirtualTabler(),
+		tree.NewTableName(pgCatalogName, tree.Name(name)),
+	)
+	if err != nil {
+		return nil, errors.Errorf("could not find pg_catalog.%s", name)
+	}
+	return h.TableOid(db, desc), nil
+}
+
 // See: https://www.postgresql.org/docs/9.6/static/catalog-pg-enum.html.
 var pgCatalogEnumTable = virtualSchemaTable{
 	schema: `
@@ -835,7 +1157,8 @@ CREATE TABLE pg_catalog.pg_extension (
 );
 `,
 	populate: func(_ context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
-		// Extensions are not supported.
+		// Extensions are not supported. The ones CREATE EXTENSION accepts are
+		// built in, and not listed.
 		return nil
 	},
 }
@@ -969,7 +1292,8 @@ CREATE TABLE pg_catalog.pg_indexes (
 	tablename NAME,
 	indexname NAME,
 	tablespace NAME,
-	indexdef STRING
+	indexdef STRING,
+	crdb_index_type STRING
 );
 `,
 	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
@@ -987,6 +1311,7 @@ CREATE TABLE pg_catalog.pg_indexes (
 					tree.NewDName(index.Name),       // indexname
 					tree.DNull,                      // tablespace
 					tree.NewDString(def),            // indexdef
+					indexTypeDatum(index),           // crdb_index_type
 				)
 			})
 		})
@@ -1008,16 +1333,20 @@ func indexDefFromDescriptor(
 		Table: tree.NormalizableTableName{
 			TableNameReference: tree.NewTableName(tree.Name(db.Name), tree.Name(table.Name)),
 		},
-		Unique:  index.Unique,
-		Columns: make(tree.IndexElemList, len(index.ColumnNames)),
-		Storing: make(tree.NameList, len(index.StoreColumnNames)),
+		Unique:   index.Unique,
+		Inverted: index.Type == sqlbase.IndexDescriptor_INVERTED,
+		Columns:  make(tree.IndexElemList, len(index.ColumnNames)),
+		Storing:  make(tree.NameList, len(index.StoreColumnNames)),
 	}
 	for i, name := range index.ColumnNames {
 		elem := tree.IndexElem{
 			Column:    tree.Name(name),
 			Direction: tree.Ascending,
 		}
-		if index.ColumnDirections[i] == sqlbase.IndexDescriptor_DESC {
+		if indexDef.Inverted {
+			// Inverted indexes are not ordered by their columns.
+			elem.Direction = tree.DefaultDirection
+		} else if index.ColumnDirections[i] == sqlbase.IndexDescriptor_DESC {
 			elem.Direction = tree.Descending
 		}
 		indexDef.Columns[i] = elem
@@ -1070,6 +1399,117 @@ CREATE TABLE pg_catalog.pg_inherits (
 	},
 }
 
+var (
+	lockTypeRelation     = tree.NewDString("relation")
+	lockTypeTuple        = tree.NewDString("tuple")
+	lockModeRowExclusive = tree.NewDString("RowExclusiveLock")
+	lockModeAccessShare  = tree.NewDString("AccessShareLock")
+)
+
+// pg_locks is a best-effort approximation of the PostgreSQL view from the
+// transactions open on the current node: the spans of keys they wrote, on
+// which they hold write intents, are reported as RowExclusiveLock and the
+// spans they read as AccessShareLock. A single key is a tuple lock, while a
+// range of keys is a relation lock. The transactions waiting on the intents
+// of others are not reported.
+//
+// The crdb_index_name and crdb_span columns are CockroachDB extensions
+// reporting the index and the keys of the lock.
+//
+// See: https://www.postgresql.org/docs/10/static/view-pg-locks.html
+var pgCatalogLocksTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_locks (
+	locktype STRING,
+	database OID,
+	relation OID,
+	page INT,
+	tuple INT,
+	virtualxid STRING,
+	transactionid INT,
+	classid OID,
+	objid OID,
+	objsubid INT,
+	virtualtransaction STRING,
+	pid INT,
+	mode STRING,
+	granted BOOL,
+	fastpath BOOL,
+	crdb_index_name NAME,
+	crdb_span STRING
+);
+`,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		type tableWithDB struct {
+			db    *sqlbase.DatabaseDescriptor
+			table *sqlbase.TableDescriptor
+		}
+		// The locks are those of the whole node, so their keys are looked up in
+		// the tables of all the databases.
+		tables := make(map[sqlbase.ID]tableWithDB)
+		if err := forEachTableDesc(ctx, p, "", func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+			tables[table.ID] = tableWithDB{db: db, table: table}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		addLock := func(txnID string, span roachpb.Span, mode tree.Datum) error {
+			locktype := lockTypeTuple
+			if len(span.EndKey) > 0 {
+				locktype = lockTypeRelation
+			}
+			database, relation, indexName := tree.DNull, tree.DNull, tree.DNull
+			if bytes.Compare(span.Key, keys.TableDataMin) >= 0 {
+				if _, tableID, indexID, err := sqlbase.DecodeTableIDIndexID(span.Key); err == nil {
+					if t, ok := tables[tableID]; ok {
+						database = h.DBOid(t.db)
+						relation = h.TableOid(t.db, t.table)
+						if index, err := t.table.FindIndexByID(indexID); err == nil {
+							indexName = tree.NewDName(index.Name)
+						}
+					}
+				}
+			}
+			return addRow(
+				locktype,                       // locktype
+				database,                       // database
+				relation,                       // relation
+				tree.DNull,                     // page
+				tree.DNull,                     // tuple
+				tree.DNull,                     // virtualxid
+				tree.DNull,                     // transactionid
+				tree.DNull,                     // classid
+				tree.DNull,                     // objid
+				tree.DNull,                     // objsubid
+				tree.NewDString(txnID),         // virtualtransaction
+				tree.DNull,                     // pid
+				mode,                           // mode
+				tree.DBoolTrue,                 // granted
+				tree.DBoolFalse,                // fastpath
+				indexName,                      // crdb_index_name
+				tree.NewDString(span.String()), // crdb_span
+			)
+		}
+
+		for _, txn := range p.ExecCfg().SessionRegistry.txnMetas(p.SessionData().User) {
+			txnID := txn.meta.Txn.ID.String()
+			for _, span := range txn.meta.Intents {
+				if err := addLock(txnID, span, lockModeRowExclusive); err != nil {
+					return err
+				}
+			}
+			for _, span := range txn.meta.RefreshReads {
+				if err := addLock(txnID, span, lockModeAccessShare); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
+
 // See: https://www.postgresql.org/docs/9.6/static/catalog-pg-namespace.html.
 var pgCatalogNamespaceTable = virtualSchemaTable{
 	schema: `
@@ -1084,10 +1524,10 @@ CREATE TABLE pg_catalog.pg_namespace (
 		h := makeOidHasher()
 		return forEachDatabaseDesc(ctx, p, func(db *sqlbase.DatabaseDescriptor) error {
 			return addRow(
-				h.NamespaceOid(db.Name),  // oid
-				tree.NewDString(db.Name), // nspname
-				tree.DNull,               // nspowner
-				tree.DNull,               // nspacl
+				pgNamespaceForDB(db, h).Oid, // oid
+				tree.NewDString(db.Name),    // nspname
+				tree.DNull,                  // nspowner
+				tree.DNull,                  // nspacl
 			)
 		})
 	},
@@ -1119,6 +1559,43 @@ CREATE TABLE pg_catalog.pg_operator (
 	},
 }
 
+// pg_prepared_statements lists the named prepared statements of the
+// session. The types of the parameters are reported as OIDs.
+//
+// See: https://www.postgresql.org/docs/10/static/view-pg-prepared-statements.html
+var pgCatalogPreparedStatementsTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_prepared_statements (
+	name STRING,
+	statement STRING,
+	prepare_time TIMESTAMPTZ,
+	parameter_types OID[],
+	from_sql BOOL
+);
+`,
+	populate: func(_ context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		return p.preparedStatements.Visit(func(name string, stmt *PreparedStatement) error {
+			paramTypes := tree.NewDArray(types.Oid)
+			for i := 1; i <= len(stmt.Types); i++ {
+				typOidDatum := tree.DNull
+				if typ, ok := stmt.Types[strconv.Itoa(i)]; ok {
+					typOidDatum = typOid(typ)
+				}
+				if err := paramTypes.Append(typOidDatum); err != nil {
+					return err
+				}
+			}
+			return addRow(
+				tree.NewDString(name),                                     // name
+				tree.NewDString(stmt.Str),                                 // statement
+				tree.MakeDTimestampTZ(stmt.PrepareTime, time.Microsecond), // prepare_time
+				paramTypes,                               // parameter_types
+				tree.MakeDBool(tree.DBool(stmt.FromSQL)), // from_sql
+			)
+		})
+	},
+}
+
 func newSingletonStringArray(s string) tree.Datum {
 	return &tree.DArray{ParamTyp: types.String, Array: tree.Datums{tree.NewDString(s)}}
 }
@@ -1362,32 +1839,51 @@ CREATE TABLE pg_catalog.pg_roles (
 		// need to do the same. This shouldn't be an issue, because pg_roles doesn't
 		// include sensitive information such as password hashes.
 		h := makeOidHasher()
+		configs, err := getRoleConfigs(ctx, p)
+		if err != nil {
+			return err
+		}
+		configByRole := make(map[string]tree.Datum, len(configs))
+		for _, c := range configs {
+			if c.databaseID == 0 {
+				configByRole[c.role] = c.config
+			}
+		}
+		options, err := getRoleOptions(ctx, p.txn, p.ExecCfg())
+		if err != nil {
+			return err
+		}
 		return forEachRole(ctx, p,
 			func(username string, isRole bool) error {
 				isRoot := tree.DBool(username == security.RootUser || username == sqlbase.AdminRole)
 				isRoleDBool := tree.DBool(isRole)
+				rolConfig, ok := configByRole[username]
+				if !ok {
+					rolConfig = tree.DNull
+				}
+				o := options[username]
 				return addRow(
-					h.UserOid(username),          // oid
-					tree.NewDName(username),      // rolname
-					tree.MakeDBool(isRoot),       // rolsuper
-					tree.MakeDBool(isRoleDBool),  // rolinherit. Roles inherit by default.
-					tree.MakeDBool(isRoot),       // rolcreaterole
-					tree.MakeDBool(isRoot),       // rolcreatedb
-					tree.DBoolFalse,              // rolcatupdate
-					tree.MakeDBool(!isRoleDBool), // rolcanlogin. Only users can login.
-					tree.DBoolFalse,              // rolreplication
-					negOneVal,                    // rolconnlimit
-					passwdStarString,             // rolpassword
-					tree.DNull,                   // rolvaliduntil
-					tree.DBoolFalse,              // rolbypassrls
-					tree.DNull,                   // rolconfig
+					h.UserOid(username),                                    // oid
+					tree.NewDName(username),                                // rolname
+					tree.MakeDBool(isRoot),                                 // rolsuper
+					tree.MakeDBool(isRoleDBool),                            // rolinherit. Roles inherit by default.
+					tree.MakeDBool(isRoot || tree.DBool(o.createRole)),     // rolcreaterole
+					tree.MakeDBool(isRoot),                                 // rolcreatedb
+					tree.DBoolFalse,                                        // rolcatupdate
+					tree.MakeDBool(!isRoleDBool && !tree.DBool(o.noLogin)), // rolcanlogin. Only users can login.
+					tree.DBoolFalse,                                        // rolreplication
+					negOneVal,                                              // rolconnlimit
+					passwdStarString,                                       // rolpassword
+					o.validUntilDatum(),                                    // rolvaliduntil
+					tree.DBoolFalse,                                        // rolbypassrls
+					rolConfig,                                              // rolconfig
 				)
 			})
 	},
 }
 
 // See: https://www.postgresql.org/docs/10/static/catalog-pg-sequence.html
-var pgCatalogSequencesTable = virtualSchemaTable{
+var pgCatalogSequenceTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE pg_catalog.pg_sequence (
 	seqrelid OID,
@@ -1421,6 +1917,60 @@ CREATE TABLE pg_catalog.pg_sequence (
 	},
 }
 
+// See: https://www.postgresql.org/docs/10/static/view-pg-sequences.html
+var pgCatalogSequencesTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_sequences (
+	schemaname NAME,
+	sequencename NAME,
+	sequenceowner NAME,
+	data_type OID,
+	start_value INT8,
+	min_value INT8,
+	max_value INT8,
+	increment_by INT8,
+	cycle BOOL,
+	cache_size INT8,
+	last_value INT8
+);
+`,
+	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+			if !table.IsSequence() {
+				return nil
+			}
+			opts := table.SequenceOpts
+			// Like in Postgres, last_value is NULL if the user may not read
+			// the sequence, or until nextval() is first called on it.
+			lastValue := tree.DNull
+			if p.CheckPrivilege(ctx, table, privilege.SELECT) == nil {
+				kv, err := p.txn.Get(ctx, keys.MakeSequenceKey(uint32(table.ID)))
+				if err != nil {
+					return err
+				}
+				if kv.Exists() {
+					if val := kv.ValueInt(); val != opts.Start-opts.Increment {
+						lastValue = tree.NewDInt(tree.DInt(val))
+					}
+				}
+			}
+			return addRow(
+				tree.NewDName(db.Name),                  // schemaname
+				tree.NewDName(table.Name),               // sequencename
+				tree.DNull,                              // sequenceowner
+				tree.NewDOid(tree.DInt(oid.T_int8)),     // data_type
+				tree.NewDInt(tree.DInt(opts.Start)),     // start_value
+				tree.NewDInt(tree.DInt(opts.MinValue)),  // min_value
+				tree.NewDInt(tree.DInt(opts.MaxValue)),  // max_value
+				tree.NewDInt(tree.DInt(opts.Increment)), // increment_by
+				tree.DBoolFalse,                         // cycle
+				tree.NewDInt(1),                         // cache_size
+				lastValue,                               // last_value
+			)
+		})
+	},
+}
+
 var (
 	varTypeString   = tree.NewDString("string")
 	settingsCtxUser = tree.NewDString("user")
@@ -1449,17 +1999,24 @@ CREATE TABLE pg_catalog.pg_settings (
     pending_restart BOOL
 );
 `,
+	update: updatePgSettings,
 	populate: func(_ context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
 		for _, vName := range varNames {
 			gen := varGen[vName]
 			value := gen.Get(&p.extendedEvalCtx)
 			valueDatum := tree.NewDString(value)
+			category, shortDesc := tree.DNull, tree.DNull
+			if desc, ok := varDescriptions[vName]; ok {
+				category = tree.NewDString(desc.category)
+				shortDesc = tree.NewDString(desc.shortDesc)
+			}
+			// None of the session variables is a quantity with a unit.
 			if err := addRow(
 				tree.NewDString(strings.ToLower(vName)), // name
 				valueDatum,                              // setting
 				tree.DNull,                              // unit
-				tree.DNull,                              // category
-				tree.DNull,                              // short_desc
+				category,                                // category
+				shortDesc,                               // short_desc
 				tree.DNull,                              // extra_desc
 				settingsCtxUser,                         // context
 				varTypeString,                           // vartype
@@ -1480,6 +2037,354 @@ CREATE TABLE pg_catalog.pg_settings (
 	},
 }
 
+// updatePgSettings plans `UPDATE pg_settings SET setting = ... WHERE ...`,
+// which sets the session variables of the selected rows like set_config()
+// does. The variables are set from the rows of
+// `SELECT name, ... FROM pg_settings WHERE ...`, once all of them have been
+// checked, and are counted as the updated rows.
+func updatePgSettings(ctx context.Context, p *planner, n *tree.Update) (planNode, error) {
+	if _, ok := n.Returning.(*tree.NoReturningClause); !ok {
+		return nil, pgerror.Unimplemented("pg_settings returning",
+			"RETURNING is not supported with UPDATE pg_settings")
+	}
+	if len(n.Exprs) != 1 || n.Exprs[0].Tuple || n.Exprs[0].Names[0].Normalize() != "setting" {
+		return nil, pgerror.Unimplemented("pg_settings columns",
+			"only the setting column of pg_settings can be updated")
+	}
+	value := n.Exprs[0].Expr
+	if _, ok := value.(tree.DefaultVal); ok {
+		return nil, pgerror.Unimplemented("pg_settings default",
+			"use RESET to restore the default value of a session variable")
+	}
+	sel := &tree.SelectClause{
+		Exprs: tree.SelectExprs{
+			{Expr: &tree.UnresolvedName{NumParts: 1, Parts: tree.NameParts{"name"}}},
+			{Expr: &tree.AnnotateTypeExpr{
+				Expr: value, Type: coltypes.String, SyntaxMode: tree.AnnotateShort,
+			}},
+		},
+		From:  &tree.From{Tables: tree.TableExprs{n.Table}},
+		Where: n.Where,
+	}
+	source, err := p.SelectClause(ctx, sel, n.OrderBy, n.Limit, nil /* with */, nil /* desiredTypes */, publicColumns)
+	if err != nil {
+		return nil, err
+	}
+	return &setSessionVarsNode{source: source}, nil
+}
+
+// pg_shdescription holds the comments on the databases, which are shared
+// across the databases like in PostgreSQL.
+//
+// See: https://www.postgresql.org/docs/9.6/static/catalog-pg-shdescription.html
+var pgCatalogShdescriptionTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_shdescription (
+	objoid OID,
+	classoid OID,
+	description STRING
+);
+`,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		comments, err := getComments(ctx, p)
+		if err != nil {
+			return err
+		}
+		if len(comments) == 0 {
+			return nil
+		}
+
+		h := makeOidHasher()
+		pgDatabaseTableOid, err := pgCatalogTableOid(ctx, p, h, "pg_database")
+		if err != nil {
+			return err
+		}
+		return forEachDatabaseDesc(ctx, p, func(db *sqlbase.DatabaseDescriptor) error {
+			comment, ok := comments[commentKey{databaseCommentType, db.ID, 0}]
+			if !ok {
+				return nil
+			}
+			return addRow(
+				h.DBOid(db),              // objoid
+				pgDatabaseTableOid,       // classoid
+				tree.NewDString(comment), // description
+			)
+		})
+	},
+}
+
+var (
+	backendStateActive = tree.NewDString("active")
+	backendStateIdle   = tree.NewDString("idle")
+	backendTypeClient  = tree.NewDString("client backend")
+)
+
+// pg_stat_activity only reports the sessions of the current node, like
+// PostgreSQL reports the backends of its server. The pid is the one assigned
+// to the session by the node, which pg_cancel_backend and
+// pg_terminate_backend accept. The database of the sessions is not tracked,
+// so these columns are NULL.
+//
+// See: https://www.postgresql.org/docs/10/static/monitoring-stats.html#PG-STAT-ACTIVITY-VIEW
+var pgCatalogStatActivityTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_stat_activity (
+	datid OID,
+	datname NAME,
+	pid INT,
+	usesysid OID,
+	usename NAME,
+	application_name STRING,
+	client_addr INET,
+	client_hostname STRING,
+	client_port INT,
+	backend_start TIMESTAMPTZ,
+	xact_start TIMESTAMPTZ,
+	query_start TIMESTAMPTZ,
+	state_change TIMESTAMPTZ,
+	wait_event_type STRING,
+	wait_event STRING,
+	state STRING,
+	backend_xid INT,
+	backend_xmin INT,
+	query STRING,
+	backend_type STRING
+);
+`,
+	currentOnly: true,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		req := serverpb.ListSessionsRequest{Username: p.SessionData().User}
+		response, err := p.extendedEvalCtx.StatusServer.ListLocalSessions(ctx, &req)
+		if err != nil {
+			return err
+		}
+		for _, rpcErr := range response.Errors {
+			log.Warning(ctx, rpcErr.Message)
+		}
+		for _, session := range response.Sessions {
+			clientAddr, clientPort := tree.DNull, tree.DNull
+			if host, port, err := net.SplitHostPort(session.ClientAddress); err == nil {
+				if addr, err := tree.ParseDIPAddrFromINetString(host); err == nil {
+					clientAddr = addr
+				}
+				if n, err := strconv.Atoi(port); err == nil {
+					clientPort = tree.NewDInt(tree.DInt(n))
+				}
+			}
+
+			// A session that runs queries is reported by its oldest query,
+			// otherwise by the last query it ran.
+			state, query, queryStart := backendStateIdle, tree.DNull, tree.DNull
+			var oldest *serverpb.ActiveQuery
+			for i := range session.ActiveQueries {
+				if q := &session.ActiveQueries[i]; oldest == nil || q.Start.Before(oldest.Start) {
+					oldest = q
+				}
+			}
+			if oldest != nil {
+				state = backendStateActive
+				query = tree.NewDString(oldest.Sql)
+				queryStart = tree.MakeDTimestampTZ(oldest.Start, time.Microsecond)
+			} else if session.LastActiveQuery != "" {
+				query = tree.NewDString(session.LastActiveQuery)
+			}
+
+			if err := addRow(
+				tree.DNull,                               // datid
+				tree.DNull,                               // datname
+				tree.NewDInt(tree.DInt(session.BackendPID)), // pid
+				h.UserOid(session.Username),              // usesysid
+				tree.NewDName(session.Username),          // usename
+				tree.NewDString(session.ApplicationName), // application_name
+				clientAddr,                               // client_addr
+				tree.DNull,                               // client_hostname
+				clientPort,                               // client_port
+				tree.MakeDTimestampTZ(session.Start, time.Microsecond), // backend_start
+				tree.DNull,        // xact_start
+				queryStart,        // query_start
+				tree.DNull,        // state_change
+				tree.DNull,        // wait_event_type
+				tree.DNull,        // wait_event
+				state,             // state
+				tree.DNull,        // backend_xid
+				tree.DNull,        // backend_xmin
+				query,             // query
+				backendTypeClient, // backend_type
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// getTableActivity returns the activity of the tables counted by this node,
+// and when the counting started. The start is NULL, and there is no
+// activity, where the statistics are not available.
+func getTableActivity(p *planner) (map[sqlbase.ID]tableActivity, tree.Datum) {
+	sqlStats := p.statsCollector.SQLStats()
+	if sqlStats == nil {
+		return nil, tree.DNull
+	}
+	return sqlStats.tables.snapshot(), tree.MakeDTimestampTZ(sqlStats.tables.start, time.Microsecond)
+}
+
+// pg_stat_database sums the rows written to the tables of each database. Like
+// pg_stat_user_tables, it reports the statements run by the current node
+// since it started. The other activity is not tracked, so these columns are
+// NULL.
+//
+// See: https://www.postgresql.org/docs/10/static/monitoring-stats.html#PG-STAT-DATABASE-VIEW
+var pgCatalogStatDatabaseTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_stat_database (
+	datid OID,
+	datname NAME,
+	numbackends INT,
+	xact_commit INT,
+	xact_rollback INT,
+	blks_read INT,
+	blks_hit INT,
+	tup_returned INT,
+	tup_fetched INT,
+	tup_inserted INT,
+	tup_updated INT,
+	tup_deleted INT,
+	conflicts INT,
+	temp_files INT,
+	temp_bytes INT,
+	deadlocks INT,
+	blk_read_time FLOAT,
+	blk_write_time FLOAT,
+	stats_reset TIMESTAMPTZ
+);
+`,
+	populate: func(ctx context.Context, p *planner, _ string, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		activity, statsReset := getTableActivity(p)
+		byDB := make(map[sqlbase.ID]tableActivity)
+		// The databases are listed whatever the current database, so are
+		// their tables.
+		if err := forEachTableDesc(ctx, p, "" /* prefix */, func(
+			db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor,
+		) error {
+			a, d := activity[table.ID], byDB[db.ID]
+			d.inserted += a.inserted
+			d.updated += a.updated
+			d.deleted += a.deleted
+			byDB[db.ID] = d
+			return nil
+		}); err != nil {
+			return err
+		}
+		return forEachDatabaseDesc(ctx, p, func(db *sqlbase.DatabaseDescriptor) error {
+			d := byDB[db.ID]
+			return addRow(
+				h.DBOid(db),                         // datid
+				tree.NewDName(db.Name),              // datname
+				tree.DNull,                          // numbackends
+				tree.DNull,                          // xact_commit
+				tree.DNull,                          // xact_rollback
+				tree.DNull,                          // blks_read
+				tree.DNull,                          // blks_hit
+				tree.DNull,                          // tup_returned
+				tree.DNull,                          // tup_fetched
+				tree.NewDInt(tree.DInt(d.inserted)), // tup_inserted
+				tree.NewDInt(tree.DInt(d.updated)),  // tup_updated
+				tree.NewDInt(tree.DInt(d.deleted)),  // tup_deleted
+				tree.DNull,                          // conflicts
+				tree.DNull,                          // temp_files
+				tree.DNull,                          // temp_bytes
+				tree.DNull,                          // deadlocks
+				tree.DNull,                          // blk_read_time
+				tree.DNull,                          // blk_write_time
+				statsReset,                          // stats_reset
+			)
+		})
+	},
+}
+
+// pg_stat_user_tables reports the scans of the tables and the rows written to
+// them by the statements run by the current node since it started, so the
+// counts differ across nodes. A scan of the whole primary index counts as a
+// sequential scan, the other scans as index scans. The number of live rows
+// and the time of the last analysis come from the latest statistics of the
+// table, if any; there is no vacuum.
+//
+// See: https://www.postgresql.org/docs/10/static/monitoring-stats.html#PG-STAT-ALL-TABLES-VIEW
+var pgCatalogStatUserTablesTable = virtualSchemaTable{
+	schema: `
+CREATE TABLE pg_catalog.pg_stat_user_tables (
+	relid OID,
+	schemaname NAME,
+	relname NAME,
+	seq_scan INT,
+	seq_tup_read INT,
+	idx_scan INT,
+	idx_tup_fetch INT,
+	n_tup_ins INT,
+	n_tup_upd INT,
+	n_tup_del INT,
+	n_tup_hot_upd INT,
+	n_live_tup INT,
+	n_dead_tup INT,
+	n_mod_since_analyze INT,
+	last_vacuum TIMESTAMPTZ,
+	last_autovacuum TIMESTAMPTZ,
+	last_analyze TIMESTAMPTZ,
+	last_autoanalyze TIMESTAMPTZ,
+	vacuum_count INT,
+	autovacuum_count INT,
+	analyze_count INT,
+	autoanalyze_count INT
+);
+`,
+	populate: func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		activity, _ := getTableActivity(p)
+		rowCounts, err := tableRowCounts(ctx, p)
+		if err != nil {
+			return err
+		}
+		return forEachTableDesc(ctx, p, prefix, func(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) error {
+			if isSystemDatabaseName(db.Name) || !table.IsTable() {
+				return nil
+			}
+			a := activity[table.ID]
+			liveTup, lastAnalyze := tree.DNull, tree.DNull
+			if c, ok := rowCounts[table.ID]; ok {
+				liveTup = c.rows
+				lastAnalyze = tree.MakeDTimestampTZ(c.createdAt, time.Microsecond)
+			}
+			return addRow(
+				h.TableOid(db, table),               // relid
+				tree.NewDName(db.Name),              // schemaname
+				tree.NewDName(table.Name),           // relname
+				tree.NewDInt(tree.DInt(a.seqScans)), // seq_scan
+				tree.DNull,                          // seq_tup_read
+				tree.NewDInt(tree.DInt(a.idxScans)), // idx_scan
+				tree.DNull,                          // idx_tup_fetch
+				tree.NewDInt(tree.DInt(a.inserted)), // n_tup_ins
+				tree.NewDInt(tree.DInt(a.updated)),  // n_tup_upd
+				tree.NewDInt(tree.DInt(a.deleted)),  // n_tup_del
+				zeroVal,                             // n_tup_hot_upd
+				liveTup,                             // n_live_tup
+				tree.DNull,                          // n_dead_tup
+				tree.DNull,                          // n_mod_since_analyze
+				tree.DNull,                          // last_vacuum
+				tree.DNull,                          // last_autovacuum
+				lastAnalyze,                         // last_analyze
+				tree.DNull,                          // last_autoanalyze
+				zeroVal,                             // vacuum_count
+				zeroVal,                             // autovacuum_count
+				tree.DNull,                          // analyze_count
+				zeroVal,                             // autoanalyze_count
+			)
+		})
+	},
+}
+
 // See: https://www.postgresql.org/docs/9.6/static/view-pg-tables.html.
 var pgCatalogTablesTable = virtualSchemaTable{
 	schema: `
@@ -1801,7 +2706,13 @@ func typColl(typ types.T, h oidHasher) tree.Datum {
 	} else if typ.Equivalent(types.String) || typ.Equivalent(types.TArray{Typ: types.String}) {
 		return h.CollationOid(defaultCollationTag)
 	} else if typ.FamilyEqual(types.FamCollatedString) {
-		return h.CollationOid(typ.(types.TCollatedString).Locale)
+		// The collations are listed under their canonical names, whichever
+		// way the locale was spelled in the COLLATE clause.
+		locale := typ.(types.TCollatedString).Locale
+		if canonical, err := tree.CanonicalCollationLocale(locale); err == nil {
+			locale = canonical
+		}
+		return h.CollationOid(locale)
 	}
 	return oidZero
 }
@@ -1850,19 +2761,12 @@ CREATE TABLE pg_catalog.pg_views (
 			if !desc.IsView() {
 				return nil
 			}
-			// Note that the view query printed will not include any column aliases
-			// specified outside the initial view query into the definition
-			// returned, unlike postgres. For example, for the view created via
-			//  `CREATE VIEW (a) AS SELECT b FROM foo`
-			// we'll only print `SELECT b FROM foo` as the view definition here,
-			// while postgres would more accurately print `SELECT b AS a FROM foo`.
-			// TODO(a-robinson): Insert column aliases into view query once we
-			// have a semantic query representation to work with (#10083).
+			definition := viewDefinition(desc, p.SessionData().SearchPath)
 			return addRow(
-				tree.NewDName(db.Name),          // schemaname
-				tree.NewDName(desc.Name),        // viewname
-				tree.DNull,                      // viewowner
-				tree.NewDString(desc.ViewQuery), // definition
+				tree.NewDName(db.Name),      // schemaname
+				tree.NewDName(desc.Name),    // viewname
+				tree.DNull,                  // viewowner
+				tree.NewDString(definition), // definition
 			)
 		})
 	},
@@ -1874,8 +2778,18 @@ CREATE TABLE pg_catalog.pg_views (
 // In Postgres, oids are physical properties of database objects which are
 // sequentially generated and naturally unique across all objects. See:
 // https://www.postgresql.org/docs/9.6/static/datatype-oid.html.
-// Because Cockroach does not have an equivalent concept, we generate arbitrary
-// fingerprints for database objects with the only requirements being that they
+// The closest Cockroach concept is the descriptor ID, which is allocated once
+// and never reused. The OIDs are assigned as follows, so that they are the
+// same on all the nodes, across restarts and across renames:
+// - the databases, and their namespaces, and the tables, views and sequences
+//   have their descriptor ID as OID.
+// - the virtual databases and tables, which all share the same descriptor ID,
+//   have a fingerprint of their name, which does not change.
+// - the types have the OID they have in Postgres. See typOid.
+// - the other objects, like indexes, columns and constraints, have a
+//   fingerprint of the descriptor ID of their table and of their own ID, or
+//   of their name when they have no ID, like the check constraints.
+// The fingerprints are arbitrary, with the only requirements being that they
 // are unique across all objects and that they are stable across accesses.
 //
 // The type has a few layers of methods:
@@ -1953,13 +2867,25 @@ func (h oidHasher) writeTable(table *sqlbase.TableDescriptor) {
 	h.writeStr(table.Name)
 }
 
+// writeTableID writes what identifies a table to the objects that belong to
+// it: its descriptor ID, which is never reused, so that their OIDs don't
+// change when the table or its database is renamed. The virtual tables all
+// share the same descriptor ID, and are identified by their fixed names.
+func (h oidHasher) writeTableID(db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor) {
+	if isVirtualDescriptor(table) {
+		h.writeDB(db)
+		h.writeTable(table)
+		return
+	}
+	h.writeUInt32(uint32(table.ID))
+}
+
 func (h oidHasher) writeIndex(index *sqlbase.IndexDescriptor) {
 	h.writeUInt32(uint32(index.ID))
 }
 
 func (h oidHasher) writeColumn(column *sqlbase.ColumnDescriptor) {
 	h.writeUInt32(uint32(column.ID))
-	h.writeStr(column.Name)
 }
 
 func (h oidHasher) writeCheckConstraint(check *sqlbase.TableDescriptor_CheckConstraint) {
@@ -1980,6 +2906,9 @@ func (h oidHasher) NamespaceOid(namespace string) *tree.DOid {
 }
 
 func (h oidHasher) DBOid(db *sqlbase.DatabaseDescriptor) *tree.DOid {
+	if !isVirtualDescriptor(db) {
+		return descriptorOid(db)
+	}
 	h.writeTypeTag(databaseTypeTag)
 	h.writeDB(db)
 	return h.getOid()
@@ -1988,18 +2917,53 @@ func (h oidHasher) DBOid(db *sqlbase.DatabaseDescriptor) *tree.DOid {
 func (h oidHasher) TableOid(
 	db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor,
 ) *tree.DOid {
+	if !isVirtualDescriptor(table) {
+		return descriptorOid(table)
+	}
 	h.writeTypeTag(tableTypeTag)
 	h.writeDB(db)
 	h.writeTable(table)
 	return h.getOid()
 }
 
+// descriptorOid returns the OID of an object with a descriptor, which is its
+// descriptor ID.
+func descriptorOid(desc sqlbase.DescriptorProto) *tree.DOid {
+	return tree.NewDOid(tree.DInt(desc.GetID()))
+}
+
+// forEachTableDescByOid is like forEachTableDesc, but only calls fn for the
+// table whose OID the given column is constrained to, if any. The OIDs of
+// the other tables are the IDs of their descriptors. The OIDs of virtual
+// tables and of indexes, which are hashes, don't identify them: if no table
+// has the given ID, fn is called for every table.
+func forEachTableDescByOid(
+	ctx context.Context,
+	p *planner,
+	prefix string,
+	constraints virtualConstraints,
+	oidCol string,
+	fn func(*sqlbase.DatabaseDescriptor, *sqlbase.TableDescriptor) error,
+) error {
+	if id, ok := constraints.getDescriptorID(oidCol); ok {
+		found := false
+		if err := forEachTableDescFiltered(ctx, p, prefix, tableDescFilter{id: id}, func(
+			db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor,
+		) error {
+			found = true
+			return fn(db, table)
+		}); err != nil || found {
+			return err
+		}
+	}
+	return forEachTableDesc(ctx, p, prefix, fn)
+}
+
 func (h oidHasher) IndexOid(
 	db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor, index *sqlbase.IndexDescriptor,
 ) *tree.DOid {
 	h.writeTypeTag(indexTypeTag)
-	h.writeDB(db)
-	h.writeTable(table)
+	h.writeTableID(db, table)
 	h.writeIndex(index)
 	return h.getOid()
 }
@@ -2008,8 +2972,7 @@ func (h oidHasher) ColumnOid(
 	db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor, column *sqlbase.ColumnDescriptor,
 ) *tree.DOid {
 	h.writeTypeTag(columnTypeTag)
-	h.writeDB(db)
-	h.writeTable(table)
+	h.writeTableID(db, table)
 	h.writeColumn(column)
 	return h.getOid()
 }
@@ -2020,8 +2983,7 @@ func (h oidHasher) CheckConstraintOid(
 	check *sqlbase.TableDescriptor_CheckConstraint,
 ) *tree.DOid {
 	h.writeTypeTag(checkConstraintTypeTag)
-	h.writeDB(db)
-	h.writeTable(table)
+	h.writeTableID(db, table)
 	h.writeCheckConstraint(check)
 	return h.getOid()
 }
@@ -2030,8 +2992,7 @@ func (h oidHasher) PrimaryKeyConstraintOid(
 	db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor, pkey *sqlbase.IndexDescriptor,
 ) *tree.DOid {
 	h.writeTypeTag(pKeyConstraintTypeTag)
-	h.writeDB(db)
-	h.writeTable(table)
+	h.writeTableID(db, table)
 	h.writeIndex(pkey)
 	return h.getOid()
 }
@@ -2040,8 +3001,7 @@ func (h oidHasher) ForeignKeyConstraintOid(
 	db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor, fk *sqlbase.ForeignKeyReference,
 ) *tree.DOid {
 	h.writeTypeTag(fkConstraintTypeTag)
-	h.writeDB(db)
-	h.writeTable(table)
+	h.writeTableID(db, table)
 	h.writeForeignKeyReference(fk)
 	return h.getOid()
 }
@@ -2050,8 +3010,7 @@ func (h oidHasher) UniqueConstraintOid(
 	db *sqlbase.DatabaseDescriptor, table *sqlbase.TableDescriptor, index *sqlbase.IndexDescriptor,
 ) *tree.DOid {
 	h.writeTypeTag(uniqueConstraintTypeTag)
-	h.writeDB(db)
-	h.writeTable(table)
+	h.writeTableID(db, table)
 	h.writeIndex(index)
 	return h.getOid()
 }
@@ -2118,6 +3077,9 @@ func init() {
 		nsp.NameStr = tree.NewDName(nsp.name)
 		nsp.Oid = h.NamespaceOid(nsp.name)
 	}
+	// Unlike pg_catalog and information_schema, the system database has a
+	// descriptor.
+	pgNamespaceSystem.Oid = descriptorOid(&sqlbase.SystemDB)
 }
 
 // pgNamespaceForDB maps a DatabaseDescriptor to its corresponding pgNamespace.
@@ -2131,6 +3093,10 @@ func pgNamespaceForDB(db *sqlbase.DatabaseDescriptor, h oidHasher) *pgNamespace
 	case informationSchemaName:
 		return pgNamespaceInformationSchema
 	default:
-		return &pgNamespace{name: db.Name, NameStr: tree.NewDName(db.Name), Oid: h.NamespaceOid(db.Name)}
+		nspOid := h.NamespaceOid(db.Name)
+		if !isVirtualDescriptor(db) {
+			nspOid = descriptorOid(db)
+		}
+		return &pgNamespace{name: db.Name, NameStr: tree.NewDName(db.Name), Oid: nspOid}
 	}
 }
@@ -1729,6 +1729,65 @@ func TestPGWireAuth(t *testing.T) {
 	})
 }
 
+func TestPGWireLoginOptions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.TODO())
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, fmt.Sprintf("CREATE USER %s WITH PASSWORD 'abc'", server.TestUser))
+
+	certURL, cleanupFn := sqlutils.PGUrl(t, s.ServingAddr(), t.Name(), url.User(server.TestUser))
+	defer cleanupFn()
+	host, port, err := net.SplitHostPort(s.ServingAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	passwordURL := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(server.TestUser, "abc"),
+		Host:     net.JoinHostPort(host, port),
+		RawQuery: "sslmode=require",
+	}
+
+	testCases := []struct {
+		options string
+		// The errors expected with certificate and password authentication,
+		// empty if the connection succeeds.
+		certErr, passwordErr string
+	}{
+		{`LOGIN`, ``, ``},
+		{`NOLOGIN`,
+			`pq: user testuser is not allowed to log in`,
+			`pq: user testuser is not allowed to log in`},
+		// VALID UNTIL only applies to passwords, as in Postgres.
+		{`LOGIN VALID UNTIL '2000-01-01'`, ``, `pq: password of user testuser has expired`},
+		{`VALID UNTIL '2999-01-01'`, ``, ``},
+		{`VALID UNTIL 'infinity'`, ``, ``},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.options, func(t *testing.T) {
+			sqlDB.Exec(t, fmt.Sprintf("ALTER USER %s WITH %s", server.TestUser, tc.options))
+			for _, c := range []struct {
+				pgURL    url.URL
+				expected string
+			}{
+				{certURL, tc.certErr},
+				{passwordURL, tc.passwordErr},
+			} {
+				err := trivialQuery(c.pgURL)
+				if c.expected == "" {
+					if err != nil {
+						t.Errorf("%s: %v", c.pgURL.RawQuery, err)
+					}
+				} else if !testutils.IsError(err, c.expected) {
+					t.Errorf("%s: expected %q, got %v", c.pgURL.RawQuery, c.expected, err)
+				}
+			}
+		})
+	}
+}
+
 func TestPGWireResultChange(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	s, _, _ := serverutils.StartServer(t, base.TestServerArgs{})
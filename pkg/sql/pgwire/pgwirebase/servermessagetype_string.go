@@ -9,10 +9,11 @@ const (
 	_ServerMessageType_name_1 = "ServerMsgCommandCompleteServerMsgDataRowServerMsgErrorResponse"
 	_ServerMessageType_name_2 = "ServerMsgCopyInResponse"
 	_ServerMessageType_name_3 = "ServerMsgEmptyQuery"
-	_ServerMessageType_name_4 = "ServerMsgAuthServerMsgParameterStatusServerMsgRowDescription"
-	_ServerMessageType_name_5 = "ServerMsgReady"
-	_ServerMessageType_name_6 = "ServerMsgNoData"
-	_ServerMessageType_name_7 = "ServerMsgParameterDescription"
+	_ServerMessageType_name_4 = "ServerMsgNoticeResponse"
+	_ServerMessageType_name_5 = "ServerMsgAuthServerMsgParameterStatusServerMsgRowDescription"
+	_ServerMessageType_name_6 = "ServerMsgReady"
+	_ServerMessageType_name_7 = "ServerMsgNoData"
+	_ServerMessageType_name_8 = "ServerMsgParameterDescription"
 )
 
 var (
@@ -20,10 +21,11 @@ var (
 	_ServerMessageType_index_1 = [...]uint8{0, 24, 40, 62}
 	_ServerMessageType_index_2 = [...]uint8{0, 23}
 	_ServerMessageType_index_3 = [...]uint8{0, 19}
-	_ServerMessageType_index_4 = [...]uint8{0, 13, 37, 60}
-	_ServerMessageType_index_5 = [...]uint8{0, 14}
-	_ServerMessageType_index_6 = [...]uint8{0, 15}
-	_ServerMessageType_index_7 = [...]uint8{0, 29}
+	_ServerMessageType_index_4 = [...]uint8{0, 23}
+	_ServerMessageType_index_5 = [...]uint8{0, 13, 37, 60}
+	_ServerMessageType_index_6 = [...]uint8{0, 14}
+	_ServerMessageType_index_7 = [...]uint8{0, 15}
+	_ServerMessageType_index_8 = [...]uint8{0, 29}
 )
 
 func (i ServerMessageType) String() string {
@@ -38,15 +40,17 @@ func (i ServerMessageType) String() string {
 		return _ServerMessageType_name_2
 	case i == 73:
 		return _ServerMessageType_name_3
+	case i == 78:
+		return _ServerMessageType_name_4
 	case 82 <= i && i <= 84:
 		i -= 82
-		return _ServerMessageType_name_4[_ServerMessageType_index_4[i]:_ServerMessageType_index_4[i+1]]
+		return _ServerMessageType_name_5[_ServerMessageType_index_5[i]:_ServerMessageType_index_5[i+1]]
 	case i == 90:
-		return _ServerMessageType_name_5
-	case i == 110:
 		return _ServerMessageType_name_6
-	case i == 116:
+	case i == 110:
 		return _ServerMessageType_name_7
+	case i == 116:
+		return _ServerMessageType_name_8
 	default:
 		return fmt.Sprintf("ServerMessageType(%d)", i)
 	}
@@ -281,6 +281,15 @@ func (c *v3Conn) handleAuthentication(ctx context.Context, insecure bool) error
 	if !exists {
 		return c.sendError(errors.Errorf("user %s does not exist", c.sessionArgs.User))
 	}
+	loginOptions, err := sql.GetUserLoginOptions(
+		ctx, c.executor, c.metrics.internalMemMetrics, c.sessionArgs.User,
+	)
+	if err != nil {
+		return c.sendError(err)
+	}
+	if loginOptions.NoLogin {
+		return c.sendError(errors.Errorf("user %s is not allowed to log in", c.sessionArgs.User))
+	}
 
 	if tlsConn, ok := c.conn.(*tls.Conn); ok {
 		var authenticationHook security.UserAuthHook
@@ -311,6 +320,10 @@ func (c *v3Conn) handleAuthentication(ctx context.Context, insecure bool) error
 		if err := authenticationHook(c.sessionArgs.User, true /* public */); err != nil {
 			return c.sendError(err)
 		}
+		// Like in Postgres, VALID UNTIL only applies to passwords.
+		if len(tlsState.PeerCertificates) == 0 && loginOptions.PasswordExpired(timeutil.Now()) {
+			return c.sendError(errors.Errorf("password of user %s has expired", c.sessionArgs.User))
+		}
 	}
 
 	c.writeBuf.initMsg(pgwirebase.ServerMsgAuth)
@@ -323,6 +336,11 @@ func (c *v3Conn) setupSession(ctx context.Context, reserved mon.BoundAccount) er
 		ctx, c.sessionArgs, c.executor, c.conn.RemoteAddr(), &c.metrics.SQLMemMetrics, c,
 	)
 	c.session.StartMonitor(c.sqlMemoryPool, reserved)
+	// The defaults of the user are not worth refusing the connection for.
+	if err := c.session.ApplyRoleSettings(ctx); err != nil {
+		log.Warningf(ctx, "cannot apply the default session variables of user %s: %v",
+			c.sessionArgs.User, err)
+	}
 	return nil
 }
 
@@ -620,6 +638,9 @@ func (c *v3Conn) handleParse(buf *pgwirebase.ReadBuffer) error {
 	}
 	// Attach pgwire-specific metadata to the PreparedStatement.
 	stmt.InTypes = inTypes
+	if err := c.sendNotices(c.wr); err != nil {
+		return err
+	}
 	c.writeBuf.initMsg(pgwirebase.ServerMsgParseComplete)
 	return c.writeBuf.finishMsg(c.wr)
 }
@@ -936,6 +957,40 @@ func (c *v3Conn) sendError(err error) error {
 	return c.wr.Flush()
 }
 
+// sendNotices sends the notices raised in the session which haven't been
+// sent yet, e.g. warnings about the use of deprecated columns.
+func (c *v3Conn) sendNotices(w io.Writer) error {
+	for _, notice := range c.session.Notices.Drain() {
+		c.writeBuf.initMsg(pgwirebase.ServerMsgNoticeResponse)
+
+		// The notices raised so far are all warnings (SQLSTATE class 01).
+		c.writeBuf.putErrFieldMsg(pgwirebase.ServerErrFieldSeverity)
+		c.writeBuf.writeTerminatedString("WARNING")
+
+		c.writeBuf.putErrFieldMsg(pgwirebase.ServerErrFieldSQLState)
+		c.writeBuf.writeTerminatedString(notice.Code)
+
+		if notice.Detail != "" {
+			c.writeBuf.putErrFieldMsg(pgwirebase.ServerErrFileldDetail)
+			c.writeBuf.writeTerminatedString(notice.Detail)
+		}
+
+		if notice.Hint != "" {
+			c.writeBuf.putErrFieldMsg(pgwirebase.ServerErrFileldHint)
+			c.writeBuf.writeTerminatedString(notice.Hint)
+		}
+
+		c.writeBuf.putErrFieldMsg(pgwirebase.ServerErrFieldMsgPrimary)
+		c.writeBuf.writeTerminatedString(notice.Message)
+
+		c.writeBuf.nullTerminate()
+		if err := c.writeBuf.finishMsg(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // sendNoData sends NoData message when there aren't any rows to
 // send. This must be set to true iff we are responding in the
 // Extended Query protocol and the portal or statement will not return
@@ -1116,6 +1171,12 @@ func (c *v3Conn) CloseResult() error {
 		))
 	}
 
+	// Notices are sent before the command completion, so that clients
+	// can associate them with the statement that raised them.
+	if err := c.sendNotices(&state.buf); err != nil {
+		return err
+	}
+
 	if state.pgTag == "INSERT" {
 		// From the postgres docs (49.5. Message Formats):
 		// `INSERT oid rows`... oid is the object ID of the inserted row if
@@ -156,6 +156,10 @@ type planNodeFastPath interface {
 var _ planNode = &alterIndexNode{}
 var _ planNode = &alterTableNode{}
 var _ planNode = &alterSequenceNode{}
+var _ planNode = &commentOnColumnNode{}
+var _ planNode = &commentOnDatabaseNode{}
+var _ planNode = &commentOnIndexNode{}
+var _ planNode = &commentOnTableNode{}
 var _ planNode = &createDatabaseNode{}
 var _ planNode = &createIndexNode{}
 var _ planNode = &createTableNode{}
@@ -187,6 +191,7 @@ var _ planNode = &testingRelocateNode{}
 var _ planNode = &renderNode{}
 var _ planNode = &scanNode{}
 var _ planNode = &scatterNode{}
+var _ planNode = &setSessionVarsNode{}
 var _ planNode = &showRangesNode{}
 var _ planNode = &showFingerprintsNode{}
 var _ planNode = &sortNode{}
@@ -195,6 +200,7 @@ var _ planNode = &unionNode{}
 var _ planNode = &updateNode{}
 var _ planNode = &valueGenerator{}
 var _ planNode = &valuesNode{}
+var _ planNode = &virtualTableNode{}
 var _ planNode = &windowNode{}
 var _ planNode = &CreateUserNode{}
 var _ planNode = &DropUserNode{}
@@ -204,6 +210,7 @@ var _ planNodeFastPath = &createTableNode{}
 var _ planNodeFastPath = &CreateUserNode{}
 var _ planNodeFastPath = &deleteNode{}
 var _ planNodeFastPath = &DropUserNode{}
+var _ planNodeFastPath = &setSessionVarsNode{}
 var _ planNodeFastPath = &setZoneConfigNode{}
 
 // planTop is the struct that collects the properties
@@ -543,16 +550,34 @@ func (p *planner) newPlan(
 		return p.AlterTable(ctx, n)
 	case *tree.AlterSequence:
 		return p.AlterSequence(ctx, n)
+	case *tree.AlterDatabaseSet:
+		return p.AlterDatabaseSet(ctx, n)
+	case *tree.AlterRoleSet:
+		return p.AlterRoleSet(ctx, n)
+	case *tree.AlterRoleOptions:
+		return p.AlterRoleOptions(ctx, n)
+	case *tree.AlterDefaultPrivileges:
+		return p.AlterDefaultPrivileges(ctx, n)
 	case *tree.AlterUserSetPassword:
 		return p.AlterUserSetPassword(ctx, n)
 	case *tree.CancelQuery:
 		return p.CancelQuery(ctx, n)
 	case *tree.CancelJob:
 		return p.CancelJob(ctx, n)
+	case *tree.CommentOnColumn:
+		return p.CommentOnColumn(ctx, n)
+	case *tree.CommentOnDatabase:
+		return p.CommentOnDatabase(ctx, n)
+	case *tree.CommentOnIndex:
+		return p.CommentOnIndex(ctx, n)
+	case *tree.CommentOnTable:
+		return p.CommentOnTable(ctx, n)
 	case *tree.Scrub:
 		return p.Scrub(ctx, n)
 	case *tree.CreateDatabase:
 		return p.CreateDatabase(ctx, n)
+	case *tree.CreateExtension:
+		return p.CreateExtension(ctx, n)
 	case *tree.CreateIndex:
 		return p.CreateIndex(ctx, n)
 	case *tree.CreateTable:
@@ -722,6 +747,12 @@ func (p *planner) doPrepare(ctx context.Context, stmt tree.Statement) (planNode,
 	p.isPreparing = true
 
 	switch n := stmt.(type) {
+	case *tree.AlterRoleSet:
+		return p.AlterRoleSet(ctx, n)
+	case *tree.AlterRoleOptions:
+		return p.AlterRoleOptions(ctx, n)
+	case *tree.AlterDefaultPrivileges:
+		return p.AlterDefaultPrivileges(ctx, n)
 	case *tree.AlterUserSetPassword:
 		return p.AlterUserSetPassword(ctx, n)
 	case *tree.CancelQuery:
@@ -69,6 +69,8 @@ func getPlanColumns(plan planNode, mut bool) sqlbase.ResultColumns {
 		return n.columns
 	case *valuesNode:
 		return n.columns
+	case *virtualTableNode:
+		return n.columns
 	case *explainPlanNode:
 		return n.run.results.columns
 	case *windowNode:
@@ -85,8 +87,6 @@ func getPlanColumns(plan planNode, mut bool) sqlbase.ResultColumns {
 		return n.getColumns(mut, relocateNodeColumns)
 	case *scatterNode:
 		return n.getColumns(mut, scatterNodeColumns)
-	case *showZoneConfigNode:
-		return n.getColumns(mut, showZoneConfigNodeColumns)
 	case *showRangesNode:
 		return n.getColumns(mut, showRangesColumns)
 	case *showFingerprintsNode:
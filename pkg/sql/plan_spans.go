@@ -35,6 +35,7 @@ func collectSpans(params runParams, plan planNode) (reads, writes roachpb.Spans,
 	case
 		*valueGenerator,
 		*valuesNode,
+		*virtualTableNode,
 		*zeroNode,
 		*unaryNode:
 		return nil, nil, nil
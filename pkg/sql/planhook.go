@@ -80,7 +80,12 @@ type PlanHookState interface {
 	// The role create/drop call into OSS code to reuse plan nodes.
 	// TODO(mberhault): it would be easier to just pass a planner to plan hooks.
 	CreateUserNode(
-		ctx context.Context, nameE, passwordE tree.Expr, ifNotExists bool, isRole bool, opName string,
+		ctx context.Context,
+		nameE, passwordE tree.Expr,
+		options tree.RoleOptions,
+		ifNotExists bool,
+		isRole bool,
+		opName string,
 	) (*CreateUserNode, error)
 	DropUserNode(
 		ctx context.Context, namesE tree.Exprs, ifExists bool, isRole bool, opName string,
@@ -49,6 +49,10 @@ type extendedEvalContext struct {
 	// tracing state should be done through the sessionDataMutator.
 	Tracing *SessionTracing
 
+	// Notices collects the notices raised during planning, to be sent to the
+	// client of the session.
+	Notices *SessionNotices
+
 	// StatusServer gives access to the Status service. Used to cancel queries.
 	StatusServer serverpb.StatusServer
 
@@ -411,10 +415,10 @@ func (p *planner) lookupFKTable(
 // isDatabaseVisible returns true if the given database is visible
 // given the provided prefix.
 // An empty prefix makes all databases visible.
-// System databases are always visible.
+// System databases are always visible, unless currentCatalogOnly is set.
 // Otherwise only the database with the same name as the prefix is available.
-func isDatabaseVisible(dbName, prefix, user string) bool {
-	if isSystemDatabaseName(dbName) {
+func isDatabaseVisible(dbName, prefix, user string, currentCatalogOnly bool) bool {
+	if isSystemDatabaseName(dbName) && !currentCatalogOnly {
 		return true
 	} else if dbName == prefix {
 		return true
@@ -34,8 +34,12 @@ func (e *Executor) PrepareStmt(session *Session, s *tree.Prepare) error {
 	for i, t := range s.Types {
 		typeHints[strconv.Itoa(i+1)] = coltypes.CastTargetToDatumType(t)
 	}
-	_, err := session.PreparedStatements.New(
+	stmt, err := session.PreparedStatements.New(
 		e, name, Statement{AST: s.Statement}, s.Statement.String(), typeHints,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	stmt.FromSQL = true
+	return nil
 }
@@ -16,6 +16,8 @@ package sql
 
 import (
 	"context"
+	"sort"
+	"time"
 	"unsafe"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
@@ -24,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/lib/pq/oid"
 )
 
@@ -56,6 +59,12 @@ type PreparedStatement struct {
 	// identifiers.
 	InTypes []oid.Oid
 
+	// PrepareTime is the time at which the statement was prepared.
+	PrepareTime time.Time
+	// FromSQL is set for the statements prepared by the PREPARE statement, as
+	// opposed to the statements prepared through the wire protocol.
+	FromSQL bool
+
 	memAcc mon.BoundAccount
 }
 
@@ -76,6 +85,12 @@ type preparedStatementsAccessor interface {
 	Delete(ctx context.Context, name string) bool
 	// DeleteAll removes all prepared statements and portals from the coolection.
 	DeleteAll(ctx context.Context)
+	// Visit calls fn for each named prepared statement, in the order of their
+	// names.
+	Visit(fn func(name string, stmt *PreparedStatement) error) error
+	// VisitPortals calls fn for each named portal, in the order of their
+	// names.
+	VisitPortals(fn func(name string, portal *PreparedPortal) error) error
 }
 
 // PreparedStatements is a mapping of PreparedStatement names to their
@@ -161,6 +176,7 @@ func (ps PreparedStatements) New(
 	}
 
 	pStmt.Str = stmtStr
+	pStmt.PrepareTime = timeutil.Now()
 	ps.stmts[name] = pStmt
 	return pStmt, nil
 }
@@ -208,6 +224,49 @@ func (ps *PreparedStatements) DeleteAll(ctx context.Context) {
 	ps.session.PreparedPortals.portals = make(map[string]*PreparedPortal)
 }
 
+// Visit is part of the preparedStatementsAccessor interface. The unnamed
+// statement is not visited.
+func (ps *PreparedStatements) Visit(fn func(name string, stmt *PreparedStatement) error) error {
+	names := make([]string, 0, len(ps.stmts))
+	for name := range ps.stmts {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := fn(name, ps.stmts[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VisitPortals is part of the preparedStatementsAccessor interface. The
+// unnamed portal is not visited.
+func (ps *PreparedStatements) VisitPortals(
+	fn func(name string, portal *PreparedPortal) error,
+) error {
+	// The planners that are not associated with a session have no portals.
+	if ps.session == nil {
+		return nil
+	}
+	portals := ps.session.PreparedPortals.portals
+	names := make([]string, 0, len(portals))
+	for name := range portals {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := fn(name, portals[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // PreparedPortal is a PreparedStatement that has been bound with query arguments.
 type PreparedPortal struct {
 	Stmt  *PreparedStatement
@@ -216,6 +275,9 @@ type PreparedPortal struct {
 	// OutFormats contains the requested formats for the output columns.
 	OutFormats []pgwirebase.FormatCode
 
+	// CreationTime is the time at which the portal was created.
+	CreationTime time.Time
+
 	memAcc mon.BoundAccount
 }
 
@@ -251,9 +313,10 @@ func (pp PreparedPortals) New(
 	ctx context.Context, name string, stmt *PreparedStatement, qargs tree.QueryArguments,
 ) (*PreparedPortal, error) {
 	portal := &PreparedPortal{
-		Stmt:   stmt,
-		Qargs:  qargs,
-		memAcc: pp.session.mon.MakeBoundAccount(),
+		Stmt:         stmt,
+		Qargs:        qargs,
+		CreationTime: timeutil.Now(),
+		memAcc:       pp.session.mon.MakeBoundAccount(),
 	}
 	sz := int64(uintptr(len(name)) + unsafe.Sizeof(*portal))
 	if err := portal.memAcc.Grow(ctx, sz); err != nil {
@@ -81,11 +81,10 @@ func (p *planner) RenameIndex(ctx context.Context, n *tree.RenameIndex) (planNod
 	if err := tableDesc.SetUpVersion(); err != nil {
 		return nil, err
 	}
-	descKey := sqlbase.MakeDescMetadataKey(tableDesc.GetID())
 	if err := tableDesc.Validate(ctx, p.txn); err != nil {
 		return nil, err
 	}
-	if err := p.txn.Put(ctx, descKey, sqlbase.WrapDescriptor(tableDesc)); err != nil {
+	if err := p.writeTableDesc(ctx, tableDesc); err != nil {
 		return nil, err
 	}
 	p.notifySchemaChange(tableDesc, sqlbase.InvalidMutationID)
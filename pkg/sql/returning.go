@@ -32,7 +32,8 @@ type returningHelper struct {
 	columns    sqlbase.ResultColumns
 	ivarHelper tree.IndexedVarHelper
 	// Processed copies of expressions from ReturningExprs.
-	exprs        []tree.TypedExpr
+	exprs []tree.TypedExpr
+	// rowCount counts the rows processed, whether they are returned or not.
 	rowCount     int
 	source       *dataSourceInfo
 	curSourceRow tree.Datums
@@ -100,8 +101,8 @@ func (p *planner) newReturningHelper(
 // cookResultRow prepares a row according to the ReturningExprs, with input values
 // from rowVals.
 func (rh *returningHelper) cookResultRow(rowVals tree.Datums) (tree.Datums, error) {
+	rh.rowCount++
 	if rh.exprs == nil {
-		rh.rowCount++
 		return rowVals, nil
 	}
 	rh.curSourceRow = rowVals
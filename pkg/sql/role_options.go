@@ -0,0 +1,328 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package sql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+// The options stored in system.role_options. The other options are the
+// defaults, which are not stored: LOGIN, NOCREATEROLE and a VALID UNTIL
+// of 'infinity'.
+const (
+	roleOptionNoLogin    = string(tree.RoleOptionNoLogin)
+	roleOptionCreateRole = string(tree.RoleOptionCreateRole)
+	roleOptionValidUntil = string(tree.RoleOptionValidUntil)
+)
+
+// roleOptionUpdate is the change of system.role_options made by a role
+// option: the option is stored, or deleted when it is reset to its default.
+type roleOptionUpdate struct {
+	option string
+	reset  bool
+	// value is the timestamp of VALID UNTIL, nil for the other options.
+	value func() (string, error)
+}
+
+// makeRoleOptionUpdates checks the options given to CREATE ROLE or ALTER
+// ROLE, or to CREATE USER or ALTER USER if isRole is not set, and returns
+// the changes they make.
+func (p *planner) makeRoleOptionUpdates(
+	options tree.RoleOptions, isRole bool, opName string,
+) ([]roleOptionUpdate, error) {
+	updates := make([]roleOptionUpdate, 0, len(options))
+	seen := make(map[string]bool, len(options))
+	for _, o := range options {
+		var u roleOptionUpdate
+		switch o.Name {
+		case tree.RoleOptionLogin:
+			if isRole {
+				return nil, errors.New("roles cannot log in; use CREATE USER instead")
+			}
+			u = roleOptionUpdate{option: roleOptionNoLogin, reset: true}
+		case tree.RoleOptionNoLogin:
+			u = roleOptionUpdate{option: roleOptionNoLogin}
+		case tree.RoleOptionCreateRole:
+			u = roleOptionUpdate{option: roleOptionCreateRole}
+		case tree.RoleOptionNoCreateRole:
+			u = roleOptionUpdate{option: roleOptionCreateRole, reset: true}
+		case tree.RoleOptionValidUntil:
+			value, err := p.TypeAsString(o.Value, opName)
+			if err != nil {
+				return nil, err
+			}
+			u = roleOptionUpdate{option: roleOptionValidUntil, value: value}
+		default:
+			return nil, errors.Errorf("unsupported role option %s", o.Name)
+		}
+		if seen[u.option] {
+			return nil, errors.New("conflicting or redundant options")
+		}
+		seen[u.option] = true
+		updates = append(updates, u)
+	}
+	return updates, nil
+}
+
+// writeRoleOptions stores the changes of the options of a user or role.
+func writeRoleOptions(params runParams, username string, updates []roleOptionUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+	if username == security.RootUser {
+		return errors.Errorf("the options of user %s cannot be changed", security.RootUser)
+	}
+
+	internalExecutor := InternalExecutor{ExecCfg: params.extendedEvalCtx.ExecCfg}
+	for _, u := range updates {
+		var value interface{}
+		reset := u.reset
+		if u.value != nil {
+			s, err := u.value()
+			if err != nil {
+				return err
+			}
+			if strings.EqualFold(s, "infinity") {
+				reset = true
+			} else {
+				ts, err := tree.ParseDTimestampTZ(s, params.EvalContext().GetLocation(), time.Microsecond)
+				if err != nil {
+					return err
+				}
+				value = ts.UTC().Format(tree.TimestampOutputFormat)
+			}
+		}
+
+		var err error
+		if reset {
+			_, err = internalExecutor.ExecuteStatementInTransaction(
+				params.ctx,
+				"reset-role-option",
+				params.p.txn,
+				`DELETE FROM system.role_options WHERE username = $1 AND option = $2`,
+				username,
+				u.option,
+			)
+		} else {
+			_, err = internalExecutor.ExecuteStatementInTransaction(
+				params.ctx,
+				"set-role-option",
+				params.p.txn,
+				`UPSERT INTO system.role_options (username, option, value) VALUES ($1, $2, $3)`,
+				username,
+				u.option,
+				value,
+			)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCreateRolePrivilege checks that the user of the planner may create,
+// alter or drop users and roles: it must have the given privilege on the
+// users table, or the CREATEROLE option. It returns whether the user only
+// has the option, in which case it may not alter superusers; see
+// checkCreateRoleTarget.
+func (p *planner) checkCreateRolePrivilege(
+	ctx context.Context, priv privilege.Kind,
+) (createRoleOnly bool, _ error) {
+	tDesc, err := getTableDesc(ctx, p.txn, p.getVirtualTabler(), userTableName)
+	if err != nil {
+		return false, err
+	}
+	privErr := p.CheckPrivilege(ctx, tDesc, priv)
+	if privErr == nil {
+		return false, nil
+	}
+
+	internalExecutor := InternalExecutor{ExecCfg: p.ExecCfg()}
+	row, err := internalExecutor.QueryRowInTransaction(
+		ctx,
+		"check-createrole",
+		p.txn,
+		`SELECT 1 FROM system.role_options WHERE username = $1 AND option = $2`,
+		p.SessionData().User,
+		roleOptionCreateRole,
+	)
+	if err != nil {
+		if sqlbase.IsUndefinedRelationError(err) {
+			return false, privErr
+		}
+		return false, err
+	}
+	if row == nil {
+		return false, privErr
+	}
+	return true, nil
+}
+
+// checkCreateRoleTarget checks that a user or role altered or dropped by a
+// user who only has the CREATEROLE option is not a superuser, as in
+// Postgres.
+func (p *planner) checkCreateRoleTarget(ctx context.Context, username string) error {
+	isSuperUser := username == security.RootUser || username == sqlbase.AdminRole
+	if !isSuperUser {
+		memberOf, err := p.MemberOfWithAdminOption(ctx, username)
+		if err != nil {
+			return err
+		}
+		_, isSuperUser = memberOf[sqlbase.AdminRole]
+	}
+	if isSuperUser {
+		return errors.Errorf("only superusers are allowed to alter superuser %s", username)
+	}
+	return nil
+}
+
+// roleOptions are the options of a user or role.
+type roleOptions struct {
+	noLogin    bool
+	createRole bool
+	// validUntil is the time after which the password is no longer valid, or
+	// nil if it does not expire.
+	validUntil *tree.DTimestampTZ
+}
+
+// getRoleOptions returns the options of the users and roles that differ
+// from the defaults, by name. There are none until the whole cluster can
+// read system.role_options.
+func getRoleOptions(
+	ctx context.Context, txn *client.Txn, execCfg *ExecutorConfig,
+) (map[string]roleOptions, error) {
+	if !execCfg.Settings.Version.IsActive(cluster.VersionRoleSettingsOptionsAndDefaultPrivileges) {
+		return nil, nil
+	}
+	internalExecutor := InternalExecutor{ExecCfg: execCfg}
+	rows, _ /* cols */, err := internalExecutor.QueryRowsInTransaction(
+		ctx,
+		"get-role-options",
+		txn,
+		`SELECT username, option, value FROM system.role_options`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	options := make(map[string]roleOptions)
+	for _, row := range rows {
+		username := string(tree.MustBeDString(row[0]))
+		o := options[username]
+		if err := o.add(string(tree.MustBeDString(row[1])), row[2]); err != nil {
+			return nil, errors.Wrapf(err, "invalid option of %s", username)
+		}
+		options[username] = o
+	}
+	return options, nil
+}
+
+// add sets an option read from system.role_options.
+func (o *roleOptions) add(option string, value tree.Datum) error {
+	switch option {
+	case roleOptionNoLogin:
+		o.noLogin = true
+	case roleOptionCreateRole:
+		o.createRole = true
+	case roleOptionValidUntil:
+		if value == tree.DNull {
+			return nil
+		}
+		ts, err := tree.ParseDTimestampTZ(string(tree.MustBeDString(value)), time.UTC, time.Microsecond)
+		if err != nil {
+			return err
+		}
+		o.validUntil = ts
+	}
+	return nil
+}
+
+// validUntilDatum returns the expiry of the password as a datum, NULL if
+// it does not expire.
+func (o roleOptions) validUntilDatum() tree.Datum {
+	if o.validUntil == nil {
+		return tree.DNull
+	}
+	return o.validUntil
+}
+
+// LoginOptions are the options of a user restricting its logins, set with
+// CREATE USER or ALTER USER.
+type LoginOptions struct {
+	// NoLogin is set if the user is not allowed to log in.
+	NoLogin bool
+	// ValidUntil is the time after which the password of the user is no
+	// longer valid, or zero if it does not expire.
+	ValidUntil time.Time
+}
+
+// PasswordExpired returns whether the password of the user is no longer
+// valid at the given time.
+func (o LoginOptions) PasswordExpired(now time.Time) bool {
+	return !o.ValidUntil.IsZero() && !now.Before(o.ValidUntil)
+}
+
+// GetUserLoginOptions returns the login options of the given user. The
+// logins of root are never restricted.
+func GetUserLoginOptions(
+	ctx context.Context, executor *Executor, metrics *MemoryMetrics, username string,
+) (LoginOptions, error) {
+	normalizedUsername := tree.Name(username).Normalize()
+	if normalizedUsername == security.RootUser {
+		return LoginOptions{}, nil
+	}
+
+	var loginOptions LoginOptions
+	err := executor.cfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		p, cleanup := newInternalPlanner(
+			"get-login-options", txn, security.RootUser, metrics, &executor.cfg)
+		defer cleanup()
+		rows, _ /* cols */, err := p.queryRows(ctx,
+			`SELECT option, value FROM system.role_options WHERE username = $1`,
+			normalizedUsername)
+		if err != nil {
+			// The table is created by a migration, which may not have run yet
+			// while the cluster is upgraded. No user has options until then.
+			if sqlbase.IsUndefinedRelationError(err) {
+				return nil
+			}
+			return errors.Wrapf(err, "error looking up the options of user %s", normalizedUsername)
+		}
+		var o roleOptions
+		for _, row := range rows {
+			if err := o.add(string(tree.MustBeDString(row[0])), row[1]); err != nil {
+				return errors.Wrapf(err, "invalid option of user %s", normalizedUsername)
+			}
+		}
+		loginOptions = LoginOptions{NoLogin: o.noLogin}
+		if o.validUntil != nil {
+			loginOptions.ValidUntil = o.validUntil.Time
+		}
+		return nil
+	})
+	return loginOptions, err
+}
@@ -0,0 +1,304 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/gossip"
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// roleSetting is the default value of a session variable for a user or
+// role, set with ALTER ROLE ... SET, or for a database, set with ALTER
+// DATABASE ... SET.
+type roleSetting struct {
+	// role is empty for the defaults of a database, and databaseID is 0 for
+	// the defaults of a user or role.
+	role       string
+	databaseID sqlbase.ID
+	variable   string
+	// value is the list of values of the SET statement, formatted as SQL.
+	value string
+}
+
+// getRoleSettings returns the default session variables of all the users,
+// roles and databases, ordered by role, database and variable. There are
+// none until the whole cluster can read system.role_settings.
+func getRoleSettings(
+	ctx context.Context, txn *client.Txn, execCfg *ExecutorConfig,
+) ([]roleSetting, error) {
+	if !execCfg.Settings.Version.IsActive(cluster.VersionRoleSettingsOptionsAndDefaultPrivileges) {
+		return nil, nil
+	}
+	internalExecutor := InternalExecutor{ExecCfg: execCfg}
+	rows, _ /* cols */, err := internalExecutor.QueryRowsInTransaction(
+		ctx,
+		"get-role-settings",
+		txn,
+		`SELECT role, database_id, variable, value FROM system.role_settings
+      ORDER BY role, database_id, variable`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	settings := make([]roleSetting, len(rows))
+	for i, row := range rows {
+		settings[i] = roleSetting{
+			role:       string(tree.MustBeDString(row[0])),
+			databaseID: sqlbase.ID(tree.MustBeDInt(row[1])),
+			variable:   string(tree.MustBeDString(row[2])),
+			value:      string(tree.MustBeDString(row[3])),
+		}
+	}
+	return settings, nil
+}
+
+// roleSettingsCache caches the contents of system.role_settings, so that
+// the sessions don't have to read them when they start. The cache of a
+// node is invalidated by the nodes that change the settings, through
+// gossip, so a session may still start with the previous defaults for a
+// short while after they changed on another node.
+type roleSettingsCache struct {
+	syncutil.Mutex
+	// generation is incremented when the cache is invalidated, so that the
+	// settings read concurrently with a change are not cached.
+	generation int64
+	// valid is set if settings holds the current settings.
+	valid    bool
+	settings []roleSetting
+}
+
+// get returns the settings, reading them if they are not cached.
+func (c *roleSettingsCache) get(
+	ctx context.Context, execCfg *ExecutorConfig,
+) ([]roleSetting, error) {
+	c.Lock()
+	if c.valid {
+		defer c.Unlock()
+		return c.settings, nil
+	}
+	generation := c.generation
+	c.Unlock()
+
+	var settings []roleSetting
+	if err := execCfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		var err error
+		settings, err = getRoleSettings(ctx, txn, execCfg)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if c.generation == generation {
+		c.settings = settings
+		c.valid = true
+	}
+	return settings, nil
+}
+
+// invalidate drops the cached settings.
+func (c *roleSettingsCache) invalidate() {
+	c.Lock()
+	defer c.Unlock()
+	c.generation++
+	c.valid = false
+	c.settings = nil
+}
+
+// invalidateRoleSettingsOnCommit arranges for the settings cached by the
+// nodes to be invalidated when the transaction of the planner commits. It
+// must be called by the statements that change system.role_settings.
+func (p *planner) invalidateRoleSettingsOnCommit(ctx context.Context) {
+	execCfg := p.ExecCfg()
+	p.txn.AddCommitTrigger(func() {
+		// The cache of this node is invalidated right away, so that the
+		// sessions it starts next see the change. The other nodes are
+		// notified through gossip.
+		if execCfg.roleSettings != nil {
+			execCfg.roleSettings.invalidate()
+		}
+		if execCfg.Gossip == nil {
+			return
+		}
+		stamp := []byte(strconv.FormatInt(timeutil.Now().UnixNano(), 10))
+		if err := execCfg.Gossip.AddInfo(gossip.KeyRoleSettings, stamp, 0 /* ttl */); err != nil {
+			log.Warningf(ctx, "cannot notify the nodes that the default session variables changed: %v", err)
+		}
+	})
+}
+
+// roleConfig holds the default session variables of a user or role, or of
+// a database.
+type roleConfig struct {
+	role       string
+	databaseID sqlbase.ID
+	// config is the array of the settings, formatted like the entries of
+	// pg_roles.rolconfig.
+	config *tree.DArray
+}
+
+// getRoleConfigs returns the default session variables of the users, roles
+// and databases that have some, ordered by role and database.
+func getRoleConfigs(ctx context.Context, p *planner) ([]roleConfig, error) {
+	settings, err := getRoleSettings(ctx, p.txn, p.ExecCfg())
+	if err != nil {
+		return nil, err
+	}
+	var configs []roleConfig
+	for _, rs := range settings {
+		if n := len(configs); n == 0 ||
+			configs[n-1].role != rs.role || configs[n-1].databaseID != rs.databaseID {
+			configs = append(configs, roleConfig{
+				role: rs.role, databaseID: rs.databaseID, config: tree.NewDArray(types.String),
+			})
+		}
+		if err := configs[len(configs)-1].config.Append(tree.NewDString(rs.config())); err != nil {
+			return nil, err
+		}
+	}
+	return configs, nil
+}
+
+// parse returns the SET statement that applies the setting.
+func (rs roleSetting) parse() (*tree.SetVar, error) {
+	stmt, err := parser.ParseOne(fmt.Sprintf("SET %s = %s", tree.NameString(rs.variable), rs.value))
+	if err != nil {
+		return nil, err
+	}
+	n, ok := stmt.(*tree.SetVar)
+	if !ok {
+		return nil, errors.Errorf("invalid value for %s: %s", rs.variable, rs.value)
+	}
+	return n, nil
+}
+
+// config formats the setting like Postgres formats the entries of
+// pg_roles.rolconfig, e.g. timezone=America/New_York.
+func (rs roleSetting) config() string {
+	value := rs.value
+	if n, err := rs.parse(); err == nil {
+		value = tree.AsStringWithFlags(&n.Values, tree.FmtBareStrings)
+	}
+	return rs.variable + "=" + value
+}
+
+// ApplyRoleSettings sets the session variables to the defaults set with
+// ALTER USER ... SET for the user of the session, then to the defaults set
+// with ALTER DATABASE ... SET for the database of the session, the defaults
+// of the user taking precedence over those of the database. As in
+// Postgres, the defaults of the roles the user is a member of don't apply,
+// and the database and the application name given by the client when it
+// connected take precedence over the defaults. A default that cannot be
+// applied, e.g. because its database was dropped since, is skipped with a
+// warning.
+func (s *Session) ApplyRoleSettings(ctx context.Context) error {
+	var settings []roleSetting
+	if c := s.execCfg.roleSettings; c != nil {
+		var err error
+		if settings, err = c.get(ctx, s.execCfg); err != nil {
+			return err
+		}
+	}
+	user := s.data.User
+	var userSettings, databaseSettings []roleSetting
+	for _, rs := range settings {
+		switch {
+		case rs.role == user && rs.databaseID == 0:
+			userSettings = append(userSettings, rs)
+		case rs.role == "":
+			databaseSettings = append(databaseSettings, rs)
+		}
+	}
+	if len(userSettings) == 0 && len(databaseSettings) == 0 {
+		return nil
+	}
+
+	return s.execCfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		ts := txn.Proto().OrigTimestamp.GoTime()
+		p := s.newPlanner(
+			txn, ts /* txnTimestamp */, ts, /* stmtTimestamp */
+			nil /* reCache */, s.statsCollector())
+
+		applied := make(map[string]bool)
+		if s.dataMutator.defaults.database != "" {
+			applied["database"] = true
+		}
+		if s.dataMutator.defaults.applicationName != "" {
+			applied["application_name"] = true
+		}
+		apply := func(rs roleSetting, of string) {
+			if applied[rs.variable] {
+				return
+			}
+			applied[rs.variable] = true
+			if err := p.applyRoleSetting(ctx, rs); err != nil {
+				log.Warningf(ctx, "cannot apply the default of %s of %s for user %s: %v",
+					rs.variable, of, user, err)
+			}
+		}
+		for _, rs := range userSettings {
+			apply(rs, rs.role)
+		}
+
+		// The database may have been set by a default of the user.
+		dbName := p.SessionData().Database
+		if len(databaseSettings) == 0 || dbName == "" {
+			return nil
+		}
+		dbID, err := p.Tables().databaseCache.getDatabaseID(
+			ctx, s.execCfg.DB.Txn, p.getVirtualTabler(), dbName)
+		if err != nil {
+			log.Warningf(ctx, "cannot apply the defaults of database %s for user %s: %v",
+				dbName, user, err)
+			return nil
+		}
+		for _, rs := range databaseSettings {
+			if rs.databaseID == dbID {
+				apply(rs, "database "+dbName)
+			}
+		}
+		return nil
+	})
+}
+
+// applyRoleSetting sets the session variable of the setting like a SET
+// statement.
+func (p *planner) applyRoleSetting(ctx context.Context, rs roleSetting) error {
+	n, err := rs.parse()
+	if err != nil {
+		return err
+	}
+	plan, err := p.SetVar(ctx, n)
+	if err != nil {
+		return err
+	}
+	return plan.(*setVarNode).startExec(runParams{ctx: ctx, extendedEvalCtx: &p.extendedEvalCtx, p: p})
+}
@@ -264,3 +264,121 @@ func TestCancelDistSQLQuery(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// TestCancelBackend cancels a query running on node 2 from node 1 by the pid
+// of its session.
+func TestCancelBackend(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.TODO()
+
+	const queryToCancel = "SELECT * FROM generate_series(1,20000000)"
+
+	tc := serverutils.StartTestCluster(t, 2, /* numNodes */
+		base.TestClusterArgs{
+			ReplicationMode: base.ReplicationManual,
+		})
+	defer tc.Stopper().Stop(ctx)
+
+	conn1 := tc.ServerConn(0)
+	// The pid is that of the session of a single connection.
+	conn2, err := tc.ServerConn(1).Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn2.Close()
+
+	var pid int64
+	if err := conn2.QueryRowContext(ctx, "SELECT pg_backend_pid()").Scan(&pid); err != nil {
+		t.Fatal(err)
+	}
+
+	errChan := make(chan error)
+	go func() {
+		rows, err := conn2.QueryContext(ctx, queryToCancel)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		for rows.Next() {
+		}
+		errChan <- rows.Err()
+	}()
+
+	testutils.SucceedsSoon(t, func() error {
+		var running int
+		if err := conn1.QueryRow(
+			"SELECT count(*) FROM [SHOW CLUSTER QUERIES] WHERE query = $1", queryToCancel,
+		).Scan(&running); err != nil {
+			t.Fatal(err)
+		}
+		if running == 0 {
+			return errors.New("query not running yet")
+		}
+		return nil
+	})
+
+	var canceled bool
+	if err := conn1.QueryRow("SELECT pg_cancel_backend($1)", pid).Scan(&canceled); err != nil {
+		t.Fatal(err)
+	}
+	if !canceled {
+		t.Fatalf("expected the session %d to be found", pid)
+	}
+
+	select {
+	case err := <-errChan:
+		if !sqlbase.IsQueryCanceledError(err) {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("no error received from query supposed to be canceled")
+	}
+}
+
+// TestTerminateBackend closes a session by its pid, which can only be done
+// from the node of the session.
+func TestTerminateBackend(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.TODO()
+
+	tc := serverutils.StartTestCluster(t, 2, /* numNodes */
+		base.TestClusterArgs{
+			ReplicationMode: base.ReplicationManual,
+		})
+	defer tc.Stopper().Stop(ctx)
+
+	conn, err := tc.ServerConn(0).Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var pid int64
+	if err := conn.QueryRowContext(ctx, "SELECT pg_backend_pid()").Scan(&pid); err != nil {
+		t.Fatal(err)
+	}
+
+	const terminate = "SELECT pg_terminate_backend($1)"
+	if _, err := tc.ServerConn(1).Exec(terminate, pid); !testutils.IsError(
+		err, "sessions can only be terminated from their own node",
+	) {
+		t.Fatalf("expected an error terminating a session of another node, got %v", err)
+	}
+
+	var terminated bool
+	if err := tc.ServerConn(0).QueryRow(terminate, pid).Scan(&terminated); err != nil {
+		t.Fatal(err)
+	}
+	if !terminated {
+		t.Fatalf("expected the session %d to be found", pid)
+	}
+
+	// The connection is closed once the session notices that it was
+	// terminated.
+	testutils.SucceedsSoon(t, func() error {
+		if _, err := conn.ExecContext(ctx, "SELECT 1"); err == nil {
+			return errors.New("session not terminated yet")
+		}
+		return nil
+	})
+}
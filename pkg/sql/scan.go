@@ -66,6 +66,15 @@ type scanNode struct {
 	// Map used to get the index for columns in cols.
 	colIdxMap map[sqlbase.ColumnID]int
 
+	// Set if the user lacks SELECT on the table but holds it on some of
+	// its columns, in which case the needed columns must all be among
+	// them. This is the error to report otherwise.
+	selectPrivilegeErr error
+	// selectPrivilegeChecked is set once the needed columns have been
+	// checked against the column privileges of the user. A scan with a
+	// selectPrivilegeErr that was never checked is refused.
+	selectPrivilegeChecked bool
+
 	// The number of backfill columns among cols. These backfill
 	// columns are always the last columns within cols.
 	numBackfillColumns int
@@ -161,6 +170,9 @@ type scanRun struct {
 }
 
 func (n *scanNode) startExec(params runParams) error {
+	if err := n.uncheckedSelectPrivilegeErr(); err != nil {
+		return err
+	}
 	tableArgs := sqlbase.RowFetcherTableArgs{
 		Desc:             n.desc,
 		Index:            n.index,
@@ -173,6 +185,16 @@ func (n *scanNode) startExec(params runParams) error {
 		false /* isCheck */, &params.p.alloc, tableArgs)
 }
 
+// uncheckedSelectPrivilegeErr returns selectPrivilegeErr if the columns the
+// scan reads were not checked against the column privileges of the user,
+// e.g. because the plan wasn't optimized; see checkColumnPrivileges.
+func (n *scanNode) uncheckedSelectPrivilegeErr() error {
+	if n.selectPrivilegeChecked {
+		return nil
+	}
+	return n.selectPrivilegeErr
+}
+
 func (n *scanNode) Close(context.Context) {
 	*n = scanNode{}
 	scanNodePool.Put(n)
@@ -267,7 +289,24 @@ func (n *scanNode) initTable(
 
 	if !p.skipSelectPrivilegeChecks {
 		if err := p.CheckPrivilege(ctx, n.desc, privilege.SELECT); err != nil {
-			return err
+			c, cErr := p.makePrivilegeChecker(ctx)
+			if cErr != nil {
+				return cErr
+			}
+			canSelectColumn := false
+			for i := range n.desc.Columns {
+				if c.columnPrivilege(&n.desc.Columns[i], privilege.SELECT) {
+					canSelectColumn = true
+					break
+				}
+			}
+			if !canSelectColumn {
+				return err
+			}
+			// The user may only read the columns it was granted SELECT on.
+			// Which columns the query reads is only known once the needed
+			// columns have been computed; see checkColumnPrivileges.
+			n.selectPrivilegeErr = err
 		}
 	}
 
@@ -38,6 +38,9 @@ type nameResolutionVisitor struct {
 	sources    multiSourceInfo
 	iVarHelper tree.IndexedVarHelper
 	searchPath sessiondata.SearchPath
+	// notices, if set, receives the notices raised by the column
+	// references, e.g. on deprecated columns.
+	notices *SessionNotices
 
 	// foundDependentVars is set to true during the analysis if an
 	// expression was found which can change values between rows of the
@@ -121,6 +124,9 @@ func (v *nameResolutionVisitor) VisitPre(expr tree.Expr) (recurse bool, newNode
 			v.err = err
 			return false, expr
 		}
+		if notice, ok := v.sources[srcIdx].deprecationNotices[colIdx]; ok && v.notices != nil {
+			v.notices.addOnce(notice)
+		}
 		ivar := v.iVarHelper.IndexedVar(v.sources[srcIdx].colOffset + colIdx)
 		v.foundDependentVars = true
 		return true, ivar
@@ -227,6 +233,7 @@ func (p *planner) resolveNames(
 		sources:            sources,
 		iVarHelper:         ivarHelper,
 		searchPath:         p.SessionData().SearchPath,
+		notices:            p.extendedEvalCtx.Notices,
 		foundDependentVars: false,
 	}
 	colOffset := 0
@@ -2187,6 +2187,34 @@ CockroachDB supports the following flags:
 		},
 	},
 
+	// See https://www.postgresql.org/docs/10/static/functions-admin.html#FUNCTIONS-ADMIN-SET
+	"set_config": {
+		tree.Builtin{
+			Types: tree.ArgTypes{
+				{"setting_name", types.String}, {"new_value", types.String}, {"is_local", types.Bool},
+			},
+			ReturnType:       tree.FixedReturnType(types.String),
+			Category:         categorySystemInfo,
+			Impure:           true,
+			DistsqlBlacklist: true,
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				if tree.MustBeDBool(args[2]) {
+					return nil, pgerror.Unimplemented("set_config local",
+						"setting a session variable for the current transaction only is not supported")
+				}
+				newValue, err := ctx.Planner.SetSessionVar(
+					ctx.Ctx(), string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDString(newValue), nil
+			},
+			Info: "Sets the session variable `setting_name` to `new_value`, like SET, and " +
+				"returns its new value. `is_local` must be false: setting a variable for " +
+				"the current transaction only is not supported.",
+		},
+	},
+
 	"crdb_internal.node_executable_version": {
 		tree.Builtin{
 			Types:      tree.ArgTypes{},
@@ -2218,6 +2246,36 @@ CockroachDB supports the following flags:
 		},
 	},
 
+	"crdb_internal.is_valid_collation": {
+		tree.Builtin{
+			Types:      tree.ArgTypes{{"locale", types.String}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Category:   categoryString,
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				_, err := tree.CanonicalCollationLocale(string(tree.MustBeDString(args[0])))
+				return tree.MakeDBool(err == nil), nil
+			},
+			Info: "Returns whether `locale` can be used in a COLLATE clause.",
+		},
+	},
+
+	"crdb_internal.normalize_collation": {
+		tree.Builtin{
+			Types:      tree.ArgTypes{{"locale", types.String}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Category:   categoryString,
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				locale, err := tree.CanonicalCollationLocale(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDString(locale), nil
+			},
+			Info: "Returns the canonical name of the collation for `locale`, which is " +
+				"the name it is reported under in pg_catalog and information_schema.",
+		},
+	},
+
 	"crdb_internal.force_error": {
 		tree.Builtin{
 			Types:      tree.ArgTypes{{"errorCode", types.String}, {"msg", types.String}},
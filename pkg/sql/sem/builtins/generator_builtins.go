@@ -99,6 +99,15 @@ var Generators = map[string][]tree.Builtin{
 			"Returns the input array as a set of rows",
 		),
 	},
+	"crdb_internal.collation_locales": {
+		makeGeneratorBuiltin(
+			tree.ArgTypes{},
+			collationLocalesValueGeneratorType,
+			makeCollationLocalesGenerator,
+			"Produces a virtual table containing the canonical names of the locales "+
+				"that collations are tailored for.",
+		),
+	},
 	"crdb_internal.unary_table": {
 		makeGeneratorBuiltin(
 			tree.ArgTypes{},
@@ -206,6 +215,47 @@ var keywordNames = func() []string {
 	return ret
 }()
 
+// collationLocalesValueGenerator supports the execution of
+// crdb_internal.collation_locales().
+type collationLocalesValueGenerator struct {
+	locales []string
+	cur     int
+}
+
+var collationLocalesValueGeneratorType = types.TTable{
+	Cols:   types.TTuple{types.String},
+	Labels: []string{"locale"},
+}
+
+func makeCollationLocalesGenerator(_ *tree.EvalContext, _ tree.Datums) (tree.ValueGenerator, error) {
+	return &collationLocalesValueGenerator{locales: tree.SupportedCollationLocales()}, nil
+}
+
+// ResolvedType implements the tree.ValueGenerator interface.
+func (*collationLocalesValueGenerator) ResolvedType() types.TTable {
+	return collationLocalesValueGeneratorType
+}
+
+// Close implements the tree.ValueGenerator interface.
+func (*collationLocalesValueGenerator) Close() {}
+
+// Start implements the tree.ValueGenerator interface.
+func (c *collationLocalesValueGenerator) Start() error {
+	c.cur = -1
+	return nil
+}
+
+// Next implements the tree.ValueGenerator interface.
+func (c *collationLocalesValueGenerator) Next() (bool, error) {
+	c.cur++
+	return c.cur < len(c.locales), nil
+}
+
+// Values implements the tree.ValueGenerator interface.
+func (c *collationLocalesValueGenerator) Values() tree.Datums {
+	return tree.Datums{tree.NewDString(c.locales[c.cur])}
+}
+
 // seriesValueGenerator supports the execution of generate_series()
 // with integer bounds.
 type seriesValueGenerator struct {
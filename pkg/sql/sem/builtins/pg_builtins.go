@@ -16,10 +16,13 @@ package builtins
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/lib/pq/oid"
+	"github.com/pkg/errors"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
@@ -137,9 +140,12 @@ func makePGGetViewDef(argTypes tree.ArgTypes) tree.Builtin {
 		DistsqlBlacklist: true,
 		ReturnType:       tree.FixedReturnType(types.String),
 		Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+			// Views with the same name may exist in several databases, so the
+			// namespace of the view must match too.
 			r, err := ctx.Planner.QueryRow(
 				ctx.Ctx(), "SELECT definition FROM pg_catalog.pg_views v JOIN pg_catalog.pg_class c ON "+
-					"c.relname=v.viewname WHERE oid=$1", args[0])
+					"c.relname=v.viewname JOIN pg_catalog.pg_namespace n ON "+
+					"c.relnamespace=n.oid AND n.nspname=v.schemaname WHERE c.oid=$1", args[0])
 			if err != nil {
 				return nil, err
 			}
@@ -152,6 +158,45 @@ func makePGGetViewDef(argTypes tree.ArgTypes) tree.Builtin {
 	}
 }
 
+// Make a pg_get_indexdef function with the given arguments. If a column
+// number is given and isn't zero, only the name of that column of the index
+// is returned, or an empty string if the index has fewer columns.
+func makePGGetIndexDef(argTypes tree.ArgTypes) tree.Builtin {
+	return tree.Builtin{
+		Types:            argTypes,
+		DistsqlBlacklist: true,
+		ReturnType:       tree.FixedReturnType(types.String),
+		Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+			r, err := ctx.Planner.QueryRow(
+				ctx.Ctx(), "SELECT indexdef FROM pg_catalog.pg_indexes WHERE crdb_oid=$1", args[0])
+			if err != nil {
+				return nil, err
+			}
+			if len(r) == 0 {
+				return nil, pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError, "unknown index (OID=%s)", args[0])
+			}
+			if len(args) == 1 || *args[1].(*tree.DInt) == 0 {
+				return r[0], nil
+			}
+			r, err = ctx.Planner.QueryRow(
+				ctx.Ctx(), "SELECT s.column_name FROM pg_catalog.pg_indexes i "+
+					"JOIN information_schema.statistics s ON i.schemaname=s.table_schema AND "+
+					"i.tablename=s.table_name AND i.indexname=s.index_name "+
+					"WHERE i.crdb_oid=$1 AND s.seq_in_index=$2 AND s.storing='NO' AND s.implicit='NO'",
+				args[0], args[1])
+			if err != nil {
+				return nil, err
+			}
+			if len(r) == 0 {
+				return tree.NewDString(""), nil
+			}
+			colName := tree.Name(tree.MustBeDString(r[0]))
+			return tree.NewDString(tree.AsString(&colName)), nil
+		},
+		Info: notUsableInfo,
+	}
+}
+
 // Make a pg_get_constraintdef function with the given arguments.
 func makePGGetConstraintDef(argTypes tree.ArgTypes) tree.Builtin {
 	return tree.Builtin{
@@ -173,16 +218,247 @@ func makePGGetConstraintDef(argTypes tree.ArgTypes) tree.Builtin {
 	}
 }
 
+// pgCatalogTableOidQuery is a subquery returning the OID of the pg_catalog
+// table named by the second placeholder, e.g. pg_class. It is used to
+// filter the descriptions by the catalog holding the described object.
+const pgCatalogTableOidQuery = "(SELECT c.oid FROM pg_catalog.pg_class c " +
+	"JOIN pg_catalog.pg_namespace n ON c.relnamespace=n.oid " +
+	"WHERE n.nspname='pg_catalog' AND c.relname=$2)"
+
+// getDescription runs a query returning the description of an object from
+// pg_description or pg_shdescription, and returns NULL if there is none.
+func getDescription(ctx *tree.EvalContext, query string, args ...interface{}) (tree.Datum, error) {
+	r, err := ctx.Planner.QueryRow(ctx.Ctx(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(r) == 0 {
+		return tree.DNull, nil
+	}
+	return r[0], nil
+}
+
+// tablePrivilegeInquiries maps the privileges that has_table_privilege can
+// be asked about to the CockroachDB privileges that allow them. TRUNCATE
+// requires the DROP privilege, and REFERENCES and TRIGGER, which are not
+// separate privileges, the privilege to change the schema of the table.
+var tablePrivilegeInquiries = map[string]privilege.Kind{
+	"SELECT":     privilege.SELECT,
+	"INSERT":     privilege.INSERT,
+	"UPDATE":     privilege.UPDATE,
+	"DELETE":     privilege.DELETE,
+	"TRUNCATE":   privilege.DROP,
+	"REFERENCES": privilege.CREATE,
+	"TRIGGER":    privilege.CREATE,
+}
+
+// columnPrivilegeInquiries is the equivalent of tablePrivilegeInquiries for
+// has_column_privilege.
+var columnPrivilegeInquiries = map[string]privilege.Kind{
+	"SELECT":     privilege.SELECT,
+	"INSERT":     privilege.INSERT,
+	"UPDATE":     privilege.UPDATE,
+	"REFERENCES": privilege.CREATE,
+}
+
+// privilegeInquiry is one of the privileges a privilege inquiry function is
+// asked about.
+type privilegeInquiry struct {
+	name string
+	// withOption is whether the privilege must be held WITH GRANT OPTION,
+	// or WITH ADMIN OPTION for roles.
+	withOption bool
+}
+
+// parsePrivilegeInquiries parses the privilege argument of a privilege
+// inquiry function: a comma-separated list of privilege names, each
+// optionally followed by WITH <option> OPTION. The names are case
+// insensitive and must be in valid.
+func parsePrivilegeInquiries(
+	arg tree.Datum, option string, valid func(name string) bool,
+) ([]privilegeInquiry, error) {
+	suffix := fmt.Sprintf(" WITH %s OPTION", option)
+	var inquiries []privilegeInquiry
+	for _, priv := range strings.Split(string(tree.MustBeDString(arg)), ",") {
+		name := strings.ToUpper(strings.Join(strings.Fields(priv), " "))
+		inquiry := privilegeInquiry{
+			name:       strings.TrimSuffix(name, suffix),
+			withOption: strings.HasSuffix(name, suffix),
+		}
+		if !valid(inquiry.name) {
+			return nil, pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError,
+				"unrecognized privilege type: %q", strings.TrimSpace(priv))
+		}
+		inquiries = append(inquiries, inquiry)
+	}
+	return inquiries, nil
+}
+
+// getRoleForPrivilegeInquiry returns the name of the user or role given as
+// a name or an OID to a privilege inquiry function.
+func getRoleForPrivilegeInquiry(ctx *tree.EvalContext, arg tree.Datum) (string, error) {
+	var r tree.Datums
+	var err error
+	switch t := arg.(type) {
+	case *tree.DString:
+		r, err = ctx.Planner.QueryRow(ctx.Ctx(),
+			"SELECT rolname FROM pg_catalog.pg_roles WHERE rolname=$1", string(*t))
+		if err == nil && len(r) == 0 {
+			err = pgerror.NewErrorf(pgerror.CodeUndefinedObjectError, "role %q does not exist", string(*t))
+		}
+	case *tree.DOid:
+		r, err = ctx.Planner.QueryRow(ctx.Ctx(),
+			"SELECT rolname FROM pg_catalog.pg_roles WHERE oid=$1", t)
+		if err == nil && len(r) == 0 {
+			err = pgerror.NewErrorf(pgerror.CodeUndefinedObjectError, "role with OID %s does not exist", t)
+		}
+	default:
+		return "", errors.Errorf("unexpected role argument %s", arg)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(tree.MustBeDString(r[0])), nil
+}
+
+// getTableForPrivilegeInquiry returns the name of the table given as a name
+// or an OID to a privilege inquiry function. It returns nil if there is no
+// table with the given OID, for which the function returns NULL.
+func getTableForPrivilegeInquiry(ctx *tree.EvalContext, arg tree.Datum) (*tree.TableName, error) {
+	switch t := arg.(type) {
+	case *tree.DString:
+		return ctx.Planner.ParseQualifiedTableName(ctx.Ctx(), string(*t))
+	case *tree.DOid:
+		r, err := ctx.Planner.QueryRow(ctx.Ctx(),
+			"SELECT n.nspname, c.relname FROM pg_catalog.pg_class c "+
+				"JOIN pg_catalog.pg_namespace n ON c.relnamespace=n.oid "+
+				"WHERE c.oid=$1 AND c.relkind IN ('r', 'v', 'S')", t)
+		if err != nil || len(r) == 0 {
+			return nil, err
+		}
+		return tree.NewTableName(
+			tree.Name(tree.MustBeDString(r[0])), tree.Name(tree.MustBeDString(r[1]))), nil
+	default:
+		return nil, errors.Errorf("unexpected table argument %s", arg)
+	}
+}
+
+// makePrivilegeInquiryBuiltins returns the overloads of a privilege inquiry
+// function whose arguments, between the optional user and the privilege, are
+// given by each of objectArgs. The user is given as a name or an OID, or is
+// the session user if omitted. fn is called with the name of the user and
+// the object arguments.
+func makePrivilegeInquiryBuiltins(
+	objectArgs []tree.ArgTypes,
+	fn func(ctx *tree.EvalContext, user string, args tree.Datums, privs tree.Datum) (tree.Datum, error),
+) []tree.Builtin {
+	userArgs := []tree.ArgTypes{
+		{},
+		{{"user", types.String}},
+		{{"user", types.Oid}},
+	}
+	var builtins []tree.Builtin
+	for _, userArg := range userArgs {
+		for _, objectArg := range objectArgs {
+			argTypes := append(append(tree.ArgTypes{}, userArg...), objectArg...)
+			argTypes = append(argTypes, tree.ArgTypes{{"privilege", types.String}}...)
+			withUser := len(userArg) > 0
+			builtins = append(builtins, tree.Builtin{
+				Types:            argTypes,
+				DistsqlBlacklist: true,
+				ReturnType:       tree.FixedReturnType(types.Bool),
+				Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+					user := ctx.SessionData.User
+					if withUser {
+						var err error
+						if user, err = getRoleForPrivilegeInquiry(ctx, args[0]); err != nil {
+							return nil, err
+						}
+						args = args[1:]
+					}
+					return fn(ctx, user, args[:len(args)-1], args[len(args)-1])
+				},
+				Info: notUsableInfo,
+			})
+		}
+	}
+	return builtins
+}
+
+// hasPrivilege returns whether the user holds any of the privileges on the
+// table, or on its column if column isn't empty.
+func hasPrivilege(
+	ctx *tree.EvalContext,
+	user string,
+	tn *tree.TableName,
+	column string,
+	privs tree.Datum,
+	inquiries map[string]privilege.Kind,
+) (tree.Datum, error) {
+	parsed, err := parsePrivilegeInquiries(privs, "GRANT", func(name string) bool {
+		_, ok := inquiries[name]
+		return ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, priv := range parsed {
+		ok, err := ctx.Planner.HasPrivilege(
+			ctx.Ctx(), user, tn, column, inquiries[priv.name], priv.withOption)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return tree.DBoolTrue, nil
+		}
+	}
+	return tree.DBoolFalse, nil
+}
+
 var pgBuiltins = map[string][]tree.Builtin{
 	// See https://www.postgresql.org/docs/9.6/static/functions-info.html.
 	"pg_backend_pid": {
 		tree.Builtin{
-			Types:      tree.ArgTypes{},
-			ReturnType: tree.FixedReturnType(types.Int),
-			Fn: func(_ *tree.EvalContext, _ tree.Datums) (tree.Datum, error) {
-				return tree.NewDInt(-1), nil
+			Types:            tree.ArgTypes{},
+			ReturnType:       tree.FixedReturnType(types.Int),
+			DistsqlBlacklist: true,
+			Fn: func(ctx *tree.EvalContext, _ tree.Datums) (tree.Datum, error) {
+				return tree.NewDInt(tree.DInt(ctx.BackendPID)), nil
 			},
-			Info: notUsableInfo,
+			Category: categorySystemInfo,
+			Info:     "Returns the process ID of the current session, as reported by pg_stat_activity.",
+		},
+	},
+
+	// See https://www.postgresql.org/docs/10/static/functions-admin.html#FUNCTIONS-ADMIN-SIGNAL.
+	"pg_cancel_backend": {
+		tree.Builtin{
+			Types:            tree.ArgTypes{{"pid", types.Int}},
+			ReturnType:       tree.FixedReturnType(types.Bool),
+			Impure:           true,
+			DistsqlBlacklist: true,
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				ok, err := ctx.Planner.CancelBackend(ctx.Ctx(), int64(tree.MustBeDInt(args[0])))
+				return tree.MakeDBool(tree.DBool(ok)), err
+			},
+			Category: categorySystemInfo,
+			Info: "Cancels the queries of the session with the given process ID, which may be " +
+				"on another node. Returns false if there is no such session.",
+		},
+	},
+	"pg_terminate_backend": {
+		tree.Builtin{
+			Types:            tree.ArgTypes{{"pid", types.Int}},
+			ReturnType:       tree.FixedReturnType(types.Bool),
+			Impure:           true,
+			DistsqlBlacklist: true,
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				ok, err := ctx.Planner.TerminateBackend(ctx.Ctx(), int64(tree.MustBeDInt(args[0])))
+				return tree.MakeDBool(tree.DBool(ok)), err
+			},
+			Category: categorySystemInfo,
+			Info: "Closes the session with the given process ID, which must be on the current " +
+				"node. Returns false if there is no such session.",
 		},
 	},
 
@@ -246,28 +522,11 @@ var pgBuiltins = map[string][]tree.Builtin{
 	// pg_get_indexdef functions like SHOW CREATE INDEX would if we supported that
 	// statement.
 	"pg_get_indexdef": {
-		tree.Builtin{
-			Types: tree.ArgTypes{
-				{"index_oid", types.Oid},
-			},
-			DistsqlBlacklist: true,
-			ReturnType:       tree.FixedReturnType(types.String),
-			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
-				r, err := ctx.Planner.QueryRow(
-					ctx.Ctx(), "SELECT indexdef FROM pg_catalog.pg_indexes WHERE crdb_oid=$1", args[0])
-				if err != nil {
-					return nil, err
-				}
-				if len(r) == 0 {
-					return nil, pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError, "unknown index (OID=%s)", args[0])
-				}
-				return r[0], nil
-			},
-			Info: notUsableInfo,
-		},
-		// The other overload for this function, pg_get_indexdef(index_oid,
-		// column_no, pretty_bool), is unimplemented, because it isn't used by
-		// supported ORMs.
+		makePGGetIndexDef(tree.ArgTypes{{"index_oid", types.Oid}}),
+		// The pretty_bool argument is ignored, as the definitions are always
+		// printed on a single line.
+		makePGGetIndexDef(tree.ArgTypes{
+			{"index_oid", types.Oid}, {"column_no", types.Int}, {"pretty_bool", types.Bool}}),
 	},
 
 	// pg_get_viewdef functions like SHOW CREATE VIEW but returns the same format as
@@ -365,28 +624,35 @@ FROM pg_catalog.pg_sequence WHERE seqrelid=$1`, args[0])
 	},
 	"col_description": {
 		tree.Builtin{
-			Types:      tree.ArgTypes{{"table_oid", types.Oid}, {"column_number", types.Int}},
-			ReturnType: tree.FixedReturnType(types.String),
-			Fn: func(_ *tree.EvalContext, _ tree.Datums) (tree.Datum, error) {
-				return tree.DNull, nil
+			Types:            tree.ArgTypes{{"table_oid", types.Oid}, {"column_number", types.Int}},
+			DistsqlBlacklist: true,
+			ReturnType:       tree.FixedReturnType(types.String),
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return getDescription(ctx, "SELECT description FROM pg_catalog.pg_description "+
+					"WHERE objoid=$1 AND objsubid=$2 LIMIT 1", args[0], args[1])
 			},
 			Info: notUsableInfo,
 		},
 	},
 	"obj_description": {
 		tree.Builtin{
-			Types:      tree.ArgTypes{{"object_oid", types.Oid}},
-			ReturnType: tree.FixedReturnType(types.String),
-			Fn: func(_ *tree.EvalContext, _ tree.Datums) (tree.Datum, error) {
-				return tree.DNull, nil
+			Types:            tree.ArgTypes{{"object_oid", types.Oid}},
+			DistsqlBlacklist: true,
+			ReturnType:       tree.FixedReturnType(types.String),
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return getDescription(ctx, "SELECT description FROM pg_catalog.pg_description "+
+					"WHERE objoid=$1 AND objsubid=0 LIMIT 1", args[0])
 			},
 			Info: notUsableInfo,
 		},
 		tree.Builtin{
-			Types:      tree.ArgTypes{{"object_oid", types.Oid}, {"catalog_name", types.String}},
-			ReturnType: tree.FixedReturnType(types.String),
-			Fn: func(_ *tree.EvalContext, _ tree.Datums) (tree.Datum, error) {
-				return tree.DNull, nil
+			Types:            tree.ArgTypes{{"object_oid", types.Oid}, {"catalog_name", types.String}},
+			DistsqlBlacklist: true,
+			ReturnType:       tree.FixedReturnType(types.String),
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return getDescription(ctx, "SELECT description FROM pg_catalog.pg_description "+
+					"WHERE objoid=$1 AND objsubid=0 AND classoid="+pgCatalogTableOidQuery+" LIMIT 1",
+					args[0], args[1])
 			},
 			Info: notUsableInfo,
 		},
@@ -403,10 +669,13 @@ FROM pg_catalog.pg_sequence WHERE seqrelid=$1`, args[0])
 	},
 	"shobj_description": {
 		tree.Builtin{
-			Types:      tree.ArgTypes{{"object_oid", types.Oid}, {"catalog_name", types.String}},
-			ReturnType: tree.FixedReturnType(types.String),
-			Fn: func(_ *tree.EvalContext, _ tree.Datums) (tree.Datum, error) {
-				return tree.DNull, nil
+			Types:            tree.ArgTypes{{"object_oid", types.Oid}, {"catalog_name", types.String}},
+			DistsqlBlacklist: true,
+			ReturnType:       tree.FixedReturnType(types.String),
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return getDescription(ctx, "SELECT description FROM pg_catalog.pg_shdescription "+
+					"WHERE objoid=$1 AND classoid="+pgCatalogTableOidQuery+" LIMIT 1",
+					args[0], args[1])
 			},
 			Info: notUsableInfo,
 		},
@@ -431,6 +700,92 @@ FROM pg_catalog.pg_sequence WHERE seqrelid=$1`, args[0])
 			Info: notUsableInfo,
 		},
 	},
+	// has_table_privilege, has_column_privilege and pg_has_role answer
+	// whether a user, the session user by default, holds the given
+	// privileges on an object. They return true if any of the
+	// comma-separated privileges is held.
+	// https://www.postgresql.org/docs/10/static/functions-info.html
+	"has_table_privilege": makePrivilegeInquiryBuiltins(
+		[]tree.ArgTypes{
+			{{"table", types.String}},
+			{{"table", types.Oid}},
+		},
+		func(ctx *tree.EvalContext, user string, args tree.Datums, privs tree.Datum) (tree.Datum, error) {
+			tn, err := getTableForPrivilegeInquiry(ctx, args[0])
+			if err != nil || tn == nil {
+				return tree.DNull, err
+			}
+			return hasPrivilege(ctx, user, tn, "" /* column */, privs, tablePrivilegeInquiries)
+		},
+	),
+	"has_column_privilege": makePrivilegeInquiryBuiltins(
+		[]tree.ArgTypes{
+			{{"table", types.String}, {"column", types.String}},
+			{{"table", types.String}, {"column", types.Int}},
+			{{"table", types.Oid}, {"column", types.String}},
+			{{"table", types.Oid}, {"column", types.Int}},
+		},
+		func(ctx *tree.EvalContext, user string, args tree.Datums, privs tree.Datum) (tree.Datum, error) {
+			tn, err := getTableForPrivilegeInquiry(ctx, args[0])
+			if err != nil || tn == nil {
+				return tree.DNull, err
+			}
+			var column string
+			switch t := args[1].(type) {
+			case *tree.DString:
+				column = string(*t)
+			case *tree.DInt:
+				// The column is given by its number in pg_attribute. There is
+				// no answer for a column that doesn't exist.
+				r, err := ctx.Planner.QueryRow(ctx.Ctx(),
+					"SELECT a.attname FROM pg_catalog.pg_attribute a "+
+						"JOIN pg_catalog.pg_class c ON a.attrelid=c.oid "+
+						"JOIN pg_catalog.pg_namespace n ON c.relnamespace=n.oid "+
+						"WHERE n.nspname=$1 AND c.relname=$2 AND a.attnum=$3",
+					tn.Schema(), tn.Table(), t)
+				if err != nil || len(r) == 0 {
+					return tree.DNull, err
+				}
+				column = string(tree.MustBeDString(r[0]))
+			}
+			return hasPrivilege(ctx, user, tn, column, privs, columnPrivilegeInquiries)
+		},
+	),
+	"pg_has_role": makePrivilegeInquiryBuiltins(
+		[]tree.ArgTypes{
+			{{"role", types.String}},
+			{{"role", types.Oid}},
+		},
+		func(ctx *tree.EvalContext, user string, args tree.Datums, privs tree.Datum) (tree.Datum, error) {
+			role, err := getRoleForPrivilegeInquiry(ctx, args[0])
+			if err != nil {
+				return nil, err
+			}
+			// Roles always inherit the privileges of the roles they are
+			// members of, so MEMBER and USAGE are the same.
+			parsed, err := parsePrivilegeInquiries(privs, "ADMIN", func(name string) bool {
+				return name == "MEMBER" || name == "USAGE"
+			})
+			if err != nil {
+				return nil, err
+			}
+			if user == role {
+				return tree.DBoolTrue, nil
+			}
+			memberOf, err := ctx.Planner.MemberOfWithAdminOption(ctx.Ctx(), user)
+			if err != nil {
+				return nil, err
+			}
+			isAdmin, isMember := memberOf[role]
+			for _, priv := range parsed {
+				if isMember && (isAdmin || !priv.withOption) {
+					return tree.DBoolTrue, nil
+				}
+			}
+			return tree.DBoolFalse, nil
+		},
+	),
+
 	// pg_table_is_visible returns true if the input oid corresponds to a table
 	// that is part of the databases on the search path.
 	// https://www.postgresql.org/docs/9.6/static/functions-info.html
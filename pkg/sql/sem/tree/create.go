@@ -29,10 +29,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
-
-	"golang.org/x/text/language"
-
-	"github.com/pkg/errors"
 )
 
 // CreateDatabase represents a CREATE DATABASE statement.
@@ -263,15 +259,14 @@ func NewColumnTableDef(
 	for _, c := range qualifications {
 		switch t := c.Qualification.(type) {
 		case ColumnCollation:
-			locale := string(t)
-			_, err := language.Parse(locale)
-			if err != nil {
-				return nil, errors.Wrapf(err, "invalid locale %s", locale)
+			if _, err := CanonicalCollationLocale(string(t)); err != nil {
+				return nil, err
 			}
-			d.Type, err = processCollationOnType(name, d.Type, t)
+			typ, err := processCollationOnType(name, d.Type, t)
 			if err != nil {
 				return nil, err
 			}
+			d.Type = typ
 		case *ColumnDefault:
 			if d.HasDefaultExpr() {
 				return nil, pgerror.NewErrorf(pgerror.CodeSyntaxError,
@@ -922,6 +917,14 @@ func (node *SequenceOptions) Format(ctx *FmtCtx) {
 				ctx.WriteString("BY ")
 			}
 			ctx.Printf("%d", *option.IntVal)
+		case SeqOptOwnedBy:
+			ctx.WriteString(option.Name)
+			ctx.WriteByte(' ')
+			if option.ColumnItemVal == nil {
+				ctx.WriteString("NONE")
+			} else {
+				ctx.FormatNode(option.ColumnItemVal)
+			}
 		}
 	}
 }
@@ -931,6 +934,9 @@ type SequenceOption struct {
 	Name string
 
 	IntVal *int64
+	// ColumnItemVal is the owning column of an OWNED BY option; nil
+	// represents OWNED BY NONE.
+	ColumnItemVal *ColumnItem
 
 	OptionalWord bool
 }
@@ -941,6 +947,7 @@ const (
 	SeqOptMinValue  = "MINVALUE"
 	SeqOptMaxValue  = "MAXVALUE"
 	SeqOptStart     = "START"
+	SeqOptOwnedBy   = "OWNED BY"
 )
 
 // CreateUser represents a CREATE USER statement.
@@ -948,6 +955,7 @@ type CreateUser struct {
 	Name        Expr
 	Password    Expr // nil if no password specified
 	IfNotExists bool
+	Options     RoleOptions
 }
 
 // HasPassword returns if the CreateUser has a password.
@@ -969,7 +977,71 @@ func (node *CreateUser) Format(ctx *FmtCtx) {
 		} else {
 			ctx.WriteString("*****")
 		}
+		if len(node.Options) > 0 {
+			ctx.WriteByte(' ')
+			ctx.FormatNode(&node.Options)
+		}
+	} else if len(node.Options) > 0 {
+		ctx.WriteString(" WITH ")
+		ctx.FormatNode(&node.Options)
+	}
+}
+
+// RoleOptionName is the keyword of a RoleOption.
+type RoleOptionName string
+
+// The role options.
+const (
+	RoleOptionLogin        RoleOptionName = "LOGIN"
+	RoleOptionNoLogin      RoleOptionName = "NOLOGIN"
+	RoleOptionCreateRole   RoleOptionName = "CREATEROLE"
+	RoleOptionNoCreateRole RoleOptionName = "NOCREATEROLE"
+	RoleOptionValidUntil   RoleOptionName = "VALID UNTIL"
+	// RoleOptionPassword only appears while CREATE USER is parsed; its value
+	// is then moved to CreateUser.Password.
+	RoleOptionPassword RoleOptionName = "PASSWORD"
+)
+
+// RoleOption is an option of a user or role given to CREATE USER, CREATE
+// ROLE, ALTER USER or ALTER ROLE.
+type RoleOption struct {
+	Name RoleOptionName
+	// Value is the timestamp of VALID UNTIL, or nil for the options without
+	// a value.
+	Value Expr
+}
+
+// RoleOptions represents a list of role options.
+type RoleOptions []RoleOption
+
+// Format implements the NodeFormatter interface.
+func (node *RoleOptions) Format(ctx *FmtCtx) {
+	for i, o := range *node {
+		if i > 0 {
+			ctx.WriteByte(' ')
+		}
+		ctx.WriteString(string(o.Name))
+		if o.Value != nil {
+			ctx.WriteByte(' ')
+			ctx.FormatNode(o.Value)
+		}
+	}
+}
+
+// SplitPassword separates the PASSWORD option from the other options. The
+// password is nil if there is no PASSWORD option, and the last one is kept
+// if there are several.
+func (node RoleOptions) SplitPassword() (Expr, RoleOptions) {
+	var password Expr
+	var options RoleOptions
+	for _, o := range node {
+		if o.Name == RoleOptionPassword {
+			password = o.Value
+		} else {
+			options = append(options, o)
+		}
 	}
+	return password, options
 }
 
 // AlterUserSetPassword represents an ALTER USER ... WITH PASSWORD statement.
@@ -994,10 +1066,113 @@ func (node *AlterUserSetPassword) Format(ctx *FmtCtx) {
 	}
 }
 
+// AlterRoleSet represents an ALTER ROLE ... SET or ALTER ROLE ... RESET
+// statement, which changes the default value of a session variable for a
+// user or role.
+type AlterRoleSet struct {
+	Name Expr
+	// IsRole is set if the statement was spelled ALTER ROLE rather than
+	// ALTER USER.
+	IsRole bool
+	// SetVar is the variable and its default values, or DEFAULT for a
+	// RESET. It is nil for a RESET ALL.
+	SetVar *SetVar
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterRoleSet) Format(ctx *FmtCtx) {
+	if node.IsRole {
+		ctx.WriteString("ALTER ROLE ")
+	} else {
+		ctx.WriteString("ALTER USER ")
+	}
+	ctx.FormatNode(node.Name)
+	switch {
+	case node.SetVar == nil:
+		ctx.WriteString(" RESET ALL")
+	case node.IsReset():
+		ctx.WriteString(" RESET ")
+		ctx.FormatNameP(&node.SetVar.Name)
+	default:
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.SetVar)
+	}
+}
+
+// IsReset returns whether the statement resets the default value of a
+// single variable.
+func (node *AlterRoleSet) IsReset() bool {
+	return isResetSetVar(node.SetVar)
+}
+
+// AlterDatabaseSet represents an ALTER DATABASE ... SET or ALTER DATABASE
+// ... RESET statement, which changes the default value of a session
+// variable for a database.
+type AlterDatabaseSet struct {
+	Name Name
+	// SetVar is the variable and its default values, or DEFAULT for a
+	// RESET. It is nil for a RESET ALL.
+	SetVar *SetVar
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDatabaseSet) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER DATABASE ")
+	ctx.FormatNode(&node.Name)
+	switch {
+	case node.SetVar == nil:
+		ctx.WriteString(" RESET ALL")
+	case node.IsReset():
+		ctx.WriteString(" RESET ")
+		ctx.FormatNameP(&node.SetVar.Name)
+	default:
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.SetVar)
+	}
+}
+
+// IsReset returns whether the statement resets the default value of a
+// single variable.
+func (node *AlterDatabaseSet) IsReset() bool {
+	return isResetSetVar(node.SetVar)
+}
+
+// isResetSetVar returns whether the SET of a default value is a RESET,
+// i.e. sets the variable to DEFAULT.
+func isResetSetVar(setVar *SetVar) bool {
+	if setVar == nil || len(setVar.Values) != 1 {
+		return false
+	}
+	_, ok := setVar.Values[0].(DefaultVal)
+	return ok
+}
+
+// AlterRoleOptions represents an ALTER ROLE ... WITH <options> statement.
+type AlterRoleOptions struct {
+	Name Expr
+	// IsRole is set if the statement was spelled ALTER ROLE rather than
+	// ALTER USER.
+	IsRole  bool
+	Options RoleOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterRoleOptions) Format(ctx *FmtCtx) {
+	if node.IsRole {
+		ctx.WriteString("ALTER ROLE ")
+	} else {
+		ctx.WriteString("ALTER USER ")
+	}
+	ctx.FormatNode(node.Name)
+	ctx.WriteString(" WITH ")
+	ctx.FormatNode(&node.Options)
+}
+
 // CreateRole represents a CREATE ROLE statement.
 type CreateRole struct {
 	Name        Expr
 	IfNotExists bool
+	Options     RoleOptions
 }
 
 // Format implements the NodeFormatter interface.
@@ -1007,6 +1182,10 @@ func (node *CreateRole) Format(ctx *FmtCtx) {
 		ctx.WriteString("IF NOT EXISTS ")
 	}
 	ctx.FormatNode(node.Name)
+	if len(node.Options) > 0 {
+		ctx.WriteString(" WITH ")
+		ctx.FormatNode(&node.Options)
+	}
 }
 
 // CreateView represents a CREATE VIEW statement.
@@ -1050,3 +1229,18 @@ func (node *CreateStats) Format(ctx *FmtCtx) {
 	ctx.WriteString(" FROM ")
 	ctx.FormatNode(&node.Table)
 }
+
+// CreateExtension represents a CREATE EXTENSION statement.
+type CreateExtension struct {
+	IfNotExists bool
+	Name        Name
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateExtension) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE EXTENSION ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+}
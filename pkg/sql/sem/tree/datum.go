@@ -940,6 +940,30 @@ type DCollatedString struct {
 	Key []byte
 }
 
+// CanonicalCollationLocale returns the canonical name of the given collation
+// locale, e.g. en-US for en_us. This is the name collations are reported
+// under by the introspection tables. It returns an error if the locale is
+// not valid, i.e. if it cannot be used in a COLLATE clause.
+func CanonicalCollationLocale(locale string) (string, error) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid locale %s", locale)
+	}
+	return tag.String(), nil
+}
+
+// SupportedCollationLocales returns the canonical names of the locales that
+// collations are tailored for. Other valid locales collate like the closest
+// of these.
+func SupportedCollationLocales() []string {
+	tags := collate.Supported()
+	locales := make([]string, len(tags))
+	for i, tag := range tags {
+		locales[i] = tag.String()
+	}
+	return locales
+}
+
 // CollationEnvironment stores the state needed by NewDCollatedString to
 // construct collation keys efficiently.
 type CollationEnvironment struct {
@@ -36,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
@@ -1925,10 +1926,21 @@ func evalDatumsCmp(
 }
 
 func matchLike(ctx *EvalContext, left, right Datum, caseInsensitive bool) (Datum, error) {
-	pattern := string(MustBeDString(right))
+	like, err := LikeMatcher(ctx, string(MustBeDString(right)), caseInsensitive)
+	if err != nil {
+		return DBoolFalse, err
+	}
+	return MakeDBool(DBool(like(string(MustBeDString(left))))), nil
+}
+
+// LikeMatcher returns a function reporting whether a string matches the
+// pattern, with the semantics of LIKE, or of ILIKE if caseInsensitive is set.
+func LikeMatcher(
+	ctx *EvalContext, pattern string, caseInsensitive bool,
+) (func(string) bool, error) {
 	like, err := optimizedLikeFunc(pattern, caseInsensitive)
 	if err != nil {
-		return DBoolFalse, pgerror.NewErrorf(
+		return nil, pgerror.NewErrorf(
 			pgerror.CodeInvalidRegularExpressionError, "LIKE regexp compilation failed: %v", err)
 	}
 
@@ -1936,12 +1948,12 @@ func matchLike(ctx *EvalContext, left, right Datum, caseInsensitive bool) (Datum
 		key := likeKey{s: pattern, caseInsensitive: caseInsensitive}
 		re, err := ctx.ReCache.GetRegexp(key)
 		if err != nil {
-			return DBoolFalse, pgerror.NewErrorf(
+			return nil, pgerror.NewErrorf(
 				pgerror.CodeInvalidRegularExpressionError, "LIKE regexp compilation failed: %v", err)
 		}
 		like = re.MatchString
 	}
-	return MakeDBool(DBool(like(string(MustBeDString(left))))), nil
+	return like, nil
 }
 
 func matchRegexpWithKey(ctx *EvalContext, str Datum, key RegexpCacheKey) (Datum, error) {
@@ -1999,6 +2011,33 @@ type EvalPlanner interface {
 
 	// EvalSubquery returns the Datum for the given subquery node.
 	EvalSubquery(expr *Subquery) (Datum, error)
+
+	// SetSessionVar sets the session variable with the given name to the
+	// given value, like SET does, and returns its new value.
+	SetSessionVar(ctx context.Context, varName, newValue string) (string, error)
+
+	// HasPrivilege returns whether user, directly or through the roles it is
+	// a member of, holds the privilege on the given table or view, or on the
+	// given column of it if column isn't empty. If grantOption is set, the
+	// user must also be allowed to grant the privilege. It returns an error
+	// if the table or the column doesn't exist.
+	HasPrivilege(
+		ctx context.Context, user string, tn *TableName, column string,
+		priv privilege.Kind, grantOption bool,
+	) (bool, error)
+
+	// MemberOfWithAdminOption looks up all the roles (direct and indirect)
+	// that member is a member of and returns a map of role -> isAdmin.
+	MemberOfWithAdminOption(ctx context.Context, member string) (map[string]bool, error)
+
+	// CancelBackend cancels the queries of the session with the given pid,
+	// which may be on another node. It returns false if there is no such
+	// session.
+	CancelBackend(ctx context.Context, pid int64) (bool, error)
+
+	// TerminateBackend closes the session with the given pid. It returns
+	// false if there is no such session.
+	TerminateBackend(ctx context.Context, pid int64) (bool, error)
 }
 
 // CtxProvider is anything that can return a Context.
@@ -2060,6 +2099,9 @@ type EvalContext struct {
 	// ApplicationName is a session variable, but it is not part of SessionData.
 	// See its definition in Session for details.
 	ApplicationName string
+	// BackendPID identifies the session like the process ID of a PostgreSQL
+	// backend. It is 0 for statements run internally.
+	BackendPID int64
 	// TxnState is a string representation of the current transactional state.
 	TxnState string
 	// TxnReadOnly specifies if the current transaction is read-only.
@@ -30,8 +30,16 @@ import (
 // Grant represents a GRANT statement.
 type Grant struct {
 	Privileges privilege.List
-	Targets    TargetList
-	Grantees   NameList
+	// Columns, if set, restricts the privileges to these columns of the
+	// target tables.
+	Columns  NameList
+	Targets  TargetList
+	Grantees NameList
+	// Until, if set, is the time at which the granted privileges expire.
+	Until Expr
+	// WithGrantOption is set for GRANT ... WITH GRANT OPTION, which allows
+	// the grantees to grant the privileges to others.
+	WithGrantOption bool
 }
 
 // TargetList represents a list of targets.
@@ -77,10 +85,22 @@ func (tl *TargetList) NormalizeTablesWithDatabase(db string) error {
 func (node *Grant) Format(ctx *FmtCtx) {
 	ctx.WriteString("GRANT ")
 	node.Privileges.Format(ctx.Buffer)
+	if node.Columns != nil {
+		ctx.WriteString(" (")
+		ctx.FormatNode(&node.Columns)
+		ctx.WriteByte(')')
+	}
 	ctx.WriteString(" ON ")
 	ctx.FormatNode(&node.Targets)
 	ctx.WriteString(" TO ")
 	ctx.FormatNode(&node.Grantees)
+	if node.Until != nil {
+		ctx.WriteString(" UNTIL ")
+		ctx.FormatNode(node.Until)
+	}
+	if node.WithGrantOption {
+		ctx.WriteString(" WITH GRANT OPTION")
+	}
 }
 
 // GrantRole represents a GRANT <role> statement.
@@ -100,3 +120,42 @@ func (node *GrantRole) Format(ctx *FmtCtx) {
 		ctx.WriteString(" WITH ADMIN OPTION")
 	}
 }
+
+// AlterDefaultPrivileges represents an ALTER DEFAULT PRIVILEGES statement.
+type AlterDefaultPrivileges struct {
+	// Roles are the creators of the tables the privileges are granted on,
+	// or empty for the current user.
+	Roles NameList
+	// Databases are the databases of the tables, or empty for the current
+	// database.
+	Databases NameList
+	// IsGrant is set for GRANT and unset for REVOKE.
+	IsGrant    bool
+	Privileges privilege.List
+	Grantees   NameList
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDefaultPrivileges) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER DEFAULT PRIVILEGES")
+	if node.Roles != nil {
+		ctx.WriteString(" FOR ROLE ")
+		ctx.FormatNode(&node.Roles)
+	}
+	if node.Databases != nil {
+		ctx.WriteString(" IN DATABASE ")
+		ctx.FormatNode(&node.Databases)
+	}
+	if node.IsGrant {
+		ctx.WriteString(" GRANT ")
+	} else {
+		ctx.WriteString(" REVOKE ")
+	}
+	node.Privileges.Format(ctx.Buffer)
+	if node.IsGrant {
+		ctx.WriteString(" ON TABLES TO ")
+	} else {
+		ctx.WriteString(" ON TABLES FROM ")
+	}
+	ctx.FormatNode(&node.Grantees)
+}
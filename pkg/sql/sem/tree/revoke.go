@@ -31,14 +31,28 @@ import (
 // PrivilegeList and TargetList are defined in grant.go
 type Revoke struct {
 	Privileges privilege.List
-	Targets    TargetList
-	Grantees   NameList
+	// Columns, if set, restricts the privileges to these columns of the
+	// target tables.
+	Columns  NameList
+	Targets  TargetList
+	Grantees NameList
+	// GrantOptionFor is set for REVOKE GRANT OPTION FOR, which only revokes
+	// the grant option, leaving the privileges themselves in place.
+	GrantOptionFor bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *Revoke) Format(ctx *FmtCtx) {
 	ctx.WriteString("REVOKE ")
+	if node.GrantOptionFor {
+		ctx.WriteString("GRANT OPTION FOR ")
+	}
 	node.Privileges.Format(ctx.Buffer)
+	if node.Columns != nil {
+		ctx.WriteString(" (")
+		ctx.FormatNode(&node.Columns)
+		ctx.WriteByte(')')
+	}
 	ctx.WriteString(" ON ")
 	ctx.FormatNode(&node.Targets)
 	ctx.WriteString(" FROM ")
@@ -181,6 +181,40 @@ func (*AlterSequence) StatementType() StatementType { return DDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*AlterSequence) StatementTag() string { return "ALTER SEQUENCE" }
 
+// StatementType implements the Statement interface.
+func (*AlterDatabaseSet) StatementType() StatementType { return Ack }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterDatabaseSet) StatementTag() string { return "ALTER DATABASE" }
+
+// StatementType implements the Statement interface.
+func (*AlterRoleSet) StatementType() StatementType { return Ack }
+
+// StatementTag returns a short string identifying the type of statement.
+func (n *AlterRoleSet) StatementTag() string {
+	if n.IsRole {
+		return "ALTER ROLE"
+	}
+	return "ALTER USER"
+}
+
+// StatementType implements the Statement interface.
+func (*AlterRoleOptions) StatementType() StatementType { return Ack }
+
+// StatementTag returns a short string identifying the type of statement.
+func (n *AlterRoleOptions) StatementTag() string {
+	if n.IsRole {
+		return "ALTER ROLE"
+	}
+	return "ALTER USER"
+}
+
+// StatementType implements the Statement interface.
+func (*AlterDefaultPrivileges) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterDefaultPrivileges) StatementTag() string { return "ALTER DEFAULT PRIVILEGES" }
+
 // StatementType implements the Statement interface.
 func (*AlterUserSetPassword) StatementType() StatementType { return RowsAffected }
 
@@ -217,6 +251,30 @@ func (*CancelQuery) StatementType() StatementType { return Ack }
 // StatementTag returns a short string identifying the type of statement.
 func (*CancelQuery) StatementTag() string { return "CANCEL QUERY" }
 
+// StatementType implements the Statement interface.
+func (*CommentOnColumn) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CommentOnColumn) StatementTag() string { return "COMMENT ON COLUMN" }
+
+// StatementType implements the Statement interface.
+func (*CommentOnDatabase) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CommentOnDatabase) StatementTag() string { return "COMMENT ON DATABASE" }
+
+// StatementType implements the Statement interface.
+func (*CommentOnIndex) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CommentOnIndex) StatementTag() string { return "COMMENT ON INDEX" }
+
+// StatementType implements the Statement interface.
+func (*CommentOnTable) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CommentOnTable) StatementTag() string { return "COMMENT ON TABLE" }
+
 // StatementType implements the Statement interface.
 func (*CommitTransaction) StatementType() StatementType { return Ack }
 
@@ -237,6 +295,12 @@ func (*CreateDatabase) StatementType() StatementType { return DDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*CreateDatabase) StatementTag() string { return "CREATE DATABASE" }
 
+// StatementType implements the Statement interface.
+func (*CreateExtension) StatementType() StatementType { return DDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateExtension) StatementTag() string { return "CREATE EXTENSION" }
+
 // StatementType implements the Statement interface.
 func (*CreateIndex) StatementType() StatementType { return DDL }
 
@@ -783,7 +847,7 @@ func (*ShowZoneConfig) StatementTag() string { return "SHOW ZONE CONFIGURATION"
 func (*ShowRanges) StatementType() StatementType { return Rows }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*ShowRanges) StatementTag() string { return "SHOW TESTING_RANGES" }
+func (*ShowRanges) StatementTag() string { return "SHOW EXPERIMENTAL_RANGES" }
 
 func (*ShowRanges) hiddenFromStats() {}
 
@@ -853,14 +917,23 @@ func (n *AlterTableDropConstraint) String() string  { return AsString(n) }
 func (n *AlterTableDropNotNull) String() string     { return AsString(n) }
 func (n *AlterTableSetDefault) String() string      { return AsString(n) }
 func (n *AlterUserSetPassword) String() string      { return AsString(n) }
+func (n *AlterDatabaseSet) String() string          { return AsString(n) }
+func (n *AlterRoleSet) String() string              { return AsString(n) }
+func (n *AlterRoleOptions) String() string          { return AsString(n) }
 func (n *AlterSequence) String() string             { return AsString(n) }
+func (n *AlterDefaultPrivileges) String() string    { return AsString(n) }
 func (n *Backup) String() string                    { return AsString(n) }
 func (n *BeginTransaction) String() string          { return AsString(n) }
 func (n *CancelJob) String() string                 { return AsString(n) }
 func (n *CancelQuery) String() string               { return AsString(n) }
+func (n *CommentOnColumn) String() string           { return AsString(n) }
+func (n *CommentOnDatabase) String() string         { return AsString(n) }
+func (n *CommentOnIndex) String() string            { return AsString(n) }
+func (n *CommentOnTable) String() string            { return AsString(n) }
 func (n *CommitTransaction) String() string         { return AsString(n) }
 func (n *CopyFrom) String() string                  { return AsString(n) }
 func (n *CreateDatabase) String() string            { return AsString(n) }
+func (n *CreateExtension) String() string           { return AsString(n) }
 func (n *CreateIndex) String() string               { return AsString(n) }
 func (n *CreateRole) String() string                { return AsString(n) }
 func (n *CreateTable) String() string               { return AsString(n) }
@@ -20,7 +20,6 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
-	"golang.org/x/text/language"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/coltypes"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -357,9 +356,8 @@ func (expr *AnnotateTypeExpr) TypeCheck(ctx *SemaContext, desired types.T) (Type
 
 // TypeCheck implements the Expr interface.
 func (expr *CollateExpr) TypeCheck(ctx *SemaContext, desired types.T) (TypedExpr, error) {
-	_, err := language.Parse(expr.Locale)
-	if err != nil {
-		return nil, errors.Wrapf(err, "invalid locale %s", expr.Locale)
+	if _, err := CanonicalCollationLocale(expr.Locale); err != nil {
+		return nil, err
 	}
 	subExpr, err := expr.Expr.TypeCheck(ctx, types.String)
 	if err != nil {
@@ -212,6 +212,9 @@ func assignSequenceOptions(
 			}
 		case tree.SeqOptStart:
 			opts.Start = *option.IntVal
+		case tree.SeqOptOwnedBy:
+			// Handled by assignSequenceOwner, which needs to look up the owning
+			// table.
 		}
 	}
 
@@ -311,6 +314,142 @@ func removeSequenceDependencies(
 	return nil
 }
 
+// assignSequenceOwner applies the OWNED BY option in optsNode, if any, to the
+// sequence: it removes the reference from the previous owning column and adds
+// one to the new owning column, saving the modified table descriptors. The
+// sequence descriptor is mutated but not saved; the caller must save it.
+func (p *planner) assignSequenceOwner(
+	ctx context.Context, seqDesc *sqlbase.TableDescriptor, optsNode tree.SequenceOptions,
+) error {
+	for _, option := range optsNode {
+		if option.Name != tree.SeqOptOwnedBy {
+			continue
+		}
+		if err := p.removeSequenceOwnerIfExists(ctx, seqDesc); err != nil {
+			return err
+		}
+		colItem := option.ColumnItemVal
+		if colItem == nil {
+			// OWNED BY NONE.
+			return nil
+		}
+
+		tn := colItem.TableName
+		if err := tn.QualifyWithDatabase(p.SessionData().Database); err != nil {
+			return err
+		}
+		tableDesc, err := MustGetTableDesc(ctx, p.txn, p.getVirtualTabler(), &tn, true /* allowAdding */)
+		if err != nil {
+			return err
+		}
+		if tableDesc.ParentID != seqDesc.ParentID {
+			return pgerror.NewErrorf(pgerror.CodeObjectNotInPrerequisiteStateError,
+				"sequence must be in same database as table it is linked to")
+		}
+		if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+			return err
+		}
+		col, err := tableDesc.FindActiveColumnByName(string(colItem.ColumnName))
+		if err != nil {
+			return err
+		}
+		ownerCol, err := tableDesc.FindActiveColumnByID(col.ID)
+		if err != nil {
+			return err
+		}
+		ownerCol.OwnsSequenceIds = append(ownerCol.OwnsSequenceIds, seqDesc.ID)
+		if err := p.saveNonmutationAndNotify(ctx, tableDesc); err != nil {
+			return err
+		}
+		seqDesc.SequenceOpts.SequenceOwner = sqlbase.TableDescriptor_SequenceOwner{
+			OwnerTableID:  tableDesc.ID,
+			OwnerColumnID: col.ID,
+		}
+	}
+	return nil
+}
+
+// removeSequenceOwnerIfExists removes the reference from the column owning the
+// sequence, if any, to the sequence, and saves the column's table descriptor.
+// The sequence descriptor is mutated but not saved; the caller must save it.
+func (p *planner) removeSequenceOwnerIfExists(
+	ctx context.Context, seqDesc *sqlbase.TableDescriptor,
+) error {
+	owner := seqDesc.SequenceOpts.SequenceOwner
+	if owner.OwnerTableID == 0 {
+		return nil
+	}
+	seqDesc.SequenceOpts.SequenceOwner = sqlbase.TableDescriptor_SequenceOwner{}
+	tableDesc, err := sqlbase.GetTableDescFromID(ctx, p.txn, owner.OwnerTableID)
+	if err != nil {
+		return err
+	}
+	if tableDesc.Dropped() {
+		// The owning table is being dropped. No need to modify it further.
+		return nil
+	}
+	col, err := tableDesc.FindColumnByID(owner.OwnerColumnID)
+	if err != nil {
+		return err
+	}
+	for i, id := range col.OwnsSequenceIds {
+		if id == seqDesc.ID {
+			col.OwnsSequenceIds = append(col.OwnsSequenceIds[:i], col.OwnsSequenceIds[i+1:]...)
+			break
+		}
+	}
+	return p.saveNonmutationAndNotify(ctx, tableDesc)
+}
+
+// canRemoveOwnedSequences returns an error if a sequence owned by the column
+// cannot be dropped along with it because it is used by a table that is not in
+// dropping.
+func (p *planner) canRemoveOwnedSequences(
+	ctx context.Context, col *sqlbase.ColumnDescriptor, dropping map[sqlbase.ID]bool,
+) error {
+	for _, sequenceID := range col.OwnsSequenceIds {
+		seqDesc, err := sqlbase.GetTableDescFromID(ctx, p.txn, sequenceID)
+		if err != nil {
+			return err
+		}
+		for _, ref := range seqDesc.DependedOnBy {
+			if !dropping[ref.ID] {
+				return p.sequenceDependencyError(ctx, seqDesc)
+			}
+		}
+	}
+	return nil
+}
+
+// dropSequencesOwnedByCol drops the sequences owned by the column, which is
+// being dropped. It returns the names of the dropped sequences. The column
+// descriptor is mutated but not saved; the caller must save it.
+func (p *planner) dropSequencesOwnedByCol(
+	ctx context.Context, col *sqlbase.ColumnDescriptor,
+) ([]string, error) {
+	var droppedSequences []string
+	for _, sequenceID := range col.OwnsSequenceIds {
+		seqDesc, err := sqlbase.GetTableDescFromID(ctx, p.txn, sequenceID)
+		if err != nil {
+			return droppedSequences, err
+		}
+		// The sequence might already be getting dropped, e.g. by a DROP
+		// DATABASE. Don't do it twice.
+		if seqDesc.Dropped() {
+			continue
+		}
+		// The owning column is going away, so there is no reference on it to
+		// remove.
+		seqDesc.SequenceOpts.SequenceOwner = sqlbase.TableDescriptor_SequenceOwner{}
+		if err := p.dropSequenceImpl(ctx, seqDesc, tree.DropCascade); err != nil {
+			return droppedSequences, err
+		}
+		droppedSequences = append(droppedSequences, seqDesc.Name)
+	}
+	col.OwnsSequenceIds = nil
+	return droppedSequences, nil
+}
+
 // getUsedSequenceNames returns the name of the sequence passed to
 // a call to nextval in the given expression, or nil if there is
 // no call to nextval.
@@ -151,6 +151,9 @@ type Session struct {
 
 	// ClientAddr is the client's IP address and port.
 	ClientAddr string
+	// pid identifies the session like the process ID of a PostgreSQL
+	// backend. It is assigned when the session is registered.
+	pid int64
 
 	//
 	// State structures for the logical SQL session.
@@ -224,6 +227,10 @@ type Session struct {
 
 	Tracing SessionTracing
 
+	// Notices collects the notices to send to the client along with the
+	// results of the statements.
+	Notices SessionNotices
+
 	tables TableCollection
 
 	// ActiveSyncQueries contains query IDs of all synchronous (i.e. non-parallel)
@@ -293,23 +300,88 @@ type SessionArgs struct {
 type SessionRegistry struct {
 	syncutil.Mutex
 	store map[*Session]struct{}
+	// pids maps the pids of the sessions in store to them.
+	pids map[int64]*Session
+	// lastPIDSeq is the sequence number of the last pid assigned to a session.
+	lastPIDSeq int64
 }
 
 // MakeSessionRegistry creates a new SessionRegistry with an empty set
 // of sessions.
 func MakeSessionRegistry() *SessionRegistry {
-	return &SessionRegistry{store: make(map[*Session]struct{})}
+	return &SessionRegistry{
+		store: make(map[*Session]struct{}),
+		pids:  make(map[int64]*Session),
+	}
 }
 
-func (r *SessionRegistry) register(s *Session) {
+// register adds the session to the registry. It returns an error if the
+// session couldn't be given a pid, in which case it is registered without
+// one and can't be signaled through pg_cancel_backend or
+// pg_terminate_backend.
+func (r *SessionRegistry) register(s *Session) error {
 	r.Lock()
+	defer r.Unlock()
 	r.store[s] = struct{}{}
-	r.Unlock()
+	pid, err := r.nextPIDLocked(s.execCfg.NodeID.Get())
+	if err != nil {
+		return err
+	}
+	s.pid = pid
+	r.pids[pid] = s
+	return nil
+}
+
+// The pids are reported as the int4 of Postgres, so they are 31-bit positive
+// numbers: the ID of the node of the session in the upper bits, so that the
+// node of a session can be told from its pid, and a sequence number in the
+// lower ones.
+const (
+	backendPIDSeqBits  = 16
+	backendPIDNodeBits = 31 - backendPIDSeqBits
+
+	maxBackendPIDSeq    = 1<<backendPIDSeqBits - 1
+	maxBackendPIDNodeID = 1<<backendPIDNodeBits - 1
+)
+
+// nextPIDLocked returns the next pid for a session on the node. The sequence
+// numbers wrap around, skipping the pids of the registered sessions. An
+// error is returned if they are all in use, or if the ID of the node doesn't
+// fit in backendPIDNodeBits.
+func (r *SessionRegistry) nextPIDLocked(nodeID roachpb.NodeID) (int64, error) {
+	if nodeID > maxBackendPIDNodeID {
+		return 0, errors.Errorf(
+			"the sessions of node %d have no pid: only the nodes with IDs up to %d have some",
+			nodeID, maxBackendPIDNodeID)
+	}
+	for i := 0; i < maxBackendPIDSeq; i++ {
+		r.lastPIDSeq = r.lastPIDSeq%maxBackendPIDSeq + 1
+		pid := makeBackendPID(nodeID, r.lastPIDSeq)
+		if _, ok := r.pids[pid]; !ok {
+			return pid, nil
+		}
+	}
+	return 0, errors.Errorf("all the %d session pids of node %d are in use", maxBackendPIDSeq, nodeID)
+}
+
+// makeBackendPID returns the pid of the session with the given sequence
+// number, between 1 and maxBackendPIDSeq, on the node, whose ID is at most
+// maxBackendPIDNodeID.
+func makeBackendPID(nodeID roachpb.NodeID, seq int64) int64 {
+	return int64(nodeID)<<backendPIDSeqBits | seq
+}
+
+// backendPIDNodeID returns the ID of the node of the session with the pid.
+func backendPIDNodeID(pid int64) roachpb.NodeID {
+	return roachpb.NodeID(pid >> backendPIDSeqBits)
 }
 
 func (r *SessionRegistry) deregister(s *Session) {
 	r.Lock()
 	delete(r.store, s)
+	if r.pids[s.pid] == s {
+		delete(r.pids, s.pid)
+	}
 	r.Unlock()
 }
 
@@ -342,6 +414,30 @@ func (r *SessionRegistry) CancelQuery(queryIDStr string, username string) (bool,
 	return false, fmt.Errorf("query ID %s not found", queryID)
 }
 
+// TerminateSession looks up the session with the given pid in the session
+// registry and closes it: its queries are canceled and its connection is
+// closed once they finish. It returns false if there is no such session.
+func (r *SessionRegistry) TerminateSession(pid int64, username string) (bool, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	session, ok := r.pids[pid]
+	if !ok {
+		return false, nil
+	}
+	if !(username == security.RootUser || username == session.data.User) {
+		return false, errMustBeSessionOwner
+	}
+	session.mu.Lock()
+	for _, queryMeta := range session.mu.ActiveQueries {
+		queryMeta.cancel()
+	}
+	session.mu.Unlock()
+	// The connection exits when the context of the session is canceled.
+	session.cancel()
+	return true, nil
+}
+
 // SerializeAll returns a slice of all sessions in the registry, converted to serverpb.Sessions.
 func (r *SessionRegistry) SerializeAll() []serverpb.Session {
 	r.Lock()
@@ -356,6 +452,35 @@ func (r *SessionRegistry) SerializeAll() []serverpb.Session {
 	return response
 }
 
+// sessionTxnMeta is the state of the open transaction of a session.
+type sessionTxnMeta struct {
+	user string
+	meta roachpb.TxnCoordMeta
+}
+
+// txnMetas returns the state of the open transactions of the sessions of the
+// given user, or of all the sessions if the user is root.
+func (r *SessionRegistry) txnMetas(username string) []sessionTxnMeta {
+	r.Lock()
+	defer r.Unlock()
+
+	var metas []sessionTxnMeta
+	for session := range r.store {
+		if !(username == security.RootUser || username == session.data.User) {
+			// Skip this session.
+			continue
+		}
+
+		session.TxnState.mu.RLock()
+		txn := session.TxnState.mu.txn
+		session.TxnState.mu.RUnlock()
+		if txn != nil {
+			metas = append(metas, sessionTxnMeta{user: session.data.User, meta: txn.GetTxnCoordMeta()})
+		}
+	}
+	return metas
+}
+
 // NewSession creates and initializes a new Session object.
 // remote can be nil.
 //
@@ -427,7 +552,9 @@ func NewSession(
 	}
 	s.context, s.cancel = contextutil.WithCancel(ctx)
 
-	e.cfg.SessionRegistry.register(s)
+	if err := e.cfg.SessionRegistry.register(s); err != nil {
+		log.Warning(ctx, err)
+	}
 
 	return s
 }
@@ -647,6 +774,7 @@ func (s *Session) extendedEvalCtx(
 			Txn:              txn,
 			SessionData:      &s.data,
 			ApplicationName:  s.dataMutator.ApplicationName(),
+			BackendPID:       s.pid,
 			TxnState:         getTransactionState(&s.TxnState),
 			TxnReadOnly:      s.TxnState.readOnly,
 			TxnImplicit:      s.TxnState.implicitTxn,
@@ -661,6 +789,7 @@ func (s *Session) extendedEvalCtx(
 		SessionMutator: s.dataMutator,
 		VirtualSchemas: s.execCfg.VirtualSchemas,
 		Tracing:        &s.Tracing,
+		Notices:        &s.Notices,
 		StatusServer:   statusServer,
 		MemMetrics:     s.memMetrics,
 		Tables:         &s.tables,
@@ -837,6 +966,7 @@ func (s *Session) serialize() serverpb.Session {
 		ActiveQueries:   activeQueries,
 		KvTxnID:         kvTxnID,
 		LastActiveQuery: lastActiveQuery,
+		BackendPID:      s.pid,
 	}
 }
 
@@ -1877,6 +2007,18 @@ func (m *sessionDataMutator) SetSafeUpdates(val bool) {
 	m.data.SafeUpdates = val
 }
 
+func (m *sessionDataMutator) SetCurrentCatalogOnly(val bool) {
+	m.data.CurrentCatalogOnly = val
+}
+
+func (m *sessionDataMutator) SetInformationSchemaCompat(val string) {
+	m.data.InformationSchemaCompat = val
+}
+
+func (m *sessionDataMutator) SetShowHiddenColumns(val bool) {
+	m.data.ShowHiddenColumns = val
+}
+
 func (m *sessionDataMutator) SetSearchPath(val sessiondata.SearchPath) {
 	m.data.SearchPath = val
 }
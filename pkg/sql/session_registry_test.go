@@ -0,0 +1,79 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestBackendPIDNodeIDs(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, nodeID := range []roachpb.NodeID{1, 2, maxBackendPIDNodeID - 1, maxBackendPIDNodeID} {
+		r := MakeSessionRegistry()
+		pid, err := r.nextPIDLocked(nodeID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pid <= 0 || pid > 1<<31-1 {
+			t.Errorf("%d: expected a positive int4 pid, got %d", nodeID, pid)
+		}
+		if n := backendPIDNodeID(pid); n != nodeID {
+			t.Errorf("%d: expected pid %d to be on node %d, got %d", nodeID, pid, nodeID, n)
+		}
+	}
+
+	// The IDs of the nodes that don't fit in the pids would be mistaken for
+	// those of other nodes.
+	for _, nodeID := range []roachpb.NodeID{maxBackendPIDNodeID + 1, 1 << 20} {
+		r := MakeSessionRegistry()
+		if _, err := r.nextPIDLocked(nodeID); !testutils.IsError(err, "have no pid") {
+			t.Errorf("%d: expected an error, got %v", nodeID, err)
+		}
+	}
+}
+
+func TestBackendPIDsRunOut(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const nodeID = 3
+	r := MakeSessionRegistry()
+	for i := 0; i < maxBackendPIDSeq; i++ {
+		pid, err := r.nextPIDLocked(nodeID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := r.pids[pid]; ok {
+			t.Fatalf("pid %d assigned twice", pid)
+		}
+		r.pids[pid] = nil
+	}
+	if _, err := r.nextPIDLocked(nodeID); !testutils.IsError(err, "are in use") {
+		t.Fatalf("expected the pids to run out, got %v", err)
+	}
+
+	// The pids of the sessions that end are reused.
+	freed := makeBackendPID(nodeID, 42)
+	delete(r.pids, freed)
+	if pid, err := r.nextPIDLocked(nodeID); err != nil {
+		t.Fatal(err)
+	} else if pid != freed {
+		t.Errorf("expected pid %d, got %d", freed, pid)
+	}
+}
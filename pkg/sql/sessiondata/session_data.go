@@ -49,6 +49,17 @@ type SessionData struct {
 	// SafeUpdates causes errors when the client
 	// sends syntax that may have unwanted side effects.
 	SafeUpdates bool
+	// CurrentCatalogOnly restricts the virtual tables that describe the
+	// schema to the objects of the current database, like the per-database
+	// catalogs of Postgres, unless another database is named explicitly.
+	CurrentCatalogOnly bool
+	// InformationSchemaCompat is the name of the database version whose
+	// conventions information_schema follows. See the
+	// information_schema_compat session variable.
+	InformationSchemaCompat string
+	// ShowHiddenColumns makes information_schema.columns also report the
+	// hidden columns, e.g. the implicit rowid primary key.
+	ShowHiddenColumns bool
 	// SequenceState gives access to the SQL sequences that have been manipulated
 	// by the session.
 	SequenceState *SequenceState
@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 )
 
@@ -98,6 +99,132 @@ func (p *planner) SetVar(ctx context.Context, n *tree.SetVar) (planNode, error)
 	return &setVarNode{v: v, typedValues: typedValues}, nil
 }
 
+// SetSessionVar implements the tree.EvalPlanner interface.
+func (p *planner) SetSessionVar(ctx context.Context, varName, newValue string) (string, error) {
+	v, err := lookupSettableVar(varName)
+	if err != nil {
+		return "", err
+	}
+	if err := setSessionVar(ctx, v, p.sessionDataMutator, &p.extendedEvalCtx, newValue); err != nil {
+		return "", err
+	}
+	return v.Get(&p.extendedEvalCtx), nil
+}
+
+// checkSessionVar returns the error SetSessionVar would return, without
+// changing the session: the value is set on scratch session data instead.
+func (p *planner) checkSessionVar(ctx context.Context, varName, newValue string) error {
+	v, err := lookupSettableVar(varName)
+	if err != nil {
+		return err
+	}
+	m := sessionDataMutator{
+		data:           &sessiondata.SessionData{},
+		defaults:       p.sessionDataMutator.defaults,
+		settings:       p.sessionDataMutator.settings,
+		curTxnReadOnly: new(bool),
+	}
+	return setSessionVar(ctx, v, m, &p.extendedEvalCtx, newValue)
+}
+
+// lookupSettableVar returns the session variable that SetSessionVar can
+// set. Tracing is left to SET TRACING, as it changes more than the session
+// data.
+func lookupSettableVar(varName string) (sessionVar, error) {
+	name := strings.ToLower(varName)
+	v, ok := varGen[name]
+	if !ok {
+		return sessionVar{}, fmt.Errorf("unknown variable: %q", name)
+	}
+	if v.Set == nil {
+		return sessionVar{}, fmt.Errorf("variable \"%s\" cannot be changed", name)
+	}
+	if name == "tracing" {
+		return sessionVar{}, fmt.Errorf("variable \"%s\" can only be changed with SET TRACING", name)
+	}
+	return v, nil
+}
+
+func setSessionVar(
+	ctx context.Context,
+	v sessionVar,
+	m sessionDataMutator,
+	evalCtx *extendedEvalContext,
+	newValue string,
+) error {
+	err := v.Set(ctx, m, evalCtx, []tree.TypedExpr{tree.NewDString(newValue)})
+	if err == nil {
+		return nil
+	}
+	// The boolean variables only accept booleans, which set_config() and
+	// UPDATE pg_settings get as strings like "on" or "true".
+	b, boolErr := tree.ParseDBool(newValue)
+	if boolErr != nil {
+		return err
+	}
+	if boolErr := v.Set(ctx, m, evalCtx, []tree.TypedExpr{b}); boolErr != nil {
+		return err
+	}
+	return nil
+}
+
+// setSessionVarsNode sets the session variables named in the first column
+// of its source to the values in the second column. All the values are
+// checked before any variable is set, so that either all the variables are
+// set or the session is left unchanged. It implements UPDATE pg_settings.
+type setSessionVarsNode struct {
+	source planNode
+
+	run struct {
+		numSet int
+	}
+}
+
+func (n *setSessionVarsNode) startExec(params runParams) error {
+	type setting struct{ name, value string }
+	var settings []setting
+	for {
+		next, err := n.source.Next(params)
+		if err != nil {
+			return err
+		}
+		if !next {
+			break
+		}
+		row := n.source.Values()
+		name, ok := row[0].(*tree.DString)
+		if !ok {
+			return pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError,
+				"invalid variable name: %s", row[0])
+		}
+		value, ok := row[1].(*tree.DString)
+		if !ok {
+			return pgerror.NewErrorf(pgerror.CodeInvalidParameterValueError,
+				"invalid value for variable %s: %s", name, row[1])
+		}
+		settings = append(settings, setting{name: string(*name), value: string(*value)})
+	}
+	for _, s := range settings {
+		if err := params.p.checkSessionVar(params.ctx, s.name, s.value); err != nil {
+			return err
+		}
+	}
+	for _, s := range settings {
+		if _, err := params.p.SetSessionVar(params.ctx, s.name, s.value); err != nil {
+			return err
+		}
+	}
+	n.run.numSet = len(settings)
+	return nil
+}
+
+func (*setSessionVarsNode) Next(runParams) (bool, error) { return false, nil }
+func (*setSessionVarsNode) Values() tree.Datums          { return nil }
+func (n *setSessionVarsNode) Close(ctx context.Context)  { n.source.Close(ctx) }
+
+// FastPathResults implements the planNodeFastPath interface.
+func (n *setSessionVarsNode) FastPathResults() (int, bool) { return n.run.numSet, true }
+
 func (n *setVarNode) startExec(params runParams) error {
 	if n.typedValues != nil {
 		for i, v := range n.typedValues {
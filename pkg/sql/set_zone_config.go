@@ -24,11 +24,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/config"
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 )
 
@@ -214,3 +216,34 @@ func getZoneConfigRaw(
 	}
 	return zone, nil
 }
+
+// getAllZoneConfigsRaw returns the zone configs of system.zones, keyed by
+// their IDs. Like getZoneConfigRaw, it does not ascend the zone config
+// hierarchy; it reads the whole table in a single scan.
+func getAllZoneConfigsRaw(
+	ctx context.Context, txn *client.Txn,
+) (map[sqlbase.ID]config.ZoneConfig, error) {
+	prefix := roachpb.Key(keys.MakeTablePrefix(keys.ZonesTableID))
+	prefix = encoding.EncodeUvarintAscending(prefix, uint64(keys.ZonesTablePrimaryIndexID))
+	kvs, err := txn.Scan(ctx, prefix, prefix.PrefixEnd(), 0 /* maxRows */)
+	if err != nil {
+		return nil, err
+	}
+	zones := make(map[sqlbase.ID]config.ZoneConfig)
+	for _, kv := range kvs {
+		_, id, err := encoding.DecodeUvarintAscending(kv.Key[len(prefix):])
+		if err != nil {
+			return nil, err
+		}
+		// Skip the keys of the other column families.
+		if !kv.Key.Equal(config.MakeZoneKey(uint32(id))) || kv.Value == nil {
+			continue
+		}
+		var zone config.ZoneConfig
+		if err := kv.ValueProto(&zone); err != nil {
+			return nil, err
+		}
+		zones[sqlbase.ID(id)] = zone
+	}
+	return zones, nil
+}
@@ -28,15 +28,15 @@ func (p *planner) ShowColumns(ctx context.Context, n *tree.ShowColumns) (planNod
 	const getColumnsQuery = `
 				SELECT
 					COLUMN_NAME AS "Field",
-					DATA_TYPE AS "Type",
+					CRDB_SQL_TYPE AS "Type",
 					(IS_NULLABLE != 'NO') AS "Null",
 					COLUMN_DEFAULT AS "Default",
 					IF(inames[1] IS NULL, ARRAY[]:::STRING[], inames) AS "Indices"
 				FROM
-					(SELECT COLUMN_NAME, DATA_TYPE, IS_NULLABLE, COLUMN_DEFAULT, ORDINAL_POSITION,
+					(SELECT COLUMN_NAME, CRDB_SQL_TYPE, IS_NULLABLE, COLUMN_DEFAULT, ORDINAL_POSITION,
 									ARRAY_AGG(INDEX_NAME) AS inames
 						 FROM
-								 (SELECT COLUMN_NAME, DATA_TYPE, IS_NULLABLE, COLUMN_DEFAULT, ORDINAL_POSITION
+								 (SELECT COLUMN_NAME, CRDB_SQL_TYPE, IS_NULLABLE, COLUMN_DEFAULT, ORDINAL_POSITION
 										FROM "".information_schema.columns
 									 WHERE TABLE_SCHEMA=%[1]s AND TABLE_NAME=%[2]s)
 								 LEFT OUTER JOIN
@@ -44,7 +44,7 @@ func (p *planner) ShowColumns(ctx context.Context, n *tree.ShowColumns) (planNod
 										FROM "".information_schema.statistics
 									 WHERE TABLE_SCHEMA=%[1]s AND TABLE_NAME=%[2]s)
 								 USING(COLUMN_NAME)
-						GROUP BY COLUMN_NAME, DATA_TYPE, IS_NULLABLE, COLUMN_DEFAULT, ORDINAL_POSITION
+						GROUP BY COLUMN_NAME, CRDB_SQL_TYPE, IS_NULLABLE, COLUMN_DEFAULT, ORDINAL_POSITION
 					 )
 				ORDER BY ORDINAL_POSITION`
 	return p.showTableDetails(ctx, "SHOW COLUMNS", n.Table, getColumnsQuery)
@@ -19,6 +19,9 @@ import (
 	"context"
 	"strings"
 
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/cockroachdb/cockroach/pkg/config"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
@@ -102,7 +105,7 @@ func (p *planner) showCreateView(
 		f.FormatNameP(&desc.Columns[i].Name)
 	}
 	f.WriteString(") AS ")
-	f.WriteString(desc.ViewQuery)
+	f.WriteString(viewDefinition(desc, p.SessionData().SearchPath))
 	return f.CloseAndGetString(), nil
 }
 
@@ -172,7 +175,11 @@ func (p *planner) showCreateSequence(
 // the prefix when the given table references other tables in the
 // current database.
 func (p *planner) showCreateTable(
-	ctx context.Context, tn *tree.Name, dbPrefix string, desc *sqlbase.TableDescriptor,
+	ctx context.Context,
+	tn *tree.Name,
+	dbPrefix string,
+	desc *sqlbase.TableDescriptor,
+	ignoreFKs bool,
 ) (string, error) {
 	a := &sqlbase.DatumAlloc{}
 
@@ -202,7 +209,7 @@ func (p *planner) showCreateTable(
 	allIdx := append(desc.Indexes, desc.PrimaryIndex)
 	for i := range allIdx {
 		idx := &allIdx[i]
-		if fk := &idx.ForeignKey; fk.IsSet() {
+		if fk := &idx.ForeignKey; fk.IsSet() && !ignoreFKs {
 			f.WriteString(",\n\tCONSTRAINT ")
 			f.FormatNameP(&fk.Name)
 			f.WriteString(" ")
@@ -267,6 +274,95 @@ func (p *planner) showCreateTable(
 	return f.CloseAndGetString(), nil
 }
 
+// showForeignKeyAlters returns the ALTER TABLE statements adding the foreign
+// key constraints of the given table, which the CREATE TABLE statement
+// returned by showCreateTable with ignoreFKs omits. This allows the tables to
+// be created in any order, the constraints being added once all of them
+// exist.
+//
+// The names of the referenced tables are prefixed as in showCreateTable.
+func (p *planner) showForeignKeyAlters(
+	ctx context.Context, tn *tree.Name, dbPrefix string, desc *sqlbase.TableDescriptor,
+) ([]string, error) {
+	var stmts []string
+	allIdx := append(desc.Indexes, desc.PrimaryIndex)
+	for i := range allIdx {
+		idx := &allIdx[i]
+		if fk := &idx.ForeignKey; fk.IsSet() {
+			f := tree.NewFmtCtxWithBuf(tree.FmtSimple)
+			f.WriteString("ALTER TABLE ")
+			f.FormatNode(tn)
+			f.WriteString(" ADD CONSTRAINT ")
+			f.FormatNameP(&fk.Name)
+			f.WriteString(" ")
+			if err := p.printForeignKeyConstraint(ctx, f.Buffer, dbPrefix, idx); err != nil {
+				return nil, err
+			}
+			stmts = append(stmts, f.CloseAndGetString())
+		}
+	}
+	return stmts, nil
+}
+
+// showZoneConfigStmts returns the statements setting the zone configs of the
+// given table, its indexes and their partitions, in this order. zones are the
+// zone configs of system.zones, as returned by getAllZoneConfigsRaw. The
+// subzones of the indexes that were dropped are skipped.
+func showZoneConfigStmts(
+	tn *tree.Name, desc *sqlbase.TableDescriptor, zones map[sqlbase.ID]config.ZoneConfig,
+) ([]string, error) {
+	if !desc.IsTable() || desc.IsVirtualTable() {
+		return nil, nil
+	}
+	zone, ok := zones[desc.ID]
+	if !ok {
+		return nil, nil
+	}
+	var stmts []string
+	configure := func(f *tree.FmtCtx, cfg interface{}) error {
+		configYAML, err := yaml.Marshal(cfg)
+		if err != nil {
+			return err
+		}
+		f.WriteString(" EXPERIMENTAL CONFIGURE ZONE ")
+		f.FormatNode(tree.NewDString(string(configYAML)))
+		stmts = append(stmts, f.CloseAndGetString())
+		return nil
+	}
+	if !zone.IsSubzonePlaceholder() {
+		f := tree.NewFmtCtxWithBuf(tree.FmtSimple)
+		f.WriteString("ALTER TABLE ")
+		f.FormatNode(tn)
+		if err := configure(f, zone); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range zone.Subzones {
+		index, err := desc.FindIndexByID(sqlbase.IndexID(s.IndexID))
+		if err != nil {
+			// The index was dropped, but the schema changer hasn't yet
+			// removed its subzones.
+			continue
+		}
+		f := tree.NewFmtCtxWithBuf(tree.FmtSimple)
+		if s.PartitionName == "" {
+			f.WriteString("ALTER INDEX ")
+			f.FormatNode(tn)
+			f.WriteString("@")
+			f.FormatNameP(&index.Name)
+		} else {
+			f.WriteString("ALTER PARTITION ")
+			f.FormatNameP(&s.PartitionName)
+			f.WriteString(" OF TABLE ")
+			f.FormatNode(tn)
+		}
+		if err := configure(f, s.Config); err != nil {
+			return nil, err
+		}
+	}
+	return stmts, nil
+}
+
 // formatQuoteNames quotes and adds commas between names.
 func formatQuoteNames(buf *bytes.Buffer, names ...string) {
 	f := tree.MakeFmtCtx(buf, tree.FmtSimple)
@@ -0,0 +1,114 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/config"
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestShowZoneConfigStmts(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	desc := &sqlbase.TableDescriptor{
+		ID:           51,
+		Name:         "t",
+		PrimaryIndex: sqlbase.IndexDescriptor{ID: 1, Name: "primary"},
+		Indexes:      []sqlbase.IndexDescriptor{{ID: 2, Name: "i"}},
+	}
+	zones := map[sqlbase.ID]config.ZoneConfig{
+		51: {
+			NumReplicas: 3,
+			Subzones: []config.Subzone{
+				{IndexID: 2, Config: config.ZoneConfig{NumReplicas: 5}},
+				// Index 3 was dropped, but its subzone wasn't removed yet.
+				{IndexID: 3, Config: config.ZoneConfig{NumReplicas: 7}},
+				{IndexID: 1, PartitionName: "p", Config: config.ZoneConfig{NumReplicas: 1}},
+			},
+		},
+	}
+	stmts, err := showZoneConfigStmts((*tree.Name)(&desc.Name), desc, zones)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"ALTER TABLE t EXPERIMENTAL CONFIGURE ZONE",
+		"ALTER INDEX t@i EXPERIMENTAL CONFIGURE ZONE",
+		"ALTER PARTITION p OF TABLE t EXPERIMENTAL CONFIGURE ZONE",
+	}
+	if len(stmts) != len(expected) {
+		t.Fatalf("expected %d statements, got %q", len(expected), stmts)
+	}
+	for i, stmt := range stmts {
+		if !strings.HasPrefix(stmt, expected[i]) {
+			t.Errorf("%d: expected %q to start with %q", i, stmt, expected[i])
+		}
+	}
+
+	// Without a zone config, there are no statements.
+	if stmts, err := showZoneConfigStmts((*tree.Name)(&desc.Name), desc, nil); err != nil {
+		t.Fatal(err)
+	} else if len(stmts) != 0 {
+		t.Errorf("expected no statements, got %q", stmts)
+	}
+}
+
+func TestGetAllZoneConfigsRaw(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	s, db, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE DATABASE d`)
+	sqlDB.Exec(t, `CREATE TABLE d.t (a INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `ALTER TABLE d.t EXPERIMENTAL CONFIGURE ZONE 'num_replicas: 1'`)
+	desc := sqlbase.GetTableDescriptor(kvDB, "d", "t")
+
+	if err := kvDB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		zones, err := getAllZoneConfigsRaw(ctx, txn)
+		if err != nil {
+			return err
+		}
+		var count int
+		sqlDB.QueryRow(t, `SELECT count(*) FROM system.zones`).Scan(&count)
+		if len(zones) != count {
+			t.Errorf("expected %d zone configs, got %d", count, len(zones))
+		}
+		for _, id := range []sqlbase.ID{0, desc.ID} {
+			expected, err := getZoneConfigRaw(ctx, txn, id)
+			if err != nil {
+				return err
+			}
+			if zone, ok := zones[id]; !ok || !zone.Equal(&expected) {
+				t.Errorf("%d: expected %+v, got %+v", id, expected, zone)
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -41,10 +41,13 @@ func (p *planner) ShowGrants(ctx context.Context, n *tree.ShowGrants) (planNode,
 	var params []string
 	var initCheck func(context.Context) error
 
+	// Grants that have expired are not shown. They remain visible in
+	// information_schema until they are revoked.
+	const notExpiredCond = `WHERE EXPIRES_AT IS NULL OR EXPIRES_AT > now()`
 	const dbPrivQuery = `SELECT TABLE_SCHEMA AS "Database", GRANTEE AS "User", PRIVILEGE_TYPE AS "Privileges" ` +
-		`FROM "".information_schema.schema_privileges`
+		`FROM "".information_schema.schema_privileges ` + notExpiredCond
 	const tablePrivQuery = `SELECT TABLE_SCHEMA AS "Database", TABLE_NAME AS "Table", GRANTEE AS "User", PRIVILEGE_TYPE AS "Privileges" ` +
-		`FROM "".information_schema.table_privileges`
+		`FROM "".information_schema.table_privileges ` + notExpiredCond
 
 	var source bytes.Buffer
 	var cond bytes.Buffer
@@ -12,12 +12,13 @@
 // implied. See the License for the specific language governing
 // permissions and limitations under the License.
 
-// This file implements the SHOW TESTING_RANGES statement:
-//   SHOW TESTING_RANGES FROM TABLE t
-//   SHOW TESTING_RANGES FROM INDEX t@idx
+// This file implements the SHOW EXPERIMENTAL_RANGES statement:
+//   SHOW EXPERIMENTAL_RANGES FROM TABLE t
+//   SHOW EXPERIMENTAL_RANGES FROM INDEX t@idx
 //
 // These statements show the ranges corresponding to the given table or index,
-// along with the list of replicas and the lease holder.
+// along with the list of replicas and the lease holder. SHOW TESTING_RANGES is
+// an alias.
 
 package sql
 
@@ -43,9 +44,11 @@ type showRangesNode struct {
 	run showRangesRun
 }
 
+// ShowRanges returns the ranges of a table or index. Only the meta keys of
+// the ranges touching its span are scanned.
+// Privileges: SELECT on table.
 func (p *planner) ShowRanges(ctx context.Context, n *tree.ShowRanges) (planNode, error) {
 	tableDesc, index, err := p.getTableAndIndex(ctx, n.Table, n.Index, privilege.SELECT)
-
 	if err != nil {
 		return nil, err
 	}
@@ -118,14 +121,14 @@ func (n *showRangesNode) Next(params runParams) (bool, error) {
 	// We do not attempt to identify the encoding directions for pretty
 	// printing a split key since it's possible for a key from an arbitrary
 	// table in the same interleaved hierarchy to appear in SHOW
-	// TESTING_RANGE. Consider the interleaved hierarchy
+	// EXPERIMENTAL_RANGES. Consider the interleaved hierarchy
 	//    parent1		      (pid1)
 	//	  child1	      (pid1, cid1)
 	//	      grandchild1     (pid1, cid1, gcid1)
 	//	  child2	      (pid1, cid2, cid3)
 	//	      grandchild2     (pid1, cid2, cid3, gcid2)
 	// and the result of
-	//    SHOW TESTING_RANGES FROM TABLE grandchild1
+	//    SHOW EXPERIMENTAL_RANGES FROM TABLE grandchild1
 	// It is possible for a split key for grandchild2 to show up.
 	// Traversing up the InterleaveDescriptor is futile since we do not
 	// know the SharedPrefixLen in between each interleaved sentinel of a
@@ -145,33 +148,21 @@ func (n *showRangesNode) Next(params runParams) (bool, error) {
 
 	n.run.values[2] = tree.NewDInt(tree.DInt(desc.RangeID))
 
-	var replicas []int
-	for _, rd := range desc.Replicas {
-		replicas = append(replicas, int(rd.StoreID))
-	}
-	sort.Ints(replicas)
-
+	replicas := sortedReplicas(&desc)
 	replicaArr := tree.NewDArray(types.Int)
 	replicaArr.Array = make(tree.Datums, len(replicas))
 	for i, r := range replicas {
-		replicaArr.Array[i] = tree.NewDInt(tree.DInt(r))
+		replicaArr.Array[i] = tree.NewDInt(tree.DInt(r.StoreID))
 	}
 	n.run.values[3] = replicaArr
 
-	// Get the lease holder.
 	// TODO(radu): this will be slow if we have a lot of ranges; find a way to
 	// make this part optional.
-	b := &client.Batch{}
-	b.AddRawRequest(&roachpb.LeaseInfoRequest{
-		Span: roachpb.Span{
-			Key: desc.StartKey.AsRawKey(),
-		},
-	})
-	if err := params.p.txn.Run(params.ctx, b); err != nil {
-		return false, errors.Wrap(err, "error getting lease info")
+	leaseHolder, err := rangeLeaseHolder(params.ctx, params.p.txn, desc.StartKey)
+	if err != nil {
+		return false, err
 	}
-	resp := b.RawResponse().Responses[0].GetInner().(*roachpb.LeaseInfoResponse)
-	n.run.values[4] = tree.NewDInt(tree.DInt(resp.Lease.Replica.StoreID))
+	n.run.values[4] = tree.NewDInt(tree.DInt(leaseHolder.StoreID))
 
 	n.run.rowIdx++
 	return true, nil
@@ -185,6 +176,35 @@ func (n *showRangesNode) Close(_ context.Context) {
 	n.run.descriptorKVs = nil
 }
 
+// rangeLeaseHolder returns the replica holding the lease of the range that
+// starts at startKey.
+func rangeLeaseHolder(
+	ctx context.Context, txn *client.Txn, startKey roachpb.RKey,
+) (roachpb.ReplicaDescriptor, error) {
+	b := &client.Batch{}
+	b.AddRawRequest(&roachpb.LeaseInfoRequest{
+		Span: roachpb.Span{
+			Key: startKey.AsRawKey(),
+		},
+	})
+	if err := txn.Run(ctx, b); err != nil {
+		return roachpb.ReplicaDescriptor{}, errors.Wrap(err, "error getting lease info")
+	}
+	resp := b.RawResponse().Responses[0].GetInner().(*roachpb.LeaseInfoResponse)
+	return resp.Lease.Replica, nil
+}
+
+// sortedReplicas returns the replicas of the range in increasing order of
+// store ID, the order in which SHOW EXPERIMENTAL_RANGES and
+// crdb_internal.ranges list them.
+func sortedReplicas(desc *roachpb.RangeDescriptor) []roachpb.ReplicaDescriptor {
+	replicas := append([]roachpb.ReplicaDescriptor(nil), desc.Replicas...)
+	sort.Slice(replicas, func(i, j int) bool {
+		return replicas[i].StoreID < replicas[j].StoreID
+	})
+	return replicas
+}
+
 // scanMetaKVs returns the meta KVs for the ranges that touch the given span.
 func scanMetaKVs(
 	ctx context.Context, txn *client.Txn, span roachpb.Span,
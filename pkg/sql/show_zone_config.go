@@ -16,132 +16,62 @@ package sql
 
 import (
 	"context"
-
-	yaml "gopkg.in/yaml.v2"
+	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/config"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
-	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
-	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
-	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 )
 
-type showZoneConfigNode struct {
-	optColumnsSlot
-	zoneSpecifier tree.ZoneSpecifier
-
-	run showZoneConfigRun
-}
-
 func (p *planner) ShowZoneConfig(ctx context.Context, n *tree.ShowZoneConfig) (planNode, error) {
 	if n.ZoneSpecifier == (tree.ZoneSpecifier{}) {
-		return p.delegateQuery(ctx, "SHOW ZONE CONFIGURATIONS", "TABLE crdb_internal.zones", nil, nil)
+		return p.delegateQuery(ctx, "SHOW ZONE CONFIGURATIONS",
+			`SELECT id, cli_specifier, config_yaml, config_proto FROM crdb_internal.zones`, nil, nil)
 	}
-	return &showZoneConfigNode{
-		zoneSpecifier: n.ZoneSpecifier,
-	}, nil
-}
 
-// These should match crdb_internal.zones.
-var showZoneConfigNodeColumns = sqlbase.ResultColumns{
-	{
-		Name: "id",
-		Typ:  types.Int,
-	},
-	{
-		Name: "cli_specifier",
-		Typ:  types.String,
-	},
-	{
-		Name: "config_yaml",
-		Typ:  types.Bytes,
-	},
-	{
-		Name: "config_proto",
-		Typ:  types.Bytes,
-	},
-}
-
-// showZoneConfigRun contains the run-time state of showZoneConfigNode
-// during local execution.
-type showZoneConfigRun struct {
-	zoneID       uint32
-	cliSpecifier string
-	protoConfig  []byte
-	yamlConfig   []byte
-	done         bool
-}
-
-func (n *showZoneConfigNode) startExec(params runParams) error {
-	if n.zoneSpecifier.TargetsIndex() {
-		_, err := params.p.expandIndexName(params.ctx, &n.zoneSpecifier.TableOrIndex, true /* requireTable */)
+	zs := n.ZoneSpecifier
+	if zs.TargetsIndex() {
+		_, err := p.expandIndexName(ctx, &zs.TableOrIndex, true /* requireTable */)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	targetID, err := resolveZone(
-		params.ctx, params.p.txn, &n.zoneSpecifier, params.SessionData().Database)
+	targetID, err := resolveZone(ctx, p.txn, &zs, p.SessionData().Database)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, index, partition, err := resolveSubzone(params.ctx, params.p.txn, &n.zoneSpecifier, targetID)
+	_, index, partition, err := resolveSubzone(ctx, p.txn, &zs, targetID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	zoneID, zone, subzone, err := GetZoneConfigInTxn(params.ctx, params.p.txn,
+	zoneID, _, subzone, err := GetZoneConfigInTxn(ctx, p.txn,
 		uint32(targetID), index, partition)
 	if err == errNoZoneConfigApplies {
 		// TODO(benesch): This shouldn't be the caller's responsibility;
 		// GetZoneConfigInTxn should just return the default zone config if no zone
 		// config applies.
-		zone = config.DefaultZoneConfig()
 		zoneID = keys.RootNamespaceID
 	} else if err != nil {
-		return err
-	} else if subzone != nil {
-		zone = subzone.Config
+		return nil, err
 	}
-	n.run.zoneID = zoneID
-
-	// Determine the CLI specifier for the zone config that actually applies
-	// without performing another KV lookup.
-	zs := ascendZoneSpecifier(n.zoneSpecifier, uint32(targetID), zoneID, subzone)
-	n.run.cliSpecifier = config.CLIZoneSpecifier(&zs)
 
-	// Ensure subzone configs don't infect the output of config_bytes.
-	zone.Subzones = nil
-	zone.SubzoneSpans = nil
-	n.run.protoConfig, err = protoutil.Marshal(&zone)
-	if err != nil {
-		return err
-	}
-	n.run.yamlConfig, err = yaml.Marshal(zone)
-	return err
-}
-
-func (n *showZoneConfigNode) Next(runParams) (bool, error) {
-	if !n.run.done {
-		n.run.done = true
-		return true, nil
-	}
-	return false, nil
+	// Determine the zone config that actually applies without performing
+	// another KV lookup, and show its row of crdb_internal.zones.
+	zs = ascendZoneSpecifier(zs, uint32(targetID), zoneID, subzone)
+	target := zoneTargetDatums(&zs)
+	return p.delegateQuery(ctx, "SHOW ZONE CONFIGURATION",
+		fmt.Sprintf(`SELECT id, cli_specifier, config_yaml, config_proto FROM crdb_internal.zones
+WHERE id = %d
+  AND target IS NOT DISTINCT FROM %s
+  AND index_name IS NOT DISTINCT FROM %s
+  AND partition_name IS NOT DISTINCT FROM %s`,
+			zoneID, target[0], target[3], target[4]),
+		nil, nil)
 }
 
-func (n *showZoneConfigNode) Values() tree.Datums {
-	return tree.Datums{
-		tree.NewDInt(tree.DInt(n.run.zoneID)),
-		tree.NewDString(n.run.cliSpecifier),
-		tree.NewDBytes(tree.DBytes(n.run.yamlConfig)),
-		tree.NewDBytes(tree.DBytes(n.run.protoConfig)),
-	}
-}
-
-func (*showZoneConfigNode) Close(context.Context) {}
-
 // ascendZoneSpecifier logically ascends the zone hierarchy for the zone
 // specified by (zs, resolvedID) until the zone matching actualID is found, and
 // returns that zone's specifier. Results are undefined if actualID is not in
@@ -18,15 +18,160 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 )
 
 func isPrivilegeSet(bits uint32, priv privilege.Kind) bool {
 	return bits&priv.Mask() != 0
 }
 
+// expiration returns the expiration time of the given privilege, in
+// nanoseconds since the Unix epoch. It returns false if the privilege
+// does not expire.
+func (u *UserPrivileges) expiration(priv privilege.Kind) (int64, bool) {
+	for _, e := range u.Expirations {
+		if privilege.Kind(e.Privilege) == priv {
+			return e.ExpiresAt, true
+		}
+	}
+	return 0, false
+}
+
+// setExpiration sets the expiration time of the given privilege, keeping
+// Expirations sorted by privilege.
+func (u *UserPrivileges) setExpiration(priv privilege.Kind, expiresAt int64) {
+	idx := sort.Search(len(u.Expirations), func(i int) bool {
+		return privilege.Kind(u.Expirations[i].Privilege) >= priv
+	})
+	if idx < len(u.Expirations) && privilege.Kind(u.Expirations[idx].Privilege) == priv {
+		u.Expirations[idx].ExpiresAt = expiresAt
+		return
+	}
+	u.Expirations = append(u.Expirations, PrivilegeExpiration{})
+	copy(u.Expirations[idx+1:], u.Expirations[idx:])
+	u.Expirations[idx] = PrivilegeExpiration{Privilege: uint32(priv), ExpiresAt: expiresAt}
+}
+
+// clearExpirations removes the expiration times of the privileges in the
+// given bitfield.
+func (u *UserPrivileges) clearExpirations(bits uint32) {
+	remaining := u.Expirations[:0]
+	for _, e := range u.Expirations {
+		if !isPrivilegeSet(bits, privilege.Kind(e.Privilege)) {
+			remaining = append(remaining, e)
+		}
+	}
+	u.Expirations = remaining
+	if len(u.Expirations) == 0 {
+		u.Expirations = nil
+	}
+}
+
+// grantor returns the user who granted the given privilege. It returns
+// false if the grantor was not recorded.
+func (u *UserPrivileges) grantor(priv privilege.Kind) (string, bool) {
+	for _, g := range u.Grantors {
+		if privilege.Kind(g.Privilege) == priv {
+			return g.Grantor, true
+		}
+	}
+	return "", false
+}
+
+// setGrantor records the user who granted the given privilege, keeping
+// Grantors sorted by privilege.
+func (u *UserPrivileges) setGrantor(priv privilege.Kind, grantor string) {
+	idx := sort.Search(len(u.Grantors), func(i int) bool {
+		return privilege.Kind(u.Grantors[i].Privilege) >= priv
+	})
+	if idx < len(u.Grantors) && privilege.Kind(u.Grantors[idx].Privilege) == priv {
+		u.Grantors[idx].Grantor = grantor
+		return
+	}
+	u.Grantors = append(u.Grantors, PrivilegeGrantor{})
+	copy(u.Grantors[idx+1:], u.Grantors[idx:])
+	u.Grantors[idx] = PrivilegeGrantor{Privilege: uint32(priv), Grantor: grantor}
+}
+
+// clearGrantors removes the grantors of the privileges in the given
+// bitfield.
+func (u *UserPrivileges) clearGrantors(bits uint32) {
+	remaining := u.Grantors[:0]
+	for _, g := range u.Grantors {
+		if !isPrivilegeSet(bits, privilege.Kind(g.Privilege)) {
+			remaining = append(remaining, g)
+		}
+	}
+	u.Grantors = remaining
+	if len(u.Grantors) == 0 {
+		u.Grantors = nil
+	}
+}
+
+// add grants the given privilege. expiresAt is the expiration time in
+// nanoseconds since the Unix epoch, or 0 if the privilege does not expire.
+// A privilege that is already held is kept for the longer of the two
+// durations.
+func (u *UserPrivileges) add(priv privilege.Kind, expiresAt int64) {
+	if isPrivilegeSet(u.Privileges, priv) {
+		cur, ok := u.expiration(priv)
+		if !ok {
+			// Already held permanently.
+			return
+		}
+		if expiresAt != 0 && cur >= expiresAt {
+			// Already held for longer.
+			return
+		}
+	}
+	u.Privileges |= priv.Mask()
+	if expiresAt == 0 {
+		u.clearExpirations(priv.Mask())
+	} else {
+		u.setExpiration(priv, expiresAt)
+	}
+}
+
+// expandAllGrantOption replaces the grant option for ALL by grant options
+// for all the other privileges, so that they can be removed individually.
+func (u *UserPrivileges) expandAllGrantOption() {
+	if !isPrivilegeSet(u.GrantOptions, privilege.ALL) {
+		return
+	}
+	u.GrantOptions = 0
+	for _, v := range privilege.ByValue {
+		if v != privilege.ALL {
+			u.GrantOptions |= v.Mask()
+		}
+	}
+}
+
+// hasPermanentAll returns true if the user holds the ALL privilege without an
+// expiration time.
+func (u *UserPrivileges) hasPermanentAll() bool {
+	if !isPrivilegeSet(u.Privileges, privilege.ALL) {
+		return false
+	}
+	_, expires := u.expiration(privilege.ALL)
+	return !expires
+}
+
+// effectivePrivileges returns the privileges bitfield without the privileges
+// that have expired at the given time.
+func (u *UserPrivileges) effectivePrivileges(now time.Time) uint32 {
+	bits := u.Privileges
+	for _, e := range u.Expirations {
+		if e.ExpiresAt <= now.UnixNano() {
+			bits &^= privilege.Kind(e.Privilege).Mask()
+		}
+	}
+	return bits
+}
+
 // findUserIndex looks for a given user and returns its
 // index in the User array if found. Returns -1 otherwise.
 func (p PrivilegeDescriptor) findUserIndex(user string) int {
@@ -129,7 +274,7 @@ func NewDefaultPrivilegeDescriptor() *PrivilegeDescriptor {
 // them into ALL?
 func (p *PrivilegeDescriptor) Grant(user string, privList privilege.List) {
 	userPriv := p.findOrCreateUser(user)
-	if isPrivilegeSet(userPriv.Privileges, privilege.ALL) {
+	if userPriv.hasPermanentAll() {
 		// User already has 'ALL' privilege: no-op.
 		return
 	}
@@ -140,9 +285,75 @@ func (p *PrivilegeDescriptor) Grant(user string, privList privilege.List) {
 		// TODO(marc): the grammar does not allow it, but we should
 		// check if other privileges are being specified and error out.
 		userPriv.Privileges = privilege.ALL.Mask()
+		userPriv.Expirations = nil
+		userPriv.Grantors = nil
+		return
+	}
+	for _, priv := range privList {
+		userPriv.add(priv, 0 /* expiresAt */)
+	}
+}
+
+// GrantUntil is like Grant, but the new privileges expire at the given time.
+// Privileges the user already holds permanently, or until a later time, are
+// left untouched.
+func (p *PrivilegeDescriptor) GrantUntil(
+	user string, privList privilege.List, expiresAt time.Time,
+) {
+	userPriv := p.findOrCreateUser(user)
+	if userPriv.hasPermanentAll() {
+		// User already has 'ALL' privilege: no-op.
+		return
+	}
+	for _, priv := range privList {
+		userPriv.add(priv, expiresAt.UnixNano())
+	}
+}
+
+// SetGrantOption marks the privileges in privList as held by user WITH
+// GRANT OPTION, allowing user to grant them to others. Privileges that the
+// user does not hold are ignored.
+func (p *PrivilegeDescriptor) SetGrantOption(user string, privList privilege.List) {
+	userPriv, ok := p.findUser(user)
+	if !ok {
+		return
+	}
+	holdsAll := isPrivilegeSet(userPriv.Privileges, privilege.ALL)
+	for _, priv := range privList {
+		if holdsAll || isPrivilegeSet(userPriv.Privileges, priv) {
+			userPriv.GrantOptions |= priv.Mask()
+		}
+	}
+}
+
+// RevokeGrantOption removes the grant option for the privileges in privList
+// from user, who keeps holding the privileges themselves.
+func (p *PrivilegeDescriptor) RevokeGrantOption(user string, privList privilege.List) {
+	userPriv, ok := p.findUser(user)
+	if !ok {
+		return
+	}
+	bits := privList.ToBitField()
+	if isPrivilegeSet(bits, privilege.ALL) {
+		userPriv.GrantOptions = 0
+		return
+	}
+	userPriv.expandAllGrantOption()
+	userPriv.GrantOptions &^= bits
+}
+
+// SetGrantor records grantor as the user who granted the privileges in
+// privList to user. Privileges that the user does not hold are ignored.
+func (p *PrivilegeDescriptor) SetGrantor(user string, privList privilege.List, grantor string) {
+	userPriv, ok := p.findUser(user)
+	if !ok {
 		return
 	}
-	userPriv.Privileges |= bits
+	for _, priv := range privList {
+		if isPrivilegeSet(userPriv.Privileges, priv) {
+			userPriv.setGrantor(priv, grantor)
+		}
+	}
 }
 
 // Revoke removes privileges from this descriptor for a given list of users.
@@ -164,17 +375,29 @@ func (p *PrivilegeDescriptor) Revoke(user string, privList privilege.List) {
 
 	if isPrivilegeSet(userPriv.Privileges, privilege.ALL) {
 		// User has 'ALL' privilege. Remove it and set
-		// all other privileges one.
-		userPriv.Privileges = 0
+		// all other privileges one, with the same expiration
+		// and grantor.
+		allExpiresAt, _ := userPriv.expiration(privilege.ALL)
+		allGrantor, hasGrantor := userPriv.grantor(privilege.ALL)
+		userPriv.Privileges &^= privilege.ALL.Mask()
+		userPriv.clearExpirations(privilege.ALL.Mask())
+		userPriv.clearGrantors(privilege.ALL.Mask())
+		userPriv.expandAllGrantOption()
 		for _, v := range privilege.ByValue {
 			if v != privilege.ALL {
-				userPriv.Privileges |= v.Mask()
+				userPriv.add(v, allExpiresAt)
+				if hasGrantor {
+					userPriv.setGrantor(v, allGrantor)
+				}
 			}
 		}
 	}
 
 	// One doesn't see "AND NOT" very often.
 	userPriv.Privileges &^= bits
+	userPriv.clearExpirations(bits)
+	userPriv.clearGrantors(bits)
+	userPriv.GrantOptions &^= bits
 
 	if userPriv.Privileges == 0 {
 		p.removeUser(user)
@@ -192,6 +415,14 @@ func (p PrivilegeDescriptor) Validate(id ID) error {
 	if !ok {
 		return fmt.Errorf("user %s does not have privileges", security.RootUser)
 	}
+	if len(rootPriv.Expirations) > 0 {
+		return fmt.Errorf("user %s must not have expiring privileges", security.RootUser)
+	}
+	for _, u := range p.Users {
+		if err := u.validate(); err != nil {
+			return err
+		}
+	}
 	if IsReservedID(id) {
 		// System databases and tables have custom maximum allowed privileges.
 		allowedPrivileges, ok := SystemAllowedPrivileges[id]
@@ -222,6 +453,51 @@ func (p PrivilegeDescriptor) Validate(id ID) error {
 	return nil
 }
 
+// ColumnPrivileges is the list of privileges that can be granted on
+// individual columns.
+var ColumnPrivileges = privilege.List{privilege.SELECT, privilege.INSERT, privilege.UPDATE}
+
+// ValidateColumn is called when writing a table descriptor with privileges
+// granted on one of its columns. Unlike table privileges, column privileges
+// are only held by the users they were granted to.
+func (p PrivilegeDescriptor) ValidateColumn() error {
+	allowed := ColumnPrivileges.ToBitField()
+	for _, u := range p.Users {
+		if remaining := u.Privileges &^ allowed; remaining != 0 {
+			return fmt.Errorf("user %s must not have %s privileges on a column",
+				u.User, privilege.ListFromBitField(remaining))
+		}
+		if err := u.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks that the expirations, grant options and grantors only
+// refer to privileges that the user holds.
+func (u UserPrivileges) validate() error {
+	for _, e := range u.Expirations {
+		if !isPrivilegeSet(u.Privileges, privilege.Kind(e.Privilege)) {
+			return fmt.Errorf("user %s has an expiration for %s privilege it does not hold",
+				u.User, privilege.Kind(e.Privilege))
+		}
+	}
+	if !isPrivilegeSet(u.Privileges, privilege.ALL) {
+		if remaining := u.GrantOptions &^ u.Privileges; remaining != 0 {
+			return fmt.Errorf("user %s has the grant option for %s privileges it does not hold",
+				u.User, privilege.ListFromBitField(remaining))
+		}
+	}
+	for _, g := range u.Grantors {
+		if !isPrivilegeSet(u.Privileges, privilege.Kind(g.Privilege)) {
+			return fmt.Errorf("user %s has a grantor for %s privilege it does not hold",
+				u.User, privilege.Kind(g.Privilege))
+		}
+	}
+	return nil
+}
+
 // UserPrivilegeString is a pair of strings describing the
 // privileges for a given user.
 type UserPrivilegeString struct {
@@ -247,25 +523,78 @@ func (p PrivilegeDescriptor) Show() []UserPrivilegeString {
 	return ret
 }
 
+// Expiration returns the time at which 'privilege' of 'user' expires. It
+// returns false if the user does not hold the privilege or holds it
+// permanently.
+func (p PrivilegeDescriptor) Expiration(user string, priv privilege.Kind) (time.Time, bool) {
+	userPriv, ok := p.findUser(user)
+	if !ok || !isPrivilegeSet(userPriv.Privileges, priv) {
+		return time.Time{}, false
+	}
+	expiresAt, ok := userPriv.expiration(priv)
+	if !ok {
+		return time.Time{}, false
+	}
+	return timeutil.Unix(0, expiresAt), true
+}
+
+// Grantor returns the user who granted 'privilege' to 'user'. It returns
+// false if the user does not hold the privilege or its grantor was not
+// recorded, e.g. because the privilege was granted when the object was
+// created.
+func (p PrivilegeDescriptor) Grantor(user string, priv privilege.Kind) (string, bool) {
+	userPriv, ok := p.findUser(user)
+	if !ok || !isPrivilegeSet(userPriv.Privileges, priv) {
+		return "", false
+	}
+	return userPriv.grantor(priv)
+}
+
+// CheckGrantOption returns true if 'user' holds 'privilege' on this
+// descriptor WITH GRANT OPTION. Privileges that have expired are not taken
+// into account.
+func (p PrivilegeDescriptor) CheckGrantOption(user string, priv privilege.Kind) bool {
+	userPriv, ok := p.findUser(user)
+	if !ok || !p.CheckPrivilege(user, priv) {
+		return false
+	}
+	// The grant option for ALL is good for every privilege.
+	return isPrivilegeSet(userPriv.GrantOptions, privilege.ALL) ||
+		isPrivilegeSet(userPriv.GrantOptions, priv)
+}
+
 // CheckPrivilege returns true if 'user' has 'privilege' on this descriptor.
+// Privileges that have expired are not taken into account.
 func (p PrivilegeDescriptor) CheckPrivilege(user string, priv privilege.Kind) bool {
+	return p.checkPrivilegeAt(user, priv, timeutil.Now())
+}
+
+func (p PrivilegeDescriptor) checkPrivilegeAt(
+	user string, priv privilege.Kind, now time.Time,
+) bool {
 	userPriv, ok := p.findUser(user)
 	if !ok {
 		// User "node" has all privileges.
 		return user == security.NodeUser
 	}
+	bits := userPriv.effectivePrivileges(now)
 	// ALL is always good.
-	if isPrivilegeSet(userPriv.Privileges, privilege.ALL) {
+	if isPrivilegeSet(bits, privilege.ALL) {
 		return true
 	}
-	return isPrivilegeSet(userPriv.Privileges, priv)
+	return isPrivilegeSet(bits, priv)
 }
 
 // AnyPrivilege returns true if 'user' has any privilege on this descriptor.
+// Privileges that have expired are not taken into account.
 func (p PrivilegeDescriptor) AnyPrivilege(user string) bool {
+	return p.anyPrivilegeAt(user, timeutil.Now())
+}
+
+func (p PrivilegeDescriptor) anyPrivilegeAt(user string, now time.Time) bool {
 	userPriv, ok := p.findUser(user)
 	if !ok {
 		return false
 	}
-	return userPriv.Privileges != 0
+	return userPriv.effectivePrivileges(now) != 0
 }
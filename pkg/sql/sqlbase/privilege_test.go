@@ -16,12 +16,14 @@ package sqlbase
 
 import (
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 )
 
 func TestPrivilege(t *testing.T) {
@@ -189,6 +191,224 @@ func TestAnyPrivilege(t *testing.T) {
 	}
 }
 
+func TestPrivilegeExpiration(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	now := timeutil.Unix(1000, 0)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	farFuture := now.Add(24 * time.Hour)
+
+	descriptor := NewDefaultPrivilegeDescriptor()
+	descriptor.GrantUntil("foo", privilege.List{privilege.SELECT}, future)
+	descriptor.GrantUntil("foo", privilege.List{privilege.INSERT}, past)
+
+	checkPrivileges := func(user string, exp map[privilege.Kind]bool) {
+		t.Helper()
+		for priv, e := range exp {
+			if found := descriptor.checkPrivilegeAt(user, priv, now); found != e {
+				t.Errorf("checkPrivilegeAt(%s, %v) for descriptor %+v = %t, expected %t",
+					user, priv, descriptor, found, e)
+			}
+		}
+	}
+	checkExpiration := func(user string, priv privilege.Kind, exp time.Time, expOk bool) {
+		t.Helper()
+		if ts, ok := descriptor.Expiration(user, priv); ok != expOk || !ts.Equal(exp) {
+			t.Errorf("Expiration(%s, %v) = %s, %t, expected %s, %t", user, priv, ts, ok, exp, expOk)
+		}
+	}
+
+	checkPrivileges("foo", map[privilege.Kind]bool{privilege.SELECT: true, privilege.INSERT: false})
+	checkExpiration("foo", privilege.SELECT, future, true)
+	checkExpiration("foo", privilege.INSERT, past, true)
+	if err := descriptor.Validate(ID(keys.MaxReservedDescID + 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// A privilege held for longer is not shortened.
+	descriptor.GrantUntil("foo", privilege.List{privilege.SELECT}, past)
+	checkExpiration("foo", privilege.SELECT, future, true)
+
+	// A permanent grant removes the expiration.
+	descriptor.Grant("foo", privilege.List{privilege.INSERT})
+	checkPrivileges("foo", map[privilege.Kind]bool{privilege.SELECT: true, privilege.INSERT: true})
+	checkExpiration("foo", privilege.INSERT, time.Time{}, false)
+
+	// A temporary grant does not shorten a permanent one.
+	descriptor.GrantUntil("foo", privilege.List{privilege.INSERT}, past)
+	checkExpiration("foo", privilege.INSERT, time.Time{}, false)
+
+	// Revoking removes the expiration.
+	descriptor.Revoke("foo", privilege.List{privilege.SELECT})
+	checkPrivileges("foo", map[privilege.Kind]bool{privilege.SELECT: false, privilege.INSERT: true})
+	checkExpiration("foo", privilege.SELECT, time.Time{}, false)
+
+	// Revoking a single privilege from a temporary ALL keeps the expiration
+	// on the others.
+	descriptor.GrantUntil("bar", privilege.List{privilege.ALL}, farFuture)
+	checkPrivileges("bar", map[privilege.Kind]bool{privilege.CREATE: true, privilege.DROP: true})
+	descriptor.Revoke("bar", privilege.List{privilege.CREATE})
+	checkPrivileges("bar", map[privilege.Kind]bool{
+		privilege.ALL: false, privilege.CREATE: false, privilege.DROP: true,
+	})
+	checkExpiration("bar", privilege.DROP, farFuture, true)
+	if !descriptor.anyPrivilegeAt("bar", now) || descriptor.anyPrivilegeAt("bar", farFuture) {
+		t.Errorf("unexpected anyPrivilegeAt result for descriptor %+v", descriptor)
+	}
+	if err := descriptor.Validate(ID(keys.MaxReservedDescID + 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Root privileges may not expire.
+	descriptor.Revoke(security.RootUser, privilege.List{privilege.SELECT})
+	descriptor.GrantUntil(security.RootUser, privilege.List{privilege.SELECT}, future)
+	if err := descriptor.Validate(ID(keys.MaxReservedDescID + 1)); !testutils.IsError(err,
+		"user root must not have expiring privileges") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPrivilegeGrantor(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	descriptor := NewDefaultPrivilegeDescriptor()
+	checkGrantor := func(user string, priv privilege.Kind, exp string, expOk bool) {
+		t.Helper()
+		if grantor, ok := descriptor.Grantor(user, priv); ok != expOk || grantor != exp {
+			t.Errorf("Grantor(%s, %v) = %q, %t, expected %q, %t", user, priv, grantor, ok, exp, expOk)
+		}
+	}
+
+	// Privileges granted without a grantor, e.g. the default ones, have none.
+	checkGrantor(security.RootUser, privilege.ALL, "", false)
+
+	descriptor.Grant("foo", privilege.List{privilege.SELECT, privilege.INSERT})
+	descriptor.SetGrantor("foo", privilege.List{privilege.SELECT, privilege.INSERT}, "bar")
+	checkGrantor("foo", privilege.SELECT, "bar", true)
+	checkGrantor("foo", privilege.INSERT, "bar", true)
+	checkGrantor("foo", privilege.DELETE, "", false)
+
+	// Granting again records the latest grantor. Privileges the user does
+	// not hold are ignored.
+	descriptor.Grant("foo", privilege.List{privilege.SELECT})
+	descriptor.SetGrantor("foo", privilege.List{privilege.SELECT, privilege.DELETE}, "baz")
+	checkGrantor("foo", privilege.SELECT, "baz", true)
+	checkGrantor("foo", privilege.INSERT, "bar", true)
+	checkGrantor("foo", privilege.DELETE, "", false)
+	if err := descriptor.Validate(ID(keys.MaxReservedDescID + 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Revoking removes the grantor.
+	descriptor.Revoke("foo", privilege.List{privilege.SELECT})
+	checkGrantor("foo", privilege.SELECT, "", false)
+	checkGrantor("foo", privilege.INSERT, "bar", true)
+
+	// Revoking a single privilege from ALL keeps the grantor on the others.
+	descriptor.Grant("qux", privilege.List{privilege.ALL})
+	descriptor.SetGrantor("qux", privilege.List{privilege.ALL}, "bar")
+	descriptor.Revoke("qux", privilege.List{privilege.CREATE})
+	checkGrantor("qux", privilege.ALL, "", false)
+	checkGrantor("qux", privilege.CREATE, "", false)
+	checkGrantor("qux", privilege.DROP, "bar", true)
+	if err := descriptor.Validate(ID(keys.MaxReservedDescID + 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Grantors of privileges that are not held are invalid.
+	userPriv, _ := descriptor.findUser("foo")
+	userPriv.setGrantor(privilege.UPDATE, "bar")
+	if err := descriptor.Validate(ID(keys.MaxReservedDescID + 1)); !testutils.IsError(err,
+		"user foo has a grantor for UPDATE privilege it does not hold") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPrivilegeGrantOption(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	descriptor := NewDefaultPrivilegeDescriptor()
+	checkGrantOption := func(user string, priv privilege.Kind, exp bool) {
+		t.Helper()
+		if ok := descriptor.CheckGrantOption(user, priv); ok != exp {
+			t.Errorf("CheckGrantOption(%s, %v) = %t, expected %t", user, priv, ok, exp)
+		}
+	}
+
+	descriptor.Grant("foo", privilege.List{privilege.SELECT, privilege.INSERT})
+	checkGrantOption("foo", privilege.SELECT, false)
+
+	// The grant option is only recorded for privileges that are held.
+	descriptor.SetGrantOption("foo", privilege.List{privilege.SELECT, privilege.DELETE})
+	checkGrantOption("foo", privilege.SELECT, true)
+	checkGrantOption("foo", privilege.INSERT, false)
+	checkGrantOption("foo", privilege.DELETE, false)
+	if err := descriptor.Validate(ID(keys.MaxReservedDescID + 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Revoking the grant option keeps the privilege.
+	descriptor.RevokeGrantOption("foo", privilege.List{privilege.SELECT})
+	checkGrantOption("foo", privilege.SELECT, false)
+	if !descriptor.CheckPrivilege("foo", privilege.SELECT) {
+		t.Errorf("expected foo to keep the SELECT privilege")
+	}
+
+	// Revoking the privilege removes the grant option.
+	descriptor.SetGrantOption("foo", privilege.List{privilege.INSERT})
+	descriptor.Revoke("foo", privilege.List{privilege.INSERT})
+	descriptor.Grant("foo", privilege.List{privilege.INSERT})
+	checkGrantOption("foo", privilege.INSERT, false)
+
+	// The grant option for ALL is good for every privilege, and is kept on
+	// the remaining privileges when one of them is revoked.
+	descriptor.Grant("bar", privilege.List{privilege.ALL})
+	descriptor.SetGrantOption("bar", privilege.List{privilege.ALL})
+	checkGrantOption("bar", privilege.DROP, true)
+	descriptor.Revoke("bar", privilege.List{privilege.CREATE})
+	checkGrantOption("bar", privilege.CREATE, false)
+	checkGrantOption("bar", privilege.DROP, true)
+	descriptor.Grant("bar", privilege.List{privilege.ALL})
+	descriptor.SetGrantOption("bar", privilege.List{privilege.ALL})
+	descriptor.RevokeGrantOption("bar", privilege.List{privilege.DROP})
+	checkGrantOption("bar", privilege.DROP, false)
+	checkGrantOption("bar", privilege.SELECT, true)
+	if !descriptor.CheckPrivilege("bar", privilege.DROP) {
+		t.Errorf("expected bar to keep the DROP privilege")
+	}
+	if err := descriptor.Validate(ID(keys.MaxReservedDescID + 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Grant options for privileges that are not held are invalid.
+	userPriv, _ := descriptor.findUser("foo")
+	userPriv.GrantOptions |= privilege.UPDATE.Mask()
+	if err := descriptor.Validate(ID(keys.MaxReservedDescID + 1)); !testutils.IsError(err,
+		`user foo has the grant option for UPDATE privileges it does not hold`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestPrivilegeValidateColumn exercises validation for column privileges.
+func TestPrivilegeValidateColumn(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// Column privileges don't need to be held by root.
+	descriptor := &PrivilegeDescriptor{}
+	descriptor.Grant("foo", privilege.List{privilege.SELECT, privilege.UPDATE})
+	descriptor.SetGrantOption("foo", privilege.List{privilege.SELECT})
+	if err := descriptor.ValidateColumn(); err != nil {
+		t.Fatal(err)
+	}
+
+	descriptor.Grant("bar", privilege.List{privilege.DELETE})
+	if err := descriptor.ValidateColumn(); !testutils.IsError(err,
+		`user bar must not have DELETE privileges on a column`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // TestPrivilegeValidate exercises validation for non-system descriptors.
 func TestPrivilegeValidate(t *testing.T) {
 	defer leaktest.AfterTest(t)()
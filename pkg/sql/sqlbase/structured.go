@@ -1094,6 +1094,12 @@ func (desc *TableDescriptor) ValidateTable() error {
 			return fmt.Errorf("column %q invalid ID (%d) > next column ID (%d)",
 				column.Name, column.ID, desc.NextColumnID)
 		}
+
+		if column.Privileges != nil {
+			if err := column.Privileges.ValidateColumn(); err != nil {
+				return errors.Wrapf(err, "column %q", column.Name)
+			}
+		}
 	}
 
 	for _, m := range desc.Mutations {
@@ -2086,7 +2092,9 @@ func ColumnsSelectors(cols []ColumnDescriptor, forUpdateOrDelete bool) tree.Sele
 	return exprs
 }
 
-func (c *ColumnType) elementColumnType() *ColumnType {
+// ElementColumnType returns the type of the elements of an array type, or nil
+// if the type is not an array.
+func (c *ColumnType) ElementColumnType() *ColumnType {
 	if c.SemanticType != ColumnType_ARRAY {
 		return nil
 	}
@@ -2134,7 +2142,7 @@ func (c *ColumnType) SQLString() string {
 		}
 		return fmt.Sprintf("%s COLLATE %s", ColumnType_STRING.String(), *c.Locale)
 	case ColumnType_ARRAY:
-		return c.elementColumnType().SQLString() + "[]"
+		return c.ElementColumnType().SQLString() + "[]"
 	}
 	if c.VisibleType != ColumnType_NONE {
 		return c.VisibleType.String()
@@ -2203,6 +2211,27 @@ func (c *ColumnType) NumericScale() (int32, bool) {
 	return 0, false
 }
 
+// defaultDatetimePrecision is the fractional seconds precision of time and
+// interval data types that do not declare one. All such types are stored
+// with microsecond precision.
+const defaultDatetimePrecision = 6
+
+// DatetimePrecision returns the declared or implicit fractional seconds
+// precision of date, time and interval data types. Returns false if the data
+// type is not a date, time or interval type.
+func (c *ColumnType) DatetimePrecision() (int32, bool) {
+	switch c.SemanticType {
+	case ColumnType_DATE:
+		return 0, true
+	case ColumnType_TIME, ColumnType_TIMESTAMP, ColumnType_TIMESTAMPTZ, ColumnType_INTERVAL:
+		if c.Precision > 0 {
+			return c.Precision, true
+		}
+		return defaultDatetimePrecision, true
+	}
+	return 0, false
+}
+
 // DatumTypeToColumnSemanticType converts a types.T to a SemanticType.
 func DatumTypeToColumnSemanticType(ptyp types.T) (ColumnType_SemanticType, error) {
 	switch ptyp {
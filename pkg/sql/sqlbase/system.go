@@ -183,6 +183,57 @@ CREATE TABLE system.role_members (
   INDEX ("role"),
   INDEX ("member")
 );`
+
+	// comments stores comments on database objects. type identifies the kind
+	// of object commented on, object_id its descriptor ID and sub_id the
+	// sub-object (e.g. the column ID), if any.
+	CommentsTableSchema = `
+CREATE TABLE system.comments (
+  type      INT NOT NULL,
+  object_id INT NOT NULL,
+  sub_id    INT NOT NULL,
+  comment   STRING NOT NULL,
+  PRIMARY KEY (type, object_id, sub_id)
+);`
+
+	// role_settings stores the default values of the session variables of
+	// the users and roles and of the databases, which are applied when a
+	// session starts. role is empty for the defaults of a database, and
+	// database_id is 0 for the defaults of a user or role. value is the list
+	// of values of the SET statement, formatted as SQL.
+	RoleSettingsTableSchema = `
+CREATE TABLE system.role_settings (
+  role        STRING NOT NULL,
+  database_id INT NOT NULL,
+  variable    STRING NOT NULL,
+  value       STRING NOT NULL,
+  PRIMARY KEY (role, database_id, variable)
+);`
+
+	// role_options stores the options of the users and roles set with CREATE
+	// ROLE or ALTER ROLE that differ from the defaults, e.g. NOLOGIN. value is
+	// the argument of the option if it has one, e.g. the timestamp of VALID
+	// UNTIL.
+	RoleOptionsTableSchema = `
+CREATE TABLE system.role_options (
+  username STRING NOT NULL,
+  option   STRING NOT NULL,
+  value    STRING,
+  PRIMARY KEY (username, option)
+);`
+
+	// default_privileges stores the privileges granted on the tables, views
+	// and sequences created by a role in a database, set with ALTER DEFAULT
+	// PRIVILEGES. privileges is a comma-separated list of the privileges
+	// granted to grantee, e.g. "INSERT,SELECT".
+	DefaultPrivilegesTableSchema = `
+CREATE TABLE system.default_privileges (
+  database_id INT NOT NULL,
+  role        STRING NOT NULL,
+  grantee     STRING NOT NULL,
+  privileges  STRING NOT NULL,
+  PRIMARY KEY (database_id, role, grantee)
+);`
 )
 
 func pk(name string) IndexDescriptor {
@@ -234,11 +285,15 @@ var SystemAllowedPrivileges = map[ID]privilege.Lists{
 	// users will be able to modify system tables' schemas at will. CREATE and
 	// DROP privileges are allowed on the above system tables for backwards
 	// compatibility reasons only!
-	keys.JobsTableID:            {privilege.ReadWriteData},
-	keys.WebSessionsTableID:     {privilege.ReadWriteData},
-	keys.TableStatisticsTableID: {privilege.ReadWriteData},
-	keys.LocationsTableID:       {privilege.ReadWriteData},
-	keys.RoleMembersTableID:     {privilege.ReadWriteData},
+	keys.JobsTableID:              {privilege.ReadWriteData},
+	keys.WebSessionsTableID:       {privilege.ReadWriteData},
+	keys.TableStatisticsTableID:   {privilege.ReadWriteData},
+	keys.LocationsTableID:         {privilege.ReadWriteData},
+	keys.RoleMembersTableID:       {privilege.ReadWriteData},
+	keys.CommentsTableID:          {privilege.ReadWriteData},
+	keys.RoleSettingsTableID:      {privilege.ReadWriteData},
+	keys.RoleOptionsTableID:       {privilege.ReadWriteData},
+	keys.DefaultPrivilegesTableID: {privilege.ReadWriteData},
 }
 
 // SystemDesiredPrivileges returns the desired privilege list (i.e., the
@@ -814,6 +869,177 @@ var (
 		NextMutationID: 1,
 	}
 
+	// CommentsTable is the descriptor for the comments table.
+	CommentsTable = TableDescriptor{
+		Name:     "comments",
+		ID:       keys.CommentsTableID,
+		ParentID: keys.SystemDatabaseID,
+		Version:  1,
+		Columns: []ColumnDescriptor{
+			{Name: "type", ID: 1, Type: colTypeInt},
+			{Name: "object_id", ID: 2, Type: colTypeInt},
+			{Name: "sub_id", ID: 3, Type: colTypeInt},
+			{Name: "comment", ID: 4, Type: colTypeString},
+		},
+		NextColumnID: 5,
+		Families: []ColumnFamilyDescriptor{
+			{
+				Name:        "primary",
+				ID:          0,
+				ColumnNames: []string{"type", "object_id", "sub_id"},
+				ColumnIDs:   []ColumnID{1, 2, 3},
+			},
+			{
+				Name:            "fam_4_comment",
+				ID:              4,
+				ColumnNames:     []string{"comment"},
+				ColumnIDs:       []ColumnID{4},
+				DefaultColumnID: 4,
+			},
+		},
+		NextFamilyID: 5,
+		PrimaryIndex: IndexDescriptor{
+			Name:             "primary",
+			ID:               1,
+			Unique:           true,
+			ColumnNames:      []string{"type", "object_id", "sub_id"},
+			ColumnDirections: []IndexDescriptor_Direction{IndexDescriptor_ASC, IndexDescriptor_ASC, IndexDescriptor_ASC},
+			ColumnIDs:        []ColumnID{1, 2, 3},
+		},
+		NextIndexID:    2,
+		Privileges:     NewCustomSuperuserPrivilegeDescriptor(SystemDesiredPrivileges(keys.CommentsTableID)),
+		FormatVersion:  InterleavedFormatVersion,
+		NextMutationID: 1,
+	}
+
+	// RoleSettingsTable is the descriptor for the role_settings table.
+	RoleSettingsTable = TableDescriptor{
+		Name:     "role_settings",
+		ID:       keys.RoleSettingsTableID,
+		ParentID: keys.SystemDatabaseID,
+		Version:  1,
+		Columns: []ColumnDescriptor{
+			{Name: "role", ID: 1, Type: colTypeString},
+			{Name: "database_id", ID: 2, Type: colTypeInt},
+			{Name: "variable", ID: 3, Type: colTypeString},
+			{Name: "value", ID: 4, Type: colTypeString},
+		},
+		NextColumnID: 5,
+		Families: []ColumnFamilyDescriptor{
+			{
+				Name:        "primary",
+				ID:          0,
+				ColumnNames: []string{"role", "database_id", "variable"},
+				ColumnIDs:   []ColumnID{1, 2, 3},
+			},
+			{
+				Name:            "fam_4_value",
+				ID:              4,
+				ColumnNames:     []string{"value"},
+				ColumnIDs:       []ColumnID{4},
+				DefaultColumnID: 4,
+			},
+		},
+		NextFamilyID: 5,
+		PrimaryIndex: IndexDescriptor{
+			Name:             "primary",
+			ID:               1,
+			Unique:           true,
+			ColumnNames:      []string{"role", "database_id", "variable"},
+			ColumnDirections: []IndexDescriptor_Direction{IndexDescriptor_ASC, IndexDescriptor_ASC, IndexDescriptor_ASC},
+			ColumnIDs:        []ColumnID{1, 2, 3},
+		},
+		NextIndexID:    2,
+		Privileges:     NewCustomSuperuserPrivilegeDescriptor(SystemDesiredPrivileges(keys.RoleSettingsTableID)),
+		FormatVersion:  InterleavedFormatVersion,
+		NextMutationID: 1,
+	}
+
+	// RoleOptionsTable is the descriptor for the role_options table.
+	RoleOptionsTable = TableDescriptor{
+		Name:     "role_options",
+		ID:       keys.RoleOptionsTableID,
+		ParentID: keys.SystemDatabaseID,
+		Version:  1,
+		Columns: []ColumnDescriptor{
+			{Name: "username", ID: 1, Type: colTypeString},
+			{Name: "option", ID: 2, Type: colTypeString},
+			{Name: "value", ID: 3, Type: colTypeString, Nullable: true},
+		},
+		NextColumnID: 4,
+		Families: []ColumnFamilyDescriptor{
+			{
+				Name:        "primary",
+				ID:          0,
+				ColumnNames: []string{"username", "option"},
+				ColumnIDs:   []ColumnID{1, 2},
+			},
+			{
+				Name:            "fam_3_value",
+				ID:              3,
+				ColumnNames:     []string{"value"},
+				ColumnIDs:       []ColumnID{3},
+				DefaultColumnID: 3,
+			},
+		},
+		NextFamilyID: 4,
+		PrimaryIndex: IndexDescriptor{
+			Name:             "primary",
+			ID:               1,
+			Unique:           true,
+			ColumnNames:      []string{"username", "option"},
+			ColumnDirections: []IndexDescriptor_Direction{IndexDescriptor_ASC, IndexDescriptor_ASC},
+			ColumnIDs:        []ColumnID{1, 2},
+		},
+		NextIndexID:    2,
+		Privileges:     NewCustomSuperuserPrivilegeDescriptor(SystemDesiredPrivileges(keys.RoleOptionsTableID)),
+		FormatVersion:  InterleavedFormatVersion,
+		NextMutationID: 1,
+	}
+
+	// DefaultPrivilegesTable is the descriptor for the default_privileges table.
+	DefaultPrivilegesTable = TableDescriptor{
+		Name:     "default_privileges",
+		ID:       keys.DefaultPrivilegesTableID,
+		ParentID: keys.SystemDatabaseID,
+		Version:  1,
+		Columns: []ColumnDescriptor{
+			{Name: "database_id", ID: 1, Type: colTypeInt},
+			{Name: "role", ID: 2, Type: colTypeString},
+			{Name: "grantee", ID: 3, Type: colTypeString},
+			{Name: "privileges", ID: 4, Type: colTypeString},
+		},
+		NextColumnID: 5,
+		Families: []ColumnFamilyDescriptor{
+			{
+				Name:        "primary",
+				ID:          0,
+				ColumnNames: []string{"database_id", "role", "grantee"},
+				ColumnIDs:   []ColumnID{1, 2, 3},
+			},
+			{
+				Name:            "fam_4_privileges",
+				ID:              4,
+				ColumnNames:     []string{"privileges"},
+				ColumnIDs:       []ColumnID{4},
+				DefaultColumnID: 4,
+			},
+		},
+		NextFamilyID: 5,
+		PrimaryIndex: IndexDescriptor{
+			Name:             "primary",
+			ID:               1,
+			Unique:           true,
+			ColumnNames:      []string{"database_id", "role", "grantee"},
+			ColumnDirections: []IndexDescriptor_Direction{IndexDescriptor_ASC, IndexDescriptor_ASC, IndexDescriptor_ASC},
+			ColumnIDs:        []ColumnID{1, 2, 3},
+		},
+		NextIndexID:    2,
+		Privileges:     NewCustomSuperuserPrivilegeDescriptor(SystemDesiredPrivileges(keys.DefaultPrivilegesTableID)),
+		FormatVersion:  InterleavedFormatVersion,
+		NextMutationID: 1,
+	}
+
 //***************************************************************************
 // WARNING: any tables added after LocationsTable must use:
 //   Privileges: NewCustomSuperuserPrivilegeDescriptor(...)
@@ -2189,7 +2189,7 @@ func CheckValueWidth(typ ColumnType, val tree.Datum, name string) error {
 		}
 	case ColumnType_ARRAY:
 		if v, ok := val.(*tree.DArray); ok {
-			elementType := *typ.elementColumnType()
+			elementType := *typ.ElementColumnType()
 			for i := range v.Array {
 				if err := CheckValueWidth(elementType, v.Array[i], name); err != nil {
 					return err
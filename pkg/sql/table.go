@@ -525,6 +525,9 @@ func (tc *TableCollection) releaseTables(ctx context.Context, opt releaseOpt) er
 }
 
 func (tc *TableCollection) addUncommittedTable(desc sqlbase.TableDescriptor) {
+	// The cached descriptors don't reflect the new version of the table,
+	// even if the transaction already modified it.
+	tc.releaseAllDescriptors()
 	for i, table := range tc.uncommittedTables {
 		if table.ID == desc.ID {
 			tc.uncommittedTables[i] = &desc
@@ -532,7 +535,6 @@ func (tc *TableCollection) addUncommittedTable(desc sqlbase.TableDescriptor) {
 		}
 	}
 	tc.uncommittedTables = append(tc.uncommittedTables, &desc)
-	tc.releaseAllDescriptors()
 }
 
 type dbAction bool
@@ -596,7 +598,9 @@ func (tc *TableCollection) getUncommittedTable(
 
 // getAllDescriptors returns all descriptors visible by the transaction,
 // first checking the TableCollection's cached descriptors for validity
-// before defaulting to a key-value scan, if necessary.
+// before defaulting to a key-value scan, if necessary. The virtual tables
+// thus share a single scan of the descriptors per transaction, e.g. when a
+// query joins several of them.
 func (tc *TableCollection) getAllDescriptors(
 	ctx context.Context, txn *client.Txn,
 ) ([]sqlbase.DescriptorProto, error) {
@@ -0,0 +1,152 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package sql
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// tableActivity counts the scans of a table and the rows written to it by
+// the statements that ran successfully on this node, for
+// pg_catalog.pg_stat_user_tables and pg_stat_database.
+type tableActivity struct {
+	// seqScans counts the scans of the whole primary index, and idxScans
+	// the other scans.
+	seqScans, idxScans int64
+	// inserted, updated and deleted count the rows written by the
+	// transactions that committed. The rows written by an UPSERT count as
+	// inserted.
+	inserted, updated, deleted int64
+}
+
+// tableActivityStats holds the activity of the tables. Unlike the statement
+// statistics, it is never reset: the counts are since the node started.
+type tableActivityStats struct {
+	// start is when the counting started.
+	start time.Time
+
+	mu struct {
+		// The tables are only added once, so the counting of a statement
+		// usually only takes the read lock.
+		syncutil.RWMutex
+		tables map[sqlbase.ID]*tableActivity
+	}
+}
+
+func newTableActivityStats() *tableActivityStats {
+	s := &tableActivityStats{start: timeutil.Now()}
+	s.mu.tables = make(map[sqlbase.ID]*tableActivity)
+	return s
+}
+
+// get returns the activity of the table, which is updated atomically.
+func (s *tableActivityStats) get(id sqlbase.ID) *tableActivity {
+	s.mu.RLock()
+	a, ok := s.mu.tables[id]
+	s.mu.RUnlock()
+	if ok {
+		return a
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok = s.mu.tables[id]; !ok {
+		a = &tableActivity{}
+		s.mu.tables[id] = a
+	}
+	return a
+}
+
+// snapshot returns a copy of the activity of the tables.
+func (s *tableActivityStats) snapshot() map[sqlbase.ID]tableActivity {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tables := make(map[sqlbase.ID]tableActivity, len(s.mu.tables))
+	for id, a := range s.mu.tables {
+		tables[id] = tableActivity{
+			seqScans: atomic.LoadInt64(&a.seqScans),
+			idxScans: atomic.LoadInt64(&a.idxScans),
+			inserted: atomic.LoadInt64(&a.inserted),
+			updated:  atomic.LoadInt64(&a.updated),
+			deleted:  atomic.LoadInt64(&a.deleted),
+		}
+	}
+	return tables
+}
+
+// tableWrites are the rows written to a table by a statement.
+type tableWrites struct {
+	id                         sqlbase.ID
+	inserted, updated, deleted int64
+}
+
+// recordPlan counts the scans and the writes of the plan of a statement that
+// ran successfully in the transaction. The writes are only counted once the
+// transaction commits.
+func (s *tableActivityStats) recordPlan(ctx context.Context, txn *client.Txn, plan planNode) {
+	var writes []tableWrites
+	_ = walkPlan(ctx, plan, planObserver{
+		enterNode: func(_ context.Context, _ string, plan planNode) (bool, error) {
+			switch n := plan.(type) {
+			case *scanNode:
+				a := s.get(n.desc.ID)
+				if n.isFullPrimaryScan() {
+					atomic.AddInt64(&a.seqScans, 1)
+				} else {
+					atomic.AddInt64(&a.idxScans, 1)
+				}
+			case *insertNode:
+				writes = append(writes, tableWrites{id: n.tableDesc.ID, inserted: int64(n.rh.rowCount)})
+			case *updateNode:
+				writes = append(writes, tableWrites{id: n.tableDesc.ID, updated: int64(n.rh.rowCount)})
+			case *deleteNode:
+				writes = append(writes, tableWrites{id: n.tableDesc.ID, deleted: int64(n.rh.rowCount)})
+			}
+			return true, nil
+		},
+	})
+	if len(writes) == 0 {
+		return
+	}
+	record := func() {
+		for _, w := range writes {
+			a := s.get(w.id)
+			atomic.AddInt64(&a.inserted, w.inserted)
+			atomic.AddInt64(&a.updated, w.updated)
+			atomic.AddInt64(&a.deleted, w.deleted)
+		}
+	}
+	// The implicit transaction of a statement may have committed with its
+	// writes already.
+	if txn.IsCommitted() {
+		record()
+	} else {
+		txn.AddCommitTrigger(record)
+	}
+}
+
+// isFullPrimaryScan returns whether the scan reads the whole primary index,
+// which is what Postgres calls a sequential scan.
+func (n *scanNode) isFullPrimaryScan() bool {
+	return n.index.ID == n.desc.PrimaryIndex.ID && len(n.spans) == 1 &&
+		n.spans[0].EqualValue(n.desc.PrimaryIndexSpan())
+}
@@ -123,6 +123,10 @@ func TestSystemTableLiterals(t *testing.T) {
 		{keys.TableStatisticsTableID, sqlbase.TableStatisticsTableSchema, sqlbase.TableStatisticsTable, false},
 		{keys.LocationsTableID, sqlbase.LocationsTableSchema, sqlbase.LocationsTable, false},
 		{keys.RoleMembersTableID, sqlbase.RoleMembersTableSchema, sqlbase.RoleMembersTable, true},
+		{keys.CommentsTableID, sqlbase.CommentsTableSchema, sqlbase.CommentsTable, true},
+		{keys.RoleSettingsTableID, sqlbase.RoleSettingsTableSchema, sqlbase.RoleSettingsTable, true},
+		{keys.RoleOptionsTableID, sqlbase.RoleOptionsTableSchema, sqlbase.RoleOptionsTable, true},
+		{keys.DefaultPrivilegesTableID, sqlbase.DefaultPrivilegesTableSchema, sqlbase.DefaultPrivilegesTable, true},
 	} {
 		var privs *sqlbase.PrivilegeDescriptor
 		if test.hasAdmin {
@@ -218,6 +218,11 @@ func (p *planner) truncateTable(ctx context.Context, id sqlbase.ID, traceKV bool
 	}
 	p.notifySchemaChange(&newTableDesc, sqlbase.InvalidMutationID)
 
+	// Move the comments to the new table.
+	if err := p.reassignTableComments(ctx, tableDesc.ID, newID); err != nil {
+		return err
+	}
+
 	// Copy the zone config.
 	b = &client.Batch{}
 	b.Get(zoneKey)
@@ -271,6 +276,12 @@ func (p *planner) findAllReferences(
 		refs[c.ID] = struct{}{}
 	}
 
+	for _, col := range table.Columns {
+		for _, sequenceID := range col.OwnsSequenceIds {
+			refs[sequenceID] = struct{}{}
+		}
+	}
+
 	tables := make([]*sqlbase.TableDescriptor, 0, len(refs))
 	for id := range refs {
 		if id == table.ID {
@@ -333,6 +344,10 @@ func reassignReferencedTables(tables []*sqlbase.TableDescriptor, oldID, newID sq
 			}
 			table.DependedOnBy = append(table.DependedOnBy, ref)
 		}
+
+		if table.SequenceOpts != nil && table.SequenceOpts.SequenceOwner.OwnerTableID == oldID {
+			table.SequenceOpts.SequenceOwner.OwnerTableID = newID
+		}
 	}
 	return nil
 }
@@ -52,7 +52,8 @@ type updateNode struct {
 var _ autoCommitNode = &updateNode{}
 
 // Update updates columns for a selection of rows from a table.
-// Privileges: UPDATE and SELECT on table. We currently always use a select statement.
+// Privileges: UPDATE (or UPDATE on the updated columns) and SELECT on table. We
+// currently always use a select statement.
 //   Notes: postgres requires UPDATE. Requires SELECT with WHERE clause with table.
 //          mysql requires UPDATE. Also requires SELECT with WHERE clause with table.
 func (p *planner) Update(
@@ -71,12 +72,21 @@ func (p *planner) Update(
 
 	tracing.AnnotateTrace()
 
+	// The virtual tables that can be updated plan their own UPDATE.
+	update, err := p.getVirtualTableUpdater(ctx, n.Table)
+	if err != nil {
+		return nil, err
+	}
+	if update != nil {
+		return update(ctx, p, n)
+	}
+
 	tn, alias, err := p.getAliasedTableName(n.Table)
 	if err != nil {
 		return nil, err
 	}
 
-	en, err := p.makeEditNode(ctx, tn, privilege.UPDATE)
+	en, err := p.makeColumnEditNode(ctx, tn, privilege.UPDATE)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +111,9 @@ func (p *planner) Update(
 	if err != nil {
 		return nil, err
 	}
+	if err := p.CheckColumnsPrivilege(ctx, en.tableDesc, updateCols, privilege.UPDATE); err != nil {
+		return nil, err
+	}
 
 	defaultExprs, err := sqlbase.MakeDefaultExprs(
 		updateCols, &p.txCtx, p.EvalContext())
@@ -378,6 +391,22 @@ type editNodeBase struct {
 
 func (p *planner) makeEditNode(
 	ctx context.Context, tn *tree.TableName, priv privilege.Kind,
+) (editNodeBase, error) {
+	en, err := p.makeColumnEditNode(ctx, tn, priv)
+	if err != nil {
+		return editNodeBase{}, err
+	}
+	if err := p.CheckPrivilege(ctx, en.tableDesc, priv); err != nil {
+		return editNodeBase{}, err
+	}
+	return en, nil
+}
+
+// makeColumnEditNode is like makeEditNode, but leaves the privilege check
+// to the caller, for the statements whose privilege may also be held on
+// the edited columns only; see CheckColumnsPrivilege.
+func (p *planner) makeColumnEditNode(
+	ctx context.Context, tn *tree.TableName, priv privilege.Kind,
 ) (editNodeBase, error) {
 	tableDesc, err := p.Tables().getTableVersion(ctx, p.txn, p.getVirtualTabler(), tn)
 	if err != nil {
@@ -399,10 +428,6 @@ func (p *planner) makeEditNode(
 		}
 	}
 
-	if err := p.CheckPrivilege(ctx, tableDesc, priv); err != nil {
-		return editNodeBase{}, err
-	}
-
 	return editNodeBase{
 		p:         p,
 		tableDesc: tableDesc,
@@ -98,6 +98,42 @@ var varGen = map[string]sessionVar{
 		},
 	},
 
+	// CockroachDB extension.
+	// With "current", the virtual tables describing the schema only report the
+	// objects of the current database (or of the database they are qualified
+	// with), like the per-database catalogs of Postgres, instead of also
+	// reporting the virtual schemas and system database.
+	`catalog_scope`: {
+		Set: func(
+			_ context.Context, m sessionDataMutator,
+			evalCtx *extendedEvalContext, values []tree.TypedExpr,
+		) error {
+			s, err := getStringVal(&evalCtx.EvalContext, `catalog_scope`, values)
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(s) {
+			case "all":
+				m.SetCurrentCatalogOnly(false)
+			case "current":
+				m.SetCurrentCatalogOnly(true)
+			default:
+				return fmt.Errorf("set catalog_scope: \"%s\" not supported", s)
+			}
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) string {
+			if evalCtx.SessionData.CurrentCatalogOnly {
+				return "current"
+			}
+			return "all"
+		},
+		Reset: func(m sessionDataMutator) error {
+			m.SetCurrentCatalogOnly(false)
+			return nil
+		},
+	},
+
 	// Supported for PG compatibility only.
 	// Controls returned message verbosity. We don't support this.
 	// See https://www.postgresql.org/docs/9.6/static/runtime-config-compatible.html
@@ -279,6 +315,38 @@ var varGen = map[string]sessionVar{
 	// See https://www.postgresql.org/docs/10/static/runtime-config-client.html
 	`extra_float_digits`: nopVar,
 
+	// CockroachDB extension.
+	// Selects the database version whose conventions information_schema
+	// follows: crdb, postgres:10 or mysql:5.7, where postgres and mysql are
+	// accepted as aliases. See infoSchemaDialect.
+	`information_schema_compat`: {
+		Set: func(
+			_ context.Context, m sessionDataMutator,
+			evalCtx *extendedEvalContext, values []tree.TypedExpr,
+		) error {
+			s, err := getStringVal(&evalCtx.EvalContext, `information_schema_compat`, values)
+			if err != nil {
+				return err
+			}
+			name, err := canonicalInfoSchemaDialectName(s)
+			if err != nil {
+				return err
+			}
+			m.SetInformationSchemaCompat(name)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) string {
+			if evalCtx.SessionData.InformationSchemaCompat == "" {
+				return crdbInfoSchemaDialectName
+			}
+			return evalCtx.SessionData.InformationSchemaCompat
+		},
+		Reset: func(m sessionDataMutator) error {
+			m.SetInformationSchemaCompat(crdbInfoSchemaDialectName)
+			return nil
+		},
+	},
+
 	// Supported for PG compatibility only.
 	// See https://www.postgresql.org/docs/10/static/runtime-config-client.html
 	`intervalstyle`: {
@@ -379,6 +447,30 @@ var varGen = map[string]sessionVar{
 		Get: func(evalCtx *extendedEvalContext) string { return evalCtx.SessionData.User },
 	},
 
+	// CockroachDB extension.
+	// When on, information_schema.columns also reports the hidden columns,
+	// e.g. the rowid column of the tables created without a primary key.
+	`show_hidden_columns`: {
+		Set: func(
+			_ context.Context, m sessionDataMutator,
+			evalCtx *extendedEvalContext, values []tree.TypedExpr,
+		) error {
+			s, err := getSingleBool("show_hidden_columns", evalCtx, values)
+			if err != nil {
+				return err
+			}
+			m.SetShowHiddenColumns(bool(*s))
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) string {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData.ShowHiddenColumns)
+		},
+		Reset: func(m sessionDataMutator) error {
+			m.SetShowHiddenColumns(false)
+			return nil
+		},
+	},
+
 	// Supported for PG compatibility only.
 	// See https://www.postgresql.org/docs/10/static/runtime-config-compatible.html#GUC-STANDARD-CONFORMING-STRINGS
 	`standard_conforming_strings`: {
@@ -533,6 +625,83 @@ func stopTracing(m sessionDataMutator) error {
 	return nil
 }
 
+// Categories of the session variables in pg_settings. The variables that
+// exist in PostgreSQL are classified like in PostgreSQL.
+const (
+	varCategoryLocale    = "Client Connection Defaults / Locale and Formatting"
+	varCategoryStatement = "Client Connection Defaults / Statement Behavior"
+	varCategoryWhatToLog = "Reporting and Logging / What to Log"
+	varCategoryWhenToLog = "Reporting and Logging / When to Log"
+	varCategoryPreset    = "Preset Options"
+	varCategoryCompat    = "Version and Platform Compatibility / Previous PostgreSQL Versions"
+	varCategoryCockroach = "CockroachDB Extensions"
+)
+
+// varDescription describes a session variable in pg_settings.
+type varDescription struct {
+	category  string
+	shortDesc string
+}
+
+// varDescriptions maps the names of the session variables to their
+// description, which is PostgreSQL's for the variables it also has.
+var varDescriptions = map[string]varDescription{
+	`application_name`: {varCategoryWhatToLog,
+		"Sets the application name to be reported in statistics and logs."},
+	`catalog_scope`: {varCategoryCockroach,
+		"Sets whether the virtual schemas only describe the current database."},
+	`client_encoding`: {varCategoryLocale,
+		"Sets the client's character set encoding."},
+	`client_min_messages`: {varCategoryWhenToLog,
+		"Sets the message levels that are sent to the client."},
+	`database`: {varCategoryCockroach,
+		"Sets the current database."},
+	`datestyle`: {varCategoryLocale,
+		"Sets the display format for date and time values."},
+	`default_transaction_isolation`: {varCategoryStatement,
+		"Sets the transaction isolation level of each new transaction."},
+	`default_transaction_read_only`: {varCategoryStatement,
+		"Sets the default read-only status of new transactions."},
+	`distsql`: {varCategoryCockroach,
+		"Sets when queries are executed by the distributed SQL engine."},
+	`extra_float_digits`: {varCategoryLocale,
+		"Sets the number of digits displayed for floating-point values."},
+	`information_schema_compat`: {varCategoryCockroach,
+		"Sets the database whose conventions information_schema follows."},
+	`intervalstyle`: {varCategoryLocale,
+		"Sets the display format for interval values."},
+	`max_index_keys`: {varCategoryPreset,
+		"Shows the maximum number of index keys."},
+	`node_id`: {varCategoryCockroach,
+		"Shows the ID of the node the session is connected to."},
+	`sql_safe_updates`: {varCategoryCockroach,
+		"Rejects the statements that could unintentionally update or delete many rows."},
+	`search_path`: {varCategoryStatement,
+		"Sets the schema search order for names that are not schema-qualified."},
+	`server_version`: {varCategoryPreset,
+		"Shows the server version."},
+	`server_version_num`: {varCategoryPreset,
+		"Shows the server version as an integer."},
+	`session_user`: {varCategoryCockroach,
+		"Shows the user of the session."},
+	`show_hidden_columns`: {varCategoryCockroach,
+		"Sets whether information_schema.columns reports the hidden columns."},
+	`standard_conforming_strings`: {varCategoryCompat,
+		"Causes '...' strings to treat backslashes literally."},
+	`timezone`: {varCategoryLocale,
+		"Sets the time zone for displaying and interpreting time stamps."},
+	`transaction_isolation`: {varCategoryStatement,
+		"Shows the current transaction's isolation level."},
+	`transaction_priority`: {varCategoryCockroach,
+		"Shows the current transaction's priority."},
+	`transaction_status`: {varCategoryCockroach,
+		"Shows the current transaction's status."},
+	`transaction_read_only`: {varCategoryStatement,
+		"Sets the current transaction's read-only status."},
+	`tracing`: {varCategoryCockroach,
+		"Sets the tracing of the session's statements."},
+}
+
 var varNames = func() []string {
 	res := make([]string, 0, len(varGen))
 	for vName := range varGen {
@@ -22,8 +22,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 )
@@ -96,6 +98,12 @@ func (p *planner) analyzeViewQuery(
 	}
 	// The plan will not be needed further.
 	defer sourcePlan.Close(ctx)
+	// The columns the view reads are checked against the privileges of the
+	// user on them, which only needs the needed columns to be known.
+	setNeededColumns(sourcePlan, allColumns(sourcePlan))
+	if err := p.checkColumnPrivileges(ctx, sourcePlan); err != nil {
+		return nil, nil, err
+	}
 
 	// TODO(a-robinson): Support star expressions as soon as we can (#10028).
 	if p.curPlan.hasStar {
@@ -269,3 +277,220 @@ func RecomputeViewDependencies(ctx context.Context, txn *client.Txn, e *Executor
 
 	return nil
 }
+
+// viewDefinition returns the query of the view, with the names of the
+// view's columns added as aliases of the query's results where they
+// differ, so that the query defines the columns on its own. For
+// example, the definition of the view created by
+//   CREATE VIEW v (a) AS SELECT b FROM foo
+// is `SELECT b AS a FROM foo`. The stored query is returned as-is if the
+// names cannot be folded in, e.g. for a VALUES clause.
+func viewDefinition(desc *sqlbase.TableDescriptor, searchPath sessiondata.SearchPath) string {
+	stmt, err := parser.ParseOne(desc.ViewQuery)
+	if err != nil {
+		return desc.ViewQuery
+	}
+	sel, ok := stmt.(*tree.Select)
+	if !ok {
+		return desc.ViewQuery
+	}
+	orderNames := orderByNames(sel.OrderBy, nil)
+	changed, ok := addViewColumnAliases(sel.Select, orderNames, desc.Columns, searchPath)
+	if !ok || !changed {
+		return desc.ViewQuery
+	}
+	return tree.AsStringWithFlags(sel, tree.FmtParsable)
+}
+
+// orderByNames adds the unqualified names the ORDER BY clause sorts by,
+// which may be the names of results or of source columns, to names.
+func orderByNames(orderBy tree.OrderBy, names map[string]struct{}) map[string]struct{} {
+	for _, o := range orderBy {
+		if n, ok := o.Expr.(*tree.UnresolvedName); ok && n.NumParts == 1 && !n.Star {
+			if names == nil {
+				names = make(map[string]struct{})
+			}
+			names[n.Parts[0]] = struct{}{}
+		}
+	}
+	return names
+}
+
+// addViewColumnAliases names the results of the select statement after
+// the given columns. orderNames are the names the enclosing ORDER BY
+// clauses sort by, which an alias must neither remove nor add. The first
+// return value indicates whether any alias was added, the second whether
+// the results could be named.
+func addViewColumnAliases(
+	stmt tree.SelectStatement,
+	orderNames map[string]struct{},
+	cols []sqlbase.ColumnDescriptor,
+	searchPath sessiondata.SearchPath,
+) (changed bool, ok bool) {
+	switch t := stmt.(type) {
+	case *tree.ParenSelect:
+		orderNames = orderByNames(t.Select.OrderBy, orderNames)
+		return addViewColumnAliases(t.Select.Select, orderNames, cols, searchPath)
+
+	case *tree.UnionClause:
+		// The results of a set operation are named after its left operand.
+		return addViewColumnAliases(t.Left.Select, orderNames, cols, searchPath)
+
+	case *tree.SelectClause:
+		// Views cannot use stars, so the results match the columns
+		// one-to-one.
+		if t.TableSelect || len(t.Exprs) != len(cols) {
+			return false, false
+		}
+		resultNames := make([]string, len(t.Exprs))
+		names := make(map[string]int, len(t.Exprs))
+		for i := range t.Exprs {
+			name, err := getRenderColName(searchPath, t.Exprs[i], nil /* helper */)
+			if err != nil {
+				return false, false
+			}
+			resultNames[i] = name
+			names[name] = i
+		}
+		for i := range t.Exprs {
+			if j, ok := names[cols[i].Name]; ok && j == i {
+				continue
+			} else if ok {
+				// The alias would shadow the name of another result, e.g.
+				// in an ORDER BY clause.
+				return false, false
+			}
+			// An ORDER BY clause sorting by the old name of the result, or
+			// by a source column with the new name, would then sort by
+			// something else.
+			if _, ok := orderNames[resultNames[i]]; ok {
+				return false, false
+			}
+			if _, ok := orderNames[cols[i].Name]; ok {
+				return false, false
+			}
+			t.Exprs[i].As = tree.Name(cols[i].Name)
+			changed = true
+		}
+		return changed, true
+	}
+	return false, false
+}
+
+// isUpdatableView determines whether the view is simple enough for
+// UPDATE, DELETE and INSERT statements on it to be translated to
+// statements on its underlying table. Following Postgres, this is the
+// case when the view query:
+// - selects from a single table or updatable view, with no WITH,
+//   DISTINCT, GROUP BY, HAVING, LIMIT, OFFSET or set operation;
+// - has no aggregate, window or set-returning function in its
+//   results;
+// - has at least one result which is a plain column of the table, which
+//   the statements on the view can modify.
+// The tables the view depends on are looked up with tableLookup.
+func (p *planner) isUpdatableView(view *sqlbase.TableDescriptor, tableLookup tableLookupFn) bool {
+	stmt, err := parser.ParseOne(view.ViewQuery)
+	if err != nil {
+		return false
+	}
+	sel, ok := stmt.(*tree.Select)
+	if !ok || sel.With != nil || sel.Limit != nil {
+		return false
+	}
+	selStmt := sel.Select
+	for {
+		paren, ok := selStmt.(*tree.ParenSelect)
+		if !ok {
+			break
+		}
+		if paren.Select.With != nil || paren.Select.Limit != nil {
+			return false
+		}
+		selStmt = paren.Select.Select
+	}
+	clause, ok := selStmt.(*tree.SelectClause)
+	if !ok || clause.Distinct || clause.DistinctOn != nil ||
+		len(clause.Window) > 0 || clause.From == nil || len(clause.From.Tables) != 1 {
+		return false
+	}
+	searchPath := p.SessionData().SearchPath
+	if p.txCtx.IsAggregate(clause, searchPath) {
+		return false
+	}
+
+	// The source must be a table or an updatable view.
+	source, ok := clause.From.Tables[0].(*tree.AliasedTableExpr)
+	if !ok || source.Ordinality {
+		return false
+	}
+	ntn, ok := source.Expr.(*tree.NormalizableTableName)
+	if !ok {
+		return false
+	}
+	tn, err := ntn.Normalize()
+	if err != nil {
+		return false
+	}
+	var sourceDesc *sqlbase.TableDescriptor
+	for _, id := range view.DependsOn {
+		db, table := tableLookup(id)
+		if table != nil && table.Name == tn.Table() && db.Name == tn.Schema() {
+			sourceDesc = table
+			break
+		}
+	}
+	switch {
+	case sourceDesc == nil, sourceDesc.IsSequence():
+		return false
+	case sourceDesc.IsView():
+		if !p.isUpdatableView(sourceDesc, tableLookup) {
+			return false
+		}
+	}
+
+	hasColumn := clause.TableSelect
+	for _, expr := range clause.Exprs {
+		if p.txCtx.WindowFuncInExpr(expr.Expr) || containsGenerator(expr.Expr, searchPath) {
+			return false
+		}
+		if name, ok := expr.Expr.(*tree.UnresolvedName); ok {
+			if vn, err := name.NormalizeVarName(); err == nil {
+				_, isColumn := vn.(*tree.ColumnItem)
+				hasColumn = hasColumn || isColumn
+			}
+		}
+	}
+	return hasColumn
+}
+
+// containsGenerator determines whether the expression calls a
+// set-returning function, outside of subqueries.
+func containsGenerator(expr tree.Expr, searchPath sessiondata.SearchPath) bool {
+	v := containsGeneratorVisitor{searchPath: searchPath}
+	tree.WalkExprConst(&v, expr)
+	return v.found
+}
+
+type containsGeneratorVisitor struct {
+	searchPath sessiondata.SearchPath
+	found      bool
+}
+
+var _ tree.Visitor = &containsGeneratorVisitor{}
+
+func (v *containsGeneratorVisitor) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	switch t := expr.(type) {
+	case *tree.FuncExpr:
+		if fd, err := t.Func.Resolve(v.searchPath); err == nil {
+			if _, ok := builtins.Generators[fd.Name]; ok {
+				v.found = true
+				return false, expr
+			}
+		}
+	case *tree.Subquery:
+		return false, expr
+	}
+	return !v.found, expr
+}
+
+func (*containsGeneratorVisitor) VisitPost(expr tree.Expr) tree.Expr { return expr }
@@ -16,16 +16,19 @@ package sql
 
 import (
 	"context"
-	"fmt"
 	"sort"
 
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/pkg/errors"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/sql/distsqlrun"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 )
 
@@ -43,6 +46,7 @@ import (
 type virtualSchema struct {
 	name           string
 	tables         []virtualSchemaTable
+	functions      []virtualSchemaFunction              // optional
 	tableValidator func(*sqlbase.TableDescriptor) error // optional
 }
 
@@ -50,6 +54,158 @@ type virtualSchema struct {
 type virtualSchemaTable struct {
 	schema   string
 	populate func(ctx context.Context, p *planner, prefix string, addRow func(...tree.Datum) error) error
+
+	// deprecatedColumns maps the names of the columns slated for removal
+	// to a hint for the clients still using them, typically naming the
+	// replacement. Clients referencing such a column receive a notice,
+	// once per session. Optional.
+	deprecatedColumns map[string]string
+
+	// update plans an UPDATE of the virtual table, for the virtual tables
+	// that can be updated. Optional.
+	update virtualTableUpdater
+
+	// minVersion is the cluster version from which the table is served, for
+	// the tables added since the previous release. Until the cluster runs
+	// it, i.e. all its nodes have been upgraded, the table is hidden on every
+	// node, as it is on the nodes that do not know it. Optional.
+	minVersion cluster.VersionKey
+	// featureFlag is the name of the boolean cluster setting turning the
+	// table on, for the tables added behind a feature flag. The table is
+	// hidden while the setting is off. Optional.
+	featureFlag string
+
+	// currentOnly is set for the tables reporting the current state of the
+	// nodes, e.g. their sessions or metrics, which can't be queried AS OF
+	// SYSTEM TIME. The other tables are populated from what the transaction
+	// reads, descriptors and system tables alike, so that they report the
+	// state as of the time of the query. Optional.
+	currentOnly bool
+
+	// keyColumns are the columns whose values the tables can be populated
+	// for directly, e.g. the names of the tables they describe. The values
+	// which the filters of a query constrain them to are passed to
+	// populateConstrained, which is then used instead of populate, so that
+	// a point lookup doesn't produce the rows of every descriptor. A
+	// constraint may not narrow the population: pg_class, for instance, is
+	// populated for every table when its oid is constrained to the OID of an
+	// index or of a virtual table, which aren't descriptor IDs, or to the ID
+	// of a table the user can't see. Optional.
+	keyColumns          []string
+	populateConstrained func(ctx context.Context, p *planner, prefix string, constraints virtualConstraints, addRow func(...tree.Datum) error) error
+
+	// gather is set instead of populate for the tables whose rows are those
+	// of a node-local table, e.g. crdb_internal.node_sessions, on all the
+	// nodes of the cluster. It names the node-local table, whose rows are
+	// read by a VirtualTableReader processor on every node; DistSQL runs
+	// them in parallel. See nodeLocalTables. Optional.
+	gather string
+}
+
+// populateRows populates the table with the rows matching the given
+// constraints, which may be nil. It may produce rows that don't match
+// them: the filters they come from are still applied to the rows.
+func (t virtualSchemaTable) populateRows(
+	ctx context.Context,
+	p *planner,
+	prefix string,
+	constraints virtualConstraints,
+	addRow func(...tree.Datum) error,
+) error {
+	if t.populateConstrained != nil {
+		return t.populateConstrained(ctx, p, prefix, constraints, addRow)
+	}
+	return t.populate(ctx, p, prefix, addRow)
+}
+
+// virtualConstraints are the values which the filters of a query constrain
+// the key columns of a virtual table to, keyed by column name. For example,
+// WHERE table_name = 't' constrains table_name to 't'.
+type virtualConstraints map[string]tree.Datum
+
+// getString returns the string a column is constrained to, if any.
+func (c virtualConstraints) getString(colName string) (string, bool) {
+	if s, ok := c[colName].(*tree.DString); ok {
+		return string(*s), true
+	}
+	return "", false
+}
+
+// getDescriptorID returns the descriptor ID an OID column is constrained to,
+// if any.
+func (c virtualConstraints) getDescriptorID(colName string) (sqlbase.ID, bool) {
+	if o, ok := c[colName].(*tree.DOid); ok && o.DInt > 0 {
+		return sqlbase.ID(o.DInt), true
+	}
+	return 0, false
+}
+
+// addFilter adds the constraints on the given key columns of the equality
+// conjuncts of a filter on the given columns, like `col = 'value'`.
+func (c virtualConstraints) addFilter(
+	evalCtx *tree.EvalContext,
+	filter tree.TypedExpr,
+	columns sqlbase.ResultColumns,
+	keyColumns []string,
+) {
+	switch t := filter.(type) {
+	case *tree.AndExpr:
+		c.addFilter(evalCtx, t.TypedLeft(), columns, keyColumns)
+		c.addFilter(evalCtx, t.TypedRight(), columns, keyColumns)
+
+	case *tree.ComparisonExpr:
+		if t.Operator != tree.EQ {
+			return
+		}
+		v, value := t.TypedLeft(), t.TypedRight()
+		if _, ok := v.(*tree.IndexedVar); !ok {
+			v, value = value, v
+		}
+		iv, ok := v.(*tree.IndexedVar)
+		if !ok || iv.Idx >= len(columns) {
+			return
+		}
+		colName := columns[iv.Idx].Name
+		if _, ok := c[colName]; ok || !isKeyColumn(colName, keyColumns) {
+			return
+		}
+		if !tree.IsConst(evalCtx, value) {
+			return
+		}
+		d, err := value.Eval(evalCtx)
+		if err != nil || d == tree.DNull {
+			// The filter fails on its own when the table is scanned.
+			return
+		}
+		c[colName] = d
+	}
+}
+
+func isKeyColumn(colName string, keyColumns []string) bool {
+	for _, k := range keyColumns {
+		if k == colName {
+			return true
+		}
+	}
+	return false
+}
+
+// virtualTableUpdater plans an UPDATE of a virtual table.
+type virtualTableUpdater func(ctx context.Context, p *planner, n *tree.Update) (planNode, error)
+
+// virtualSchemaFunction represents a table function within a virtualSchema.
+// Table functions are virtual tables whose contents depend on arguments, and
+// are used as data sources like set-returning functions, for example
+// `SELECT * FROM crdb_internal.table_columns('db.t')`. They allow targeted
+// introspection without visiting every descriptor.
+//
+// The schema is a CREATE TABLE statement describing the result columns, and
+// the table name is used as the function name. Table functions must be
+// called with their schema name as prefix.
+type virtualSchemaFunction struct {
+	schema   string
+	argTypes []types.T
+	populate func(ctx context.Context, p *planner, args tree.Datums, addRow func(...tree.Datum) error) error
 }
 
 // virtualSchemas holds a slice of statically registered virtualSchema objects.
@@ -81,11 +237,15 @@ type virtualSchemaEntry struct {
 	desc              *sqlbase.DatabaseDescriptor
 	tables            map[string]virtualTableEntry
 	orderedTableNames []string
+	functions         map[string]virtualFunctionEntry
 }
 
 func (e virtualSchemaEntry) tableNames(explicitSchema bool) tree.TableNames {
 	var res tree.TableNames
 	for _, tableName := range e.orderedTableNames {
+		if !e.tables[tableName].isActive() {
+			continue
+		}
 		res = append(res, tree.MakeTableName(tree.Name(e.desc.Name), tree.Name(tableName)))
 	}
 	return res
@@ -94,52 +254,113 @@ func (e virtualSchemaEntry) tableNames(explicitSchema bool) tree.TableNames {
 type virtualTableEntry struct {
 	tableDef virtualSchemaTable
 	desc     *sqlbase.TableDescriptor
+	// deprecationNotices maps the ordinals of the deprecated columns to
+	// the notice sent when they are referenced.
+	deprecationNotices map[int]*pgerror.Error
+	// st and featureFlag are used to tell whether the table is active.
+	st          *cluster.Settings
+	featureFlag *settings.BoolSetting
 }
 
-type virtualTableConstructor func(context.Context, *planner, string) (planNode, error)
+// isActive returns whether the table is served: the cluster runs its
+// minimum version, and its feature flag is on. The tables that are not
+// active are hidden, as if they did not exist.
+func (e virtualTableEntry) isActive() bool {
+	if v := e.tableDef.minVersion; v != cluster.VersionBase && !e.st.Version.IsActive(v) {
+		return false
+	}
+	return e.featureFlag == nil || e.featureFlag.Get(&e.st.SV)
+}
+
+type virtualTableConstructor func(
+	context.Context, *planner, string, virtualConstraints,
+) (planNode, error)
 
 // getPlanInfo returns the column metadata and a constructor for a new
-// valuesNode for the virtual table. We use deferred construction here
-// so as to avoid populating a RowContainer during query preparation,
-// where we can't guarantee it will be Close()d in case of error.
+// virtualTableNode streaming the rows of the virtual table. name is the name
+// of the table visible in EXPLAIN.
 func (e virtualTableEntry) getPlanInfo(
-	ctx context.Context,
+	name string,
 ) (sqlbase.ResultColumns, virtualTableConstructor) {
-	var columns sqlbase.ResultColumns
-	for _, col := range e.desc.Columns {
-		columns = append(columns, sqlbase.ResultColumn{
-			Name: col.Name,
-			Typ:  col.Type.ToDatumType(),
-		})
+	columns := virtualDescColumns(e.desc)
+
+	constructor := func(
+		ctx context.Context, p *planner, prefix string, constraints virtualConstraints,
+	) (planNode, error) {
+		if e.tableDef.gather != "" {
+			spec, err := e.gatherSpec(ctx, p, name)
+			if err != nil {
+				return nil, err
+			}
+			n := newVirtualTableNode(p.ExecCfg().DistSQLSrv.Stopper, name, columns,
+				func(ctx context.Context, addRow func(...tree.Datum) error) error {
+					return p.gatherNodeLocalRows(ctx, &spec, addRow)
+				})
+			n.nodeLocal = &spec
+			return n, nil
+		}
+		return newVirtualTableNode(p.ExecCfg().DistSQLSrv.Stopper, name, columns,
+			func(ctx context.Context, addRow func(...tree.Datum) error) error {
+				return e.tableDef.populateRows(ctx, p, prefix, constraints, addRow)
+			}), nil
 	}
 
-	constructor := func(ctx context.Context, p *planner, prefix string) (planNode, error) {
-		v := p.newContainerValuesNode(columns, 0)
+	return columns, constructor
+}
 
-		err := e.tableDef.populate(ctx, p, prefix, func(datums ...tree.Datum) error {
-			if r, c := len(datums), len(v.columns); r != c {
-				panic(fmt.Sprintf("datum row count and column count differ: %d vs %d", r, c))
-			}
-			for i, col := range v.columns {
-				datum := datums[i]
-				if !(datum == tree.DNull || datum.ResolvedType().Equivalent(col.Typ)) {
-					panic(fmt.Sprintf("datum column %q expected to be type %s; found type %s",
-						col.Name, col.Typ, datum.ResolvedType()))
-				}
-			}
-			_, err := v.rows.AddRow(ctx, datums)
-			return err
-		})
-		if err != nil {
-			v.Close(ctx)
-			return nil, err
+// gatherSpec returns the spec of the VirtualTableReaders gathering the rows of
+// the table, which must have gather set, for the user of the planner. name is
+// the name of the table used in the error returned when the user may not read
+// it.
+func (e virtualTableEntry) gatherSpec(
+	ctx context.Context, p *planner, name string,
+) (distsqlrun.VirtualTableReaderSpec, error) {
+	if nodeLocalTables[e.tableDef.gather].superuserOnly {
+		if err := p.RequireSuperUser(ctx, "read "+name); err != nil {
+			return distsqlrun.VirtualTableReaderSpec{}, err
 		}
-		return v, nil
+	}
+	return makeVirtualTableReaderSpec(
+		e.tableDef.gather, virtualDescColumns(e.desc), p.SessionData().User,
+	)
+}
+
+type virtualFunctionEntry struct {
+	funcDef virtualSchemaFunction
+	desc    *sqlbase.TableDescriptor
+}
+
+type virtualFunctionConstructor func(context.Context, *planner, tree.Datums) (planNode, error)
+
+// getPlanInfo returns the column metadata and a constructor for a new
+// virtualTableNode streaming the rows of the table function, given the
+// evaluated arguments. See virtualTableEntry.getPlanInfo.
+func (e virtualFunctionEntry) getPlanInfo(
+	name string,
+) (sqlbase.ResultColumns, virtualFunctionConstructor) {
+	columns := virtualDescColumns(e.desc)
+
+	constructor := func(_ context.Context, p *planner, args tree.Datums) (planNode, error) {
+		return newVirtualTableNode(p.ExecCfg().DistSQLSrv.Stopper, name, columns,
+			func(ctx context.Context, addRow func(...tree.Datum) error) error {
+				return e.funcDef.populate(ctx, p, args, addRow)
+			}), nil
 	}
 
 	return columns, constructor
 }
 
+func virtualDescColumns(desc *sqlbase.TableDescriptor) sqlbase.ResultColumns {
+	var columns sqlbase.ResultColumns
+	for _, col := range desc.Columns {
+		columns = append(columns, sqlbase.ResultColumn{
+			Name: col.Name,
+			Typ:  col.Type.ToDatumType(),
+		})
+	}
+	return columns
+}
+
 // NewVirtualSchemaHolder creates a new VirtualSchemaHolder.
 func NewVirtualSchemaHolder(
 	ctx context.Context, st *cluster.Settings,
@@ -154,7 +375,7 @@ func NewVirtualSchemaHolder(
 		tables := make(map[string]virtualTableEntry, len(schema.tables))
 		orderedTableNames := make([]string, 0, len(schema.tables))
 		for _, table := range schema.tables {
-			tableDesc, err := initVirtualTableDesc(ctx, table, st)
+			tableDesc, err := initVirtualTableDesc(ctx, table.schema, st)
 			if err != nil {
 				return nil, errors.Wrapf(err, "failed to initialize %s", table.schema)
 			}
@@ -164,17 +385,52 @@ func NewVirtualSchemaHolder(
 					return nil, errors.Wrap(err, "programmer error")
 				}
 			}
+			notices, err := makeDeprecationNotices(dbName, &tableDesc, table.deprecatedColumns)
+			if err != nil {
+				return nil, errors.Wrap(err, "programmer error")
+			}
+			featureFlag, err := lookupFeatureFlag(table.featureFlag)
+			if err != nil {
+				return nil, errors.Wrapf(err, "programmer error: %s.%s", dbName, tableDesc.Name)
+			}
+			if err := checkKeyColumns(&tableDesc, table); err != nil {
+				return nil, errors.Wrapf(err, "programmer error: %s.%s", dbName, tableDesc.Name)
+			}
+			if err := checkGather(table); err != nil {
+				return nil, errors.Wrapf(err, "programmer error: %s.%s", dbName, tableDesc.Name)
+			}
 			tables[tableDesc.Name] = virtualTableEntry{
-				tableDef: table,
-				desc:     &tableDesc,
+				tableDef:           table,
+				desc:               &tableDesc,
+				deprecationNotices: notices,
+				st:                 st,
+				featureFlag:        featureFlag,
 			}
 			orderedTableNames = append(orderedTableNames, tableDesc.Name)
 		}
 		sort.Strings(orderedTableNames)
+		functions := make(map[string]virtualFunctionEntry, len(schema.functions))
+		for _, function := range schema.functions {
+			funcDesc, err := initVirtualTableDesc(ctx, function.schema, st)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to initialize %s", function.schema)
+			}
+
+			if schema.tableValidator != nil {
+				if err := schema.tableValidator(&funcDesc); err != nil {
+					return nil, errors.Wrap(err, "programmer error")
+				}
+			}
+			functions[funcDesc.Name] = virtualFunctionEntry{
+				funcDef: function,
+				desc:    &funcDesc,
+			}
+		}
 		vs.entries[dbName] = virtualSchemaEntry{
 			desc:              dbDesc,
 			tables:            tables,
 			orderedTableNames: orderedTableNames,
+			functions:         functions,
 		}
 		vs.orderedNames[i] = dbName
 	}
@@ -182,6 +438,88 @@ func NewVirtualSchemaHolder(
 	return vs, nil
 }
 
+// makeDeprecationNotices creates the notices to send when the deprecated
+// columns of a virtual table are referenced, keyed by column ordinal.
+func makeDeprecationNotices(
+	schemaName string, desc *sqlbase.TableDescriptor, deprecatedColumns map[string]string,
+) (map[int]*pgerror.Error, error) {
+	if len(deprecatedColumns) == 0 {
+		return nil, nil
+	}
+	notices := make(map[int]*pgerror.Error, len(deprecatedColumns))
+	for colName, hint := range deprecatedColumns {
+		found := false
+		for i := range desc.Columns {
+			if desc.Columns[i].Name != colName {
+				continue
+			}
+			notice := pgerror.NewErrorf(pgerror.CodeWarningDeprecatedFeatureError,
+				"column %s.%s.%s is deprecated", schemaName, desc.Name, colName)
+			notice.Hint = hint
+			notices[i] = notice
+			found = true
+			break
+		}
+		if !found {
+			return nil, errors.Errorf("deprecated column %s.%s.%s does not exist",
+				schemaName, desc.Name, colName)
+		}
+	}
+	return notices, nil
+}
+
+// checkKeyColumns checks that the key columns of a virtual table exist, and
+// that it can be populated given their constraints.
+func checkKeyColumns(desc *sqlbase.TableDescriptor, table virtualSchemaTable) error {
+	if len(table.keyColumns) == 0 {
+		return nil
+	}
+	if table.populateConstrained == nil {
+		return errors.New("key columns require populateConstrained")
+	}
+	for _, colName := range table.keyColumns {
+		if _, err := desc.FindActiveColumnByName(colName); err != nil {
+			return errors.Errorf("key column %s does not exist", colName)
+		}
+	}
+	return nil
+}
+
+// checkGather checks that a table gathering the rows of a node-local table
+// names a known one, and that the other tables can be populated.
+func checkGather(table virtualSchemaTable) error {
+	if table.gather == "" {
+		if table.populate == nil && table.populateConstrained == nil {
+			return errors.New("populate or gather required")
+		}
+		return nil
+	}
+	if table.populate != nil || table.populateConstrained != nil {
+		return errors.New("gather excludes populate")
+	}
+	if _, ok := nodeLocalTables[table.gather]; !ok {
+		return errors.Errorf("unknown node-local table %s", table.gather)
+	}
+	return nil
+}
+
+// lookupFeatureFlag returns the boolean cluster setting with the given name,
+// or nil if the name is empty.
+func lookupFeatureFlag(name string) (*settings.BoolSetting, error) {
+	if name == "" {
+		return nil, nil
+	}
+	s, ok := settings.Lookup(name)
+	if !ok {
+		return nil, errors.Errorf("feature flag %s is not a cluster setting", name)
+	}
+	b, ok := s.(*settings.BoolSetting)
+	if !ok {
+		return nil, errors.Errorf("feature flag %s is a %s setting, not a bool", name, s.Typ())
+	}
+	return b, nil
+}
+
 // Virtual databases and tables each have an empty set of privileges. In practice,
 // all users have SELECT privileges on the database/tables, but this is handled
 // separately from normal SELECT privileges, because the virtual schemas need more
@@ -198,9 +536,9 @@ func initVirtualDatabaseDesc(name string) *sqlbase.DatabaseDescriptor {
 }
 
 func initVirtualTableDesc(
-	ctx context.Context, t virtualSchemaTable, st *cluster.Settings,
+	ctx context.Context, schema string, st *cluster.Settings,
 ) (sqlbase.TableDescriptor, error) {
-	stmt, err := parser.ParseOne(t.schema)
+	stmt, err := parser.ParseOne(schema)
 	if err != nil {
 		return sqlbase.TableDescriptor{}, err
 	}
@@ -272,7 +610,7 @@ func (e *Executor) IsVirtualDatabase(name string) bool {
 // getVirtualTableEntry is part of the VirtualTabler interface.
 func (vs *VirtualSchemaHolder) getVirtualTableEntry(tn *tree.TableName) (virtualTableEntry, error) {
 	if db, ok := vs.getVirtualSchemaEntry(string(tn.SchemaName)); ok {
-		if t, ok := db.tables[string(tn.TableName)]; ok {
+		if t, ok := db.tables[string(tn.TableName)]; ok && t.isActive() {
 			return t, nil
 		}
 		return virtualTableEntry{}, sqlbase.NewUndefinedRelationError(tn)
@@ -280,12 +618,26 @@ func (vs *VirtualSchemaHolder) getVirtualTableEntry(tn *tree.TableName) (virtual
 	return virtualTableEntry{}, nil
 }
 
+// getVirtualFunctionEntry checks if the provided names match a virtual
+// database/table function pair, and returns the function's entry if they do.
+// getVirtualFunctionEntry is part of the VirtualTabler interface.
+func (vs *VirtualSchemaHolder) getVirtualFunctionEntry(
+	schemaName, funcName string,
+) (virtualFunctionEntry, bool) {
+	if db, ok := vs.getVirtualSchemaEntry(schemaName); ok {
+		f, ok := db.functions[funcName]
+		return f, ok
+	}
+	return virtualFunctionEntry{}, false
+}
+
 // VirtualTabler is used to fetch descriptors for virtual tables and databases.
 type VirtualTabler interface {
 	getVirtualTableDesc(tn *tree.TableName) (*sqlbase.TableDescriptor, error)
 	getVirtualDatabaseDesc(name string) *sqlbase.DatabaseDescriptor
 	getVirtualSchemaEntry(name string) (virtualSchemaEntry, bool)
 	getVirtualTableEntry(tn *tree.TableName) (virtualTableEntry, error)
+	getVirtualFunctionEntry(schemaName, funcName string) (virtualFunctionEntry, bool)
 	isVirtualDatabase(name string) bool
 	getEntries() map[string]virtualSchemaEntry
 }
@@ -332,6 +684,12 @@ func (nilVirtualTabler) getVirtualTableEntry(tn *tree.TableName) (virtualTableEn
 	return virtualTableEntry{}, nil
 }
 
+func (nilVirtualTabler) getVirtualFunctionEntry(
+	schemaName, funcName string,
+) (virtualFunctionEntry, bool) {
+	return virtualFunctionEntry{}, false
+}
+
 func (nilVirtualTabler) isVirtualDatabase(name string) bool {
 	return false
 }
@@ -339,3 +697,52 @@ func (nilVirtualTabler) isVirtualDatabase(name string) bool {
 func (nilVirtualTabler) getEntries() map[string]virtualSchemaEntry {
 	return nil
 }
+
+// getVirtualTableUpdater returns the function planning the UPDATE of the
+// given table if it is a virtual table that can be updated, or nil
+// otherwise.
+func (p *planner) getVirtualTableUpdater(
+	ctx context.Context, target tree.TableExpr,
+) (virtualTableUpdater, error) {
+	if ate, ok := target.(*tree.AliasedTableExpr); ok {
+		target = ate.Expr
+	}
+	t, ok := target.(*tree.NormalizableTableName)
+	if !ok {
+		return nil, nil
+	}
+	tn, err := t.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	if tn.SchemaName == "" {
+		// The unqualified names are only resolved if a virtual schema on the
+		// search path has an updatable table with this name, so that
+		// updating regular tables does not resolve their name twice.
+		if !p.isUpdatableVirtualTableOnSearchPath(string(tn.TableName)) {
+			return nil, nil
+		}
+		qualified := *tn
+		if err := p.searchAndQualifyDatabase(ctx, &qualified); err != nil {
+			return nil, err
+		}
+		tn = &qualified
+	}
+	entry, err := p.getVirtualTabler().getVirtualTableEntry(tn)
+	if err != nil {
+		return nil, err
+	}
+	return entry.tableDef.update, nil
+}
+
+func (p *planner) isUpdatableVirtualTableOnSearchPath(name string) bool {
+	iter := p.SessionData().SearchPath.Iter()
+	for schemaName, ok := iter(); ok; schemaName, ok = iter() {
+		if schema, ok := p.getVirtualTabler().getVirtualSchemaEntry(schemaName); ok {
+			if t, ok := schema.tables[name]; ok && t.tableDef.update != nil && t.isActive() {
+				return true
+			}
+		}
+	}
+	return false
+}
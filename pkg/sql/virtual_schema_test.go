@@ -0,0 +1,110 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestVirtualTableEntryIsActive(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	v := cluster.VersionByKey(cluster.VersionPartitioning)
+	st := cluster.MakeTestingClusterSettingsWithVersion(v, v)
+
+	flag, err := lookupFeatureFlag("sql.metrics.statement_details.enabled")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lookupFeatureFlag("sql.metrics.statement_details.threshold"); err == nil {
+		t.Fatal("expected an error for a setting that is not a boolean")
+	}
+	if _, err := lookupFeatureFlag("nonexistent"); err == nil {
+		t.Fatal("expected an error for an unknown setting")
+	}
+
+	testData := []struct {
+		minVersion cluster.VersionKey
+		withFlag   bool
+		flagValue  bool
+		expected   bool
+	}{
+		{cluster.VersionBase, false, false, true},
+		{cluster.VersionClearRange, false, false, true},
+		{cluster.VersionPartitioning, false, false, true},
+		{cluster.VersionTxnSpanRefresh, false, false, false},
+		{cluster.VersionBase, true, true, true},
+		{cluster.VersionBase, true, false, false},
+		{cluster.VersionTxnSpanRefresh, true, true, false},
+	}
+	for _, d := range testData {
+		e := virtualTableEntry{
+			tableDef: virtualSchemaTable{minVersion: d.minVersion},
+			st:       st,
+		}
+		if d.withFlag {
+			e.featureFlag = flag
+			flag.Override(&st.SV, d.flagValue)
+		}
+		if active := e.isActive(); active != d.expected {
+			t.Errorf("%+v: expected active %t, got %t", d, d.expected, active)
+		}
+	}
+}
+
+func TestVirtualConstraintsAddFilter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testData := []struct {
+		expr     string
+		expected string
+	}{
+		{`a = 1`, `a=1`},
+		{`i = 'foo' AND a = 2`, `a=2 i='foo'`},
+		{`'foo' = i`, `i='foo'`},
+		{`i = lower('FOO') AND c`, `i='foo'`},
+		{`a > 1`, ``},
+		{`b = 1`, ``},
+		{`a = b`, ``},
+		{`a = 1 OR i = 'foo'`, ``},
+	}
+	p := makeTestPlanner()
+	for _, d := range testData {
+		t.Run(d.expr, func(t *testing.T) {
+			p.extendedEvalCtx = makeTestingExtendedEvalContext()
+			defer p.extendedEvalCtx.Stop(context.Background())
+			sel := makeSelectNode(t, p)
+			expr := parseAndNormalizeExpr(t, p, d.expr, sel)
+
+			c := make(virtualConstraints)
+			c.addFilter(p.EvalContext(), expr, sel.sourceInfo[0].sourceColumns, []string{"a", "i"})
+			var constraints []string
+			for colName, v := range c {
+				constraints = append(constraints, fmt.Sprintf("%s=%s", colName, v))
+			}
+			sort.Strings(constraints)
+			if s := strings.Join(constraints, " "); s != d.expected {
+				t.Errorf("expected %q, got %q", d.expected, s)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/distsqlrun"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+)
+
+// virtualTableNode streams the rows of a virtual table or table function
+// as they are produced by its populate function, so that the consumers of
+// large virtual tables, like joins against user tables, run in bounded
+// memory instead of waiting for all the rows to be buffered.
+//
+// The populate function runs in its own goroutine, an async task of the
+// stopper which is started by the first call to Next. The rows are pulled one at a time: populate only runs
+// while the consumer waits in Next, and the consumer only runs while
+// populate waits in addRow. The planner is thus never used concurrently.
+//
+// When a LIMIT of at most maxInlineVirtualTableLimit rows is pushed down,
+// populate instead runs inline until it produced the rows needed, which
+// saves the goroutine and the handoffs of each row between it and the
+// consumer.
+type virtualTableNode struct {
+	// name is the name of the virtual table, visible in EXPLAIN.
+	name     string
+	columns  sqlbase.ResultColumns
+	populate func(ctx context.Context, addRow func(...tree.Datum) error) error
+	// stopper runs the goroutine of populate.
+	stopper *stop.Stopper
+	// nodeLocal is set for the tables gathering the rows of a node-local
+	// table from every node. It is the spec of the VirtualTableReaders which
+	// DistSQL plans instead of the node; see virtualSchemaTable.gather.
+	nodeLocal *distsqlrun.VirtualTableReaderSpec
+
+	// hardLimit, if not 0, is the number of rows the consumer needs at
+	// most, as set by applyLimit.
+	hardLimit int64
+
+	run virtualTableRun
+}
+
+// virtualTableRun contains the run-time state of virtualTableNode during
+// local execution.
+type virtualTableRun struct {
+	started, done bool
+	// rows receives the rows produced by populate, and is closed when
+	// populate returns.
+	rows chan tree.Datums
+	// resume is sent on by Next to let populate produce the next row.
+	resume chan struct{}
+	// cancel is closed by Close to stop populate early.
+	cancel chan struct{}
+	// err is the error populate returned. It is set before rows is closed.
+	err error
+
+	// inline is set when populate ran inline, in which case buffered holds
+	// the rows it produced.
+	inline   bool
+	buffered []tree.Datums
+	// numRows is the number of rows returned by Next.
+	numRows int64
+
+	values tree.Datums
+}
+
+// maxInlineVirtualTableLimit is the largest hard limit for which populate
+// runs inline, buffering the rows it produces.
+const maxInlineVirtualTableLimit = 1000
+
+// errVirtualTableClosed is returned by addRow when the consumer closed the
+// virtualTableNode before populate produced all the rows.
+var errVirtualTableClosed = errors.New("virtual table closed")
+
+// errVirtualTableLimitReached is returned by addRow when populate ran inline
+// and produced all the rows the consumer needs.
+var errVirtualTableLimitReached = errors.New("virtual table limit reached")
+
+func newVirtualTableNode(
+	stopper *stop.Stopper,
+	name string,
+	columns sqlbase.ResultColumns,
+	populate func(ctx context.Context, addRow func(...tree.Datum) error) error,
+) *virtualTableNode {
+	return &virtualTableNode{name: name, columns: columns, populate: populate, stopper: stopper}
+}
+
+func (n *virtualTableNode) start(ctx context.Context) {
+	n.run.started = true
+	if n.hardLimit > 0 && n.hardLimit <= maxInlineVirtualTableLimit {
+		n.run.inline = true
+		n.run.buffered = make([]tree.Datums, 0, n.hardLimit)
+		n.run.err = n.populate(ctx, func(datums ...tree.Datum) error {
+			n.run.buffered = append(n.run.buffered, datums)
+			if int64(len(n.run.buffered)) >= n.hardLimit {
+				return errVirtualTableLimitReached
+			}
+			return nil
+		})
+		if n.run.err == errVirtualTableLimitReached {
+			n.run.err = nil
+		}
+		return
+	}
+	n.run.rows = make(chan tree.Datums)
+	n.run.resume = make(chan struct{})
+	n.run.cancel = make(chan struct{})
+	if err := n.stopper.RunAsyncTask(ctx, "virtual table populate", func(ctx context.Context) {
+		defer close(n.run.rows)
+		defer func() {
+			// A panic would otherwise crash the node, as the goroutine is
+			// not covered by the recovery of the statement execution.
+			if r := recover(); r != nil {
+				log.Errorf(ctx, "panic while populating virtual table %s: %v", n.name, r)
+				n.run.err = pgerror.NewErrorf(pgerror.CodeInternalError,
+					"panic while populating virtual table %s: %v", n.name, r)
+			}
+		}()
+		err := n.populate(ctx, func(datums ...tree.Datum) error {
+			select {
+			case n.run.rows <- datums:
+			case <-n.run.cancel:
+				return errVirtualTableClosed
+			}
+			select {
+			case <-n.run.resume:
+				return nil
+			case <-n.run.cancel:
+				return errVirtualTableClosed
+			}
+		})
+		if err != errVirtualTableClosed {
+			n.run.err = err
+		}
+	}); err != nil {
+		n.run.err = err
+		close(n.run.rows)
+	}
+}
+
+func (n *virtualTableNode) Next(params runParams) (bool, error) {
+	if n.run.done {
+		return false, nil
+	}
+	if n.hardLimit > 0 && n.run.numRows >= n.hardLimit {
+		// Stop populate right away rather than when the node is closed.
+		n.Close(params.ctx)
+		return false, nil
+	}
+	if !n.run.started {
+		n.start(params.ctx)
+	} else if !n.run.inline {
+		n.run.resume <- struct{}{}
+	}
+	var datums tree.Datums
+	if n.run.inline {
+		if n.run.numRows >= int64(len(n.run.buffered)) {
+			n.run.done = true
+			return false, n.run.err
+		}
+		datums = n.run.buffered[n.run.numRows]
+	} else {
+		var ok bool
+		if datums, ok = <-n.run.rows; !ok {
+			n.run.done = true
+			return false, n.run.err
+		}
+	}
+	n.run.numRows++
+	if r, c := len(datums), len(n.columns); r != c {
+		panic(fmt.Sprintf("datum row count and column count differ: %d vs %d", r, c))
+	}
+	for i, col := range n.columns {
+		datum := datums[i]
+		if !(datum == tree.DNull || datum.ResolvedType().Equivalent(col.Typ)) {
+			panic(fmt.Sprintf("datum column %q expected to be type %s; found type %s",
+				col.Name, col.Typ, datum.ResolvedType()))
+		}
+	}
+	n.run.values = datums
+	return true, nil
+}
+
+func (n *virtualTableNode) Values() tree.Datums { return n.run.values }
+
+func (n *virtualTableNode) Close(context.Context) {
+	n.run.buffered = nil
+	if n.run.started && !n.run.done && !n.run.inline {
+		// Stop populate, which is waiting for the consumer, and wait for it
+		// to return.
+		close(n.run.cancel)
+		for range n.run.rows {
+		}
+		n.run.done = true
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/types"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+)
+
+func TestVirtualTableNodeLimit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(context.Background())
+
+	testData := []struct {
+		rows     int
+		limit    int64
+		soft     bool
+		expected int
+	}{
+		{10, math.MaxInt64, true, 10},
+		{10, 3, false, 3},
+		{10, 3, true, 10},
+		{10, 20, false, 10},
+		{2 * maxInlineVirtualTableLimit, maxInlineVirtualTableLimit + 1, false, maxInlineVirtualTableLimit + 1},
+	}
+	for _, d := range testData {
+		t.Run(fmt.Sprintf("%d/%d/%t", d.rows, d.limit, d.soft), func(t *testing.T) {
+			var produced int
+			n := newVirtualTableNode(stopper, "test",
+				sqlbase.ResultColumns{{Name: "i", Typ: types.Int}},
+				func(ctx context.Context, addRow func(...tree.Datum) error) error {
+					for i := 0; i < d.rows; i++ {
+						if err := addRow(tree.NewDInt(tree.DInt(i))); err != nil {
+							return err
+						}
+						produced++
+					}
+					return nil
+				})
+			(&planner{}).applyLimit(n, d.limit, d.soft)
+
+			params := runParams{ctx: context.Background()}
+			var count int
+			for {
+				next, err := n.Next(params)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !next {
+					break
+				}
+				if v := int(*n.Values()[0].(*tree.DInt)); v != count {
+					t.Fatalf("expected row %d, got %d", count, v)
+				}
+				count++
+			}
+			n.Close(params.ctx)
+
+			if count != d.expected {
+				t.Errorf("expected %d rows, got %d", d.expected, count)
+			}
+			// populate must not produce more rows than the consumer needs.
+			if produced > d.expected {
+				t.Errorf("expected at most %d rows to be produced, got %d", d.expected, produced)
+			}
+		})
+	}
+}
+
+func TestVirtualTableNodePanic(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(context.Background())
+
+	n := newVirtualTableNode(stopper, "test",
+		sqlbase.ResultColumns{{Name: "i", Typ: types.Int}},
+		func(ctx context.Context, addRow func(...tree.Datum) error) error {
+			if err := addRow(tree.NewDInt(1)); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	params := runParams{ctx: context.Background()}
+	defer n.Close(params.ctx)
+
+	if next, err := n.Next(params); err != nil || !next {
+		t.Fatalf("expected a row, got %t, %v", next, err)
+	}
+	if _, err := n.Next(params); !testutils.IsError(err, "panic while populating virtual table test: boom") {
+		t.Fatalf("expected the panic to be returned, got %v", err)
+	}
+}
@@ -158,6 +158,26 @@ var backwardCompatibleMigrations = []migrationDescriptor{
 		name:   "add default system.jobs zone config",
 		workFn: addDefaultSystemJobsZoneConfig,
 	},
+	{
+		name:             "create system.comments table",
+		workFn:           createCommentsTable,
+		newDescriptorIDs: []sqlbase.ID{keys.CommentsTableID},
+	},
+	{
+		name:             "create system.role_settings table",
+		workFn:           createRoleSettingsTable,
+		newDescriptorIDs: []sqlbase.ID{keys.RoleSettingsTableID},
+	},
+	{
+		name:             "create system.role_options table",
+		workFn:           createRoleOptionsTable,
+		newDescriptorIDs: []sqlbase.ID{keys.RoleOptionsTableID},
+	},
+	{
+		name:             "create system.default_privileges table",
+		workFn:           createDefaultPrivilegesTable,
+		newDescriptorIDs: []sqlbase.ID{keys.DefaultPrivilegesTableID},
+	},
 }
 
 // migrationDescriptor describes a single migration hook that's used to modify
@@ -465,6 +485,22 @@ func createRoleMembersTable(ctx context.Context, r runner) error {
 	return createSystemTable(ctx, r, sqlbase.RoleMembersTable)
 }
 
+func createCommentsTable(ctx context.Context, r runner) error {
+	return createSystemTable(ctx, r, sqlbase.CommentsTable)
+}
+
+func createRoleSettingsTable(ctx context.Context, r runner) error {
+	return createSystemTable(ctx, r, sqlbase.RoleSettingsTable)
+}
+
+func createRoleOptionsTable(ctx context.Context, r runner) error {
+	return createSystemTable(ctx, r, sqlbase.RoleOptionsTable)
+}
+
+func createDefaultPrivilegesTable(ctx context.Context, r runner) error {
+	return createSystemTable(ctx, r, sqlbase.DefaultPrivilegesTable)
+}
+
 func createSystemTable(ctx context.Context, r runner, desc sqlbase.TableDescriptor) error {
 	// We install the table at the KV layer so that we can choose a known ID in
 	// the reserved ID space. (The SQL layer doesn't allow this.)
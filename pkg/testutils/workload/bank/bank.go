@@ -154,6 +154,7 @@ func (b *bank) Ops() []workload.Operation {
 					to = rng.Intn(b.rows - 1)
 				}
 				amount := rand.Intn(maxTransfer)
+				workload.SetOpDetails(ctx, `transfer`, from, to, amount)
 				_, err := updateStmt.ExecContext(ctx, from, to, amount)
 				return err
 			}, nil
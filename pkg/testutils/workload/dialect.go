@@ -0,0 +1,143 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package workload
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Dialect is the SQL dialect of the database a workload runs against.
+type Dialect string
+
+const (
+	// CockroachDialect is the dialect of CockroachDB, which the schemas and
+	// operations of the Generators are written in.
+	CockroachDialect Dialect = `cockroach`
+	// PostgresDialect is the dialect of PostgreSQL, which workloads can run
+	// against for comparison. The schemas are translated to it and the
+	// initial splits are skipped.
+	PostgresDialect Dialect = `postgres`
+)
+
+// DialectOf returns the dialect of the database that db is connected to.
+func DialectOf(ctx context.Context, db *gosql.DB) (Dialect, error) {
+	var version string
+	if err := db.QueryRowContext(ctx, `SELECT version()`).Scan(&version); err != nil {
+		return ``, err
+	}
+	if strings.Contains(version, `CockroachDB`) {
+		return CockroachDialect, nil
+	}
+	return PostgresDialect, nil
+}
+
+// CheckDialect returns an error if the generator doesn't support the
+// dialect, see Meta.Dialects.
+func CheckDialect(gen Generator, d Dialect) error {
+	if d == CockroachDialect {
+		return nil
+	}
+	meta := gen.Meta()
+	for _, supported := range meta.Dialects {
+		if supported == d {
+			return nil
+		}
+	}
+	return errors.Errorf(`%s does not support the %s dialect`, meta.Name, d)
+}
+
+var (
+	familyRE     = regexp.MustCompile(`(?i)^family\b`)
+	interleaveRE = regexp.MustCompile(`(?is)\)\s*interleave\s+in\s+parent\s.*$`)
+	indexRE      = regexp.MustCompile(`(?is)^(unique\s+)?index\b\s*([^\s(]*)\s*(\([^)]*\))(\s+storing\s*\([^)]*\))?$`)
+	// postgresTypes maps the CockroachDB types that PostgreSQL doesn't have
+	// to their PostgreSQL equivalents. SERIAL is 64 bits in CockroachDB.
+	postgresTypes = []struct {
+		re   *regexp.Regexp
+		repl string
+	}{
+		{regexp.MustCompile(`(?i)\bstring\b`), `TEXT`},
+		{regexp.MustCompile(`(?i)\bbytes\b`), `BYTEA`},
+		{regexp.MustCompile(`(?i)\bserial\b`), `BIGSERIAL`},
+	}
+)
+
+// CreateTableStmts returns the statements creating the given table in the
+// dialect.
+func (d Dialect) CreateTableStmts(table Table) ([]string, error) {
+	createStmt := fmt.Sprintf(`CREATE TABLE "%s" %s`, table.Name, table.Schema)
+	if d == CockroachDialect {
+		return []string{createStmt}, nil
+	}
+
+	// PostgreSQL has no interleaved tables or column families, and its
+	// indexes are created by separate statements.
+	schema := strings.TrimSpace(interleaveRE.ReplaceAllString(table.Schema, `)`))
+	if !strings.HasPrefix(schema, `(`) || !strings.HasSuffix(schema, `)`) {
+		return nil, errors.Errorf(`%s: unexpected schema: %s`, table.Name, table.Schema)
+	}
+	var defs, indexStmts []string
+	for _, def := range splitTopLevel(schema[1 : len(schema)-1]) {
+		def = strings.TrimSpace(def)
+		if familyRE.MatchString(def) {
+			continue
+		}
+		if m := indexRE.FindStringSubmatch(def); m != nil {
+			// The STORING columns are dropped.
+			create := `CREATE INDEX`
+			if m[1] != `` {
+				create = `CREATE UNIQUE INDEX`
+			}
+			if name := m[2]; name != `` {
+				create += ` ` + name
+			}
+			indexStmts = append(indexStmts, fmt.Sprintf(`%s ON "%s" %s`, create, table.Name, m[3]))
+			continue
+		}
+		for _, t := range postgresTypes {
+			def = t.re.ReplaceAllString(def, t.repl)
+		}
+		defs = append(defs, def)
+	}
+	createStmt = fmt.Sprintf(`CREATE TABLE "%s" (%s)`, table.Name, strings.Join(defs, `, `))
+	return append([]string{createStmt}, indexStmts...), nil
+}
+
+// splitTopLevel splits s at the commas that are not within parentheses.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
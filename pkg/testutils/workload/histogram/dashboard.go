@@ -0,0 +1,144 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package histogram
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/codahale/hdrhistogram"
+
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// DashboardWidth is the number of ticks covered by the sparklines of a
+// Dashboard.
+const DashboardWidth = 40
+
+// clearScreen moves the cursor of a terminal to its top left corner and
+// clears the screen.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// sparkBars are the bars of a sparkline, from the lowest to the highest.
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws a bar per value, scaled to the largest of the values.
+func sparkline(values []float64) string {
+	var max float64
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	var buf bytes.Buffer
+	for _, v := range values {
+		var i int
+		switch {
+		case v <= 0:
+		case v >= max:
+			i = len(sparkBars) - 1
+		default:
+			i = int(v/max*float64(len(sparkBars)-1) + 0.5)
+		}
+		buf.WriteRune(sparkBars[i])
+	}
+	return buf.String()
+}
+
+// window holds the last values of a figure, oldest first.
+type window []float64
+
+// add appends a value, dropping the oldest one if the window is full.
+func (w window) add(v float64) window {
+	if len(w) < DashboardWidth {
+		return append(w, v)
+	}
+	copy(w, w[1:])
+	w[len(w)-1] = v
+	return w
+}
+
+// Dashboard reports the operations recorded in a Registry like a Reporter,
+// but as a screen that it redraws in place every tick, which is easier to
+// follow on a terminal than scrolling lines. The screen has a line per name
+// with the figures of the last tick and sparklines of the throughput and of
+// the 99th percentile latency of the last ticks, and a sparkline of the
+// errors. The summary at the end is printed below the last screen, as by a
+// Reporter.
+type Dashboard struct {
+	w        io.Writer
+	interval time.Duration
+
+	// opsPerSec and p99 hold the figures of the last ticks by name, and
+	// errors the number of errors of the last ticks.
+	opsPerSec, p99 map[string]window
+	errors         window
+	lastNumErr     int
+}
+
+// NewDashboard returns a Dashboard drawing on w, which should be a
+// terminal, and which is ticked every interval.
+func NewDashboard(w io.Writer, interval time.Duration) *Dashboard {
+	return &Dashboard{
+		w:         w,
+		interval:  interval,
+		opsPerSec: make(map[string]window),
+		p99:       make(map[string]window),
+	}
+}
+
+// Tick ticks the registry and redraws the screen. numErr is the number of
+// errors that happened so far.
+func (d *Dashboard) Tick(reg *Registry, numErr int) {
+	d.errors = d.errors.add(float64(numErr - d.lastNumErr))
+	d.lastNumErr = numErr
+
+	// The screen is written at once, so that it doesn't flicker.
+	var rows bytes.Buffer
+	elapsed := timeutil.Since(reg.Start())
+	reg.Tick(func(t Tick) {
+		elapsed = t.CumulativeElapsed
+		opsPerSec := float64(t.Ops) / t.Elapsed.Seconds()
+		p99 := millis(t.Hist.ValueAtQuantile(99))
+		d.opsPerSec[t.Name] = d.opsPerSec[t.Name].add(opsPerSec)
+		d.p99[t.Name] = d.p99[t.Name].add(p99)
+		fmt.Fprintf(&rows, "%-20s %14.1f %14.1f %8.1f %8.1f  %-*s  %s\n",
+			t.Name,
+			opsPerSec,
+			float64(t.CumulativeOps)/t.CumulativeElapsed.Seconds(),
+			millis(t.Hist.ValueAtQuantile(50)),
+			p99,
+			DashboardWidth, sparkline(d.opsPerSec[t.Name]),
+			sparkline(d.p99[t.Name]))
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString(clearScreen)
+	fmt.Fprintf(&buf, "elapsed %s, %d errors\n", roundElapsed(elapsed, d.interval), numErr)
+	fmt.Fprintf(&buf, "errors/tick  %s\n\n", sparkline(d.errors))
+	fmt.Fprintf(&buf, "%-20s %14s %14s %8s %8s  %-*s  %s\n",
+		"name", "ops/sec(inst)", "ops/sec(cum)", "p50(ms)", "p99(ms)",
+		DashboardWidth, "ops/sec", "p99(ms)")
+	buf.Write(rows.Bytes())
+	_, _ = d.w.Write(buf.Bytes())
+}
+
+// Total ticks the registry one last time and prints the summary of a
+// Reporter below the last screen.
+func (d *Dashboard) Total(reg *Registry, numErr int) *hdrhistogram.Histogram {
+	return NewReporter(d.w, d.interval).Total(reg, numErr)
+}
@@ -0,0 +1,287 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package histogram records the latencies of the operations run by load
+// generators and aggregates them periodically, so that the tools generating
+// load report them consistently.
+package histogram
+
+import (
+	"runtime"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/codahale/hdrhistogram"
+
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+const (
+	// MinLatency and MaxLatency are the bounds of the recorded latencies.
+	// Latencies out of bounds are recorded as the nearest bound.
+	MinLatency = 100 * time.Microsecond
+	MaxLatency = 10 * time.Second
+)
+
+// NewHistogram returns an empty histogram of latencies, in nanoseconds.
+func NewHistogram() *hdrhistogram.Histogram {
+	return hdrhistogram.New(MinLatency.Nanoseconds(), MaxLatency.Nanoseconds(), 1)
+}
+
+// Stripe is a latency histogram owned by a single goroutine. Recording
+// into it takes no locks: the goroutine only touches atomics that live on
+// the stripe itself, so that goroutines never contend with each other, and
+// the registry only synchronizes with a goroutine once per tick.
+//
+// The stripe is double-buffered. The goroutine records into hists[cur]; to
+// collect the recorded values, the registry flips cur and waits until the
+// goroutine is no longer recording into the previous histogram before
+// reading it.
+type Stripe struct {
+	// ops is the number of successful operations, including the ones whose
+	// latency was not sampled.
+	ops uint64
+	// cur is the index into hists of the histogram being recorded into.
+	cur int32
+	// recording[i] is non-zero while the goroutine records a value into
+	// hists[i].
+	recording [2]int32
+	hists     [2]*hdrhistogram.Histogram
+
+	// Pad the stripe to its own cache lines so that neighboring stripes are
+	// not invalidated by each other's atomics.
+	_ [64]byte
+}
+
+func newStripe() *Stripe {
+	return &Stripe{
+		hists: [2]*hdrhistogram.Histogram{NewHistogram(), NewHistogram()},
+	}
+}
+
+// Record records a latency. It must only be called by the goroutine owning
+// the stripe.
+func (s *Stripe) Record(d time.Duration) {
+	for {
+		idx := atomic.LoadInt32(&s.cur)
+		atomic.AddInt32(&s.recording[idx], 1)
+		if atomic.LoadInt32(&s.cur) == idx {
+			// The value is clamped, so this cannot fail.
+			_ = s.hists[idx].RecordValue(clampLatency(d, MinLatency, MaxLatency).Nanoseconds())
+			atomic.AddInt32(&s.recording[idx], -1)
+			return
+		}
+		// rotate flipped the histograms under us and may already be reading
+		// hists[idx]. Back off and record into the other one.
+		atomic.AddInt32(&s.recording[idx], -1)
+	}
+}
+
+// IncOps counts a successful operation.
+func (s *Stripe) IncOps() {
+	atomic.AddUint64(&s.ops, 1)
+}
+
+// Ops returns the number of successful operations.
+func (s *Stripe) Ops() uint64 {
+	return atomic.LoadUint64(&s.ops)
+}
+
+// rotate merges the latencies recorded since the last call to rotate into
+// into and resets them. It must not be called concurrently with itself.
+func (s *Stripe) rotate(into *hdrhistogram.Histogram) {
+	prev := atomic.LoadInt32(&s.cur)
+	atomic.StoreInt32(&s.cur, 1-prev)
+	// A Record call that started before the flip may still be writing into
+	// the previous histogram. Calls that observe the flip back off without
+	// touching it, so this waits for at most one RecordValue.
+	for atomic.LoadInt32(&s.recording[prev]) != 0 {
+		runtime.Gosched()
+	}
+	h := s.hists[prev]
+	into.Merge(h)
+	h.Reset()
+}
+
+func clampLatency(d, min, max time.Duration) time.Duration {
+	if d < min {
+		return min
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
+
+// Tick describes the operations recorded under a name since the previous
+// tick of a Registry and since the Registry was created.
+type Tick struct {
+	// Name is the name the operations were recorded under.
+	Name string
+	// Now is the time of the tick.
+	Now time.Time
+	// Hist holds the latencies recorded since the previous tick, which
+	// happened Elapsed ago. Ops successful operations ran in the meantime.
+	Hist    *hdrhistogram.Histogram
+	Ops     uint64
+	Elapsed time.Duration
+	// Cumulative holds all the latencies recorded since the Registry was
+	// created, which happened CumulativeElapsed ago. CumulativeOps successful
+	// operations ran in the meantime.
+	Cumulative        *hdrhistogram.Histogram
+	CumulativeOps     uint64
+	CumulativeElapsed time.Duration
+}
+
+// Registry groups the Stripes of the goroutines running operations by name
+// (e.g. by kind of operation) and aggregates what they record each time it
+// ticks.
+type Registry struct {
+	start time.Time
+
+	mu struct {
+		syncutil.Mutex
+		lastTick time.Time
+		// stripes are the Stripes recording under each name.
+		stripes map[string][]*Stripe
+		// window holds the latencies recorded under each name since the
+		// previous tick. It is reset by every tick rather than reallocated, as
+		// ticks can be frequent.
+		window map[string]*hdrhistogram.Histogram
+		// cumulative and ops are the latencies recorded and the number of
+		// operations run under each name as of the last tick.
+		cumulative map[string]*hdrhistogram.Histogram
+		ops        map[string]uint64
+	}
+}
+
+// NewRegistry returns an empty Registry. The cumulative figures of its ticks
+// start from the time it is created.
+func NewRegistry() *Registry {
+	r := &Registry{start: timeutil.Now()}
+	r.mu.lastTick = r.start
+	r.mu.stripes = make(map[string][]*Stripe)
+	r.mu.window = make(map[string]*hdrhistogram.Histogram)
+	r.mu.cumulative = make(map[string]*hdrhistogram.Histogram)
+	r.mu.ops = make(map[string]uint64)
+	return r
+}
+
+// Start returns the time the Registry was created.
+func (r *Registry) Start() time.Time {
+	return r.start
+}
+
+// NewStripe returns a Stripe recording under the given name, for use by a
+// single goroutine.
+func (r *Registry) NewStripe(name string) *Stripe {
+	s := newStripe()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mu.stripes[name] = append(r.mu.stripes[name], s)
+	if _, ok := r.mu.cumulative[name]; !ok {
+		r.mu.window[name] = NewHistogram()
+		r.mu.cumulative[name] = NewHistogram()
+	}
+	return s
+}
+
+// Ops returns the number of successful operations recorded under all names.
+// Unlike the figures of the ticks, it is up to date.
+func (r *Registry) Ops() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ops uint64
+	for _, stripes := range r.mu.stripes {
+		for _, s := range stripes {
+			ops += s.Ops()
+		}
+	}
+	return ops
+}
+
+// Tick collects what was recorded since the previous tick and calls fn with
+// the resulting Tick of each name, in the order of the names. The histograms
+// passed to fn are only valid until fn returns.
+func (r *Registry) Tick(fn func(Tick)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := timeutil.Now()
+	elapsed := now.Sub(r.mu.lastTick)
+	r.mu.lastTick = now
+
+	names := make([]string, 0, len(r.mu.stripes))
+	for name := range r.mu.stripes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		h := r.mu.window[name]
+		h.Reset()
+		var ops uint64
+		for _, s := range r.mu.stripes[name] {
+			s.rotate(h)
+			ops += s.Ops()
+		}
+		cumulative := r.mu.cumulative[name]
+		cumulative.Merge(h)
+		prevOps := r.mu.ops[name]
+		r.mu.ops[name] = ops
+		fn(Tick{
+			Name:              name,
+			Now:               now,
+			Hist:              h,
+			Ops:               ops - prevOps,
+			Elapsed:           elapsed,
+			Cumulative:        cumulative,
+			CumulativeOps:     ops,
+			CumulativeElapsed: now.Sub(r.start),
+		})
+	}
+}
+
+// Counts are the number of successful operations run and the latencies
+// recorded under a name.
+type Counts struct {
+	Ops  uint64
+	Hist *hdrhistogram.Histogram
+}
+
+// Sub returns the operations run and the latencies recorded after prev, which
+// holds earlier Counts of the same name.
+func (c Counts) Sub(prev Counts) Counts {
+	s := c.Hist.Export()
+	if prev.Hist != nil {
+		for i, n := range prev.Hist.Export().Counts {
+			s.Counts[i] -= n
+		}
+	}
+	return Counts{Ops: c.Ops - prev.Ops, Hist: hdrhistogram.Import(s)}
+}
+
+// Cumulative returns copies of the Counts of each name since the Registry was
+// created, as of its last tick. The Counts of two calls can be subtracted to
+// get what was recorded between the ticks preceding them.
+func (r *Registry) Cumulative() map[string]Counts {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := make(map[string]Counts, len(r.mu.cumulative))
+	for name, h := range r.mu.cumulative {
+		counts[name] = Counts{Ops: r.mu.ops[name], Hist: hdrhistogram.Import(h.Export())}
+	}
+	return counts
+}
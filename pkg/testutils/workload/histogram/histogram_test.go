@@ -0,0 +1,224 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package histogram
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestRegistryTick(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	reg := NewRegistry()
+	reads := []*Stripe{reg.NewStripe(`read`), reg.NewStripe(`read`)}
+	write := reg.NewStripe(`write`)
+
+	record := func(s *Stripe, d time.Duration) {
+		s.Record(d)
+		s.IncOps()
+	}
+	record(reads[0], time.Millisecond)
+	record(reads[1], 2*time.Millisecond)
+	record(write, time.Hour)
+
+	type summary struct {
+		name               string
+		count, ops, cumOps int64
+		max                time.Duration
+	}
+	tick := func() []summary {
+		var ticks []summary
+		reg.Tick(func(tick Tick) {
+			ticks = append(ticks, summary{
+				name:   tick.Name,
+				count:  tick.Hist.TotalCount(),
+				ops:    int64(tick.Ops),
+				cumOps: int64(tick.CumulativeOps),
+				max:    time.Duration(tick.Cumulative.Max()),
+			})
+		})
+		return ticks
+	}
+
+	ticks := tick()
+	if len(ticks) != 2 || ticks[0].name != `read` || ticks[1].name != `write` {
+		t.Fatalf(`expected ticks for read and write, got %+v`, ticks)
+	}
+	if s := ticks[0]; s.count != 2 || s.ops != 2 || s.cumOps != 2 {
+		t.Errorf(`unexpected read tick %+v`, s)
+	}
+	// Latencies above the maximum are recorded as the maximum.
+	if s := ticks[1]; s.count != 1 || s.max < MaxLatency || s.max > 2*MaxLatency {
+		t.Errorf(`unexpected write tick %+v`, s)
+	}
+
+	record(reads[1], time.Millisecond)
+	ticks = tick()
+	if s := ticks[0]; s.count != 1 || s.ops != 1 || s.cumOps != 3 {
+		t.Errorf(`unexpected read tick %+v`, s)
+	}
+	if s := ticks[1]; s.count != 0 || s.ops != 0 || s.cumOps != 1 {
+		t.Errorf(`unexpected write tick %+v`, s)
+	}
+	if ops := reg.Ops(); ops != 4 {
+		t.Errorf(`expected 4 ops got %d`, ops)
+	}
+}
+
+func TestRegistryCumulative(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	reg := NewRegistry()
+	s := reg.NewStripe(`read`)
+	record := func(d time.Duration) {
+		s.Record(d)
+		s.IncOps()
+	}
+	record(time.Millisecond)
+	record(time.Millisecond)
+	reg.Tick(func(Tick) {})
+	before := reg.Cumulative()
+
+	// What is recorded after a tick is only reported by the next one.
+	record(100 * time.Millisecond)
+	if c := reg.Cumulative()[`read`]; c.Ops != 2 || c.Hist.TotalCount() != 2 {
+		t.Errorf(`expected 2 ops and latencies got %d and %d`, c.Ops, c.Hist.TotalCount())
+	}
+	reg.Tick(func(Tick) {})
+	after := reg.Cumulative()
+
+	c := after[`read`].Sub(before[`read`])
+	if c.Ops != 1 || c.Hist.TotalCount() != 1 {
+		t.Fatalf(`expected 1 op and latency got %d and %d`, c.Ops, c.Hist.TotalCount())
+	}
+	if min := time.Duration(c.Hist.Min()); min < 90*time.Millisecond {
+		t.Errorf(`expected a latency of 100ms got %s`, min)
+	}
+	// The earlier Counts are left untouched.
+	if c := before[`read`]; c.Ops != 2 || c.Hist.TotalCount() != 2 {
+		t.Errorf(`expected 2 ops and latencies got %d and %d`, c.Ops, c.Hist.TotalCount())
+	}
+	if c := after[`read`].Sub(Counts{}); c.Ops != 3 || c.Hist.TotalCount() != 3 {
+		t.Errorf(`expected 3 ops and latencies got %d and %d`, c.Ops, c.Hist.TotalCount())
+	}
+}
+
+func TestReporter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	reg := NewRegistry()
+	s := reg.NewStripe(`read`)
+	s.Record(time.Millisecond)
+	s.IncOps()
+
+	var buf bytes.Buffer
+	r := NewReporter(&buf, time.Second)
+	r.Tick(reg, 0)
+	r.Tick(reg, 0)
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 3 {
+		t.Fatalf(`expected a header and 2 lines got:\n%s`, buf.String())
+	}
+
+	buf.Reset()
+	// A latency measured by an operation besides its own has no operations.
+	reg.NewStripe(`read-visible`).Record(time.Second)
+	total := r.Total(reg, 1)
+	if n := total.TotalCount(); n != 1 {
+		t.Errorf(`expected 1 recorded latency got %d`, n)
+	}
+	for _, name := range []string{`read`, `read-visible`} {
+		if !strings.Contains(buf.String(), name) {
+			t.Errorf(`expected the summary to name %s got:\n%s`, name, buf.String())
+		}
+	}
+}
+
+func TestRoundElapsed(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		elapsed, interval, expected time.Duration
+	}{
+		{1400 * time.Millisecond, time.Second, time.Second},
+		{5100 * time.Millisecond, 5 * time.Second, 5 * time.Second},
+		{2900 * time.Millisecond, 1500 * time.Millisecond, 3 * time.Second},
+		{1260 * time.Millisecond, 100 * time.Millisecond, 1300 * time.Millisecond},
+		{240 * time.Millisecond, 250 * time.Millisecond, 250 * time.Millisecond},
+		{1400 * time.Millisecond, 0, time.Second},
+	}
+	for _, tc := range testCases {
+		if actual := roundElapsed(tc.elapsed, tc.interval); actual != tc.expected {
+			t.Errorf(`roundElapsed(%s, %s): expected %s got %s`,
+				tc.elapsed, tc.interval, tc.expected, actual)
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		values   []float64
+		expected string
+	}{
+		{nil, ``},
+		{[]float64{0, 0}, `▁▁`},
+		{[]float64{0, 1, 2, 7}, `▁▂▃█`},
+		{[]float64{5, 5}, `██`},
+	}
+	for _, tc := range testCases {
+		if actual := sparkline(tc.values); actual != tc.expected {
+			t.Errorf(`sparkline(%v): expected %q got %q`, tc.values, tc.expected, actual)
+		}
+	}
+}
+
+func TestDashboard(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	reg := NewRegistry()
+	s := reg.NewStripe(`read`)
+
+	var buf bytes.Buffer
+	d := NewDashboard(&buf, time.Second)
+	for i := 0; i < DashboardWidth+5; i++ {
+		s.Record(time.Millisecond)
+		s.IncOps()
+		buf.Reset()
+		d.Tick(reg, i)
+	}
+	// Every tick redraws the whole screen.
+	screen := buf.String()
+	if !strings.HasPrefix(screen, clearScreen) || strings.Count(screen, clearScreen) != 1 {
+		t.Fatalf(`expected a single screen got:\n%q`, screen)
+	}
+	if !strings.Contains(screen, fmt.Sprintf(`%d errors`, DashboardWidth+4)) {
+		t.Errorf(`expected the number of errors on the screen got:\n%s`, screen)
+	}
+	// The sparklines only show the last ticks.
+	if n := len(d.opsPerSec[`read`]); n != DashboardWidth {
+		t.Errorf(`expected %d ticks in the window got %d`, DashboardWidth, n)
+	}
+	if !strings.Contains(screen, strings.Repeat(`█`, DashboardWidth)) {
+		t.Errorf(`expected the errors sparkline to be full got:\n%s`, screen)
+	}
+}
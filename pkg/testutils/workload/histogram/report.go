@@ -0,0 +1,122 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package histogram
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/codahale/hdrhistogram"
+	"github.com/tylertreat/hdrhistogram-writer"
+)
+
+// TickReporter reports the operations recorded in a Registry every tick,
+// and once more at the end. Reporter and Dashboard are TickReporters.
+type TickReporter interface {
+	Tick(reg *Registry, numErr int)
+	Total(reg *Registry, numErr int) *hdrhistogram.Histogram
+}
+
+// Reporter prints the standard reports of the operations recorded in a
+// Registry: a line per name every tick, with a header every 20 lines, and a
+// summary line per name at the end.
+type Reporter struct {
+	w io.Writer
+	// interval is the expected time between ticks. The elapsed time of
+	// each line is rounded to it when it is below a second.
+	interval time.Duration
+	lines    int
+}
+
+// NewReporter returns a Reporter printing to w, which is ticked every
+// interval.
+func NewReporter(w io.Writer, interval time.Duration) *Reporter {
+	return &Reporter{w: w, interval: interval}
+}
+
+func millis(v int64) float64 {
+	return time.Duration(v).Seconds() * 1000
+}
+
+// Tick ticks the registry and prints a line per name reporting the
+// operations run since the previous tick. numErr is the number of errors
+// that happened so far.
+func (r *Reporter) Tick(reg *Registry, numErr int) {
+	reg.Tick(func(t Tick) {
+		if r.lines%20 == 0 {
+			fmt.Fprintln(r.w, "_elapsed___errors__ops/sec(inst)___ops/sec(cum)__p50(ms)__p95(ms)__p99(ms)_pMax(ms)__name")
+		}
+		r.lines++
+		fmt.Fprintf(r.w, "%8s %8d %14.1f %14.1f %8.1f %8.1f %8.1f %8.1f  %s\n",
+			roundElapsed(t.CumulativeElapsed, r.interval),
+			numErr,
+			float64(t.Ops)/t.Elapsed.Seconds(),
+			float64(t.CumulativeOps)/t.CumulativeElapsed.Seconds(),
+			millis(t.Hist.ValueAtQuantile(50)),
+			millis(t.Hist.ValueAtQuantile(95)),
+			millis(t.Hist.ValueAtQuantile(99)),
+			millis(t.Hist.ValueAtQuantile(100)),
+			t.Name)
+	})
+}
+
+// roundElapsed rounds the elapsed time to the interval between ticks, so
+// that it is the same on every run and consecutive lines are
+// distinguishable, or to the second without an interval.
+func roundElapsed(elapsed, interval time.Duration) time.Duration {
+	unit := time.Second
+	if interval > 0 {
+		unit = interval
+	}
+	return (elapsed + unit/2) / unit * unit
+}
+
+// Total ticks the registry one last time and prints a line per name
+// summarizing the operations run since the registry was created. It returns
+// the latencies recorded under all the names that operations ran under, which
+// leaves out the latencies measured by operations besides their own.
+func (r *Reporter) Total(reg *Registry, numErr int) *hdrhistogram.Histogram {
+	total := NewHistogram()
+	fmt.Fprintln(r.w, "\n_elapsed___errors_____ops(total)___ops/sec(cum)__avg(ms)__p50(ms)__p95(ms)__p99(ms)_pMax(ms)__name")
+	reg.Tick(func(t Tick) {
+		h := t.Cumulative
+		fmt.Fprintf(r.w, "%7.1fs %8d %14d %14.1f %8.1f %8.1f %8.1f %8.1f %8.1f  %s\n",
+			t.CumulativeElapsed.Seconds(), numErr,
+			t.CumulativeOps, float64(t.CumulativeOps)/t.CumulativeElapsed.Seconds(),
+			time.Duration(h.Mean()).Seconds()*1000,
+			millis(h.ValueAtQuantile(50)),
+			millis(h.ValueAtQuantile(95)),
+			millis(h.ValueAtQuantile(99)),
+			millis(h.ValueAtQuantile(100)),
+			t.Name)
+		if t.CumulativeOps > 0 {
+			total.Merge(h)
+		}
+	})
+	fmt.Fprintln(r.w)
+	return total
+}
+
+// WriteFile writes the distribution of the histogram in the HdrHistogram
+// Plotter format to the file at path, or to stdout if path is -. See
+// https://hdrhistogram.github.io/HdrHistogram/plotFiles.html
+func WriteFile(h *hdrhistogram.Histogram, path string) error {
+	if path == "-" {
+		return histwriter.WriteDistribution(h, nil, 1, os.Stdout)
+	}
+	return histwriter.WriteDistributionFile(h, nil, 1, path)
+}
@@ -25,12 +25,16 @@ import (
 	"hash"
 	"math"
 	"math/rand"
+	"strconv"
+	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 
 	"github.com/cockroachdb/cockroach/pkg/testutils/workload"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 )
 
 const (
@@ -44,9 +48,16 @@ type kv struct {
 	minBlockSizeBytes, maxBlockSizeBytes int
 	cycleLength                          int64
 	readPercent                          int
+	readAfterWritePercent                int
 	writeSeq, seed                       int64
 	sequential                           bool
 	splits                               int
+	keyRangeStr                          string
+
+	keyRange keyRange
+	// published holds the most recent write to be read back by another
+	// worker, if --read-after-write-percent is set.
+	published chan publishedWrite
 }
 
 func init() {
@@ -57,7 +68,8 @@ var kvMeta = workload.Meta{
 	Name: `kv`,
 	Description: `KV reads and writes to keys spread (by default, uniformly` +
 		` at random) across the cluster`,
-	Version: `1.0.0`,
+	Version:  `1.0.0`,
+	Dialects: []workload.Dialect{workload.PostgresDialect},
 	New: func() workload.Generator {
 		g := &kv{flags: pflag.NewFlagSet(`kv`, pflag.ContinueOnError)}
 		g.flags.IntVar(&g.batchSize, `batch`, 1, `Number of blocks to insert in a single SQL statement`)
@@ -65,10 +77,16 @@ var kvMeta = workload.Meta{
 		g.flags.IntVar(&g.maxBlockSizeBytes, `max-block-bytes`, 2, `Maximum amount of raw data written with each insertion`)
 		g.flags.Int64Var(&g.cycleLength, `cycle-length`, math.MaxInt64, `Number of keys repeatedly accessed by each writer`)
 		g.flags.IntVar(&g.readPercent, `read-percent`, 0, `Percent (0-100) of operations that are reads of existing keys`)
+		g.flags.IntVar(&g.readAfterWritePercent, `read-after-write-percent`, 0,
+			`Percent (0-100) of operations that read back the key just written by another worker, `+
+				`failing if the write isn't visible. Use with --dedicated-conns to read on another connection.`)
 		g.flags.Int64Var(&g.writeSeq, `write-seq`, 0, `Initial write sequence value.`)
 		g.flags.Int64Var(&g.seed, `seed`, 1, `Key hash seed.`)
 		g.flags.BoolVar(&g.sequential, `sequential`, false, `Pick keys sequentially instead of randomly.`)
 		g.flags.IntVar(&g.splits, `splits`, 0, `Number of splits to perform before starting normal operations`)
+		g.flags.StringVar(&g.keyRangeStr, `key-range`, `0.0-1.0`,
+			`Fraction of the keyspace (as lo-hi, e.g. 0.0-0.1) that reads and writes are restricted to. `+
+				`Useful to deliberately create hotspots.`)
 		return g
 	},
 }
@@ -89,10 +107,16 @@ func (w *kv) Hooks() workload.Hooks {
 				return errors.Errorf("Value of 'max-block-bytes' (%d) must be greater than or equal to value of 'min-block-bytes' (%d)",
 					w.maxBlockSizeBytes, w.minBlockSizeBytes)
 			}
+			if w.readPercent+w.readAfterWritePercent > 100 {
+				return errors.Errorf("Sum of 'read-percent' (%d) and 'read-after-write-percent' (%d) must be at most 100",
+					w.readPercent, w.readAfterWritePercent)
+			}
 			if w.sequential && w.splits > 0 {
 				return errors.New("'sequential' and 'splits' cannot both be enabled")
 			}
-			return nil
+			var err error
+			w.keyRange, err = parseKeyRange(w.keyRangeStr)
+			return err
 		},
 	}
 }
@@ -119,8 +143,13 @@ func (w *kv) Tables() []workload.Table {
 // Ops implements the Generator interface.
 func (w *kv) Ops() []workload.Operation {
 	opFn := func(db *gosql.DB) (func(context.Context) error, error) {
+		dialect, err := workload.DialectOf(context.Background(), db)
+		if err != nil {
+			return nil, err
+		}
+
 		var buf bytes.Buffer
-		buf.WriteString(`SELECT k, v FROM test.kv WHERE k IN (`)
+		buf.WriteString(`SELECT k, v FROM kv WHERE k IN (`)
 		for i := 0; i < w.batchSize; i++ {
 			if i > 0 {
 				buf.WriteString(", ")
@@ -134,7 +163,11 @@ func (w *kv) Ops() []workload.Operation {
 		}
 
 		buf.Reset()
-		buf.WriteString(`UPSERT INTO test. kv (k, v) VALUES`)
+		if dialect == workload.CockroachDialect {
+			buf.WriteString(`UPSERT INTO kv (k, v) VALUES`)
+		} else {
+			buf.WriteString(`INSERT INTO kv (k, v) VALUES`)
+		}
 
 		for i := 0; i < w.batchSize; i++ {
 			j := i * 2
@@ -143,18 +176,27 @@ func (w *kv) Ops() []workload.Operation {
 			}
 			fmt.Fprintf(&buf, ` ($%d, $%d)`, j+1, j+2)
 		}
+		if dialect != workload.CockroachDialect {
+			buf.WriteString(` ON CONFLICT (k) DO UPDATE SET v = excluded.v`)
+		}
 
 		writeStmt, err := db.Prepare(buf.String())
 		if err != nil {
 			return nil, err
 		}
 
-		op := kvOp{
+		op := &kvOp{
 			config:    w,
 			db:        db,
 			readStmt:  readStmt,
 			writeStmt: writeStmt,
 		}
+		if w.readAfterWritePercent > 0 {
+			op.readAfterWriteStmt, err = db.Prepare(`SELECT v FROM kv WHERE k = $1`)
+			if err != nil {
+				return nil, err
+			}
+		}
 		seq := &sequence{config: w, val: w.writeSeq}
 		if w.sequential {
 			op.g = newSequentialGenerator(seq)
@@ -164,34 +206,71 @@ func (w *kv) Ops() []workload.Operation {
 		return op.run, nil
 	}
 
+	name := fmt.Sprintf(`r%02dw%02d`, w.readPercent, 100-w.readPercent)
+	if w.readAfterWritePercent > 0 {
+		w.published = make(chan publishedWrite, 1)
+		name = fmt.Sprintf(`r%02draw%02dw%02d`,
+			w.readPercent, w.readAfterWritePercent, 100-w.readPercent-w.readAfterWritePercent)
+	}
 	return []workload.Operation{{
-		Name: fmt.Sprintf(`r%02dw%02d`, w.readPercent, 100-w.readPercent),
+		Name: name,
 		Fn:   opFn,
+		// Reads have no effect and writes are upserts of random blocks, so
+		// a lost write is indistinguishable from one that was overwritten.
+		Idempotent: true,
 	}}
 }
 
 type kvOp struct {
-	config    *kv
-	db        *gosql.DB
-	readStmt  *gosql.Stmt
-	writeStmt *gosql.Stmt
-	g         keyGenerator
+	config             *kv
+	db                 *gosql.DB
+	readStmt           *gosql.Stmt
+	writeStmt          *gosql.Stmt
+	readAfterWriteStmt *gosql.Stmt
+	g                  keyGenerator
+}
+
+// publishedWrite is a write published by a worker for another one to read
+// back.
+type publishedWrite struct {
+	writer *kvOp
+	key    int64
+	// committed is when the write was acknowledged to its worker.
+	committed time.Time
 }
 
 func (o *kvOp) run(ctx context.Context) error {
-	if o.g.rand().Intn(100) < o.config.readPercent {
-		args := make([]interface{}, o.config.batchSize)
-		for i := 0; i < o.config.batchSize; i++ {
-			args[i] = o.g.readKey()
+	n := o.g.rand().Intn(100)
+	if n < o.config.readAfterWritePercent {
+		if w, ok := o.takeWrite(); ok {
+			return o.readAfterWrite(ctx, w)
 		}
-		rows, err := o.readStmt.Query(args...)
-		if err != nil {
-			return err
-		}
-		for rows.Next() {
-		}
-		return rows.Err()
+		// There is no write of another worker to read back yet, so this
+		// operation writes for the others instead.
+		return o.write(ctx)
+	}
+	if n < o.config.readAfterWritePercent+o.config.readPercent {
+		return o.read(ctx)
+	}
+	return o.write(ctx)
+}
+
+func (o *kvOp) read(ctx context.Context) error {
+	args := make([]interface{}, o.config.batchSize)
+	for i := 0; i < o.config.batchSize; i++ {
+		args[i] = o.g.readKey()
 	}
+	workload.SetOpDetails(ctx, `read`, args...)
+	rows, err := o.readStmt.Query(args...)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+func (o *kvOp) write(ctx context.Context) error {
 	const argCount = 2
 	args := make([]interface{}, argCount*o.config.batchSize)
 	for i := 0; i < o.config.batchSize; i++ {
@@ -199,8 +278,68 @@ func (o *kvOp) run(ctx context.Context) error {
 		args[j+0] = o.g.writeKey()
 		args[j+1] = randomBlock(o.config, o.g.rand())
 	}
-	_, err := o.writeStmt.Exec(args...)
-	return err
+	workload.SetOpDetails(ctx, `write`, args...)
+	if _, err := o.writeStmt.Exec(args...); err != nil {
+		return err
+	}
+	if o.config.published != nil {
+		o.publish(publishedWrite{writer: o, key: args[0].(int64), committed: timeutil.Now()})
+	}
+	return nil
+}
+
+// readAfterWrite reads back the key of a write of another worker, which must
+// be visible as the write was acknowledged, and records how long after the
+// acknowledgement the read saw it. Only the presence of the key is checked:
+// its value may have been overwritten since, as the workers write the same
+// keys.
+func (o *kvOp) readAfterWrite(ctx context.Context, w publishedWrite) error {
+	workload.SetOpDetails(ctx, `read-after-write`, w.key)
+	var v []byte
+	err := o.readAfterWriteStmt.QueryRow(w.key).Scan(&v)
+	if err == gosql.ErrNoRows {
+		return errors.Errorf("key %d written by another worker %s ago is not visible",
+			w.key, timeutil.Since(w.committed))
+	} else if err != nil {
+		return err
+	}
+	workload.RecordLatency(ctx, `read-after-write-visible`, timeutil.Since(w.committed))
+	return nil
+}
+
+// publish makes w the write to be read back by another worker, replacing the
+// previous one if no worker took it yet: the most recent write is the one
+// that tells the most about how quickly writes become visible.
+func (o *kvOp) publish(w publishedWrite) {
+	for {
+		select {
+		case o.config.published <- w:
+			return
+		default:
+		}
+		select {
+		case <-o.config.published:
+		default:
+		}
+	}
+}
+
+// takeWrite returns the most recent write published by another worker, if
+// any. A write of this worker is given back to the others, unless a more
+// recent one was published in the meantime.
+func (o *kvOp) takeWrite() (publishedWrite, bool) {
+	select {
+	case w := <-o.config.published:
+		if w.writer != o {
+			return w, true
+		}
+		select {
+		case o.config.published <- w:
+		default:
+		}
+	default:
+	}
+	return publishedWrite{}, false
 }
 
 type sequence struct {
@@ -252,15 +391,21 @@ func (g *hashGenerator) hash(v int64) int64 {
 }
 
 func (g *hashGenerator) writeKey() int64 {
-	return g.hash(g.seq.write())
+	return g.restrict(g.hash(g.seq.write()))
 }
 
 func (g *hashGenerator) readKey() int64 {
 	v := g.seq.read()
 	if v == 0 {
-		return 0
+		return g.restrict(0)
 	}
-	return g.hash(g.random.Int63n(v))
+	return g.restrict(g.hash(g.random.Int63n(v)))
+}
+
+// restrict maps a hashed key, which is spread over all of int64, into the
+// configured key range.
+func (g *hashGenerator) restrict(k int64) int64 {
+	return g.seq.config.keyRange.restrict(k, math.MinInt64, math.MaxInt64)
 }
 
 func (g *hashGenerator) rand() *rand.Rand {
@@ -280,21 +425,80 @@ func newSequentialGenerator(seq *sequence) *sequentialGenerator {
 }
 
 func (g *sequentialGenerator) writeKey() int64 {
-	return g.seq.write()
+	return g.restrict(g.seq.write())
 }
 
 func (g *sequentialGenerator) readKey() int64 {
 	v := g.seq.read()
 	if v == 0 {
-		return 0
+		return g.restrict(0)
 	}
-	return g.random.Int63n(v)
+	return g.restrict(g.random.Int63n(v))
+}
+
+// restrict maps a sequential key, which is in [0, cycleLength), into the
+// configured key range.
+func (g *sequentialGenerator) restrict(k int64) int64 {
+	return g.seq.config.keyRange.restrict(k, 0, g.seq.config.cycleLength-1)
 }
 
 func (g *sequentialGenerator) rand() *rand.Rand {
 	return g.random
 }
 
+// keyRange is the [lo, hi) fraction of the keyspace that generated keys are
+// restricted to. The zero value covers the whole keyspace.
+type keyRange struct {
+	lo, hi float64
+}
+
+// parseKeyRange parses a key range of the form `lo-hi`, where lo and hi are
+// fractions in [0, 1] and lo < hi.
+func parseKeyRange(s string) (keyRange, error) {
+	parts := strings.Split(s, `-`)
+	if len(parts) != 2 {
+		return keyRange{}, errors.Errorf("invalid key range %q: expected lo-hi", s)
+	}
+	var r keyRange
+	var err error
+	if r.lo, err = strconv.ParseFloat(parts[0], 64); err != nil {
+		return keyRange{}, errors.Wrapf(err, "invalid key range %q", s)
+	}
+	if r.hi, err = strconv.ParseFloat(parts[1], 64); err != nil {
+		return keyRange{}, errors.Wrapf(err, "invalid key range %q", s)
+	}
+	if r.lo < 0 || r.hi > 1 || r.lo >= r.hi {
+		return keyRange{}, errors.Errorf(
+			"invalid key range %q: must satisfy 0 <= lo < hi <= 1", s)
+	}
+	return r, nil
+}
+
+func (r keyRange) isFull() bool {
+	return r.hi == 0 || (r.lo == 0 && r.hi == 1)
+}
+
+// restrict maps k, a key in [min, max], into the sub-span of [min, max]
+// covered by the key range. Keys that were uniformly distributed over [min,
+// max] remain uniformly distributed over the sub-span.
+func (r keyRange) restrict(k, min, max int64) int64 {
+	if r.isFull() {
+		return k
+	}
+	span := uint64(max - min)
+	start := uint64(r.lo * float64(span))
+	width := uint64((r.hi - r.lo) * float64(span))
+	if width > span-start {
+		// Guard against floating point rounding pushing the end of the range
+		// past max.
+		width = span - start
+	}
+	if width == 0 {
+		width = 1
+	}
+	return min + int64(start+uint64(k-min)%width)
+}
+
 func randomBlock(config *kv, r *rand.Rand) []byte {
 	blockSize := r.Intn(config.maxBlockSizeBytes-config.minBlockSizeBytes) + config.minBlockSizeBytes
 	blockData := make([]byte, blockSize)
@@ -0,0 +1,104 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package kv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestParseKeyRange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	tests := []struct {
+		in     string
+		lo, hi float64
+		err    string
+	}{
+		{in: `0.0-1.0`, lo: 0, hi: 1},
+		{in: `0.25-0.5`, lo: 0.25, hi: 0.5},
+		{in: `0.5`, err: `expected lo-hi`},
+		{in: `a-1`, err: `invalid key range`},
+		{in: `0.5-0.5`, err: `must satisfy`},
+		{in: `0.5-1.5`, err: `must satisfy`},
+	}
+	for _, test := range tests {
+		r, err := parseKeyRange(test.in)
+		if test.err != `` {
+			if !testutils.IsError(err, test.err) {
+				t.Errorf(`%s: expected error %q got: %+v`, test.in, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf(`%s: unexpected error: %+v`, test.in, err)
+			continue
+		}
+		if r.lo != test.lo || r.hi != test.hi {
+			t.Errorf(`%s: got [%f, %f) expected [%f, %f)`, test.in, r.lo, r.hi, test.lo, test.hi)
+		}
+	}
+}
+
+func TestKeyRangeRestrict(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	rng := rand.New(rand.NewSource(1))
+	for _, r := range []keyRange{{0, 0.1}, {0.45, 0.55}, {0.9, 1}} {
+		for _, bounds := range [][2]int64{{math.MinInt64, math.MaxInt64}, {0, 999}} {
+			min, max := bounds[0], bounds[1]
+			span := float64(uint64(max - min))
+			lo := min + int64(uint64(r.lo*span))
+			width := uint64((r.hi - r.lo) * span)
+			for i := 0; i < 1000; i++ {
+				k := min + int64(uint64(rng.Int63())%uint64(max-min))
+				restricted := r.restrict(k, min, max)
+				if restricted < lo || uint64(restricted-lo) > width {
+					t.Errorf(`[%f, %f): %d restricted to %d outside of %d+%d`,
+						r.lo, r.hi, k, restricted, lo, width)
+				}
+			}
+		}
+	}
+}
+
+func TestTakeWrite(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	config := &kv{published: make(chan publishedWrite, 1)}
+	a, b := &kvOp{config: config}, &kvOp{config: config}
+
+	if _, ok := a.takeWrite(); ok {
+		t.Fatal(`expected no write to take before any was published`)
+	}
+	a.publish(publishedWrite{writer: a, key: 1})
+	a.publish(publishedWrite{writer: a, key: 2})
+	if _, ok := a.takeWrite(); ok {
+		t.Fatal(`expected a worker not to take its own write`)
+	}
+	// The most recent write is the one left for the other workers.
+	w, ok := b.takeWrite()
+	if !ok || w.key != 2 {
+		t.Fatalf(`expected to take the write of key 2 got %+v (%t)`, w, ok)
+	}
+	if _, ok := b.takeWrite(); ok {
+		t.Fatal(`expected a write to be taken only once`)
+	}
+}
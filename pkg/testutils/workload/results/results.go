@@ -0,0 +1,114 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package results defines the results of a run of the workload tool, as
+// written by its --json-results flag, so that the tools consuming them
+// (dashboards, regression detectors, ...) share their definition with the
+// tool writing them.
+package results
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// Version is the version of the format of the results written by this
+// package. It is bumped whenever a field is removed or changes meaning, but
+// not when a field is added: readers ignore the fields they don't know, and
+// leave the fields missing from older results empty.
+//
+// Results written before the format was versioned have no version, which is
+// read as 0, and are otherwise the same as those of version 1.
+const Version = 1
+
+// Run is the results of a run.
+type Run struct {
+	// Version is the version of the format of the results.
+	Version     int     `json:"version"`
+	Benchmark   string  `json:"benchmark"`
+	Generator   string  `json:"generator"`
+	Concurrency int     `json:"concurrency"`
+	ElapsedSec  float64 `json:"elapsed_sec"`
+	Ops         uint64  `json:"ops"`
+	Errors      int     `json:"errors"`
+	OpsPerSec   float64 `json:"ops_per_sec"`
+	AvgMs       float64 `json:"avg_ms"`
+	P50Ms       float64 `json:"p50_ms"`
+	P95Ms       float64 `json:"p95_ms"`
+	P99Ms       float64 `json:"p99_ms"`
+	MaxMs       float64 `json:"max_ms"`
+	// Redials and Replays count the lost connections re-dialed and the
+	// operations attempted again on them, with --dedicated-conns.
+	Redials int64 `json:"redials"`
+	Replays int64 `json:"replays"`
+	// Host describes the machine the workload ran on.
+	Host Host `json:"host"`
+}
+
+// Host describes the machine the workload runs on. The results of runs are
+// annotated with it, as the hardware of the client often explains the
+// differences between runs made from different machines.
+//
+// The fields that can't be detected on a platform are left empty.
+type Host struct {
+	Hostname   string `json:"hostname,omitempty"`
+	OS         string `json:"os"`
+	Arch       string `json:"arch"`
+	Kernel     string `json:"kernel,omitempty"`
+	CPUModel   string `json:"cpu_model,omitempty"`
+	NumCPU     int    `json:"num_cpu"`
+	GOMAXPROCS int    `json:"gomaxprocs"`
+	GoVersion  string `json:"go_version"`
+	// NICSpeedMbps is the speed of the fastest network interface, as reported
+	// by its driver.
+	NICSpeedMbps int `json:"nic_speed_mbps,omitempty"`
+}
+
+// Marshal returns the results as indented JSON, as written by
+// --json-results. The version of the format is set to Version.
+func Marshal(r Run) ([]byte, error) {
+	r.Version = Version
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Parse parses results written by --json-results. It returns an error if
+// they are of a newer version than this package knows, as their fields may
+// have changed meaning.
+func Parse(data []byte) (Run, error) {
+	var r Run
+	if err := json.Unmarshal(data, &r); err != nil {
+		return Run{}, errors.Wrap(err, `parsing workload results`)
+	}
+	if r.Version > Version {
+		return Run{}, errors.Errorf(
+			`workload results of version %d are newer than the supported version %d`,
+			r.Version, Version)
+	}
+	return r, nil
+}
+
+// ReadFile parses the results written by --json-results to the file at path.
+func ReadFile(path string) (Run, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Run{}, err
+	}
+	return Parse(data)
+}
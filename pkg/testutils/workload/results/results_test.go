@@ -0,0 +1,79 @@
+// Copyright 2018 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package results
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestMarshalParse(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	r := Run{
+		Benchmark: `BenchmarkWorkload/generator=kv`,
+		Generator: `kv`,
+		Ops:       100,
+		P99Ms:     1.5,
+		Host:      Host{OS: `linux`, NumCPU: 8},
+	}
+	data, err := Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Version = Version
+	if !reflect.DeepEqual(r, parsed) {
+		t.Errorf(`expected %+v got %+v`, r, parsed)
+	}
+}
+
+func TestParse(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	tests := []struct {
+		in      string
+		version int
+		err     string
+	}{
+		// Results written before the format was versioned.
+		{in: `{"generator": "kv", "ops": 5}`, version: 0},
+		{in: `{"version": 1, "generator": "kv", "unknown": true}`, version: 1},
+		{in: `{"version": 2, "generator": "kv"}`, err: `newer than the supported version 1`},
+		{in: `{"generator": 1}`, err: `parsing workload results`},
+	}
+	for _, test := range tests {
+		r, err := Parse([]byte(test.in))
+		if test.err != `` {
+			if !testutils.IsError(err, test.err) {
+				t.Errorf(`%s: expected error %q got: %+v`, test.in, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf(`%s: unexpected error: %+v`, test.in, err)
+			continue
+		}
+		if r.Version != test.version || r.Generator != `kv` {
+			t.Errorf(`%s: unexpected results %+v`, test.in, r)
+		}
+	}
+}
@@ -165,7 +165,7 @@ func (w *tpcc) Ops() []workload.Operation {
 			idx := int(atomic.AddInt64(&w.workers, 1)) - 1
 			warehouse := idx / numWorkersPerWarehouse
 			worker := &worker{config: w, idx: idx, db: db, warehouse: warehouse}
-			fn := func(_ context.Context) error { return worker.run() }
+			fn := func(ctx context.Context) error { return worker.run(ctx) }
 			return fn, nil
 		},
 	}}
@@ -16,6 +16,7 @@
 package tpcc
 
 import (
+	"context"
 	gosql "database/sql"
 	"math"
 	"math/rand"
@@ -24,6 +25,8 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils/workload"
 )
 
 const (
@@ -110,7 +113,7 @@ func initializeMix(config *tpcc) error {
 	return nil
 }
 
-func (w *worker) run() error {
+func (w *worker) run(ctx context.Context) error {
 	transactionType := rand.Intn(w.config.totalWeight)
 	weightSum := 0
 	var t tx
@@ -126,6 +129,7 @@ func (w *worker) run() error {
 	} else {
 		time.Sleep(time.Duration(t.keyingTime) * time.Second)
 	}
+	workload.SetOpDetails(ctx, t.name, warehouseID)
 
 	if _, err := t.run(w.config, w.db, warehouseID); err != nil {
 		return errors.Wrapf(err, "error in %s", t.name)
@@ -27,6 +27,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -83,6 +84,10 @@ type Meta struct {
 	// Version is a semantic version for this generator. It should be bumped
 	// whenever InitialRowFn or InitialRowCount change for any of the tables.
 	Version string
+	// Dialects lists the dialects other than CockroachDialect that the
+	// schema and the operations of this generator support. Most generators
+	// use CockroachDB-specific statements and support none.
+	Dialects []Dialect
 	// New returns an unconfigured instance of this generator.
 	New func() Generator
 }
@@ -120,6 +125,68 @@ type Operation struct {
 	// Fn returns a function to be called once per unit of work to be done.
 	// Various generator tools use this to track progress.
 	Fn func(*gosql.DB) (func(context.Context) error, error)
+	// Idempotent is whether a unit of work may be attempted again when its
+	// outcome is unknown, as when the connection is lost before its result
+	// is received. The function is simply called again, so this requires
+	// that the work either having been done or not keeps the workload's
+	// invariants.
+	Idempotent bool
+}
+
+// OpDetails describes a unit of work done by an Operation. Tools running
+// Operations may ask for it to keep track of the work that led to an error.
+type OpDetails struct {
+	// Type is the kind of work done, e.g. the transaction picked from a mix.
+	Type string
+	// Params are the parameters of the work, e.g. the placeholder values of
+	// the statements run.
+	Params []interface{}
+}
+
+type opDetailsKey struct{}
+
+// WithOpDetails returns a context that an Operation's function can be called
+// with to have it fill in the given details through SetOpDetails.
+func WithOpDetails(ctx context.Context, details *OpDetails) context.Context {
+	return context.WithValue(ctx, opDetailsKey{}, details)
+}
+
+// SetOpDetails records the details of the unit of work being done by an
+// Operation called with ctx, if its caller asked for them with
+// WithOpDetails. The params are retained, so they must not be modified
+// afterwards.
+func SetOpDetails(ctx context.Context, typ string, params ...interface{}) {
+	if details, ok := ctx.Value(opDetailsKey{}).(*OpDetails); ok {
+		details.Type = typ
+		details.Params = params
+	}
+}
+
+// LatencyRecorder records latencies measured by an Operation besides the
+// latency of its units of work, e.g. how long a write took to become visible
+// to another connection.
+type LatencyRecorder interface {
+	// RecordLatency records a latency under the given name. It is called by
+	// a single goroutine at a time.
+	RecordLatency(name string, d time.Duration)
+}
+
+type latencyRecorderKey struct{}
+
+// WithLatencyRecorder returns a context that an Operation's function can be
+// called with to have it report the latencies it measures through
+// RecordLatency.
+func WithLatencyRecorder(ctx context.Context, r LatencyRecorder) context.Context {
+	return context.WithValue(ctx, latencyRecorderKey{}, r)
+}
+
+// RecordLatency records a latency measured by an Operation called with ctx
+// under the given name, if its caller asked for them with
+// WithLatencyRecorder. The name must not be that of an Operation.
+func RecordLatency(ctx context.Context, name string, d time.Duration) {
+	if r, ok := ctx.Value(latencyRecorderKey{}).(LatencyRecorder); ok {
+		r.RecordLatency(name, d)
+	}
 }
 
 var registered = make(map[string]Meta)
@@ -177,21 +244,32 @@ func DatumSize(x interface{}) int64 {
 // The size of the loaded data is returned in bytes, suitable for use with
 // SetBytes of benchmarks. The exact definition of this is deferred to the
 // DatumSize implementation.
-func Setup(db *gosql.DB, gen Generator, batchSize int) (int64, error) {
-	if batchSize <= 0 {
-		batchSize = 1000
-	}
-	var insertStmtBuf bytes.Buffer
-
+//
+// The tables are created in the dialect of the database db is connected to.
+// The statements are canceled when ctx is done, and the error then tells
+// which table was being created or loaded.
+func Setup(ctx context.Context, db *gosql.DB, gen Generator, batchSize int) (int64, error) {
 	tables := gen.Tables()
 	hooks := gen.Hooks()
 
+	dialect, err := DialectOf(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	if err := CheckDialect(gen, dialect); err != nil {
+		return 0, err
+	}
 	var size int64
 	for _, table := range tables {
-		createStmt := fmt.Sprintf(`CREATE TABLE "%s" %s`, table.Name, table.Schema)
-		if _, err := db.Exec(createStmt); err != nil {
+		createStmts, err := dialect.CreateTableStmts(table)
+		if err != nil {
 			return 0, err
 		}
+		for _, createStmt := range createStmts {
+			if _, err := db.ExecContext(ctx, createStmt); err != nil {
+				return 0, errors.Wrapf(err, `creating table %s`, table.Name)
+			}
+		}
 	}
 
 	if hooks.PreLoad != nil {
@@ -201,44 +279,75 @@ func Setup(db *gosql.DB, gen Generator, batchSize int) (int64, error) {
 	}
 
 	for _, table := range tables {
-		for rowIdx := 0; rowIdx < table.InitialRowCount; {
-			insertStmtBuf.Reset()
-			fmt.Fprintf(&insertStmtBuf, `INSERT INTO "%s" VALUES `, table.Name)
+		tableSize, err := LoadRows(ctx, db, table, 0, table.InitialRowCount, batchSize)
+		if err != nil {
+			return 0, err
+		}
+		size += tableSize
+	}
+	return size, nil
+}
 
-			var params []interface{}
-			for batchIdx := 0; batchIdx < batchSize && rowIdx < table.InitialRowCount; batchIdx++ {
-				if batchIdx != 0 {
+// LoadRows inserts the rows of the table's initial data with indexes in
+// [start, end) via batched INSERTs of batchSize rows, or of 1000 rows if
+// batchSize is not positive. Setup loads the rows below InitialRowCount;
+// the following ones grow the table, which requires InitialRowFn to compute
+// distinct rows past it. The size of the inserted data is returned as by
+// Setup.
+func LoadRows(
+	ctx context.Context, db *gosql.DB, table Table, start, end int, batchSize int,
+) (int64, error) {
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	var insertStmtBuf bytes.Buffer
+	var size int64
+	for rowIdx := start; rowIdx < end; {
+		insertStmtBuf.Reset()
+		fmt.Fprintf(&insertStmtBuf, `INSERT INTO "%s" VALUES `, table.Name)
+
+		var params []interface{}
+		for batchIdx := 0; batchIdx < batchSize && rowIdx < end; batchIdx++ {
+			if batchIdx != 0 {
+				insertStmtBuf.WriteString(`,`)
+			}
+			insertStmtBuf.WriteString(`(`)
+			row := table.InitialRowFn(rowIdx)
+			for i, datum := range row {
+				size += DatumSize(datum)
+				if i != 0 {
 					insertStmtBuf.WriteString(`,`)
 				}
-				insertStmtBuf.WriteString(`(`)
-				row := table.InitialRowFn(rowIdx)
-				for i, datum := range row {
-					size += DatumSize(datum)
-					if i != 0 {
-						insertStmtBuf.WriteString(`,`)
-					}
-					fmt.Fprintf(&insertStmtBuf, `$%d`, len(params)+i+1)
-				}
-				params = append(params, row...)
-				insertStmtBuf.WriteString(`)`)
-				rowIdx++
+				fmt.Fprintf(&insertStmtBuf, `$%d`, len(params)+i+1)
 			}
-			if len(params) > 0 {
-				insertStmt := insertStmtBuf.String()
-				if _, err := db.Exec(insertStmt, params...); err != nil {
-					return 0, err
-				}
+			params = append(params, row...)
+			insertStmtBuf.WriteString(`)`)
+			rowIdx++
+		}
+		if len(params) > 0 {
+			insertStmt := insertStmtBuf.String()
+			if _, err := db.ExecContext(ctx, insertStmt, params...); err != nil {
+				return 0, errors.Wrapf(err, `loading table %s`, table.Name)
 			}
 		}
 	}
 	return size, nil
 }
 
-// Split creates the range splits defined by the given table.
+// Split creates the range splits defined by the given table. Databases other
+// than CockroachDB have no ranges to split, so nothing is done for them. If
+// ctx is done first, the error tells how many of the splits were made.
 func Split(ctx context.Context, db *gosql.DB, table Table, concurrency int) error {
 	if table.SplitCount <= 0 {
 		return nil
 	}
+	dialect, err := DialectOf(ctx, db)
+	if err != nil {
+		return err
+	}
+	if dialect != CockroachDialect {
+		return nil
+	}
 	splitPoints := make([][]interface{}, table.SplitCount)
 	for splitIdx := 0; splitIdx < table.SplitCount; splitIdx++ {
 		splitPoints[splitIdx] = table.SplitFn(splitIdx)
@@ -252,6 +361,10 @@ func Split(ctx context.Context, db *gosql.DB, table Table, concurrency int) erro
 	splitCh <- pair{0, len(splitPoints)}
 	doneCh := make(chan error)
 
+	// The workers stop once the splits are done or one failed, or when ctx
+	// is done, which is also how the ones waiting on a statement learn about
+	// it. None of them must be left blocked on a channel.
+	ctx, cancel := context.WithCancel(ctx)
 	log.Infof(ctx, `starting %d splits`, len(splitPoints))
 	var wg sync.WaitGroup
 	for i := 0; i < concurrency; i++ {
@@ -261,9 +374,11 @@ func Split(ctx context.Context, db *gosql.DB, table Table, concurrency int) erro
 		go func() {
 			defer wg.Done()
 			for {
-				p, ok := <-splitCh
-				if !ok {
-					break
+				var p pair
+				select {
+				case p = <-splitCh:
+				case <-ctx.Done():
+					return
 				}
 				m := (p.lo + p.hi) / 2
 				split := strings.Join(StringTuple(splitPoints[m]), `,`)
@@ -271,7 +386,10 @@ func Split(ctx context.Context, db *gosql.DB, table Table, concurrency int) erro
 				buf.Reset()
 				fmt.Fprintf(&buf, `ALTER TABLE %s SPLIT AT VALUES (%s)`, table.Name, split)
 				if _, err := db.ExecContext(ctx, buf.String()); err != nil {
-					doneCh <- errors.Wrap(err, buf.String())
+					select {
+					case doneCh <- errors.Wrap(err, buf.String()):
+					case <-ctx.Done():
+					}
 					return
 				}
 
@@ -285,13 +403,21 @@ func Split(ctx context.Context, db *gosql.DB, table Table, concurrency int) erro
 					log.Warningf(ctx, `%s: %s`, buf.String(), err)
 				}
 
-				doneCh <- nil
+				select {
+				case doneCh <- nil:
+				case <-ctx.Done():
+					return
+				}
 				go func() {
-					if p.lo < m {
-						splitCh <- pair{p.lo, m}
-					}
-					if m+1 < p.hi {
-						splitCh <- pair{m + 1, p.hi}
+					for _, next := range []pair{{p.lo, m}, {m + 1, p.hi}} {
+						if next.lo >= next.hi {
+							continue
+						}
+						select {
+						case splitCh <- next:
+						case <-ctx.Done():
+							return
+						}
 					}
 				}()
 			}
@@ -299,12 +425,17 @@ func Split(ctx context.Context, db *gosql.DB, table Table, concurrency int) erro
 	}
 
 	defer func() {
-		close(splitCh)
+		cancel()
 		wg.Wait()
 	}()
 	for finished := 1; finished <= len(splitPoints); finished++ {
-		if err := <-doneCh; err != nil {
-			return err
+		select {
+		case err := <-doneCh:
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), `after %d of %d splits`, finished-1, len(splitPoints))
 		}
 		if finished%1000 == 0 {
 			log.Infof(ctx, "finished %d of %d splits", finished, len(splitPoints))
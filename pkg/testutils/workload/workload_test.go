@@ -17,14 +17,18 @@ package workload_test
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/workload"
 	"github.com/cockroachdb/cockroach/pkg/testutils/workload/bank"
+	_ "github.com/cockroachdb/cockroach/pkg/testutils/workload/kv"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 )
 
@@ -62,7 +66,7 @@ func TestSetup(t *testing.T) {
 			sqlDB.Exec(t, `DROP TABLE IF EXISTS bank`)
 
 			gen := bank.FromRows(test.rows)
-			if _, err := workload.Setup(sqlDB.DB, gen, test.batchSize); err != nil {
+			if _, err := workload.Setup(ctx, sqlDB.DB, gen, test.batchSize); err != nil {
 				t.Fatalf("%+v", err)
 			}
 
@@ -77,6 +81,34 @@ func TestSetup(t *testing.T) {
 	}
 }
 
+func TestLoadRows(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const rows, batchSize = 10, 3
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{UseDatabase: `test`})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE DATABASE test`)
+
+	gen := bank.FromRows(rows)
+	if _, err := workload.Setup(ctx, sqlDB.DB, gen, batchSize); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	// The rows past the initial ones grow the table.
+	table := gen.Tables()[0]
+	if _, err := workload.LoadRows(ctx, sqlDB.DB, table, rows, 2*rows, batchSize); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	var c, maxID int
+	sqlDB.QueryRow(t, `SELECT COUNT(*), MAX(id) FROM bank`).Scan(&c, &maxID)
+	if c != 2*rows || maxID != 2*rows-1 {
+		t.Errorf(`got %d rows up to id %d expected %d up to %d`, c, maxID, 2*rows, 2*rows-1)
+	}
+}
+
 func TestSplits(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -111,3 +143,101 @@ func TestSplits(t *testing.T) {
 		})
 	}
 }
+
+// TestSplitsCanceled checks that Split returns, rather than leaving its
+// workers blocked, when its context is done while splits are in flight.
+func TestSplitsCanceled(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const rows, payloadBytes, ranges, concurrency = 10, 0, 100, 10
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{
+		UseDatabase: `test`,
+		Knobs: base.TestingKnobs{
+			SQLExecutor: &sql.ExecutorTestingKnobs{
+				BeforeExecute: func(_ context.Context, stmt string, _ bool) {
+					if strings.Contains(stmt, `SPLIT AT`) {
+						cancel()
+					}
+				},
+			},
+		},
+	})
+	defer s.Stopper().Stop(context.Background())
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE DATABASE test`)
+
+	gen := bank.FromConfig(rows, payloadBytes, ranges)
+	table := gen.Tables()[0]
+	sqlDB.Exec(t, fmt.Sprintf(`CREATE TABLE %s %s`, table.Name, table.Schema))
+
+	err := workload.Split(ctx, sqlDB.DB, table, concurrency)
+	if !testutils.IsError(err, `context canceled`) {
+		t.Fatalf(`expected "context canceled" error got: %+v`, err)
+	}
+}
+
+func TestCheckDialect(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	kvMeta, err := workload.Get(`kv`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kvGen, bankGen := kvMeta.New(), bank.FromRows(1)
+
+	for _, gen := range []workload.Generator{kvGen, bankGen} {
+		if err := workload.CheckDialect(gen, workload.CockroachDialect); err != nil {
+			t.Errorf(`%s: expected success got: %+v`, gen.Meta().Name, err)
+		}
+	}
+	if err := workload.CheckDialect(kvGen, workload.PostgresDialect); err != nil {
+		t.Errorf(`expected success got: %+v`, err)
+	}
+	const expected = `bank does not support the postgres dialect`
+	if err := workload.CheckDialect(bankGen, workload.PostgresDialect); !testutils.IsError(err, expected) {
+		t.Errorf(`expected %q error got: %+v`, expected, err)
+	}
+}
+
+func TestCreateTableStmts(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	table := workload.Table{
+		Name: `t`,
+		Schema: `(
+			a INT NOT NULL,
+			b STRING,
+			c BYTES,
+			d SERIAL,
+			e DECIMAL(4,2),
+			PRIMARY KEY (a, d),
+			INDEX (b),
+			UNIQUE INDEX t_c (c, e) STORING (b),
+			FAMILY (a, b, c, d, e)
+		) INTERLEAVE IN PARENT p (a)`,
+	}
+
+	stmts, err := workload.CockroachDialect.CreateTableStmts(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{`CREATE TABLE "t" ` + table.Schema}; !reflect.DeepEqual(stmts, expected) {
+		t.Errorf(`expected %q got %q`, expected, stmts)
+	}
+
+	stmts, err = workload.PostgresDialect.CreateTableStmts(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`CREATE TABLE "t" (a INT NOT NULL, b TEXT, c BYTEA, d BIGSERIAL, e DECIMAL(4,2), PRIMARY KEY (a, d))`,
+		`CREATE INDEX ON "t" (b)`,
+		`CREATE UNIQUE INDEX t_c ON "t" (c, e)`,
+	}
+	if !reflect.DeepEqual(stmts, expected) {
+		t.Errorf(`expected %q got %q`, expected, stmts)
+	}
+}